@@ -0,0 +1,320 @@
+/*
+Package ast declares the types used to represent the syntax tree of a dcell
+expression.
+
+The tree of a compiled expression is available through [dcell.Expr.AST]. Every
+node records the span of source text that it was parsed from, and the tree can
+be traversed with [Walk] or [Inspect].
+*/
+package ast
+
+// Position is a position in the source text of an expression.
+type Position struct {
+	// Offset is the offset of the position, in characters, starting at 0.
+	Offset int
+
+	// Line is the line number of the position, starting at 1.
+	Line int
+
+	// Column is the column number of the position, in characters, starting
+	// at 1.
+	Column int
+}
+
+// Span is the range of source text that a node was parsed from. It is
+// embedded in every node.
+type Span struct {
+	// Start is the position of the first character of the node.
+	Start Position
+
+	// Stop is the position immediately after the last character of the node.
+	Stop Position
+}
+
+// Pos returns the position of the first character of the node.
+func (s Span) Pos() Position {
+	return s.Start
+}
+
+// End returns the position immediately after the last character of the node.
+func (s Span) End() Position {
+	return s.Stop
+}
+
+// Node is a node of the syntax tree.
+type Node interface {
+	Pos() Position
+	End() Position
+}
+
+// Expr is a node of the syntax tree that produces a value.
+type Expr interface {
+	Node
+	exprNode()
+}
+
+// Comment is a comment in the source text, such as `# explanation`, which
+// extends to the end of the line. Comments are not part of the syntax tree.
+type Comment struct {
+	Span
+
+	// Text is the text of the comment, including the leading '#'.
+	Text string
+}
+
+// Operator is a unary or binary operator.
+type Operator string
+
+// Unary operators.
+const (
+	Not    Operator = "!"
+	BitNot Operator = "~"
+	Plus   Operator = "+"
+	Minus  Operator = "-"
+)
+
+// Binary operators.
+const (
+	Pow      Operator = "**"
+	Mul      Operator = "*"
+	Div      Operator = "/"
+	FloorDiv Operator = "//"
+	Mod      Operator = "%"
+	Add      Operator = "+"
+	Sub      Operator = "-"
+	And      Operator = "&&"
+	Or       Operator = "||"
+	Implies  Operator = "<->"
+	Shl      Operator = "<<"
+	Shr      Operator = ">>"
+	BitAnd   Operator = "&"
+	BitOr    Operator = "|"
+	BitXor   Operator = "^"
+	Lt       Operator = "<"
+	Le       Operator = "<="
+	Gt       Operator = ">"
+	Ge       Operator = ">="
+	Eq       Operator = "=="
+	Ne       Operator = "!="
+	Match    Operator = "=~"
+	NotMatch Operator = "!~"
+	Elvis    Operator = "?:"
+	Coalesce Operator = "??"
+)
+
+// String returns the symbol of the operator.
+func (o Operator) String() string {
+	return string(o)
+}
+
+// LiteralKind is the kind of value of a [Literal].
+type LiteralKind int
+
+// Kinds of literals.
+const (
+	StringLiteral LiteralKind = iota
+	IntLiteral
+	FloatLiteral
+	BoolLiteral
+	NullLiteral
+)
+
+var literalKindNames = [...]string{
+	StringLiteral: "string",
+	IntLiteral:    "int",
+	FloatLiteral:  "float",
+	BoolLiteral:   "bool",
+	NullLiteral:   "null",
+}
+
+// String returns the name of the literal kind.
+func (k LiteralKind) String() string {
+	if k < 0 || int(k) >= len(literalKindNames) {
+		return "unknown"
+	}
+	return literalKindNames[k]
+}
+
+type (
+	// Literal is a string, number, boolean, or null literal.
+	Literal struct {
+		Span
+		Kind LiteralKind
+
+		// Value is the value of the literal, which is a string, int64,
+		// float64, bool, or nil depending on its kind.
+		Value any
+
+		// Raw is the source text of the literal, such as `0x1F` or `'str'`.
+		Raw string
+	}
+
+	// List is a list literal, such as `[1, 2, 3]` or `[user.id, owner.id]`.
+	List struct {
+		Span
+		Elems []Expr
+	}
+
+	// Object is an object literal, such as `{name: user.login, id: user.id}`.
+	Object struct {
+		Span
+		Props []*Property
+	}
+
+	// Property is a key and value of an [Object], such as `name: user.login`
+	// or `"content-type": "text/plain"`.
+	Property struct {
+		Span
+		Key   string
+		Raw   string // the source text of the key, such as `name` or `"name"`
+		Value Expr
+	}
+
+	// FormatString is a format string literal, such as
+	// `f"PR #{number} by {user.login}"`. Its parts are the string [Literal]
+	// text and the [Interpolation] expressions of the string, in order.
+	FormatString struct {
+		Span
+		Parts []Node
+	}
+
+	// Interpolation is an expression interpolated by a [FormatString], such
+	// as `{price:.2f}`.
+	Interpolation struct {
+		Span
+		X    Expr
+		Spec string // the format spec, such as `.2f`, or empty
+	}
+
+	// Member is a member access, such as `x.name` or `x?.name`. A member of
+	// the value that the expression is evaluated against, such as `name`, has
+	// no X.
+	Member struct {
+		Span
+		X    Expr // nil for members of the root value
+		Name string
+		Safe bool // whether the access is written with `?.`
+	}
+
+	// Wildcard selects every member of a value, such as `x.*`.
+	Wildcard struct {
+		Span
+		X    Expr // nil for the root value
+		Safe bool // whether the access is written with `?.`
+	}
+
+	// Call is a function call. A member function call, such as `x.lower()`,
+	// has the receiver as X; a free function call, such as `lower(x)`, has no
+	// X.
+	Call struct {
+		Span
+		X    Expr // nil for free function calls
+		Name string
+		Args []Expr
+		Safe bool // whether the call is written with `?.`
+	}
+
+	// Index is an index expression, such as `x[0]`.
+	Index struct {
+		Span
+		X     Expr
+		Index Expr
+	}
+
+	// Slice is a slice expression, such as `x[1:3]`.
+	Slice struct {
+		Span
+		X    Expr
+		Low  Expr // nil if omitted
+		High Expr // nil if omitted
+	}
+
+	// Unary is a unary operation, such as `!x` or `-x`.
+	Unary struct {
+		Span
+		Op Operator
+		X  Expr
+	}
+
+	// Binary is a binary operation, such as `x + y` or `x ?? y`.
+	Binary struct {
+		Span
+		Op    Operator
+		OpPos Position // position of the operator
+		X, Y  Expr
+	}
+
+	// Ternary is a conditional expression, such as `x ? y : z`.
+	Ternary struct {
+		Span
+		Cond, Then, Else Expr
+	}
+
+	// Is is a type test, such as `x is int` or `x is not string`.
+	Is struct {
+		Span
+		X    Expr
+		Type string
+		Not  bool
+	}
+
+	// As is a type conversion, such as `x as float`.
+	As struct {
+		Span
+		X    Expr
+		Type string
+	}
+
+	// In is a membership test, such as `x in y` or `x not in y`.
+	In struct {
+		Span
+		X, Y Expr
+		Not  bool
+	}
+
+	// Paren is a parenthesized expression.
+	Paren struct {
+		Span
+		X Expr
+	}
+
+	// Variable is a reference to a variable, such as `$user`. The name does
+	// not include the leading '$'.
+	Variable struct {
+		Span
+		Name string
+	}
+
+	// Lambda is a lambda passed as a function argument, such as `x => x.name`.
+	Lambda struct {
+		Span
+		Param string
+		Body  Expr
+	}
+
+	// Param is a reference to the parameter of an enclosing [Lambda].
+	Param struct {
+		Span
+		Name string
+	}
+)
+
+func (*Literal) exprNode()      {}
+func (*List) exprNode()         {}
+func (*Object) exprNode()       {}
+func (*FormatString) exprNode() {}
+func (*Member) exprNode()       {}
+func (*Wildcard) exprNode()     {}
+func (*Call) exprNode()         {}
+func (*Index) exprNode()        {}
+func (*Slice) exprNode()        {}
+func (*Unary) exprNode()        {}
+func (*Binary) exprNode()       {}
+func (*Ternary) exprNode()      {}
+func (*Is) exprNode()           {}
+func (*As) exprNode()           {}
+func (*In) exprNode()           {}
+func (*Paren) exprNode()        {}
+func (*Variable) exprNode()     {}
+func (*Lambda) exprNode()       {}
+func (*Param) exprNode()        {}
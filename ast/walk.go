@@ -0,0 +1,117 @@
+package ast
+
+import "fmt"
+
+// A Visitor's Visit method is invoked for each node encountered by [Walk]. If
+// the result visitor w is not nil, Walk visits each of the children of node
+// with the visitor w, followed by a call of w.Visit(nil).
+type Visitor interface {
+	Visit(node Node) (w Visitor)
+}
+
+// Walk traverses the syntax tree in depth-first order. It starts by calling
+// v.Visit(node); node must not be nil. If the visitor w returned by
+// v.Visit(node) is not nil, Walk is invoked recursively with visitor w for
+// each of the non-nil children of node, followed by a call of w.Visit(nil).
+//
+// Children are visited in the order that they appear in the source text.
+func Walk(v Visitor, node Node) {
+	if v = v.Visit(node); v == nil {
+		return
+	}
+
+	switch n := node.(type) {
+	case *Literal, *Variable, *Param:
+		// no children
+	case *List:
+		walkList(v, n.Elems)
+	case *Object:
+		for _, prop := range n.Props {
+			Walk(v, prop)
+		}
+	case *Property:
+		Walk(v, n.Value)
+	case *FormatString:
+		for _, part := range n.Parts {
+			Walk(v, part)
+		}
+	case *Interpolation:
+		Walk(v, n.X)
+	case *Member:
+		walkOptional(v, n.X)
+	case *Wildcard:
+		walkOptional(v, n.X)
+	case *Call:
+		walkOptional(v, n.X)
+		walkList(v, n.Args)
+	case *Index:
+		Walk(v, n.X)
+		Walk(v, n.Index)
+	case *Slice:
+		Walk(v, n.X)
+		walkOptional(v, n.Low)
+		walkOptional(v, n.High)
+	case *Unary:
+		Walk(v, n.X)
+	case *Binary:
+		Walk(v, n.X)
+		Walk(v, n.Y)
+	case *Ternary:
+		Walk(v, n.Cond)
+		Walk(v, n.Then)
+		Walk(v, n.Else)
+	case *Is:
+		Walk(v, n.X)
+	case *As:
+		Walk(v, n.X)
+	case *In:
+		Walk(v, n.X)
+		Walk(v, n.Y)
+	case *Paren:
+		Walk(v, n.X)
+	case *Lambda:
+		Walk(v, n.Body)
+	default:
+		panic(fmt.Sprintf("ast.Walk: unexpected node type %T", n))
+	}
+
+	v.Visit(nil)
+}
+
+func walkOptional(v Visitor, x Expr) {
+	if x != nil {
+		Walk(v, x)
+	}
+}
+
+func walkList(v Visitor, list []Expr) {
+	for _, x := range list {
+		Walk(v, x)
+	}
+}
+
+type inspector func(Node) bool
+
+func (f inspector) Visit(node Node) Visitor {
+	if f(node) {
+		return f
+	}
+	return nil
+}
+
+// Inspect traverses the syntax tree in depth-first order. It starts by calling
+// f(node); node must not be nil. If f returns true, Inspect invokes f
+// recursively for each of the non-nil children of node, followed by a call of
+// f(nil).
+//
+// Example:
+//
+//	ast.Inspect(expr.AST(), func(n ast.Node) bool {
+//	    if m, ok := n.(*ast.Member); ok && m.X == nil {
+//	        fields = append(fields, m.Name)
+//	    }
+//	    return true
+//	})
+func Inspect(node Node, f func(Node) bool) {
+	Walk(inspector(f), node)
+}
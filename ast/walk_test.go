@@ -0,0 +1,180 @@
+package ast_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"rodusek.dev/pkg/dcell/ast"
+)
+
+func describe(node ast.Node) string {
+	switch n := node.(type) {
+	case nil:
+		return "end"
+	case *ast.Literal:
+		return "literal " + n.Raw
+	case *ast.List:
+		return "list"
+	case *ast.Object:
+		return "object"
+	case *ast.Property:
+		return "property " + n.Key
+	case *ast.FormatString:
+		return "format string"
+	case *ast.Interpolation:
+		return "interpolation " + n.Spec
+	case *ast.Member:
+		return "member " + n.Name
+	case *ast.Wildcard:
+		return "wildcard"
+	case *ast.Call:
+		return "call " + n.Name
+	case *ast.Index:
+		return "index"
+	case *ast.Slice:
+		return "slice"
+	case *ast.Unary:
+		return "unary " + n.Op.String()
+	case *ast.Binary:
+		return "binary " + n.Op.String()
+	case *ast.Ternary:
+		return "ternary"
+	case *ast.Is:
+		return "is " + n.Type
+	case *ast.As:
+		return "as " + n.Type
+	case *ast.In:
+		return "in"
+	case *ast.Paren:
+		return "paren"
+	case *ast.Variable:
+		return "variable " + n.Name
+	case *ast.Lambda:
+		return "lambda " + n.Param
+	case *ast.Param:
+		return "param " + n.Name
+	}
+	return fmt.Sprintf("%T", node)
+}
+
+func TestWalk(t *testing.T) {
+	t.Parallel()
+	one := &ast.Literal{Kind: ast.IntLiteral, Value: int64(1), Raw: "1"}
+	name := &ast.Member{Name: "name"}
+
+	testCases := []struct {
+		name string
+		node ast.Node
+		want []string
+	}{
+		{
+			name: "leaf",
+			node: one,
+			want: []string{"literal 1", "end"},
+		}, {
+			name: "root member",
+			node: name,
+			want: []string{"member name", "end"},
+		}, {
+			name: "nested member",
+			node: &ast.Member{X: name, Name: "first"},
+			want: []string{"member first", "member name", "end", "end"},
+		}, {
+			name: "binary",
+			node: &ast.Binary{Op: ast.Add, X: name, Y: one},
+			want: []string{"binary +", "member name", "end", "literal 1", "end", "end"},
+		}, {
+			name: "free call",
+			node: &ast.Call{Name: "lower", Args: []ast.Expr{name}},
+			want: []string{"call lower", "member name", "end", "end"},
+		}, {
+			name: "member call with lambda",
+			node: &ast.Call{
+				X:    name,
+				Name: "any",
+				Args: []ast.Expr{&ast.Lambda{Param: "x", Body: &ast.Param{Name: "x"}}},
+			},
+			want: []string{"call any", "member name", "end", "lambda x", "param x", "end", "end", "end"},
+		}, {
+			name: "slice without bounds",
+			node: &ast.Slice{X: name},
+			want: []string{"slice", "member name", "end", "end"},
+		}, {
+			name: "ternary",
+			node: &ast.Ternary{Cond: name, Then: one, Else: &ast.Variable{Name: "v"}},
+			want: []string{"ternary", "member name", "end", "literal 1", "end", "variable v", "end", "end"},
+		}, {
+			name: "object",
+			node: &ast.Object{Props: []*ast.Property{{Key: "a", Raw: "a", Value: &ast.List{Elems: []ast.Expr{one}}}}},
+			want: []string{"object", "property a", "list", "literal 1", "end", "end", "end", "end"},
+		}, {
+			name: "format string",
+			node: &ast.FormatString{Parts: []ast.Node{&ast.Literal{Raw: "#"}, &ast.Interpolation{X: name, Spec: ">5"}}},
+			want: []string{"format string", "literal #", "end", "interpolation >5", "member name", "end", "end", "end"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var got []string
+
+			ast.Inspect(tc.node, func(n ast.Node) bool {
+				got = append(got, describe(n))
+				return true
+			})
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Inspect() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInspect_Prune(t *testing.T) {
+	t.Parallel()
+	node := &ast.Binary{
+		Op: ast.And,
+		X:  &ast.Paren{X: &ast.Member{Name: "hidden"}},
+		Y:  &ast.Member{Name: "visible"},
+	}
+	var got []string
+
+	ast.Inspect(node, func(n ast.Node) bool {
+		if n != nil {
+			got = append(got, describe(n))
+		}
+		_, isParen := n.(*ast.Paren)
+		return !isParen
+	})
+
+	want := []string{"binary &&", "paren", "member visible"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Inspect() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLiteralKind_String(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		kind ast.LiteralKind
+		want string
+	}{
+		{kind: ast.StringLiteral, want: "string"},
+		{kind: ast.IntLiteral, want: "int"},
+		{kind: ast.FloatLiteral, want: "float"},
+		{kind: ast.BoolLiteral, want: "bool"},
+		{kind: ast.NullLiteral, want: "null"},
+		{kind: ast.LiteralKind(-1), want: "unknown"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.want, func(t *testing.T) {
+			t.Parallel()
+			if got, want := tc.kind.String(), tc.want; got != want {
+				t.Errorf("String() = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package dcell
+
+import (
+	"rodusek.dev/pkg/dcell/internal/compile"
+	"rodusek.dev/pkg/dcell/internal/errs"
+	"rodusek.dev/pkg/dcell/internal/expr"
+)
+
+// ErrBudgetExceeded is returned when an evaluation exceeds one of the limits
+// of the [Budget] of the expression. The returned error is a [*BudgetError]
+// that describes which limit was exceeded.
+var ErrBudgetExceeded = errs.ErrBudgetExceeded
+
+// BudgetError is the error returned when an evaluation exceeds a limit of its
+// [Budget].
+type BudgetError = errs.BudgetError
+
+// Limit names the limit of a [Budget] that was exceeded in a [BudgetError].
+type Limit = errs.Limit
+
+const (
+	// LimitEvaluations is the limit set by [Budget.MaxEvaluations].
+	LimitEvaluations = errs.LimitEvaluations
+
+	// LimitStringLength is the limit set by [Budget.MaxStringLength].
+	LimitStringLength = errs.LimitStringLength
+
+	// LimitSliceLength is the limit set by [Budget.MaxSliceLength].
+	LimitSliceLength = errs.LimitSliceLength
+
+	// LimitDepth is the limit set by [Budget.MaxDepth].
+	LimitDepth = errs.LimitDepth
+)
+
+// Budget limits the amount of work that a single evaluation of an expression
+// may perform, which makes it safe to evaluate expressions from untrusted
+// sources. A zero value for any limit means that the limit is not enforced.
+type Budget struct {
+	// MaxEvaluations is the maximum number of expression nodes that may be
+	// evaluated, such as operators, member accesses, and function calls.
+	MaxEvaluations int
+
+	// MaxStringLength is the maximum length, in bytes, of any string produced
+	// by the evaluation.
+	MaxStringLength int
+
+	// MaxSliceLength is the maximum length of any slice produced by the
+	// evaluation, such as through wildcards and projections.
+	MaxSliceLength int
+
+	// MaxDepth is the maximum depth of nested expression evaluations.
+	MaxDepth int
+}
+
+// WithBudget limits every evaluation of the compiled expression to the given
+// budget. When a limit is exceeded, evaluation stops with an error that wraps
+// [ErrBudgetExceeded].
+//
+// Example:
+//
+//	dcell.Compile(rule, dcell.WithBudget(dcell.Budget{
+//	    MaxEvaluations:  10_000,
+//	    MaxStringLength: 64 << 10,
+//	}))
+func WithBudget(b Budget) Option {
+	return &option{key: budgetKey(b), fn: func(c *compile.Config) error {
+		c.Budget = &expr.Budget{
+			MaxEvaluations:  b.MaxEvaluations,
+			MaxStringLength: b.MaxStringLength,
+			MaxSliceLength:  b.MaxSliceLength,
+			MaxDepth:        b.MaxDepth,
+		}
+		return nil
+	}}
+}
+
+type budgetKey Budget
@@ -0,0 +1,175 @@
+package dcell
+
+import (
+	"container/list"
+	"sync"
+)
+
+// CacheStats is a snapshot of the statistics of a [Cache].
+type CacheStats struct {
+	// Hits is the number of compilations that were served by the cache,
+	// including those that waited for a concurrent compilation of the same
+	// expression.
+	Hits uint64
+
+	// Misses is the number of compilations that were not served by the
+	// cache, either because they compiled the expression, or because they
+	// waited for a concurrent compilation of it that failed.
+	Misses uint64
+
+	// Evictions is the number of expressions that were removed from the cache
+	// to make room for others.
+	Evictions uint64
+
+	// Len is the number of expressions in the cache.
+	Len int
+
+	// Capacity is the maximum number of expressions in the cache.
+	Capacity int
+}
+
+// Cache is a bounded cache of compiled expressions, keyed by the text of the
+// expression and the options that it was compiled with. When the cache is
+// full, the least recently used expression is evicted.
+//
+// A Cache is safe for concurrent use. Concurrent compilations of the same
+// expression and options are deduplicated, so that the expression is only
+// compiled once. Expressions that fail to compile are not cached.
+//
+// Options such as [WithVariables], [WithBudget], and [WithMethods] are
+// identified by their arguments, but options that hold functions, such as
+// [WithFunc] and [WithPureFunc], are identified by the Option value itself,
+// since different functions may share a name and a signature. Such options
+// must be created once and reused, such as in a package-level variable:
+// creating them anew for each call to [Cache.Compile] makes every call a
+// miss.
+//
+// Example:
+//
+//	var slug = dcell.WithPureFunc("slug", Slugify)
+//
+//	func match(cache *dcell.Cache, rule string, event any) (*dcell.Result, error) {
+//		expr, err := cache.Compile(rule, slug)
+//		if err != nil {
+//			return nil, err
+//		}
+//		return expr.Eval(event)
+//	}
+type Cache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[cacheKey]*list.Element
+	lru      *list.List
+	stats    CacheStats
+}
+
+type cacheKey struct {
+	expression string
+	options    any
+}
+
+// optionsKey chains the cache keys of a list of options into a single
+// comparable value.
+type optionsKey struct {
+	option any
+	next   any
+}
+
+// cacheEntry is an expression in the cache. Its result is available once done
+// is closed.
+type cacheEntry struct {
+	key  cacheKey
+	done chan struct{}
+	expr *Expr
+	err  error
+}
+
+// NewCache creates a cache that holds at most capacity compiled expressions.
+// It panics if capacity is less than 1.
+func NewCache(capacity int) *Cache {
+	if capacity < 1 {
+		panic("dcell: cache capacity must be positive")
+	}
+	return &Cache{
+		capacity: capacity,
+		entries:  make(map[cacheKey]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// Compile compiles a dcell expression string into an Expr like [Compile],
+// returning the cached Expr if the expression was already compiled with the
+// same options.
+func (c *Cache) Compile(expression string, opts ...Option) (*Expr, error) {
+	key := cacheKey{expression: expression}
+	for i := len(opts) - 1; i >= 0; i-- {
+		key.options = optionsKey{option: opts[i].cacheKey(), next: key.options}
+	}
+
+	c.mu.Lock()
+	if elem, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(elem)
+		c.mu.Unlock()
+
+		entry := elem.Value.(*cacheEntry)
+		<-entry.done
+
+		c.mu.Lock()
+		if entry.err != nil {
+			c.stats.Misses++
+		} else {
+			c.stats.Hits++
+		}
+		c.mu.Unlock()
+		return entry.expr, entry.err
+	}
+	entry := &cacheEntry{
+		key:  key,
+		done: make(chan struct{}),
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	c.stats.Misses++
+	if c.lru.Len() > c.capacity {
+		c.remove(c.lru.Back())
+		c.stats.Evictions++
+	}
+	c.mu.Unlock()
+
+	entry.expr, entry.err = Compile(expression, opts...)
+	close(entry.done)
+
+	if entry.err != nil {
+		c.mu.Lock()
+		if elem, ok := c.entries[key]; ok && elem.Value == entry {
+			c.remove(elem)
+		}
+		c.mu.Unlock()
+	}
+	return entry.expr, entry.err
+}
+
+// MustCompile compiles a dcell expression string into an Expr like
+// [Cache.Compile], and panics if it fails.
+func (c *Cache) MustCompile(expression string, opts ...Option) *Expr {
+	e, err := c.Compile(expression, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// Stats returns a snapshot of the statistics of the cache.
+func (c *Cache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Len = c.lru.Len()
+	stats.Capacity = c.capacity
+	return stats
+}
+
+func (c *Cache) remove(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*cacheEntry)
+	delete(c.entries, entry.key)
+}
@@ -11,6 +11,7 @@ program
 expression
   : term                                                # termExpression
   | expression '.' invocation                           # invocationExpression
+  | expression '?.' invocation                          # safeInvocationExpression
   | expression '[' index ']'                            # indexExpression
   | expression ('is' 'not' | 'is') type                 # isExpression
   | expression ('in' | 'not' 'in') expression           # containsExpression
@@ -21,6 +22,7 @@ expression
   | expression '**' expression                          # exponentiationExpression
   | expression ('*' | '/' | '//' | '%') expression      # multiplicativeExpression
   | expression ('+' | '-') expression                   # additiveExpression
+  | expression ('=~' | '!~') expression                 # matchExpression
   | expression ('&&' | 'and') expression                # logicalAndExpression
   | expression ('||' | 'or') expression                 # logicalOrExpression
   | expression ('<->' | 'implies') expression           # implicationExpression
@@ -38,6 +40,7 @@ expression
 term
   : literal                                            # literalTerm
   | invocation                                         # invocationTerm
+  | VARIABLE                                           # variableTerm
   ;
 
 invocation
@@ -47,7 +50,16 @@ invocation
   ;
 
 parameterList
-  : expression (',' expression)*
+  : parameter (',' parameter)*
+  ;
+
+parameter
+  : lambda                                             # lambdaParameter
+  | expression                                         # expressionParameter
+  ;
+
+lambda
+  : identifier '=>' expression
   ;
 
 identifier
@@ -66,6 +78,8 @@ literal
   | ('true' | 'false')                                 # booleanLiteral
   | 'null'                                             # nullLiteral
   | list                                               # listLiteral
+  | object                                             # objectLiteral
+  | FORMAT_STRING                                      # formatStringLiteral
   ;
 
 type
@@ -73,7 +87,15 @@ type
   ;
 
 list
-  : '[' (literal (',' literal)*)? ']'
+  : '[' (expression (',' expression)*)? ']'
+  ;
+
+object
+  : '{' (property (',' property)*)? '}'
+  ;
+
+property
+  : (identifier | string) ':' expression
   ;
 
 string
@@ -99,6 +121,7 @@ float
 ******************************************************************************/
 
 IDENTIFIER       : [a-zA-Z_][a-zA-Z0-9_-]*([a-zA-Z0-9_])?;
+VARIABLE         : '$' IDENTIFIER ;
 DECIMAL_INTEGER  : ('-'? [1-9][0-9]* | '0') ;
 HEX_INTEGER      : '0' [xX] [0-9a-fA-F]+ ;
 OCTAL_INTEGER    : '0' [0-7]+ ;
@@ -108,6 +131,10 @@ SCIENTIFIC_FLOAT : '-'? ('0' | [1-9][0-9]*) ('.' [0-9]+)? [eE] [+-]? [1-9][0-9]*
 SINGLE_QUOTE_STRING : '\'' (ESC | ~['\\\r\n])* '\'' ;
 DOUBLE_QUOTE_STRING : '"' (ESC | ~["\\\r\n])* '"' ;
 TRIPLE_QUOTE_STRING : '"""' (ESC | .)*? '"""' ;
+FORMAT_STRING
+  : 'f"' (ESC | '{{' | '}}' | INTERPOLATION | ~["\\{}\r\n])* '"'
+  | 'f\'' (ESC | '{{' | '}}' | INTERPOLATION | ~['\\{}\r\n])* '\''
+  ;
 
 // Pipe whitespace to the HIDDEN channel to support retrieving source text through the parser.
 WS             : [ \t\r\n]+ -> channel(HIDDEN) ;
@@ -119,6 +146,18 @@ fragment ESC
   : '\\' ([`'\\/fnrt] | UNICODE)
   ;
 
+// An expression embedded in a format string, whose braces are balanced and
+// whose strings may contain any characters, including the quote of the format
+// string.
+fragment INTERPOLATION
+  : '{' (INTERPOLATION | NESTED_STRING | ~["'{}])* '}'
+  ;
+
+fragment NESTED_STRING
+  : '"' (ESC | ~["\\])* '"'
+  | '\'' (ESC | ~['\\])* '\''
+  ;
+
 fragment UNICODE
   : 'u' HEX HEX HEX HEX
   ;
@@ -1,22 +1,62 @@
 package dcell
 
 import (
+	"context"
 	"encoding"
 	"reflect"
+	"strings"
 
+	"rodusek.dev/pkg/dcell/ast"
 	"rodusek.dev/pkg/dcell/internal/compile"
+	"rodusek.dev/pkg/dcell/internal/errs"
 	"rodusek.dev/pkg/dcell/internal/expr"
+	"rodusek.dev/pkg/dcell/internal/jsondoc"
+	"rodusek.dev/pkg/dcell/internal/members"
+	"rodusek.dev/pkg/dcell/internal/vm"
 )
 
+// ErrDivisionByZero is returned when the divisor of a division or modulo
+// operation is zero. Dividing by a constant zero, such as in `x / 0`, fails
+// at compile time.
+var ErrDivisionByZero = errs.ErrDivisionByZero
+
+// ErrKeyNotFound is the error wrapped by a [*KeyError], which is returned when
+// an index expression such as `headers["Content-Type"]` looks up a key that
+// does not exist in a map.
+var ErrKeyNotFound = errs.ErrKeyNotFound
+
+// KeyError is the error returned when an index expression looks up a key that
+// does not exist in a map. For string keys, it suggests the closest keys of
+// the map.
+type KeyError = errs.KeyError
+
 // Option is an option that can be used to configure the dcell compiler.
 type Option interface {
 	apply(*compile.Config) error
+
+	// cacheKey returns a comparable value that identifies the effect of the
+	// option, which keys the expressions of a [Cache].
+	cacheKey() any
+}
+
+// option is an Option identified by a key derived from its arguments, so that
+// equal options created separately share the entries of a [Cache]. Options
+// whose arguments cannot be compared, such as functions, have no key and are
+// identified by their address instead.
+type option struct {
+	key any
+	fn  func(*compile.Config) error
 }
 
-type option func(*compile.Config) error
+func (o *option) apply(c *compile.Config) error {
+	return o.fn(c)
+}
 
-func (o option) apply(c *compile.Config) error {
-	return o(c)
+func (o *option) cacheKey() any {
+	if o.key == nil {
+		return o
+	}
+	return o.key
 }
 
 var _ Option = (*option)(nil)
@@ -28,44 +68,155 @@ var _ Option = (*option)(nil)
 // from the input types to the function's parameter types and from the function's
 // return types to the output types.
 //
+// If the first parameter of fn is a [context.Context], it is not part of the
+// function's signature in expressions; instead, the context passed to
+// [Expr.EvalContext] is provided to it.
+//
+// Parameters of a function type returning T or (T, error) accept lambda
+// arguments such as `x => x.name`, which are converted into Go functions that
+// evaluate the lambda body on each call.
+//
+// A [Cache] identifies the option by its address rather than by fn, so the
+// option must be created once and reused for its cached expressions to be
+// shared.
+//
 // Example:
 //
 //	dcell.WithFunc(func(base, exponent int) (int, error) {
 //	    return int(math.Pow(float64(base), float64(exponent))), nil
 //	})
 func WithFunc(name string, fn any) Option {
-	return option(func(c *compile.Config) error {
+	return &option{fn: func(c *compile.Config) error {
 		return c.FuncTable.AddFunc(name, fn)
-	})
+	}}
+}
+
+// WithPureFunc adds a function to the dcell function table, like [WithFunc],
+// and marks it as pure: its result only depends on its arguments, and calling
+// it has no side effects.
+//
+// Calls to pure functions whose arguments are all constant, such as
+// `slug("Hello World")`, are evaluated once when the expression is compiled
+// rather than on every evaluation. Errors from such calls are reported by
+// [Compile]. Like [WithFunc], the option must be reused to share the
+// expressions of a [Cache].
+func WithPureFunc(name string, fn any) Option {
+	return &option{fn: func(c *compile.Config) error {
+		if err := c.FuncTable.AddFunc(name, fn); err != nil {
+			return err
+		}
+		entry, _ := c.FuncTable.Lookup(name)
+		entry.SetPure(true)
+		return nil
+	}}
+}
+
+// WithVariables declares the names of the variables that an expression may
+// reference, given without the leading '$'. Referencing an undeclared variable
+// fails at compile time with suggestions for the closest declared names.
+//
+// Without this option, any variable may be referenced, and unbound variables
+// are only reported when the expression is evaluated.
+//
+// Example:
+//
+//	dcell.Compile(`$user.role == "admin"`, dcell.WithVariables("user"))
+func WithVariables(names ...string) Option {
+	return &option{key: variablesKey(strings.Join(names, "\x00")), fn: func(c *compile.Config) error {
+		if c.Variables == nil {
+			c.Variables = make([]string, 0, len(names))
+		}
+		c.Variables = append(c.Variables, names...)
+		return nil
+	}}
 }
 
+type variablesKey string
+
+// Vars is a set of named variables that are bound when evaluating an
+// expression, keyed by name without the leading '$'.
+type Vars map[string]any
+
 // Expr is a compiled dcell expression that can be evaluated.
 type Expr struct {
-	expr    expr.Expr
-	display string
+	expr       expr.Expr
+	evaluator  expr.Expr
+	ast        ast.Expr
+	display    string
+	budget     *expr.Budget
+	members    *members.Policy
+	resultType reflect.Type
+	paths      *jsondoc.Paths
 }
 
 // Compile compiles a dcell expression string into an Expr.
+//
+// Sub-expressions that only consist of constants, such as `60 * 60 * 24`, are
+// evaluated once at compile time, and errors raised by them, such as dividing
+// by zero, are returned by Compile.
 func Compile(expression string, opts ...Option) (*Expr, error) {
-	cfg := &compile.Config{
-		FuncTable: tableV1(),
+	cfg, err := newConfig(opts)
+	if err != nil {
+		return nil, err
 	}
-	for _, opt := range opts {
-		if err := opt.apply(cfg); err != nil {
-			return nil, err
-		}
+	program, err := compile.NewProgram(expression, cfg)
+	if err != nil {
+		return nil, err
 	}
-	tree, err := compile.NewTree(expression, cfg)
+	evaluator, err := newEvaluator(program.Expr, cfg)
 	if err != nil {
 		return nil, err
 	}
 	result := &Expr{
-		expr:    tree,
-		display: expression,
+		expr:       program.Expr,
+		evaluator:  evaluator,
+		ast:        program.AST,
+		display:    expression,
+		budget:     cfg.Budget,
+		members:    cfg.Members,
+		resultType: program.Type,
+		paths:      jsondoc.Analyze(program.Expr),
 	}
 	return result, nil
 }
 
+func newConfig(opts []Option) (*compile.Config, error) {
+	cfg := &compile.Config{
+		// Derive a new table so that functions added through options do not
+		// leak into the shared built-in table.
+		FuncTable: tableV1().New(),
+	}
+	for _, opt := range opts {
+		if err := opt.apply(cfg); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// newEvaluator returns the expression that evaluates the tree e, which is
+// either e itself or its program for the virtual machine.
+func newEvaluator(e expr.Expr, cfg *compile.Config) (expr.Expr, error) {
+	if !cfg.VM {
+		return e, nil
+	}
+	return vm.Compile(e)
+}
+
+// WithVM evaluates the expression on a stack-based virtual machine, rather
+// than by walking its tree. The virtual machine keeps booleans, numbers, and
+// strings unboxed, and allocates less per evaluation, but it is not faster
+// for every expression: constants, projections, and lambdas evaluate faster
+// as trees. Results and errors are the same either way.
+func WithVM() Option {
+	return &option{key: vmKey{}, fn: func(c *compile.Config) error {
+		c.VM = true
+		return nil
+	}}
+}
+
+type vmKey struct{}
+
 // MustCompile compiles a dcell expression string into an Expr and panics
 // if it fails.
 func MustCompile(expr string, opts ...Option) *Expr {
@@ -78,18 +229,70 @@ func MustCompile(expr string, opts ...Option) *Expr {
 
 // Eval evaluates the expression with the provided value context.
 func (e *Expr) Eval(v any) (*Result, error) {
+	return e.eval(context.Background(), v, nil)
+}
+
+// EvalWith evaluates the expression with the provided value context and
+// variables. Each variable is accessible in the expression as `$name`.
+func (e *Expr) EvalWith(v any, vars Vars) (*Result, error) {
+	return e.eval(context.Background(), v, vars)
+}
+
+// EvalContext evaluates the expression with the provided value context,
+// stopping early if ctx is cancelled or its deadline is exceeded, in which
+// case the error of ctx is returned. If ctx is already done, the expression
+// is not evaluated at all.
+//
+// Functions registered with [WithFunc] that accept a [context.Context] as
+// their first parameter receive ctx when they are called.
+func (e *Expr) EvalContext(ctx context.Context, v any) (*Result, error) {
+	return e.eval(ctx, v, nil)
+}
+
+func (e *Expr) eval(goctx context.Context, v any, vars Vars) (*Result, error) {
+	// Expressions that never consult the context, such as folded constants,
+	// must still fail if it is already done.
+	if err := goctx.Err(); err != nil {
+		return nil, err
+	}
 	rv := reflect.ValueOf(v)
-	ctx := expr.NewContext(rv)
-	got, err := e.expr.Eval(ctx)
+	ctx := expr.NewContext(rv).WithContext(goctx).WithMembers(e.members)
+	if e.budget != nil {
+		ctx = ctx.WithBudget(*e.budget)
+	}
+	if len(vars) > 0 {
+		bound := make(map[string]reflect.Value, len(vars))
+		for name, value := range vars {
+			bound[name] = reflect.ValueOf(value)
+		}
+		ctx = ctx.WithVars(bound)
+	}
+	got, err := e.evaluator.Eval(ctx)
 	if err != nil {
 		return nil, err
 	}
 	result := &Result{
-		inner: got,
+		inner:   got,
+		members: e.members,
 	}
 	return result, nil
 }
 
+// EvalAs evaluates the expression with the provided value context, and
+// converts the result into a T as described in [Result.Decode].
+func EvalAs[T any](e *Expr, v any) (T, error) {
+	var out T
+	result, err := e.Eval(v)
+	if err != nil {
+		return out, err
+	}
+	if err := result.Decode(&out); err != nil {
+		var zero T
+		return zero, err
+	}
+	return out, nil
+}
+
 // MustEval evaluates the expression with the provided value context and panics
 // if it fails.
 func (e *Expr) MustEval(v any) *Result {
@@ -100,6 +303,23 @@ func (e *Expr) MustEval(v any) *Result {
 	return result
 }
 
+// MustEvalWith evaluates the expression with the provided value context and
+// variables, and panics if it fails.
+func (e *Expr) MustEvalWith(v any, vars Vars) *Result {
+	result, err := e.EvalWith(v, vars)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// AST returns the syntax tree of the expression, which may be traversed with
+// [ast.Walk] or [ast.Inspect]. It returns nil for expressions decoded with
+// [DecodeBinary] or [DecodeJSON], which are not parsed.
+func (e *Expr) AST() ast.Expr {
+	return e.ast
+}
+
 // String returns the string representation of the expression.
 func (e *Expr) String() string {
 	if e == nil {
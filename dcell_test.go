@@ -1,11 +1,24 @@
 package dcell_test
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"rodusek.dev/pkg/dcell"
+	"rodusek.dev/pkg/dcell/ast"
+	"rodusek.dev/pkg/dcell/internal/errs"
+	"rodusek.dev/pkg/dcell/internal/invocation"
 )
 
 func TestCompile(t *testing.T) {
@@ -71,64 +84,2660 @@ func TestWithFunc(t *testing.T) {
 	}
 }
 
+func TestStringFunctions(t *testing.T) {
+	t.Parallel()
+	type input struct {
+		Title string
+		Owner *string
+	}
+
+	testCases := []struct {
+		name string
+		expr string
+		want any
+	}{
+		{
+			name: "free function",
+			expr: `startsWith(Title, "WIP")`,
+			want: true,
+		}, {
+			name: "member function",
+			expr: `Title.lower()`,
+			want: "wip: add string functions",
+		}, {
+			name: "chained member functions",
+			expr: `Title.trimPrefix("WIP:").trim().upper()`,
+			want: "ADD STRING FUNCTIONS",
+		}, {
+			name: "member function on nil",
+			expr: `Owner.lower()`,
+			want: nil,
+		}, {
+			name: "free function on nil",
+			expr: `lower(Owner)`,
+			want: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			sut := dcell.MustCompile(tc.expr)
+
+			result, err := sut.Eval(input{Title: "WIP: Add string functions"})
+			if err != nil {
+				t.Fatalf("Eval() error = %v", err)
+			}
+
+			if got, want := result.Interface(), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Eval() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestCollectionFunctions(t *testing.T) {
+	t.Parallel()
+	type label struct {
+		Name  string `dcell:"name"`
+		Color string `dcell:"color"`
+	}
+	type input struct {
+		Owner  string   `dcell:"owner"`
+		Labels []label  `dcell:"labels"`
+		Tags   []string `dcell:"tags"`
+	}
+
+	testCases := []struct {
+		name string
+		expr string
+		want any
+	}{
+		{
+			name: "where",
+			expr: `labels.where(l => l.color == "red").name`,
+			want: []string{"bug", "urgent"},
+		}, {
+			name: "select",
+			expr: `labels.select(l => l.name.upper())`,
+			want: []string{"BUG", "DOCS", "URGENT"},
+		}, {
+			name: "any",
+			expr: `labels.any(l => l.name == "docs")`,
+			want: true,
+		}, {
+			name: "all",
+			expr: `all(labels, l => l.color == "red")`,
+			want: false,
+		}, {
+			name: "none",
+			expr: `labels.none(l => l.name == "wontfix")`,
+			want: true,
+		}, {
+			name: "first",
+			expr: `labels.first(l => l.color == "blue").name`,
+			want: "docs",
+		}, {
+			name: "count",
+			expr: `labels.count(l => l.color == "red")`,
+			want: 2,
+		}, {
+			name: "sortBy",
+			expr: `labels.sortBy(l => l.color).name`,
+			want: []string{"docs", "bug", "urgent"},
+		}, {
+			name: "groupBy",
+			expr: `labels.groupBy(l => l.color).blue.name`,
+			want: []string{"docs"},
+		}, {
+			name: "nested lambda refers to outer parameter",
+			expr: `where(labels, l => tags.any(t => t == l.name)).name`,
+			want: []string{"docs"},
+		}, {
+			name: "lambda refers to root",
+			expr: `count(labels, l => startsWith(l.name, owner))`,
+			want: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			sut := dcell.MustCompile(tc.expr)
+
+			result, err := sut.Eval(input{
+				Owner: "bu",
+				Labels: []label{
+					{Name: "bug", Color: "red"},
+					{Name: "docs", Color: "blue"},
+					{Name: "urgent", Color: "red"},
+				},
+				Tags: []string{"docs", "help"},
+			})
+			if err != nil {
+				t.Fatalf("Eval() error = %v", err)
+			}
+
+			if got, want := result.Interface(), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Eval() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestWithFunc_Lambda(t *testing.T) {
+	t.Parallel()
+	sut := dcell.MustCompile("apply(value, x => x * 2)", dcell.WithFunc("apply", func(v int, fn func(int) int) int {
+		return fn(v)
+	}))
+
+	result, err := sut.Eval(struct {
+		Value int `dcell:"value"`
+	}{Value: 21})
+	if err != nil {
+		t.Fatalf("Eval() error = %v", err)
+	}
+
+	i, err := result.Int64()
+	if err != nil {
+		t.Fatalf("Eval() error = %v", err)
+	}
+	if got, want := i, int64(42); got != want {
+		t.Errorf("Eval() = %v, want %v", got, want)
+	}
+}
+
+func TestWithFunc_DoesNotLeak(t *testing.T) {
+	t.Parallel()
+	_ = dcell.MustCompile("leaky()", dcell.WithFunc("leaky", func() int {
+		return 42
+	}))
+
+	_, err := dcell.Compile("leaky()")
+
+	if err == nil {
+		t.Errorf("Compile() error = nil, want error")
+	}
+}
+
+func TestWithPureFunc(t *testing.T) {
+	t.Parallel()
+	calls := 0
+	sut := dcell.MustCompile(`slug("Hello World") + "/" + slug(name)`, dcell.WithPureFunc("slug", func(s string) string {
+		calls++
+		return strings.ReplaceAll(strings.ToLower(s), " ", "-")
+	}))
+
+	for range 2 {
+		result, err := sut.Eval(map[string]string{"name": "Go Dev"})
+		if err != nil {
+			t.Fatalf("Eval() error = %v", err)
+		}
+		if got, want := result.Interface(), "hello-world/go-dev"; got != want {
+			t.Errorf("Eval() = %v, want %v", got, want)
+		}
+	}
+
+	if got, want := calls, 3; got != want {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+}
+
+func TestCompile_DivisionByZero(t *testing.T) {
+	t.Parallel()
+
+	_, err := dcell.Compile("count * 60 / (1 - 1)")
+
+	if got, want := err, dcell.ErrDivisionByZero; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Errorf("Compile() error = %v, want %v", got, want)
+	}
+	var semanticErr *dcell.SemanticError
+	if !errors.As(err, &semanticErr) {
+		t.Fatalf("Compile() error = %v, want SemanticError", err)
+	}
+	if got, want := semanticErr.Trace[0], "count*60/(1-1)"; got != want {
+		t.Errorf("Compile() trace = %v, want %v", got, want)
+	}
+}
+
 func TestMustCompile_Success(t *testing.T) {
 	t.Parallel()
 
-	defer func() {
-		if r := recover(); r != nil {
-			t.Errorf("MustCompile() panicked: %v", r)
-		}
-	}()
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("MustCompile() panicked: %v", r)
+		}
+	}()
+
+	expr := dcell.MustCompile("1 + 2")
+	if expr == nil {
+		t.Errorf("MustCompile() returned nil")
+	}
+}
+
+func TestExpr_Eval(t *testing.T) {
+	t.Parallel()
+	sut := dcell.MustCompile("1 + 2")
+
+	result, err := sut.Eval(nil)
+
+	if got, want := err, (error)(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Errorf("Eval() error = %v", err)
+	}
+	i, err := result.Int64()
+	if err != nil {
+		t.Fatalf("Eval() error = %v", err)
+	}
+	if got, want := i, int64(3); got != want {
+		t.Errorf("Eval() = %v, want %v", got, want)
+	}
+}
+
+func TestExpr_MustEval_Error(t *testing.T) {
+	t.Parallel()
+	sut := dcell.MustCompile("a / b")
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("MustEval() did not panic")
+		}
+	}()
+	_ = sut.MustEval(map[string]int{"a": 1, "b": 0})
+}
+
+func TestExpr_MustEval_Success(t *testing.T) {
+	t.Parallel()
+	sut := dcell.MustCompile("1 + 2")
+
+	result := sut.MustEval(nil)
+
+	i, err := result.Int64()
+	if err != nil {
+		t.Fatalf("MustEval() error = %v", err)
+	}
+	if got, want := i, int64(3); got != want {
+		t.Errorf("MustEval() = %v, want %v", got, want)
+	}
+}
+
+func TestEvalAs(t *testing.T) {
+	t.Parallel()
+	type label struct {
+		Name string `dcell:"name"`
+	}
+	input := map[string]any{
+		"labels": []any{
+			map[string]any{"name": "bug"},
+			map[string]any{"name": "ui"},
+		},
+	}
+
+	t.Run("Projection into typed slice", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := dcell.EvalAs[[]string](dcell.MustCompile(`labels.name`), input)
+
+		if err != nil {
+			t.Fatalf("EvalAs() error = %v", err)
+		}
+		if want := []string{"bug", "ui"}; !cmp.Equal(got, want) {
+			t.Errorf("EvalAs() = %v, want %v", got, want)
+		}
+	})
+	t.Run("Maps into structs", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := dcell.EvalAs[[]label](dcell.MustCompile(`labels`), input)
+
+		if err != nil {
+			t.Fatalf("EvalAs() error = %v", err)
+		}
+		if want := []label{{Name: "bug"}, {Name: "ui"}}; !cmp.Equal(got, want) {
+			t.Errorf("EvalAs() = %v, want %v", got, want)
+		}
+	})
+	t.Run("Evaluation error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := dcell.EvalAs[int](dcell.MustCompile(`a / b`), map[string]int{"a": 1, "b": 0})
+
+		if err == nil {
+			t.Errorf("EvalAs() error = nil, want error")
+		}
+	})
+	t.Run("Conversion error", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := dcell.EvalAs[[]int](dcell.MustCompile(`[1, 2.5]`), nil)
+
+		if got, want := err, dcell.ErrConvert; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+			t.Fatalf("EvalAs() error = %v, want %v", got, want)
+		}
+		if got != nil {
+			t.Errorf("EvalAs() = %v, want nil", got)
+		}
+	})
+}
+
+func TestResult_Decode(t *testing.T) {
+	t.Parallel()
+	type user struct {
+		Name string  `dcell:"name"`
+		Age  uint8   `dcell:"age"`
+		Boss *string `dcell:"boss"`
+	}
+	result := dcell.MustCompile(`users`).MustEval(map[string]any{
+		"users": []any{
+			map[string]any{"name": "alice", "age": 30},
+			map[string]any{"name": "bob", "age": 300, "boss": "alice"},
+		},
+	})
+
+	t.Run("Into struct", func(t *testing.T) {
+		t.Parallel()
+		var got user
+
+		err := dcell.MustCompile(`users[0]`).MustEval(map[string]any{
+			"users": []any{map[string]any{"name": "alice", "age": 30}},
+		}).Decode(&got)
+
+		if err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		if want := (user{Name: "alice", Age: 30}); !cmp.Equal(got, want) {
+			t.Errorf("Decode() = %v, want %v", got, want)
+		}
+	})
+	t.Run("Error reports path", func(t *testing.T) {
+		t.Parallel()
+		var got []user
+
+		err := result.Decode(&got)
+
+		var convertErr *dcell.ConvertError
+		if !errors.As(err, &convertErr) {
+			t.Fatalf("Decode() error = %v, want ConvertError", err)
+		}
+		if got, want := convertErr.Path, "[1].age"; got != want {
+			t.Errorf("Decode() error path = %q, want %q", got, want)
+		}
+	})
+	t.Run("Into any", func(t *testing.T) {
+		t.Parallel()
+		var got any
+
+		err := result.Decode(&got)
+
+		if err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		if want := result.Interface(); !cmp.Equal(got, want) {
+			t.Errorf("Decode() = %v, want %v", got, want)
+		}
+	})
+	t.Run("Non-pointer", func(t *testing.T) {
+		t.Parallel()
+		var got []user
+
+		if err := result.Decode(got); err == nil {
+			t.Errorf("Decode() error = nil, want error")
+		}
+	})
+}
+
+func TestResult_Kind(t *testing.T) {
+	t.Parallel()
+	type object struct{ Name string }
+
+	testCases := []struct {
+		name  string
+		input any
+		want  dcell.Kind
+	}{
+		{name: "Nil", input: nil, want: dcell.KindNull},
+		{name: "Nil pointer", input: (*int)(nil), want: dcell.KindNull},
+		{name: "Bool", input: true, want: dcell.KindBool},
+		{name: "Int", input: int8(1), want: dcell.KindInt},
+		{name: "Uint", input: uint(1), want: dcell.KindUint},
+		{name: "Float", input: float32(1), want: dcell.KindFloat},
+		{name: "String", input: "a", want: dcell.KindString},
+		{name: "List", input: []any{1}, want: dcell.KindList},
+		{name: "Array", input: [1]int{1}, want: dcell.KindList},
+		{name: "Map", input: map[string]int{}, want: dcell.KindMap},
+		{name: "Object", input: &object{}, want: dcell.KindObject},
+		{name: "Other", input: func() {}, want: dcell.KindOther},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := dcell.MustCompile(`$v`).MustEvalWith(nil, dcell.Vars{"v": tc.input})
+
+			if got, want := result.Kind(), tc.want; got != want {
+				t.Errorf("Result.Kind() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestResult_List(t *testing.T) {
+	t.Parallel()
+	result := dcell.MustCompile(`items.name`).MustEval(map[string]any{
+		"items": []map[string]string{{"name": "a"}, {"name": "b"}, {"name": "c"}},
+	})
+
+	if got, want := result.Len(), 3; got != want {
+		t.Errorf("Result.Len() = %v, want %v", got, want)
+	}
+	if got, want := result.Index(1).Interface(), any("b"); got != want {
+		t.Errorf("Result.Index(1) = %v, want %v", got, want)
+	}
+	if got, want := result.Index(-1).Interface(), any("c"); got != want {
+		t.Errorf("Result.Index(-1) = %v, want %v", got, want)
+	}
+	if got := result.Index(3); !got.IsNil() {
+		t.Errorf("Result.Index(3) = %v, want nil", got.Interface())
+	}
+	var got []any
+	for element := range result.All() {
+		got = append(got, element.Interface())
+	}
+	if want := []any{"a", "b", "c"}; !cmp.Equal(got, want) {
+		t.Errorf("Result.All() = %v, want %v", got, want)
+	}
+}
+
+func TestResult_List_NotList(t *testing.T) {
+	t.Parallel()
+	result := dcell.MustCompile(`"abc"`).MustEval(nil)
+
+	if got, want := result.Len(), 0; got != want {
+		t.Errorf("Result.Len() = %v, want %v", got, want)
+	}
+	if got := result.Index(0); !got.IsNil() {
+		t.Errorf("Result.Index(0) = %v, want nil", got.Interface())
+	}
+	for element := range result.All() {
+		t.Errorf("Result.All() yielded %v, want nothing", element.Interface())
+	}
+}
+
+func TestResult_Map(t *testing.T) {
+	t.Parallel()
+	type repository struct {
+		Name  string `dcell:"name"`
+		Stars int
+	}
+
+	testCases := []struct {
+		name     string
+		input    any
+		wantKeys []string
+		wantGet  map[string]any
+	}{
+		{
+			name:     "Map",
+			input:    map[string]int{"b": 2, "a": 1},
+			wantKeys: []string{"a", "b"},
+			wantGet:  map[string]any{"a": 1, "b": 2, "c": nil},
+		}, {
+			name:     "Map with integer keys",
+			input:    map[int]string{10: "ten", 2: "two"},
+			wantKeys: []string{"10", "2"},
+			wantGet:  map[string]any{"10": "ten", "3": nil},
+		}, {
+			name:     "Object",
+			input:    repository{Name: "dcell", Stars: 5},
+			wantKeys: []string{"name", "Stars"},
+			wantGet:  map[string]any{"name": "dcell", "Stars": 5, "Name": nil},
+		}, {
+			name:    "List",
+			input:   []string{"a"},
+			wantGet: map[string]any{"0": nil},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result := dcell.MustCompile(`$v`).MustEvalWith(nil, dcell.Vars{"v": tc.input})
+
+			if got, want := result.Keys(), tc.wantKeys; !cmp.Equal(got, want) {
+				t.Errorf("Result.Keys() = %v, want %v", got, want)
+			}
+			if got, want := result.Len(), len(tc.wantKeys); result.Kind() == dcell.KindMap && got != want {
+				t.Errorf("Result.Len() = %v, want %v", got, want)
+			}
+			for key, want := range tc.wantGet {
+				if got := result.Get(key).Interface(); !cmp.Equal(got, want) {
+					t.Errorf("Result.Get(%q) = %v, want %v", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestExpr_EvalJSON(t *testing.T) {
+	t.Parallel()
+	type user struct {
+		Login string `dcell:"login"`
+		ID    uint64 `dcell:"id"`
+	}
+	type label struct {
+		Name string `dcell:"name"`
+	}
+	type repo struct {
+		FullName string `dcell:"full_name"`
+		Name     string `dcell:"name"`
+		Private  bool   `dcell:"private"`
+	}
+	type event struct {
+		Action string  `dcell:"action"`
+		Number float64 `dcell:"number"`
+		Labels []label `dcell:"labels"`
+		Repo   repo    `dcell:"repo"`
+		Sender user    `dcell:"sender"`
+	}
+	data := []byte(`{
+		"action": "opened",
+		"number": 1.5,
+		"labels": [{"name": "bug"}, {"name": "ui"}],
+		"repo": {"full_name": "octocat/hello", "name": "hello", "private": false},
+		"sender": {"login": "octocat", "id": 18446744073709551615}
+	}`)
+	input := event{
+		Action: "opened",
+		Number: 1.5,
+		Labels: []label{{Name: "bug"}, {Name: "ui"}},
+		Repo:   repo{FullName: "octocat/hello", Name: "hello"},
+		Sender: user{Login: "octocat", ID: 18446744073709551615},
+	}
+
+	testCases := []struct {
+		name string
+		expr string
+	}{
+		{name: "Member", expr: `action == "opened"`},
+		{name: "Nested member", expr: `sender.login.upper()`},
+		{name: "Large integer", expr: `sender.id`},
+		{name: "Float", expr: `number * 2`},
+		{name: "Projection", expr: `labels.name`},
+		{name: "Lambda", expr: `labels.any(l => l.name == "bug")`},
+		{name: "Operators", expr: `sender.login + "/" + action`},
+		{name: "Wildcard", expr: `repo.*`},
+		{name: "Is integer", expr: `sender.id is uint`},
+		{name: "Is float", expr: `number is float`},
+		{name: "Is not string", expr: `repo.private is not string`},
+		{name: "As string", expr: `sender.id as string`},
+		{name: "As int", expr: `number as int`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			sut := dcell.MustCompile(tc.expr)
+			want := sut.MustEval(input)
+
+			got, err := sut.EvalJSON(data)
+
+			if err != nil {
+				t.Fatalf("EvalJSON() error = %v", err)
+			}
+			if !got.Equal(want) {
+				t.Errorf("EvalJSON() = %v, want %v", got.Interface(), want.Interface())
+			}
+		})
+	}
+}
+
+func TestExpr_EvalJSONReader(t *testing.T) {
+	t.Parallel()
+	sut := dcell.MustCompile(`github.event.action == "opened"`)
+
+	result, err := sut.EvalJSONReader(strings.NewReader(`{"github": {"event": {"action": "opened"}}}`))
+
+	if err != nil {
+		t.Fatalf("EvalJSONReader() error = %v", err)
+	}
+	if got, want := result.Interface(), any(true); got != want {
+		t.Errorf("EvalJSONReader() = %v, want %v", got, want)
+	}
+}
+
+func TestExpr_EvalJSON_Suggestion(t *testing.T) {
+	t.Parallel()
+	type repo struct {
+		FullName string `dcell:"full_name"`
+		Name     string `dcell:"name"`
+	}
+	type event struct {
+		Action string `dcell:"action"`
+		Repo   repo   `dcell:"repo"`
+	}
+	data := []byte(`{"action": "opened", "repo": {"full_name": "octocat/hello", "name": "hello"}}`)
+	sut := dcell.MustCompile(`repo.nmae`)
+	_, want := sut.Eval(event{})
+
+	_, err := sut.EvalJSON(data)
+
+	if err == nil || want == nil {
+		t.Fatalf("EvalJSON() error = %v, want %v", err, want)
+	}
+	if got, want := err.Error(), want.Error(); got != want {
+		t.Errorf("EvalJSON() error = %v, want %v", got, want)
+	}
+	if got, want := err.Error(), "did you mean 'name'"; !strings.Contains(got, want) {
+		t.Errorf("EvalJSON() error = %v, want containing %q", got, want)
+	}
+}
+
+func TestExpr_EvalJSON_Error(t *testing.T) {
+	t.Parallel()
+	sut := dcell.MustCompile(`action`)
+
+	testCases := []struct {
+		name string
+		data string
+	}{
+		{name: "Invalid JSON", data: `{"action": }`},
+		{name: "Missing member", data: `{"event": "opened"}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := sut.EvalJSON([]byte(tc.data)); err == nil {
+				t.Errorf("EvalJSON() error = nil, want error")
+			}
+		})
+	}
+}
+
+// rawDocument is a document that decodes its members only when they are
+// accessed.
+type rawDocument map[string]json.RawMessage
+
+func (d rawDocument) DCellMember(name string) (any, bool) {
+	raw, ok := d[name]
+	if !ok {
+		return nil, false
+	}
+	var doc rawDocument
+	if err := json.Unmarshal(raw, &doc); err == nil {
+		return doc, true
+	}
+	var v any
+	return v, json.Unmarshal(raw, &v) == nil
+}
+
+func (d rawDocument) DCellKeys() []string {
+	keys := make([]string, 0, len(d))
+	for key := range d {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func TestMemberResolver(t *testing.T) {
+	t.Parallel()
+	input := rawDocument{
+		"repository": json.RawMessage(`{"name": "dcell", "owner": {"login": "bitwizeshift"}}`),
+		"action":     json.RawMessage(`"opened"`),
+	}
+
+	testCases := []struct {
+		name    string
+		expr    string
+		want    any
+		wantErr error
+	}{
+		{
+			name: "Member",
+			expr: `action == "opened"`,
+			want: true,
+		}, {
+			name: "Nested member",
+			expr: `repository.owner.login`,
+			want: "bitwizeshift",
+		}, {
+			name: "Wildcard",
+			expr: `repository.*.count()`,
+			want: 2,
+		}, {
+			name:    "Missing member",
+			expr:    `repository.nmae`,
+			wantErr: errs.ErrUnknownName,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			sut := dcell.MustCompile(tc.expr)
+
+			result, err := sut.Eval(input)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Eval() error = %v, want %v", got, want)
+			}
+			if err != nil {
+				return
+			}
+			if got, want := result.Interface(), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Eval() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestExpr_EvalWith(t *testing.T) {
+	t.Parallel()
+	type user struct {
+		Role string `dcell:"role"`
+	}
+	type request struct {
+		Path string `dcell:"path"`
+	}
+
+	testCases := []struct {
+		name    string
+		expr    string
+		vars    dcell.Vars
+		want    any
+		wantErr error
+	}{
+		{
+			name: "variable member access",
+			expr: `$user.role`,
+			vars: dcell.Vars{"user": user{Role: "admin"}},
+			want: "admin",
+		}, {
+			name: "variables mixed with root",
+			expr: `($user.role == "admin") && startsWith(path, $prefix)`,
+			vars: dcell.Vars{"user": &user{Role: "admin"}, "prefix": "/api"},
+			want: true,
+		}, {
+			name: "variable used as function argument",
+			expr: `path.trimPrefix($prefix)`,
+			vars: dcell.Vars{"prefix": "/api"},
+			want: "/users",
+		}, {
+			name: "nil variable",
+			expr: `$user.role`,
+			vars: dcell.Vars{"user": nil},
+			want: nil,
+		}, {
+			name:    "unbound variable",
+			expr:    `$user`,
+			vars:    dcell.Vars{"users": nil},
+			wantErr: errs.ErrUnknownName,
+		}, {
+			name:    "no variables",
+			expr:    `$user`,
+			wantErr: errs.ErrUnknownName,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			sut := dcell.MustCompile(tc.expr)
+
+			result, err := sut.EvalWith(request{Path: "/api/users"}, tc.vars)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("EvalWith() error = %v, want %v", got, want)
+			}
+			if err != nil {
+				return
+			}
+			if got, want := result.Interface(), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("EvalWith() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestExpr_MustEvalWith_Error(t *testing.T) {
+	t.Parallel()
+	sut := dcell.MustCompile("$missing")
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("MustEvalWith() did not panic")
+		}
+	}()
+	_ = sut.MustEvalWith(nil, nil)
+}
+
+func TestWithVariables(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		expr    string
+		opts    []dcell.Option
+		wantErr error
+	}{
+		{
+			name: "undeclared variables are accepted",
+			expr: `$anything`,
+		}, {
+			name: "declared variable",
+			expr: `$user.role == "admin"`,
+			opts: []dcell.Option{dcell.WithVariables("user", "prefix")},
+		}, {
+			name:    "misspelled variable",
+			expr:    `$usr.role == "admin"`,
+			opts:    []dcell.Option{dcell.WithVariables("user", "prefix")},
+			wantErr: errs.ErrUnknownName,
+		}, {
+			name:    "no variables declared",
+			expr:    `$user`,
+			opts:    []dcell.Option{dcell.WithVariables()},
+			wantErr: errs.ErrUnknownName,
+		}, {
+			name: "variables declared across options",
+			expr: `$user + $prefix`,
+			opts: []dcell.Option{dcell.WithVariables("user"), dcell.WithVariables("prefix")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := dcell.Compile(tc.expr, tc.opts...)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("Compile() error = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestWithVariables_Suggestions(t *testing.T) {
+	t.Parallel()
+
+	_, err := dcell.Compile(`$usr`, dcell.WithVariables("user", "prefix"))
+
+	if err == nil {
+		t.Fatalf("Compile() error = nil, want error")
+	}
+	if got, want := err.Error(), "did you mean '$user'?"; !strings.Contains(got, want) {
+		t.Errorf("Compile() error = %q, want it to contain %q", got, want)
+	}
+}
+
+func TestExpr_EvalContext(t *testing.T) {
+	t.Parallel()
+	type key struct{}
+	sut := dcell.MustCompile(`greet(name)`, dcell.WithFunc("greet", func(ctx context.Context, name string) string {
+		return ctx.Value(key{}).(string) + ", " + name
+	}))
+	ctx := context.WithValue(context.Background(), key{}, "hello")
+
+	result, err := sut.EvalContext(ctx, struct {
+		Name string `dcell:"name"`
+	}{Name: "world"})
+
+	if err != nil {
+		t.Fatalf("EvalContext() error = %v", err)
+	}
+	if got, want := result.Interface(), "hello, world"; got != want {
+		t.Errorf("EvalContext() = %v, want %v", got, want)
+	}
+}
+
+func TestExpr_EvalContext_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		expr  string
+		input any
+	}{
+		{
+			name:  "member access",
+			expr:  `a.b`,
+			input: map[string]any{"a": map[string]any{"b": 1}},
+		}, {
+			name:  "wildcard",
+			expr:  `*`,
+			input: map[string]any{"a": 1, "b": 2},
+		}, {
+			name:  "function call",
+			expr:  `lower(name)`,
+			input: map[string]any{"name": "HELLO"},
+		}, {
+			name: "folded constant",
+			expr: `1 + 2`,
+		}, {
+			name:  "struct member access",
+			expr:  `name`,
+			input: struct{ Name string }{Name: "hello"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			sut := dcell.MustCompile(tc.expr)
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			_, err := sut.EvalContext(ctx, tc.input)
+
+			if got, want := err, context.Canceled; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("EvalContext() error = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestExpr_EvalContext_DeadlineExceeded(t *testing.T) {
+	t.Parallel()
+	sut := dcell.MustCompile(`slow().done`, dcell.WithFunc("slow", func(ctx context.Context) (map[string]any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := sut.EvalContext(ctx, nil)
+
+	if got, want := err, context.DeadlineExceeded; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Errorf("EvalContext() error = %v, want %v", got, want)
+	}
+}
+
+func TestWithBudget(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		expr      string
+		input     any
+		budget    dcell.Budget
+		wantLimit dcell.Limit
+	}{
+		{
+			name:   "within budget",
+			expr:   `(a + b) * 2`,
+			input:  map[string]int{"a": 1, "b": 2},
+			budget: dcell.Budget{MaxEvaluations: 100, MaxDepth: 10},
+		}, {
+			name:      "too many evaluations",
+			expr:      `a + a + a + a + a + a`,
+			input:     map[string]int{"a": 1},
+			budget:    dcell.Budget{MaxEvaluations: 5},
+			wantLimit: dcell.LimitEvaluations,
+		}, {
+			name:      "too deep",
+			expr:      `!!!!!!a`,
+			input:     map[string]bool{"a": true},
+			budget:    dcell.Budget{MaxDepth: 4},
+			wantLimit: dcell.LimitDepth,
+		}, {
+			name:      "string too long",
+			expr:      `"abc".repeat(10)`,
+			budget:    dcell.Budget{MaxStringLength: 16},
+			wantLimit: dcell.LimitStringLength,
+		}, {
+			name:      "repeat checked before building",
+			expr:      `s.repeat(1099511627776)`,
+			input:     map[string]string{"s": "x"},
+			budget:    dcell.Budget{MaxStringLength: 16},
+			wantLimit: dcell.LimitStringLength,
+		}, {
+			name:      "padding checked before building",
+			expr:      `s.padLeft(1099511627776)`,
+			input:     map[string]string{"s": "x"},
+			budget:    dcell.Budget{MaxStringLength: 16},
+			wantLimit: dcell.LimitStringLength,
+		}, {
+			name:      "wildcard too large",
+			expr:      `*`,
+			input:     map[string]int{"a": 1, "b": 2, "c": 3},
+			budget:    dcell.Budget{MaxSliceLength: 2},
+			wantLimit: dcell.LimitSliceLength,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			sut := dcell.MustCompile(tc.expr, dcell.WithBudget(tc.budget))
+
+			_, err := sut.Eval(tc.input)
+
+			if tc.wantLimit == "" {
+				if err != nil {
+					t.Fatalf("Eval() error = %v", err)
+				}
+				return
+			}
+			if got, want := err, dcell.ErrBudgetExceeded; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Eval() error = %v, want %v", got, want)
+			}
+			var budgetErr *dcell.BudgetError
+			if !errors.As(err, &budgetErr) {
+				t.Fatalf("Eval() error = %v, want BudgetError", err)
+			}
+			if got, want := budgetErr.Limit, tc.wantLimit; got != want {
+				t.Errorf("Eval() limit = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestWithBudget_ResetsPerEval(t *testing.T) {
+	t.Parallel()
+	sut := dcell.MustCompile(`1 + 1`, dcell.WithBudget(dcell.Budget{MaxEvaluations: 3}))
+
+	for range 3 {
+		if _, err := sut.Eval(nil); err != nil {
+			t.Fatalf("Eval() error = %v", err)
+		}
+	}
+}
+
+func TestWithVM(t *testing.T) {
+	t.Parallel()
+	input := map[string]any{
+		"users": []map[string]any{
+			{"name": "alice", "age": 30},
+			{"name": "bob", "age": 25},
+		},
+	}
+
+	testCases := []struct {
+		name string
+		expr string
+	}{
+		{name: "Arithmetic", expr: `users[0].age * 2 - users[1].age`},
+		{name: "Comparison", expr: `users[0].age > users[1].age && users[0].name < "b"`},
+		{name: "Constant", expr: `60 * 60 * 24`},
+		{name: "Projection", expr: `users.name`},
+		{name: "Lambda", expr: `users.where(u => u.age > 26).name`},
+		{name: "Error", expr: `users[0].name - 1`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tree := dcell.MustCompile(tc.expr)
+			sut := dcell.MustCompile(tc.expr, dcell.WithVM())
+			want, wantErr := tree.Eval(input)
+
+			got, err := sut.Eval(input)
+
+			if (err == nil) != (wantErr == nil) || (err != nil && err.Error() != wantErr.Error()) {
+				t.Fatalf("Eval() error = %v, want %v", err, wantErr)
+			}
+			if err == nil && !got.Equal(want) {
+				t.Errorf("Eval() = %v, want %v", got.Interface(), want.Interface())
+			}
+		})
+	}
+}
+
+func TestCompileFor(t *testing.T) {
+	t.Parallel()
+	type label struct {
+		Name string `dcell:"name"`
+	}
+	type pullRequest struct {
+		Title  string  `dcell:"title"`
+		Labels []label `dcell:"labels"`
+	}
+	type event struct {
+		PullRequest *pullRequest `dcell:"pull_request"`
+	}
+
+	testCases := []struct {
+		name    string
+		expr    string
+		want    reflect.Type
+		wantErr error
+	}{
+		{
+			name: "member access",
+			expr: `pull_request.title`,
+			want: reflect.TypeFor[string](),
+		}, {
+			name: "built-in function",
+			expr: `pull_request.title.startsWith("WIP")`,
+			want: reflect.TypeFor[bool](),
+		}, {
+			name: "lambda over elements",
+			expr: `pull_request.labels.any(l => l.name == "bug")`,
+			want: reflect.TypeFor[bool](),
+		}, {
+			name:    "misspelled member",
+			expr:    `event.pull_requst.title`,
+			wantErr: errs.ErrUnknownName,
+		}, {
+			name:    "misspelled nested member",
+			expr:    `pull_request.titel`,
+			wantErr: errs.ErrUnknownName,
+		}, {
+			name:    "misspelled member in lambda",
+			expr:    `pull_request.labels.any(l => l.nmae == "bug")`,
+			wantErr: errs.ErrUnknownName,
+		}, {
+			name:    "incompatible operands",
+			expr:    `pull_request.title - 1`,
+			wantErr: dcell.ErrCompile,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			sut, err := dcell.CompileFor[event](tc.expr)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("CompileFor() error = %v, want %v", got, want)
+			}
+			if err != nil {
+				var semanticErr *dcell.SemanticError
+				if !errors.As(err, &semanticErr) {
+					t.Errorf("CompileFor() error = %T, want *dcell.SemanticError", err)
+				}
+				return
+			}
+			if got, want := sut.ResultType(), tc.want; got != want {
+				t.Errorf("ResultType() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestNamingOptions(t *testing.T) {
+	t.Parallel()
+	type owner struct {
+		Login string `json:"login"`
+	}
+	type repository struct {
+		FullName string `json:"full_name"`
+		Owner    owner  `json:"owner" yaml:"maintainer"`
+		Secret   string `dcell:"-" json:"secret"`
+		Token    string `json:"-"`
+	}
+	input := repository{FullName: "bitwizeshift/dcell", Owner: owner{Login: "bitwizeshift"}, Secret: "s", Token: "t"}
+
+	testCases := []struct {
+		name    string
+		expr    string
+		opts    []dcell.Option
+		want    any
+		wantErr error
+	}{
+		{
+			name: "Go name without options",
+			expr: `FullName`,
+			want: "bitwizeshift/dcell",
+		}, {
+			name: "Fallback tag",
+			expr: `owner.login`,
+			opts: []dcell.Option{dcell.WithTagFallback("json")},
+			want: "bitwizeshift",
+		}, {
+			name: "Fallback tags in order",
+			expr: `maintainer.login`,
+			opts: []dcell.Option{dcell.WithTagFallback("yaml", "json")},
+			want: "bitwizeshift",
+		}, {
+			name:    "Field hidden by dcell tag",
+			expr:    `Secret`,
+			wantErr: errs.ErrUnknownName,
+		}, {
+			name:    "Field hidden by fallback tag",
+			expr:    `Token`,
+			opts:    []dcell.Option{dcell.WithTagFallback("json")},
+			wantErr: errs.ErrUnknownName,
+		}, {
+			name: "Wildcard skips hidden fields",
+			expr: `*.count()`,
+			opts: []dcell.Option{dcell.WithTagFallback("json")},
+			want: 2,
+		}, {
+			name: "Case-insensitive names",
+			expr: `fullname`,
+			opts: []dcell.Option{dcell.WithCaseInsensitiveNames()},
+			want: "bitwizeshift/dcell",
+		}, {
+			name: "Separator-insensitive names",
+			expr: `full-name`,
+			opts: []dcell.Option{dcell.WithTagFallback("json"), dcell.WithSeparatorInsensitiveNames()},
+			want: "bitwizeshift/dcell",
+		}, {
+			name:    "Separators are not folded without option",
+			expr:    `full-name`,
+			opts:    []dcell.Option{dcell.WithTagFallback("json")},
+			wantErr: errs.ErrUnknownName,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			sut := dcell.MustCompile(tc.expr, tc.opts...)
+
+			result, err := sut.Eval(input)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Eval() error = %v, want %v", got, want)
+			}
+			if err != nil {
+				return
+			}
+			if got, want := result.Interface(), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Eval() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestNamingOptions_Suggestions(t *testing.T) {
+	t.Parallel()
+	type repository struct {
+		FullName string `json:"full_name"`
+	}
+	sut := dcell.MustCompile(`full_nme`, dcell.WithTagFallback("json"))
+
+	_, err := sut.Eval(repository{})
+
+	var nameErr *errs.NameError
+	if !errors.As(err, &nameErr) {
+		t.Fatalf("Eval() error = %v, want NameError", err)
+	}
+	if got, want := nameErr.Suggestions, []string{"full_name"}; !cmp.Equal(got, want) {
+		t.Errorf("Eval() suggestions = %v, want %v", got, want)
+	}
+}
+
+func TestNamingOptions_Result(t *testing.T) {
+	t.Parallel()
+	type repository struct {
+		FullName string `json:"full_name"`
+		Stars    int    `json:"stars"`
+	}
+	type summary struct {
+		Name string `json:"full_name"`
+	}
+	sut := dcell.MustCompile(`$repo`, dcell.WithTagFallback("json"), dcell.WithCaseInsensitiveNames())
+	result := sut.MustEvalWith(nil, dcell.Vars{"repo": repository{FullName: "dcell", Stars: 5}})
+
+	if got, want := result.Keys(), []string{"full_name", "stars"}; !cmp.Equal(got, want) {
+		t.Errorf("Result.Keys() = %v, want %v", got, want)
+	}
+	if got, want := result.Get("FULL_NAME").Interface(), any("dcell"); got != want {
+		t.Errorf("Result.Get() = %v, want %v", got, want)
+	}
+	var got summary
+	if err := result.Decode(&got); err != nil {
+		t.Fatalf("Result.Decode() error = %v", err)
+	}
+	if want := (summary{Name: "dcell"}); got != want {
+		t.Errorf("Result.Decode() = %v, want %v", got, want)
+	}
+}
+
+type pbUser struct {
+	name string
+}
+
+func (u *pbUser) GetName() string {
+	return u.name
+}
+
+func (u *pbUser) GetDisplayName() string {
+	return strings.ToUpper(u.name)
+}
+
+func TestMethodOptions(t *testing.T) {
+	t.Parallel()
+	home, _ := url.Parse("https://example.com/dcell")
+	type event struct {
+		Deadline time.Time
+		Elapsed  time.Duration
+		Home     *url.URL
+		User     *pbUser
+	}
+	input := event{
+		Deadline: time.Date(2026, time.March, 14, 0, 0, 0, 0, time.UTC),
+		Elapsed:  90 * time.Minute,
+		Home:     home,
+		User:     &pbUser{name: "alice"},
+	}
+	timeType := reflect.TypeFor[time.Time]()
+
+	testCases := []struct {
+		name    string
+		expr    string
+		opts    []dcell.Option
+		want    any
+		wantErr error
+	}{
+		{
+			name:    "Methods not allowed by default",
+			expr:    `Deadline.Year()`,
+			wantErr: errs.ErrUnknownName,
+		}, {
+			name: "Method of allowed type",
+			expr: `Deadline.Year()`,
+			opts: []dcell.Option{dcell.WithMethods(timeType)},
+			want: 2026,
+		}, {
+			name: "Method with arguments",
+			expr: `Deadline.Format("2006-01-02")`,
+			opts: []dcell.Option{dcell.WithMethods(timeType)},
+			want: "2026-03-14",
+		}, {
+			name: "Method with pointer receiver",
+			expr: `Home.Hostname()`,
+			opts: []dcell.Option{dcell.WithMethods(reflect.TypeFor[*url.URL]())},
+			want: "example.com",
+		}, {
+			name: "Method with converted arguments",
+			expr: `Deadline.AddDate(1, 0, 0).Year()`,
+			opts: []dcell.Option{dcell.WithMethods(timeType)},
+			want: 2027,
+		}, {
+			name:    "Method with float for integer argument",
+			expr:    `Deadline.AddDate(1.5, 0, 0)`,
+			opts:    []dcell.Option{dcell.WithMethods(timeType)},
+			wantErr: invocation.ErrBadArgument,
+		}, {
+			name:    "Methods without types",
+			expr:    `Deadline.Year()`,
+			opts:    []dcell.Option{dcell.WithMethods()},
+			wantErr: cmpopts.AnyError,
+		}, {
+			name:    "Getters without types",
+			expr:    `User.name`,
+			opts:    []dcell.Option{dcell.WithGetters()},
+			wantErr: cmpopts.AnyError,
+		}, {
+			name:    "Method of type not allowed",
+			expr:    `Elapsed.Hours()`,
+			opts:    []dcell.Option{dcell.WithMethods(timeType)},
+			wantErr: errs.ErrUnknownName,
+		}, {
+			name: "Methods of all types",
+			expr: `Elapsed.Hours()`,
+			opts: []dcell.Option{dcell.WithAllMethods()},
+			want: 1.5,
+		}, {
+			name: "Function takes precedence over method",
+			expr: `Deadline.Year()`,
+			opts: []dcell.Option{
+				dcell.WithMethods(timeType),
+				dcell.WithFunc("Year", func(time.Time) int { return 1 }),
+			},
+			want: 1,
+		}, {
+			name: "Method type-checked against schema",
+			expr: `Deadline.Year() + 1`,
+			opts: []dcell.Option{dcell.WithMethods(timeType), dcell.WithSchema(reflect.TypeFor[event]())},
+			want: int64(2027),
+		}, {
+			name:    "Getters not read by default",
+			expr:    `User.name`,
+			wantErr: errs.ErrUnknownName,
+		}, {
+			name: "Getter of allowed type",
+			expr: `User.name`,
+			opts: []dcell.Option{dcell.WithGetters(reflect.TypeFor[*pbUser]())},
+			want: "alice",
+		}, {
+			name: "Getter of all types",
+			expr: `User.displayName`,
+			opts: []dcell.Option{dcell.WithAllGetters()},
+			want: "ALICE",
+		}, {
+			name:    "Getters do not allow methods",
+			expr:    `User.GetName()`,
+			opts:    []dcell.Option{dcell.WithAllGetters()},
+			wantErr: errs.ErrUnknownName,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			sut, err := dcell.Compile(tc.expr, tc.opts...)
+			var result *dcell.Result
+			if err == nil {
+				result, err = sut.Eval(input)
+			}
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Eval() error = %v, want %v", got, want)
+			}
+			if err != nil {
+				return
+			}
+			if got, want := result.Interface(), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Eval() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+type Account struct {
+	Login string `json:"login"`
+	ID    int    `json:"id"`
+}
+
+type Membership struct {
+	ID   int    `json:"id"`
+	Role string `json:"role"`
+}
+
+type Moderator struct {
+	Account
+	*Membership
+	Level int `json:"level"`
+}
+
+func TestEmbeddedStructs(t *testing.T) {
+	t.Parallel()
+	input := Moderator{
+		Account:    Account{Login: "octocat", ID: 1},
+		Membership: &Membership{ID: 2, Role: "maintainer"},
+		Level:      3,
+	}
+
+	testCases := []struct {
+		name    string
+		expr    string
+		want    any
+		wantErr error
+	}{
+		{
+			name: "Promoted field",
+			expr: `login`,
+			want: "octocat",
+		}, {
+			name: "Field promoted through pointer",
+			expr: `role`,
+			want: "maintainer",
+		}, {
+			name: "Embedded struct by name",
+			expr: `Account.id`,
+			want: 1,
+		}, {
+			name: "Wildcard includes promoted fields",
+			expr: `*.count()`,
+			want: 3,
+		}, {
+			name:    "Ambiguous name",
+			expr:    `id`,
+			wantErr: errs.ErrAmbiguousName,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			sut := dcell.MustCompile(tc.expr, dcell.WithTagFallback("json"))
+
+			result, err := sut.Eval(input)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Eval() error = %v, want %v", got, want)
+			}
+			if err != nil {
+				return
+			}
+			if got, want := result.Interface(), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Eval() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestEmbeddedStructs_Unexported(t *testing.T) {
+	t.Parallel()
+	type user struct {
+		Name string
+	}
+	type admin struct {
+		user
+		Level int
+	}
+	input := admin{user: user{Name: "octocat"}, Level: 1}
+
+	testCases := []struct {
+		name string
+		expr string
+		want any
+	}{
+		{name: "Promoted field", expr: `Name`, want: "octocat"},
+		{name: "Wildcard includes promoted fields", expr: `*`, want: []any{"octocat", 1}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			sut := dcell.MustCompile(tc.expr)
+
+			result, err := sut.Eval(input)
+
+			if err != nil {
+				t.Fatalf("Eval() error = %v", err)
+			}
+			if got, want := result.Interface(), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Eval() = %v, want %v", got, want)
+			}
+		})
+	}
+
+	if _, err := dcell.MustCompile(`user`).Eval(input); err == nil {
+		t.Errorf("Eval() error = nil, want error for unexported embedded struct")
+	}
+}
+
+func TestEmbeddedStructs_Errors(t *testing.T) {
+	t.Parallel()
+	opts := []dcell.Option{dcell.WithTagFallback("json"), dcell.WithSchema(reflect.TypeFor[Moderator]())}
+
+	_, err := dcell.Compile(`id`, opts...)
+	if got, want := err.Error(), "ambiguous name: 'id' may refer to any of 'Account.id', 'Membership.id'"; !strings.Contains(got, want) {
+		t.Errorf("Compile() error = %v, want %v", got, want)
+	}
+
+	_, err = dcell.Compile(`logn`, opts...)
+	var nameErr *errs.NameError
+	if !errors.As(err, &nameErr) {
+		t.Fatalf("Compile() error = %v, want NameError", err)
+	}
+	if got, want := nameErr.Suggestions, []string{"login"}; !cmp.Equal(got, want) {
+		t.Errorf("Compile() suggestions = %v, want %v", got, want)
+	}
+}
+
+func TestListAndObjectLiterals(t *testing.T) {
+	t.Parallel()
+	input := `{
+		"user": {"id": 1, "login": "octocat"},
+		"owner": {"id": 2, "login": "hubot"},
+		"labels": ["bug", "docs"]
+	}`
+
+	testCases := []struct {
+		name string
+		expr string
+		want any
+	}{
+		{
+			name: "list of members",
+			expr: `[user.id, owner.id]`,
+			want: []int64{1, 2},
+		}, {
+			name: "list of different types",
+			expr: `[user.login, user.id, null]`,
+			want: []any{"octocat", int64(1), nil},
+		}, {
+			name: "list membership",
+			expr: `owner.login in [user.login, "hubot"]`,
+			want: true,
+		}, {
+			name: "object",
+			expr: `{name: user.login, id: user.id}`,
+			want: map[string]any{"name": "octocat", "id": int64(1)},
+		}, {
+			name: "object of the same types",
+			expr: `{'author': user.login, "reviewer": owner.login}`,
+			want: map[string]string{"author": "octocat", "reviewer": "hubot"},
+		}, {
+			name: "nested",
+			expr: `{users: [user.login, owner.login], count: labels.count()}`,
+			want: map[string]any{"users": []string{"octocat", "hubot"}, "count": 2},
+		}, {
+			name: "member of object",
+			expr: `{name: user.login}.name`,
+			want: "octocat",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			sut := dcell.MustCompile(tc.expr)
+
+			result, err := sut.EvalJSON([]byte(input))
+
+			if err != nil {
+				t.Fatalf("EvalJSON() error = %v", err)
+			}
+			if got, want := result.Interface(), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("EvalJSON() = %#v, want %#v", got, want)
+			}
+		})
+	}
+
+	t.Run("duplicate key", func(t *testing.T) {
+		t.Parallel()
+		_, err := dcell.Compile(`{name: user.login, "name": owner.login}`)
+
+		if got, want := err, errs.ErrDuplicateKey; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+			t.Errorf("Compile() error = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestFormatStrings(t *testing.T) {
+	t.Parallel()
+	input := `{
+		"number": 42,
+		"user": {"login": "octocat", "name": null},
+		"price": 3.14159,
+		"merged": true
+	}`
+
+	testCases := []struct {
+		name string
+		expr string
+		want string
+	}{
+		{
+			name: "members",
+			expr: `f"PR #{number} by {user.login}"`,
+			want: "PR #42 by octocat",
+		}, {
+			name: "expressions",
+			expr: `f'{number + 1} {user.login.upper()} {merged ? "merged" : "open"}'`,
+			want: "43 OCTOCAT merged",
+		}, {
+			name: "nested quotes",
+			expr: `f"by {user.name ?? "anonymous"}"`,
+			want: "by anonymous",
+		}, {
+			name: "nested format string",
+			expr: `f"{f"#{number}":>5}"`,
+			want: "  #42",
+		}, {
+			name: "null and bool",
+			expr: `f"{user.name}/{merged}"`,
+			want: "null/true",
+		}, {
+			name: "format specs",
+			expr: `f"{price:.2f}|{number:^5}|{number:04x}|{user.login:.4}"`,
+			want: "3.14| 42  |002a|octo",
+		}, {
+			name: "escapes",
+			expr: `f"{{number}}\t{number}"`,
+			want: "{number}\t42",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			sut := dcell.MustCompile(tc.expr)
+
+			result, err := sut.EvalJSON([]byte(input))
+
+			if err != nil {
+				t.Fatalf("EvalJSON() error = %v", err)
+			}
+			if got, want := result.Interface(), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("EvalJSON() = %#v, want %#v", got, want)
+			}
+		})
+	}
+
+	for _, expr := range []string{`f"{number:.2d}"`, `f"{}"`, `f"{number +}"`, `f"{1:d} {"a":d}"`} {
+		t.Run(expr, func(t *testing.T) {
+			t.Parallel()
+			_, err := dcell.Compile(expr)
+
+			if got, want := err, dcell.ErrCompile; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("Compile() error = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestFormatStrings_AsString(t *testing.T) {
+	t.Parallel()
+	number := 42
+	testCases := []struct {
+		name  string
+		value any
+	}{
+		{name: "float32", value: float32(0.1)},
+		{name: "float32 in interface", value: []any{float32(0.1)}},
+		{name: "pointer", value: &number},
+		{name: "large float", value: 1e21},
+		{name: "unsigned integer", value: uint64(18446744073709551615)},
+		{name: "bool", value: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			operand := `v`
+			if _, ok := tc.value.([]any); ok {
+				operand = `v[0]`
+			}
+			vars := dcell.Vars{"v": tc.value}
+			want := dcell.MustCompile(`$` + operand + ` as string`).MustEvalWith(nil, vars)
+
+			got := dcell.MustCompile(`f"{$` + operand + `}"`).MustEvalWith(nil, vars)
+
+			if !got.Equal(want) {
+				t.Errorf("Eval() = %v, want %v", got.Interface(), want.Interface())
+			}
+		})
+	}
+}
+
+func TestRegexps(t *testing.T) {
+	t.Parallel()
+	input := `{
+		"ref": "refs/heads/release-1.2",
+		"title": "Fix #12 and #34",
+		"labels": ["bug", "release-blocker"],
+		"pattern": "^refs/heads/",
+		"missing": null
+	}`
+
+	testCases := []struct {
+		name string
+		expr string
+		want any
+	}{
+		{
+			name: "match",
+			expr: `ref =~ "^refs/heads/release-.*"`,
+			want: true,
+		}, {
+			name: "not match",
+			expr: `ref !~ "^refs/tags/"`,
+			want: true,
+		}, {
+			name: "match with logical operators",
+			expr: `ref =~ "release" && title =~ "#[0-9]+" || false`,
+			want: true,
+		}, {
+			name: "dynamic pattern",
+			expr: `ref =~ pattern && ref !~ pattern + "main$"`,
+			want: true,
+		}, {
+			name: "dynamic pattern in lambda",
+			expr: `labels.where(l => "release-blocker" =~ l).count()`,
+			want: 1,
+		}, {
+			name: "null does not match",
+			expr: `[missing =~ ".*", missing !~ ".*", ref =~ missing]`,
+			want: []bool{false, true, false},
+		}, {
+			name: "matches",
+			expr: `labels.any(l => l.matches("^release-"))`,
+			want: true,
+		}, {
+			name: "find",
+			expr: `[title.find("#[0-9]+"), find(title, "#x")]`,
+			want: []any{"#12", nil},
+		}, {
+			name: "findAll",
+			expr: `title.findAll("[0-9]+")`,
+			want: []string{"12", "34"},
+		}, {
+			name: "replaceRegex",
+			expr: `replaceRegex(ref, "^refs/heads/(.*)-(.*)$", "$2 ($1)")`,
+			want: "1.2 (release)",
+		}, {
+			name: "captures",
+			expr: `ref.captures("release-(?P<major>[0-9]+)\\.([0-9]+)")`,
+			want: map[string]string{"0": "release-1.2", "1": "1", "major": "1", "2": "2"},
+		}, {
+			name: "named capture",
+			expr: `captures(ref, "-(?P<version>.*)").version`,
+			want: "1.2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			sut := dcell.MustCompile(tc.expr)
+
+			result, err := sut.EvalJSON([]byte(input))
+
+			if err != nil {
+				t.Fatalf("EvalJSON() error = %v", err)
+			}
+			if got, want := result.Interface(), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("EvalJSON() = %#v, want %#v", got, want)
+			}
+		})
+	}
+
+	for _, expr := range []string{`ref =~ "("`, `ref !~ "a" + "("`, `ref.matches("[z-a]")`, `find(ref, "(?=a)")`} {
+		t.Run(expr, func(t *testing.T) {
+			t.Parallel()
+			_, err := dcell.Compile(expr)
+
+			if got, want := err, dcell.ErrCompile; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("Compile() error = %v, want %v", got, want)
+			}
+			if got, want := err, dcell.ErrInvalidPattern; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("Compile() error = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestWithMaxPatternLength(t *testing.T) {
+	t.Parallel()
+	opt := dcell.WithMaxPatternLength(8)
+	vars := dcell.Vars{"short": "^a+$", "long": "^(a+)+b+$"}
+
+	testCases := []struct {
+		name           string
+		expr           string
+		wantCompileErr error
+		wantErr        error
+	}{
+		{name: "short literal", expr: `"aa" =~ "^a+$"`},
+		{name: "long literal", expr: `"aa" =~ "^(a+)+b+$"`, wantCompileErr: dcell.ErrInvalidPattern},
+		{name: "long literal argument", expr: `"aa".find("^(a+)+b+$")`, wantCompileErr: dcell.ErrInvalidPattern},
+		{name: "short dynamic pattern", expr: `"aa" =~ $short`},
+		{name: "long dynamic pattern", expr: `"aa" =~ $long`, wantErr: dcell.ErrInvalidPattern},
+		{name: "long dynamic argument", expr: `matches("aa", $long)`, wantErr: dcell.ErrInvalidPattern},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			sut, err := dcell.Compile(tc.expr, opt)
+			if got, want := err, tc.wantCompileErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Compile() error = %v, want %v", got, want)
+			}
+			if err != nil {
+				return
+			}
+
+			_, err = sut.EvalWith(nil, vars)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("EvalWith() error = %v, want %v", got, want)
+			}
+		})
+	}
+
+	t.Run("decoded", func(t *testing.T) {
+		t.Parallel()
+		data, err := dcell.MustCompile(`ref =~ "^(a+)+b+$"`).MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary() error = %v", err)
+		}
+
+		_, err = dcell.DecodeBinary(data, opt)
+
+		if got, want := err, dcell.ErrInvalidPattern; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+			t.Errorf("DecodeBinary() error = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("not positive", func(t *testing.T) {
+		t.Parallel()
+		_, err := dcell.Compile(`"a" =~ "a"`, dcell.WithMaxPatternLength(0))
+
+		if err == nil {
+			t.Errorf("Compile() error = nil, want error")
+		}
+	})
+}
+
+func TestIndexing(t *testing.T) {
+	t.Parallel()
+	type request struct {
+		Headers map[string]string `dcell:"headers"`
+		Codes   map[int]string    `dcell:"codes"`
+		Path    string            `dcell:"path"`
+	}
+	input := request{
+		Headers: map[string]string{"Content-Type": "application/json"},
+		Codes:   map[int]string{404: "not found"},
+		Path:    "/héllo",
+	}
+
+	testCases := []struct {
+		name    string
+		expr    string
+		vars    dcell.Vars
+		want    any
+		wantErr error
+	}{
+		{
+			name: "string key",
+			expr: `headers["Content-Type"]`,
+			want: "application/json",
+		}, {
+			name: "key from variable",
+			expr: `headers[$key]`,
+			vars: dcell.Vars{"key": "Content-Type"},
+			want: "application/json",
+		}, {
+			name: "key converted to map key type",
+			expr: `codes[404]`,
+			want: "not found",
+		}, {
+			name:    "missing key",
+			expr:    `headers["Accept"]`,
+			wantErr: dcell.ErrKeyNotFound,
+		}, {
+			name: "rune of string",
+			expr: `path[2]`,
+			want: "é",
+		}, {
+			name: "runes of string",
+			expr: `path[1:-1]`,
+			want: "héll",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			sut := dcell.MustCompile(tc.expr)
+
+			result, err := sut.EvalWith(input, tc.vars)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("EvalWith() error = %v, want %v", got, want)
+			}
+			if err != nil {
+				return
+			}
+			if got, want := result.Interface(), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("EvalWith() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestIndexing_KeyError(t *testing.T) {
+	t.Parallel()
+	sut := dcell.MustCompile(`headers["Content-Typo"]`)
+
+	_, err := sut.Eval(map[string]any{"headers": map[string]string{"Content-Type": "text/plain"}})
+
+	var keyErr *dcell.KeyError
+	if !errors.As(err, &keyErr) {
+		t.Fatalf("Eval() error = %v, want KeyError", err)
+	}
+	if got, want := keyErr.Suggestions, []string{"Content-Type"}; !cmp.Equal(got, want) {
+		t.Errorf("Eval() suggestions = %v, want %v", got, want)
+	}
+}
+
+func TestIndexing_EvalJSON(t *testing.T) {
+	t.Parallel()
+	sut := dcell.MustCompile(`labels["team.platform"] + "/" + labels["owner name"]`)
+	data := []byte(`{"labels": {"team.platform": "infra", "owner name": "octocat"}, "other": 1}`)
+
+	result, err := sut.EvalJSON(data)
+
+	if err != nil {
+		t.Fatalf("EvalJSON() error = %v", err)
+	}
+	if got, want := result.Interface(), any("infra/octocat"); got != want {
+		t.Errorf("EvalJSON() = %v, want %v", got, want)
+	}
+}
+
+func TestWithStrictNulls(t *testing.T) {
+	t.Parallel()
+	type user struct {
+		Login string `dcell:"login"`
+	}
+	type pullRequest struct {
+		Title  string            `dcell:"title"`
+		User   *user             `dcell:"user"`
+		Labels []string          `dcell:"labels"`
+		Meta   map[string]string `dcell:"meta"`
+	}
+	type event struct {
+		PullRequest *pullRequest `dcell:"pull_request"`
+	}
+	input := event{PullRequest: &pullRequest{Title: "x"}}
+
+	testCases := []struct {
+		name    string
+		expr    string
+		input   any
+		want    any
+		wantErr error
+	}{
+		{
+			name:  "non-null navigation",
+			expr:  `pull_request.title == "x"`,
+			input: input,
+			want:  true,
+		}, {
+			name:  "null result",
+			expr:  `pull_request.user`,
+			input: input,
+			want:  nil,
+		}, {
+			name:    "member of null",
+			expr:    `pull_request.title == "x"`,
+			input:   event{},
+			wantErr: dcell.ErrNullNavigation,
+		}, {
+			name:    "member of nested null",
+			expr:    `pull_request.user.login`,
+			input:   input,
+			wantErr: dcell.ErrNullNavigation,
+		}, {
+			name:    "index of null",
+			expr:    `pull_request.meta["a"]`,
+			input:   input,
+			wantErr: dcell.ErrNullNavigation,
+		}, {
+			name:    "wildcard of null",
+			expr:    `pull_request.user.*`,
+			input:   input,
+			wantErr: dcell.ErrNullNavigation,
+		}, {
+			name:    "member function of null",
+			expr:    `pull_request.user.login.upper()`,
+			input:   input,
+			wantErr: dcell.ErrNullNavigation,
+		}, {
+			name:  "safe navigation",
+			expr:  `pull_request?.title`,
+			input: event{},
+			want:  nil,
+		}, {
+			name:  "safe navigation short-circuits the rest",
+			expr:  `pull_request?.user.login.upper()`,
+			input: event{},
+			want:  nil,
+		}, {
+			name:    "navigation after safe navigation",
+			expr:    `pull_request?.user.login`,
+			input:   input,
+			wantErr: dcell.ErrNullNavigation,
+		}, {
+			name:  "coalesce",
+			expr:  `pull_request.user.login ?? "ghost"`,
+			input: input,
+			want:  "ghost",
+		}, {
+			name:  "elvis",
+			expr:  `pull_request.title ?: "untitled"`,
+			input: event{},
+			want:  "untitled",
+		}, {
+			name:    "right operand of coalesce",
+			expr:    `null ?? pull_request.user.login`,
+			input:   input,
+			wantErr: dcell.ErrNullNavigation,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			sut := dcell.MustCompile(tc.expr, dcell.WithStrictNulls())
+
+			result, err := sut.Eval(tc.input)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Eval() error = %v, want %v", got, want)
+			}
+			if err != nil {
+				return
+			}
+			if got, want := result.Interface(), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Eval() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestWithStrictNulls_Path(t *testing.T) {
+	t.Parallel()
+	sut := dcell.MustCompile(`event.pull_request.title == "x"`, dcell.WithStrictNulls())
+
+	_, err := sut.EvalJSON([]byte(`{"event": {"pull_request": null}}`))
 
-	expr := dcell.MustCompile("1 + 2")
-	if expr == nil {
-		t.Errorf("MustCompile() returned nil")
+	var nullErr *dcell.NullError
+	if !errors.As(err, &nullErr) {
+		t.Fatalf("EvalJSON() error = %v, want NullError", err)
+	}
+	if got, want := nullErr.Path, "event.pull_request"; got != want {
+		t.Errorf("EvalJSON() path = %v, want %v", got, want)
 	}
 }
 
-func TestExpr_Eval(t *testing.T) {
+func TestWithStrictNulls_Default(t *testing.T) {
 	t.Parallel()
-	sut := dcell.MustCompile("1 + 2")
+	sut := dcell.MustCompile(`event.pull_request.title == "x"`)
 
-	result, err := sut.Eval(nil)
+	result, err := sut.EvalJSON([]byte(`{"event": {"pull_request": null}}`))
 
-	if got, want := err, (error)(nil); !cmp.Equal(got, want, cmpopts.EquateErrors()) {
-		t.Errorf("Eval() error = %v", err)
+	if err != nil {
+		t.Fatalf("EvalJSON() error = %v", err)
 	}
-	i, err := result.Int64()
+	if got, want := result.Interface(), any(false); got != want {
+		t.Errorf("EvalJSON() = %v, want %v", got, want)
+	}
+}
+
+func TestWithMissingMembersAsNull(t *testing.T) {
+	t.Parallel()
+	type label struct {
+		Name string `dcell:"name"`
+	}
+	type event struct {
+		Action string `dcell:"action"`
+		Label  *label `dcell:"label"`
+	}
+
+	testCases := []struct {
+		name  string
+		expr  string
+		input string
+		want  any
+	}{
+		{
+			name:  "member exists",
+			expr:  `event.label.name == "bug"`,
+			input: `{"event": {"label": {"name": "bug"}}}`,
+			want:  true,
+		}, {
+			name:  "member is missing",
+			expr:  `event.label.name == "bug"`,
+			input: `{"event": {"action": "opened"}}`,
+			want:  false,
+		}, {
+			name:  "missing member is null",
+			expr:  `event.label`,
+			input: `{"event": {}}`,
+			want:  nil,
+		}, {
+			name:  "coalesce of missing member",
+			expr:  `event.label.name ?? "none"`,
+			input: `{"event": {}}`,
+			want:  "none",
+		}, {
+			name:  "missing member of scalar",
+			expr:  `event.action.name`,
+			input: `{"event": {"action": "opened"}}`,
+			want:  nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			sut := dcell.MustCompile(tc.expr, dcell.WithMissingMembersAsNull())
+
+			result, err := sut.EvalJSON([]byte(tc.input))
+
+			if err != nil {
+				t.Fatalf("EvalJSON() error = %v", err)
+			}
+			if got, want := result.Interface(), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("EvalJSON() = %v, want %v", got, want)
+			}
+		})
+	}
+
+	t.Run("struct", func(t *testing.T) {
+		t.Parallel()
+		sut := dcell.MustCompile(`label.color ?? "none"`, dcell.WithMissingMembersAsNull())
+
+		result, err := sut.Eval(event{Label: &label{Name: "bug"}})
+
+		if err != nil {
+			t.Fatalf("Eval() error = %v", err)
+		}
+		if got, want := result.Interface(), any("none"); got != want {
+			t.Errorf("Eval() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("schema allows missing fields", func(t *testing.T) {
+		t.Parallel()
+		sut, err := dcell.Compile(`label.color`,
+			dcell.WithMissingMembersAsNull(),
+			dcell.WithSchema(reflect.TypeFor[event]()),
+		)
+		if err != nil {
+			t.Fatalf("Compile() error = %v", err)
+		}
+
+		result, err := sut.Eval(event{Label: &label{Name: "bug"}})
+
+		if err != nil {
+			t.Fatalf("Eval() error = %v", err)
+		}
+		if got := result.Interface(); got != nil {
+			t.Errorf("Eval() = %v, want nil", got)
+		}
+	})
+}
+
+func TestHas(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		expr    string
+		input   string
+		opts    []dcell.Option
+		want    any
+		wantErr error
+	}{
+		{
+			name:  "member exists",
+			expr:  `has(event.label) && (event.label.name == "bug")`,
+			input: `{"event": {"label": {"name": "bug"}}}`,
+			want:  true,
+		}, {
+			name:  "member is missing",
+			expr:  `has(event.label) && (event.label.name == "bug")`,
+			input: `{"event": {"action": "opened"}}`,
+			want:  false,
+		}, {
+			name:  "member is null",
+			expr:  `has(event.label)`,
+			input: `{"event": {"label": null}}`,
+			want:  false,
+		}, {
+			name:  "member is zero",
+			expr:  `has(event.draft)`,
+			input: `{"event": {"draft": false}}`,
+			want:  true,
+		}, {
+			name:  "exists",
+			expr:  `exists(event.labels[0].name)`,
+			input: `{"event": {"labels": [{"name": "bug"}]}}`,
+			want:  true,
+		}, {
+			name:  "index out of bounds",
+			expr:  `exists(event.labels[0].name)`,
+			input: `{"event": {"labels": []}}`,
+			want:  false,
+		}, {
+			name:  "variable is not bound",
+			expr:  `has($user)`,
+			input: `{}`,
+			want:  false,
+		}, {
+			name:  "navigation through null with strict nulls",
+			expr:  `has(event.label.name)`,
+			input: `{"event": {"label": null}}`,
+			opts:  []dcell.Option{dcell.WithStrictNulls()},
+			want:  false,
+		}, {
+			name:    "errors other than absence",
+			expr:    `has(event.count / event.zero)`,
+			input:   `{"event": {"count": 1, "zero": 0}}`,
+			wantErr: errs.ErrDivisionByZero,
+		}, {
+			name:  "function of the same name",
+			expr:  `has(event.label)`,
+			input: `{"event": {"label": {}}}`,
+			opts: []dcell.Option{dcell.WithFunc("has", func(v any) string {
+				return "custom"
+			})},
+			want: "custom",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			sut := dcell.MustCompile(tc.expr, tc.opts...)
+
+			result, err := sut.EvalJSON([]byte(tc.input))
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("EvalJSON() error = %v, want %v", got, want)
+			}
+			if err != nil {
+				return
+			}
+			if got, want := result.Interface(), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("EvalJSON() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestWithSchema(t *testing.T) {
+	t.Parallel()
+	type input struct {
+		Count int `dcell:"count"`
+	}
+	sut := dcell.MustCompile("count * 2", dcell.WithSchema(reflect.TypeFor[input]()))
+
+	result, err := sut.Eval(input{Count: 21})
 	if err != nil {
 		t.Fatalf("Eval() error = %v", err)
 	}
-	if got, want := i, int64(3); got != want {
+
+	if got, want := result.Interface(), any(int64(42)); got != want {
 		t.Errorf("Eval() = %v, want %v", got, want)
 	}
+	if got, want := sut.ResultType(), reflect.TypeFor[int64](); got != want {
+		t.Errorf("ResultType() = %v, want %v", got, want)
+	}
 }
 
-func TestExpr_MustEval_Error(t *testing.T) {
+func TestExpr_ResultType_NoSchema(t *testing.T) {
 	t.Parallel()
-	sut := dcell.MustCompile("1 / 0")
+	sut := dcell.MustCompile("count * 2")
 
-	defer func() {
-		if r := recover(); r == nil {
-			t.Errorf("MustEval() did not panic")
+	if got := sut.ResultType(); got != nil {
+		t.Errorf("ResultType() = %v, want nil", got)
+	}
+}
+
+func TestExpr_AST(t *testing.T) {
+	t.Parallel()
+	sut := dcell.MustCompile(`(user.login == "x") && any(labels, l => l.name == "bug")`)
+	var got []string
+
+	ast.Inspect(sut.AST(), func(n ast.Node) bool {
+		if m, ok := n.(*ast.Member); ok && m.X == nil {
+			got = append(got, m.Name)
 		}
-	}()
-	_ = sut.MustEval(nil)
+		return true
+	})
+
+	want := []string{"user", "labels"}
+	if !cmp.Equal(got, want) {
+		t.Errorf("AST() root members = %v, want %v", got, want)
+	}
 }
 
-func TestExpr_MustEval_Success(t *testing.T) {
+func TestFormat(t *testing.T) {
 	t.Parallel()
-	sut := dcell.MustCompile("1 + 2")
+	opts := map[string][]dcell.FormatOption{
+		"default":  nil,
+		"keywords": {dcell.FormatKeywordOperators(), dcell.FormatSingleQuotes()},
+		"wrapped":  {dcell.FormatLineWidth(10), dcell.FormatIndent("\t")},
+	}
+	inputs := []string{
+		`user.login=='octocat'and not draft`,
+		"# only bots\nuser.type == \"Bot\" || labels.*.name.contains('bot') # any label",
+		`(a ?? b) ?: - 1 + count(c[1:]) ** 2`,
+		`where(labels, l => l.name in ["bug", 'fix']) is not string`,
+	}
 
-	result := sut.MustEval(nil)
+	for name, opts := range opts {
+		for _, input := range inputs {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
 
-	i, err := result.Int64()
-	if err != nil {
-		t.Fatalf("MustEval() error = %v", err)
+				got, err := dcell.Format(input, opts...)
+				if err != nil {
+					t.Fatalf("Format(%q) error = %v", input, err)
+				}
+
+				again, err := dcell.Format(got, opts...)
+				if err != nil {
+					t.Fatalf("Format(%q) error = %v", got, err)
+				}
+				if again != got {
+					t.Errorf("Format(%q) = %q, want %q", got, again, got)
+				}
+				ignoreSpans := cmpopts.IgnoreTypes(ast.Span{}, ast.Position{})
+				ignoreRaw := cmpopts.IgnoreFields(ast.Literal{}, "Raw")
+				want := dcell.MustCompile(input).AST()
+				if diff := cmp.Diff(want, dcell.MustCompile(got).AST(), ignoreSpans, ignoreRaw); diff != "" {
+					t.Errorf("Format(%q) changed meaning (-want +got):\n%s", input, diff)
+				}
+			})
+		}
 	}
-	if got, want := i, int64(3); got != want {
-		t.Errorf("MustEval() = %v, want %v", got, want)
+}
+
+func TestFormat_SyntaxError(t *testing.T) {
+	t.Parallel()
+
+	_, err := dcell.Format("a &&")
+
+	if err == nil {
+		t.Errorf("Format() error = nil, want error")
+	}
+}
+
+func TestCache_Compile(t *testing.T) {
+	t.Parallel()
+	double := dcell.WithFunc("double", func(x int) int { return x * 2 })
+
+	testCases := []struct {
+		name       string
+		first      []dcell.Option
+		second     []dcell.Option
+		wantHits   uint64
+		wantMisses uint64
+	}{
+		{
+			name:       "no options",
+			wantHits:   1,
+			wantMisses: 1,
+		}, {
+			name:       "equal variables",
+			first:      []dcell.Option{dcell.WithVariables("a", "b")},
+			second:     []dcell.Option{dcell.WithVariables("a", "b")},
+			wantHits:   1,
+			wantMisses: 1,
+		}, {
+			name:       "different variables",
+			first:      []dcell.Option{dcell.WithVariables("a")},
+			second:     []dcell.Option{dcell.WithVariables("b")},
+			wantMisses: 2,
+		}, {
+			name:       "equal budgets",
+			first:      []dcell.Option{dcell.WithBudget(dcell.Budget{MaxDepth: 4})},
+			second:     []dcell.Option{dcell.WithBudget(dcell.Budget{MaxDepth: 4})},
+			wantHits:   1,
+			wantMisses: 1,
+		}, {
+			name:       "same function option",
+			first:      []dcell.Option{double},
+			second:     []dcell.Option{double},
+			wantHits:   1,
+			wantMisses: 1,
+		}, {
+			name:       "separately created function options",
+			first:      []dcell.Option{dcell.WithFunc("double", func(x int) int { return x * 2 })},
+			second:     []dcell.Option{dcell.WithFunc("double", func(x int) int { return x * 2 })},
+			wantMisses: 2,
+		}, {
+			name:       "equal method types",
+			first:      []dcell.Option{dcell.WithMethods(reflect.TypeFor[time.Time]())},
+			second:     []dcell.Option{dcell.WithMethods(reflect.TypeFor[time.Time]())},
+			wantHits:   1,
+			wantMisses: 1,
+		}, {
+			name:       "different method types",
+			first:      []dcell.Option{dcell.WithMethods(reflect.TypeFor[time.Time]())},
+			second:     []dcell.Option{dcell.WithMethods(reflect.TypeFor[time.Duration]())},
+			wantMisses: 2,
+		}, {
+			name:       "equal getter types",
+			first:      []dcell.Option{dcell.WithGetters(reflect.TypeFor[*pbUser]())},
+			second:     []dcell.Option{dcell.WithGetters(reflect.TypeFor[*pbUser]())},
+			wantHits:   1,
+			wantMisses: 1,
+		}, {
+			name:       "different order",
+			first:      []dcell.Option{dcell.WithVariables("a"), double},
+			second:     []dcell.Option{double, dcell.WithVariables("a")},
+			wantMisses: 2,
+		}, {
+			name:       "additional option",
+			second:     []dcell.Option{dcell.WithVariables("a")},
+			wantMisses: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			sut := dcell.NewCache(4)
+
+			first, err := sut.Compile("1 + 2", tc.first...)
+			if err != nil {
+				t.Fatalf("Compile() error = %v", err)
+			}
+			second, err := sut.Compile("1 + 2", tc.second...)
+			if err != nil {
+				t.Fatalf("Compile() error = %v", err)
+			}
+
+			stats := sut.Stats()
+			if got, want := stats.Hits, tc.wantHits; got != want {
+				t.Errorf("Stats().Hits = %v, want %v", got, want)
+			}
+			if got, want := stats.Misses, tc.wantMisses; got != want {
+				t.Errorf("Stats().Misses = %v, want %v", got, want)
+			}
+			if got, want := first == second, tc.wantHits > 0; got != want {
+				t.Errorf("Compile() shared = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestCache_Eviction(t *testing.T) {
+	t.Parallel()
+	sut := dcell.NewCache(2)
+
+	sut.MustCompile("a")
+	sut.MustCompile("b")
+	sut.MustCompile("a")
+	sut.MustCompile("c")
+	sut.MustCompile("a")
+	sut.MustCompile("b")
+
+	want := dcell.CacheStats{
+		Hits:      2,
+		Misses:    4,
+		Evictions: 2,
+		Len:       2,
+		Capacity:  2,
 	}
+	if got := sut.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCache_ErrorsNotCached(t *testing.T) {
+	t.Parallel()
+	sut := dcell.NewCache(2)
+
+	for range 2 {
+		if _, err := sut.Compile("a &&"); err == nil {
+			t.Fatalf("Compile() error = nil, want error")
+		}
+	}
+
+	stats := sut.Stats()
+	if got, want := stats.Misses, uint64(2); got != want {
+		t.Errorf("Stats().Misses = %v, want %v", got, want)
+	}
+	if got, want := stats.Len, 0; got != want {
+		t.Errorf("Stats().Len = %v, want %v", got, want)
+	}
+}
+
+func TestCache_ConcurrentCompile(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int64
+	shout := dcell.WithPureFunc("shout", func(s string) string {
+		calls.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return strings.ToUpper(s)
+	})
+	sut := dcell.NewCache(2)
+	results := make([]*dcell.Expr, 16)
+
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = sut.MustCompile(`shout("a")`, shout)
+		}()
+	}
+	wg.Wait()
+
+	if got, want := calls.Load(), int64(1); got != want {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+	for _, result := range results {
+		if got, want := result, results[0]; got != want {
+			t.Errorf("Compile() = %p, want %p", got, want)
+		}
+	}
+	stats := sut.Stats()
+	if got, want := stats.Misses, uint64(1); got != want {
+		t.Errorf("Stats().Misses = %v, want %v", got, want)
+	}
+	if got, want := stats.Hits, uint64(len(results)-1); got != want {
+		t.Errorf("Stats().Hits = %v, want %v", got, want)
+	}
+}
+
+func TestCache_ConcurrentCompileError(t *testing.T) {
+	t.Parallel()
+	fail := dcell.WithPureFunc("fail", func(s string) (string, error) {
+		time.Sleep(10 * time.Millisecond)
+		return "", errors.New(s)
+	})
+	sut := dcell.NewCache(2)
+	const calls = 16
+
+	var wg sync.WaitGroup
+	for range calls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := sut.Compile(`fail("a")`, fail); err == nil {
+				t.Errorf("Compile() error = nil, want error")
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := sut.Stats()
+	if got, want := stats.Misses, uint64(calls); got != want {
+		t.Errorf("Stats().Misses = %v, want %v", got, want)
+	}
+	if got, want := stats.Hits, uint64(0); got != want {
+		t.Errorf("Stats().Hits = %v, want %v", got, want)
+	}
+}
+
+func TestNewCache_InvalidCapacity(t *testing.T) {
+	t.Parallel()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewCache(0) did not panic")
+		}
+	}()
+
+	dcell.NewCache(0)
 }
 
 func TestExpr_String(t *testing.T) {
@@ -207,3 +2816,117 @@ func TestExpr_MarshalText(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodeBinary(t *testing.T) {
+	t.Parallel()
+	greet := dcell.WithFunc("greet", func(name string) string { return "hi " + name })
+	sut := dcell.MustCompile(`greet(user.login.lower()) + "!"`, greet)
+	input := map[string]any{"user": map[string]string{"login": "Octocat"}}
+
+	data, err := sut.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+	decoded, err := dcell.DecodeBinary(data, greet)
+	if err != nil {
+		t.Fatalf("DecodeBinary() error = %v", err)
+	}
+
+	if got, want := decoded.String(), sut.String(); got != want {
+		t.Errorf("DecodeBinary().String() = %v, want %v", got, want)
+	}
+	if got, want := decoded.MustEval(input).Interface(), any("hi octocat!"); got != want {
+		t.Errorf("DecodeBinary().Eval() = %v, want %v", got, want)
+	}
+}
+
+func TestDecodeJSON(t *testing.T) {
+	t.Parallel()
+	sut := dcell.MustCompile(`labels.any(l => l == "bug") ? 60 * 60 : $fallback`, dcell.WithVariables("fallback"))
+
+	data, err := sut.EncodeJSON()
+	if err != nil {
+		t.Fatalf("EncodeJSON() error = %v", err)
+	}
+	decoded, err := dcell.DecodeJSON(data)
+	if err != nil {
+		t.Fatalf("DecodeJSON() error = %v", err)
+	}
+
+	input := map[string]any{"labels": []string{"bug"}}
+	if got, want := decoded.MustEvalWith(input, nil).Interface(), any(int64(3600)); got != want {
+		t.Errorf("DecodeJSON().Eval() = %v, want %v", got, want)
+	}
+}
+
+func TestDecodeBinary_Error(t *testing.T) {
+	t.Parallel()
+	double := dcell.WithFunc("double", func(x int) int { return x * 2 })
+	data, err := dcell.MustCompile(`double(x)`, double).MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+
+	testCases := []struct {
+		name    string
+		data    []byte
+		opts    []dcell.Option
+		wantErr error
+	}{
+		{
+			name:    "missing function",
+			data:    data,
+			wantErr: errs.ErrUnknownName,
+		}, {
+			name:    "mismatched arity",
+			data:    data,
+			opts:    []dcell.Option{dcell.WithFunc("double", func(x, y int) int { return x * y })},
+			wantErr: dcell.ErrDecode,
+		}, {
+			name:    "malformed data",
+			data:    data[:len(data)/2],
+			opts:    []dcell.Option{double},
+			wantErr: dcell.ErrDecode,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := dcell.DecodeBinary(tc.data, tc.opts...)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("DecodeBinary() error = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestExpr_UnmarshalBinary(t *testing.T) {
+	t.Parallel()
+	data, err := dcell.MustCompile(`name.upper()`).MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+	var sut dcell.Expr
+
+	if err := sut.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v", err)
+	}
+
+	input := map[string]string{"name": "dcell"}
+	if got, want := sut.MustEval(input).Interface(), any("DCELL"); got != want {
+		t.Errorf("Eval() = %v, want %v", got, want)
+	}
+}
+
+func TestExpr_MarshalBinary_Unencodable(t *testing.T) {
+	t.Parallel()
+	type point struct{ X, Y int }
+	sut := dcell.MustCompile(`origin()`, dcell.WithPureFunc("origin", func() point { return point{} }))
+
+	if _, err := sut.MarshalBinary(); err == nil {
+		t.Errorf("MarshalBinary() error = nil, want error")
+	}
+}
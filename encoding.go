@@ -0,0 +1,126 @@
+package dcell
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+
+	"rodusek.dev/pkg/dcell/internal/codec"
+	"rodusek.dev/pkg/dcell/internal/jsondoc"
+)
+
+// ErrDecode is the error wrapped by all errors raised when decoding an
+// expression with [DecodeBinary], [DecodeJSON], or [Expr.UnmarshalBinary].
+var ErrDecode = codec.ErrDecode
+
+// MarshalBinary encodes the compiled expression tree into a compact binary
+// form, which can be decoded with [DecodeBinary] without parsing the
+// expression again.
+//
+// Functions are encoded by name, and literals by value. Expressions with
+// literals that have no portable form, such as the result of a pure function
+// that returns a struct, cannot be encoded.
+func (e *Expr) MarshalBinary() ([]byte, error) {
+	doc, err := e.document()
+	if err != nil {
+		return nil, err
+	}
+	return doc.MarshalBinary()
+}
+
+var _ encoding.BinaryMarshaler = (*Expr)(nil)
+
+// UnmarshalBinary decodes an expression encoded with [Expr.MarshalBinary],
+// linking it against the built-in functions. Use [DecodeBinary] to link it
+// against functions added with [WithFunc].
+func (e *Expr) UnmarshalBinary(b []byte) error {
+	exp, err := DecodeBinary(b)
+	if err != nil {
+		return err
+	}
+	*e = *exp
+	return nil
+}
+
+var _ encoding.BinaryUnmarshaler = (*Expr)(nil)
+
+// EncodeJSON encodes the compiled expression tree as JSON, like
+// [Expr.MarshalBinary]. The JSON form can be decoded with [DecodeJSON].
+//
+// Expr does not implement [json.Marshaler], so that expressions embedded in
+// JSON documents keep being encoded as their source text.
+func (e *Expr) EncodeJSON() ([]byte, error) {
+	doc, err := e.document()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+}
+
+func (e *Expr) document() (*codec.Document, error) {
+	node, err := codec.Encode(e.expr)
+	if err != nil {
+		return nil, fmt.Errorf("dcell: encode %q: %w", e.display, err)
+	}
+	doc := &codec.Document{
+		Version: codec.Version,
+		Source:  e.display,
+		Expr:    node,
+	}
+	return doc, nil
+}
+
+// DecodeBinary decodes an expression encoded with [Expr.MarshalBinary].
+//
+// Functions are linked by name against the built-in functions and those added
+// with [WithFunc] or [WithPureFunc], and decoding fails if a function is
+// missing or does not accept the number of arguments that it is called with.
+// Variables are checked against [WithVariables], patterns against
+// [WithMaxPatternLength], and [WithBudget] applies as it does to [Compile].
+// [WithSchema] has no effect, since the expression is not parsed.
+func DecodeBinary(b []byte, opts ...Option) (*Expr, error) {
+	doc := &codec.Document{}
+	if err := doc.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return decode(doc, opts)
+}
+
+// DecodeJSON decodes an expression encoded with [Expr.EncodeJSON], like
+// [DecodeBinary].
+func DecodeJSON(b []byte, opts ...Option) (*Expr, error) {
+	doc := &codec.Document{}
+	if err := json.Unmarshal(b, doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecode, err)
+	}
+	return decode(doc, opts)
+}
+
+func decode(doc *codec.Document, opts []Option) (*Expr, error) {
+	cfg, err := newConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	decoder := &codec.Decoder{
+		FuncTable: cfg.FuncTable,
+		Variables: cfg.Variables,
+		Patterns:  cfg.Patterns,
+	}
+	e, err := decoder.DecodeDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	evaluator, err := newEvaluator(e, cfg)
+	if err != nil {
+		return nil, err
+	}
+	result := &Expr{
+		expr:      e,
+		evaluator: evaluator,
+		display:   doc.Source,
+		budget:    cfg.Budget,
+		members:   cfg.Members,
+		paths:     jsondoc.Analyze(e),
+	}
+	return result, nil
+}
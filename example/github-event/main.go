@@ -11,95 +11,23 @@ import (
 	"rodusek.dev/pkg/dcell"
 )
 
-type Ref struct {
-	Label string `json:"label" dcell:"label"`
-	Ref   string `json:"ref" dcell:"ref"`
-	SHA   string `json:"sha" dcell:"sha"`
-	User  User   `json:"user" dcell:"user"`
-}
-
-type User struct {
-	Login     string `json:"login" dcell:"login"`
-	ID        int    `json:"id" dcell:"id"`
-	AvatarURL string `json:"avatar_url" dcell:"avatar_url"`
-	URL       string `json:"url" dcell:"url"`
-	HTMLURL   string `json:"html_url" dcell:"html_url"`
-}
-
-type PullRequest struct {
-	URL               string  `json:"url" dcell:"url"`
-	ID                int     `json:"id" dcell:"id"`
-	HTMLURL           string  `json:"html_url" dcell:"html_url"`
-	DiffURL           string  `json:"diff_url" dcell:"diff_url"`
-	PatchURL          string  `json:"patch_url" dcell:"patch_url"`
-	IssueURL          string  `json:"issue_url" dcell:"issue_url"`
-	Number            int     `json:"number" dcell:"number"`
-	State             string  `json:"state" dcell:"state"`
-	Locked            bool    `json:"locked" dcell:"locked"`
-	Title             string  `json:"title" dcell:"title"`
-	User              User    `json:"user" dcell:"user"`
-	Body              string  `json:"body" dcell:"body"`
-	CreatedAt         string  `json:"created_at" dcell:"created_at"`
-	UpdatedAt         string  `json:"updated_at" dcell:"updated_at"`
-	ClosedAt          *string `json:"closed_at" dcell:"closed_at"`
-	MergedAt          *string `json:"merged_at" dcell:"merged_at"`
-	MergeCommitSHA    *string `json:"merge_commit_sha" dcell:"merge_commit_sha"`
-	Assignee          *string `json:"assignee" dcell:"assignee"`
-	Milestone         *string `json:"milestone" dcell:"milestone"`
-	CommitsURL        string  `json:"commits_url" dcell:"commits_url"`
-	ReviewCommentsURL string  `json:"review_comments_url" dcell:"review_comments_url"`
-	CommentsURL       string  `json:"comments_url" dcell:"comments_url"`
-	StatusesURL       string  `json:"statuses_url" dcell:"statuses_url"`
-
-	Head Ref `json:"head" dcell:"head"`
-	Base Ref `json:"base" dcell:"base"`
-}
-
-type Repository struct {
-	ID       int    `json:"id" dcell:"id"`
-	Name     string `json:"name" dcell:"name"`
-	FullName string `json:"full_name" dcell:"full_name"`
-	Owner    User   `json:"owner" dcell:"owner"`
-	Private  bool   `json:"private" dcell:"private"`
-	HTMLURL  string `json:"html_url" dcell:"html_url"`
-	URL      string `json:"url" dcell:"url"`
-}
-
-type Event struct {
-	Action      string       `json:"action" dcell:"action"`
-	Number      int          `json:"number" dcell:"number"`
-	PullRequest *PullRequest `json:"pull_request" dcell:"pull_request"`
-	Repository  Repository   `json:"repository" dcell:"repository"`
-	Sender      User         `json:"sender" dcell:"sender"`
-}
-
-type GitHubContext struct {
-	EventName string `json:"event_name" dcell:"event_name"`
-	Ref       string `json:"ref" dcell:"ref"`
-	Event     Event  `json:"event" dcell:"event"`
-}
-
-type Context struct {
-	GitHub GitHubContext `json:"github" dcell:"github"`
-}
-
 //go:embed pull_request.payload.json
 var eventPayload []byte
 
-func LoadContext() (*Context, error) {
-	var event Event
-	if err := json.Unmarshal(eventPayload, &event); err != nil {
+// LoadContext returns the JSON document of the `github` context of a
+// workflow triggered by the pull request event of the payload.
+func LoadContext() ([]byte, error) {
+	ref, err := dcell.MustCompile(`pull_request.head.ref`).EvalJSON(eventPayload)
+	if err != nil {
 		return nil, fmt.Errorf("pull_request: %w", err)
 	}
-	ctx := &Context{
-		GitHub: GitHubContext{
-			EventName: "pull_request",
-			Ref:       event.PullRequest.Head.Ref,
-			Event:     event,
+	return json.Marshal(map[string]any{
+		"github": map[string]any{
+			"event_name": "pull_request",
+			"ref":        ref.Interface(),
+			"event":      json.RawMessage(eventPayload),
 		},
-	}
-
-	return ctx, nil
+	})
 }
 
 func main() {
@@ -115,7 +43,7 @@ func main() {
 	if err != nil {
 		log.Fatalf("error: loading context failed: %v", err)
 	}
-	result, err := expr.Eval(ctx)
+	result, err := expr.EvalJSON(ctx)
 	if err != nil {
 		log.Fatalf("error: evaluation failed: %v", err)
 	}
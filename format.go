@@ -0,0 +1,79 @@
+package dcell
+
+import (
+	"rodusek.dev/pkg/dcell/internal/compile"
+	"rodusek.dev/pkg/dcell/internal/format"
+)
+
+// FormatOption is an option that configures the style of [Format].
+type FormatOption interface {
+	applyFormat(*format.Config)
+}
+
+type formatOption func(*format.Config)
+
+func (o formatOption) applyFormat(c *format.Config) {
+	o(c)
+}
+
+var _ FormatOption = (*formatOption)(nil)
+
+// FormatKeywordOperators prints the logical operators as the keywords `and`,
+// `or`, `implies`, and `not` rather than as `&&`, `||`, `<->`, and `!`.
+func FormatKeywordOperators() FormatOption {
+	return formatOption(func(c *format.Config) {
+		c.KeywordOperators = true
+	})
+}
+
+// FormatSingleQuotes prints string literals with single quotes rather than
+// double quotes. Strings that contain a single quote are still printed with
+// double quotes.
+func FormatSingleQuotes() FormatOption {
+	return formatOption(func(c *format.Config) {
+		c.SingleQuotes = true
+	})
+}
+
+// FormatLineWidth sets the width, in characters, beyond which chains of `&&`
+// and `||` operations are wrapped with one operand per line. A width of 0
+// disables wrapping. The default width is 80.
+func FormatLineWidth(width int) FormatOption {
+	return formatOption(func(c *format.Config) {
+		c.LineWidth = width
+	})
+}
+
+// FormatIndent sets the indentation of each level of wrapped lines. The
+// default indentation is two spaces.
+func FormatIndent(indent string) FormatOption {
+	return formatOption(func(c *format.Config) {
+		c.Indent = indent
+	})
+}
+
+// Format returns the canonical form of a dcell expression, so that
+// expressions that only differ in spacing, operator spelling, or quoting are
+// formatted identically. Comments are kept, and the formatted expression
+// compiles to the same expression as the original.
+//
+// Format only checks the syntax of the expression; it does not resolve the
+// functions or variables that it references.
+//
+// Example:
+//
+//	dcell.Format(`a and(b||c) # check`) // `a && (b || c) # check`
+func Format(expression string, opts ...FormatOption) (string, error) {
+	cfg := &format.Config{
+		LineWidth: 80,
+		Indent:    "  ",
+	}
+	for _, opt := range opts {
+		opt.applyFormat(cfg)
+	}
+	node, comments, err := compile.ParseAST(expression)
+	if err != nil {
+		return "", err
+	}
+	return format.Node(node, comments, cfg), nil
+}
@@ -0,0 +1,155 @@
+package codec
+
+import (
+	"encoding"
+	"encoding/binary"
+	"fmt"
+)
+
+// magic is the prefix of binary encoded documents.
+const magic = "dcel"
+
+// MarshalBinary encodes the document into a compact binary form.
+//
+// The binary form starts with a magic prefix and the version, followed by
+// the source text and the expression tree. Strings are encoded as their
+// uvarint length followed by their bytes, and each node of the tree as a
+// presence byte, its op, name, type, and value strings, and the uvarint count
+// of its operands followed by the operands.
+func (d *Document) MarshalBinary() ([]byte, error) {
+	b := []byte(magic)
+	b = binary.AppendUvarint(b, uint64(d.Version))
+	b = appendString(b, d.Source)
+	b = appendNode(b, d.Expr)
+	return b, nil
+}
+
+var _ encoding.BinaryMarshaler = (*Document)(nil)
+
+// UnmarshalBinary decodes a document from the binary form produced by
+// [Document.MarshalBinary]. Errors wrap [ErrDecode].
+func (d *Document) UnmarshalBinary(b []byte) error {
+	if len(b) < len(magic) || string(b[:len(magic)]) != magic {
+		return fmt.Errorf("%w: not a binary encoded expression", ErrDecode)
+	}
+	r := &reader{buf: b[len(magic):]}
+	version := r.uvarint()
+	source := r.string()
+	node := r.node()
+	if r.err != nil {
+		return r.err
+	}
+	if len(r.buf) != 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrDecode, len(r.buf))
+	}
+	*d = Document{
+		Version: int(version),
+		Source:  source,
+		Expr:    node,
+	}
+	return nil
+}
+
+var _ encoding.BinaryUnmarshaler = (*Document)(nil)
+
+func appendString(b []byte, s string) []byte {
+	b = binary.AppendUvarint(b, uint64(len(s)))
+	return append(b, s...)
+}
+
+func appendNode(b []byte, n *Node) []byte {
+	if n == nil {
+		return append(b, 0)
+	}
+	b = append(b, 1)
+	b = appendString(b, string(n.Op))
+	b = appendString(b, n.Name)
+	b = appendString(b, n.Type)
+	b = appendString(b, n.Value)
+	b = binary.AppendUvarint(b, uint64(len(n.Args)))
+	for _, arg := range n.Args {
+		b = appendNode(b, arg)
+	}
+	return b
+}
+
+// reader reads the binary form of a document. After the first error, all
+// reads return zero values and the error is kept in err.
+type reader struct {
+	buf []byte
+	err error
+}
+
+func (r *reader) fail(what string) {
+	if r.err == nil {
+		r.err = fmt.Errorf("%w: truncated or malformed %s", ErrDecode, what)
+	}
+}
+
+func (r *reader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		r.fail("integer")
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *reader) string() string {
+	n := r.uvarint()
+	if r.err != nil {
+		return ""
+	}
+	if n > uint64(len(r.buf)) {
+		r.fail("string")
+		return ""
+	}
+	s := string(r.buf[:n])
+	r.buf = r.buf[n:]
+	return s
+}
+
+func (r *reader) node() *Node {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.buf) == 0 {
+		r.fail("node")
+		return nil
+	}
+	present := r.buf[0]
+	r.buf = r.buf[1:]
+	switch present {
+	case 0:
+		return nil
+	case 1:
+	default:
+		r.fail("node")
+		return nil
+	}
+
+	n := &Node{
+		Op:    Op(r.string()),
+		Name:  r.string(),
+		Type:  r.string(),
+		Value: r.string(),
+	}
+	count := r.uvarint()
+	// Each operand takes at least one byte, which bounds the allocation for
+	// malformed input.
+	if count > uint64(len(r.buf)) {
+		r.fail("node")
+		return nil
+	}
+	if count > 0 {
+		n.Args = make([]*Node, 0, count)
+	}
+	for range count {
+		n.Args = append(n.Args, r.node())
+	}
+	return n
+}
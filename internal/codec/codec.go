@@ -0,0 +1,133 @@
+/*
+Package codec encodes compiled dcell expression trees into a portable form,
+so that an expression compiled in one process can be evaluated in another
+without being parsed again.
+
+The portable form is a tree of [Node] values, which is encoded as either JSON
+or a compact binary format. Functions are referenced by name, and are linked
+against the function table of the decoding side.
+*/
+package codec
+
+import (
+	"errors"
+)
+
+// ErrDecode is the error wrapped by all errors raised when decoding an
+// expression.
+var ErrDecode = errors.New("decode")
+
+// Version is the version of the encoding produced by this package. Documents
+// of other versions are rejected. Nodes of an [Op] or [Type] that the decoder
+// does not know are rejected with [ErrDecode] as well, so adding nodes to the
+// vocabulary does not change the version.
+const Version = 1
+
+// Op is the kind of operation of a [Node].
+type Op string
+
+const (
+	OpLiteral  Op = "literal"
+	OpSequence Op = "sequence"
+	OpMember   Op = "member"
+	OpWildcard Op = "wildcard"
+	OpParam    Op = "param"
+	OpVariable Op = "variable"
+	OpIndex    Op = "index"
+	OpSlice    Op = "slice"
+	OpCall     Op = "call"
+	OpMethod   Op = "method"
+	OpGoMethod Op = "gomethod"
+	OpLambda   Op = "lambda"
+	OpNonNull  Op = "nonnull"
+	OpHas      Op = "has"
+	OpList     Op = "list"
+	OpObject   Op = "object"
+	OpFormat   Op = "format"
+	OpPattern  Op = "pattern"
+
+	OpNot    Op = "not"
+	OpBitNot Op = "bitnot"
+	OpPlus   Op = "plus"
+	OpMinus  Op = "minus"
+
+	OpPow      Op = "pow"
+	OpMul      Op = "mul"
+	OpDiv      Op = "div"
+	OpFloorDiv Op = "floordiv"
+	OpMod      Op = "mod"
+	OpAdd      Op = "add"
+	OpSub      Op = "sub"
+	OpShl      Op = "shl"
+	OpShr      Op = "shr"
+	OpBitAnd   Op = "bitand"
+	OpBitOr    Op = "bitor"
+	OpBitXor   Op = "bitxor"
+	OpAnd      Op = "and"
+	OpOr       Op = "or"
+	OpImplies  Op = "implies"
+	OpEq       Op = "eq"
+	OpLt       Op = "lt"
+	OpLe       Op = "le"
+	OpGt       Op = "gt"
+	OpGe       Op = "ge"
+	OpIn       Op = "in"
+	OpNotIn    Op = "notin"
+	OpMatch    Op = "match"
+	OpNotMatch Op = "notmatch"
+	OpIs       Op = "is"
+	OpAs       Op = "as"
+	OpTernary  Op = "ternary"
+	OpElvis    Op = "elvis"
+	OpCoalesce Op = "coalesce"
+)
+
+// Literal types are the types of the values of [OpLiteral] nodes.
+const (
+	TypeNull   = "null"
+	TypeBool   = "bool"
+	TypeInt    = "int"
+	TypeUint   = "uint"
+	TypeFloat  = "float"
+	TypeString = "string"
+	TypeList   = "list"
+	TypeMap    = "map"
+	TypeRegexp = "regexp"
+)
+
+// Node is a node of the portable form of an expression tree.
+type Node struct {
+	// Op is the operation of the node.
+	Op Op `json:"op"`
+
+	// Name is the name of the member, variable, lambda parameter, or function
+	// of the node, the key of a literal that is an entry of a map literal, or
+	// the path of an operand that must not be null.
+	Name string `json:"name,omitempty"`
+
+	// Type is the type of a literal, or the type operand of `is` and `as`.
+	Type string `json:"type,omitempty"`
+
+	// Value is the text of a scalar literal, the source of a [TypeRegexp]
+	// literal, or the format spec of an [OpFormat] node.
+	Value string `json:"value,omitempty"`
+
+	// Args are the operands of the node, in evaluation order. The elements
+	// of list and map literals are also stored as operands, and the keys and
+	// values of [OpObject] nodes are stored as operands in turn, with keys as
+	// string literals. Operands that are omitted, such as the bounds of an
+	// open slice, are nil.
+	Args []*Node `json:"args,omitempty"`
+}
+
+// Document is an encoded expression.
+type Document struct {
+	// Version is the version of the encoding.
+	Version int `json:"version"`
+
+	// Source is the source text that the expression was compiled from.
+	Source string `json:"source"`
+
+	// Expr is the root of the expression tree.
+	Expr *Node `json:"expr"`
+}
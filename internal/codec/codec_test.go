@@ -0,0 +1,420 @@
+package codec_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"rodusek.dev/pkg/dcell/internal/codec"
+	"rodusek.dev/pkg/dcell/internal/compile"
+	"rodusek.dev/pkg/dcell/internal/errs"
+	"rodusek.dev/pkg/dcell/internal/expr"
+	"rodusek.dev/pkg/dcell/internal/invocation"
+	"rodusek.dev/pkg/dcell/internal/invocation/arity"
+	"rodusek.dev/pkg/dcell/internal/regex"
+	"rodusek.dev/pkg/dcell/internal/stdlib"
+)
+
+func newTable() *invocation.Table {
+	table := invocation.NewTable()
+	stdlib.AddStrings(table)
+	stdlib.AddCollections(table)
+	stdlib.AddRegexps(table)
+	_ = table.AddFunc("double", func(x int64) int64 { return x * 2 })
+	return table
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+	type nested struct {
+		Items []int64 `dcell:"items"`
+	}
+	input := struct {
+		Name   string   `dcell:"name"`
+		Age    int64    `dcell:"age"`
+		Tags   []string `dcell:"tags"`
+		Score  float64  `dcell:"score"`
+		Nested nested   `dcell:"nested"`
+	}{
+		Name:   "Alice",
+		Age:    30,
+		Tags:   []string{"admin", "dev"},
+		Score:  2.5,
+		Nested: nested{Items: []int64{3, 1, 2}},
+	}
+
+	testCases := []struct {
+		name string
+		expr string
+	}{
+		{name: "member", expr: `nested.items[1]`},
+		{name: "slice", expr: `tags[1:]`},
+		{name: "open slice", expr: `nested.items[:2]`},
+		{name: "wildcard", expr: `nested.*`},
+		{name: "arithmetic", expr: `-age + 2 ** 3 * score / 2 // 1 % 7`},
+		{name: "bitwise", expr: `~age & 7 | 8 ^ 1 << 2 >> 1`},
+		{name: "logic", expr: `!(age > 20) || age <= 30 && score >= 2 <-> score < 3`},
+		{name: "equality", expr: `name == "Alice" && name != "Bob"`},
+		{name: "membership", expr: `"dev" in tags && "ops" not in tags`},
+		{name: "match", expr: `name =~ "^Al" && name !~ tags[0] + "$"`},
+		{name: "regex functions", expr: `name.replaceRegex("(l)", "$1$1").find("l+") + tags.join(",").findAll("[a-z]+")[1]`},
+		{name: "types", expr: `age is int && (score as int) is not string`},
+		{name: "ternary", expr: `age > 18 ? "adult" : "minor"`},
+		{name: "elvis", expr: `name == "Bob" ?: name`},
+		{name: "coalesce", expr: `(age > 40 ? name : null) ?? "default"`},
+		{name: "free function", expr: `double(age)`},
+		{name: "member function", expr: `name.lower().startsWith("al")`},
+		{name: "lambda", expr: `any(tags, t => t.endsWith("v")) && nested.items.where(i => i > 1).count()`},
+		{name: "list literal", expr: `[1, "a", 2.5, true, null, [2]]`},
+		{name: "narrowed list literal", expr: `[[1, 2], ["a"]]`},
+		{name: "list", expr: `[name, age, nested.items[0]]`},
+		{name: "object", expr: `{name: name, "the-tags": tags, nested: {age: age, items: []}}`},
+		{name: "format string", expr: `f"{name} is {age:>4} {nested.items[0]:.2f}"`},
+		{name: "folded literal", expr: `"ab".repeat(3) + "c" + (60 * 60 as string)`},
+		{name: "variable", expr: `$limit - age`},
+		{name: "presence", expr: `has(nested.items[5]) || exists(name) && !has(nested.missing)`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			program, err := compile.NewProgram(tc.expr, &compile.Config{FuncTable: newTable()})
+			if err != nil {
+				t.Fatalf("NewProgram(%q) error = %v", tc.expr, err)
+			}
+			want := eval(t, program.Expr, input)
+
+			node, err := codec.Encode(program.Expr)
+			if err != nil {
+				t.Fatalf("Encode(%q) error = %v", tc.expr, err)
+			}
+			doc := &codec.Document{Version: codec.Version, Source: tc.expr, Expr: node}
+
+			for name, roundTrip := range map[string]func(*codec.Document) (*codec.Document, error){
+				"json":   jsonRoundTrip,
+				"binary": binaryRoundTrip,
+			} {
+				got, err := roundTrip(doc)
+				if err != nil {
+					t.Fatalf("%s round trip error = %v", name, err)
+				}
+				if !cmp.Equal(got, doc) {
+					t.Fatalf("%s round trip mismatch (-want +got):\n%s", name, cmp.Diff(doc, got))
+				}
+
+				decoder := &codec.Decoder{FuncTable: newTable()}
+				decoded, err := decoder.DecodeDocument(got)
+				if err != nil {
+					t.Fatalf("DecodeDocument() error = %v", err)
+				}
+				if got := eval(t, decoded, input); !cmp.Equal(got, want) {
+					t.Errorf("Eval(%s decoded %q) = %v, want %v", name, tc.expr, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestEncode_Literals(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		value any
+		want  *codec.Node
+	}{
+		{
+			name:  "large integer",
+			value: int64(1<<62 + 1),
+			want:  &codec.Node{Op: codec.OpLiteral, Type: codec.TypeInt, Value: "4611686018427387905"},
+		}, {
+			name:  "unsigned integer",
+			value: uint64(1<<64 - 1),
+			want:  &codec.Node{Op: codec.OpLiteral, Type: codec.TypeUint, Value: "18446744073709551615"},
+		}, {
+			name:  "float",
+			value: 0.1,
+			want:  &codec.Node{Op: codec.OpLiteral, Type: codec.TypeFloat, Value: "0.1"},
+		}, {
+			name:  "regexp",
+			value: regexp.MustCompile(`^refs/heads/.*`),
+			want:  &codec.Node{Op: codec.OpLiteral, Type: codec.TypeRegexp, Value: "^refs/heads/.*"},
+		}, {
+			name:  "map sorted by key",
+			value: map[string]int{"b": 2, "a": 1},
+			want: &codec.Node{Op: codec.OpLiteral, Type: codec.TypeMap, Args: []*codec.Node{
+				{Op: codec.OpLiteral, Name: "a", Type: codec.TypeInt, Value: "1"},
+				{Op: codec.OpLiteral, Name: "b", Type: codec.TypeInt, Value: "2"},
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := codec.Encode(expr.Literal(tc.value))
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("Encode() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEncode_Error(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		expr expr.Expr
+	}{
+		{name: "struct literal", expr: expr.Literal(struct{}{})},
+		{name: "unnamed function", expr: expr.FreeFunc(nil)},
+		{name: "map with non-string keys", expr: expr.Literal(map[int]int{1: 1})},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := codec.Encode(tc.expr)
+
+			if err == nil {
+				t.Errorf("Encode() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestDecoder_Decode_Error(t *testing.T) {
+	t.Parallel()
+	literal := &codec.Node{Op: codec.OpLiteral, Type: codec.TypeInt, Value: "1"}
+
+	testCases := []struct {
+		name      string
+		node      *codec.Node
+		variables []string
+		patterns  *regex.Policy
+		wantErr   error
+	}{
+		{
+			name:    "missing function",
+			node:    &codec.Node{Op: codec.OpCall, Name: "tripple", Args: []*codec.Node{literal}},
+			wantErr: errs.ErrUnknownName,
+		}, {
+			name:    "function arity mismatch",
+			node:    &codec.Node{Op: codec.OpCall, Name: "double", Args: []*codec.Node{literal, literal}},
+			wantErr: arity.ErrBadArity,
+		}, {
+			name:    "member function arity mismatch",
+			node:    &codec.Node{Op: codec.OpMethod, Name: "double", Args: []*codec.Node{literal}},
+			wantErr: arity.ErrBadArity,
+		}, {
+			name:    "unknown operation",
+			node:    &codec.Node{Op: "concat", Args: []*codec.Node{literal, literal}},
+			wantErr: codec.ErrDecode,
+		}, {
+			name:    "missing operand",
+			node:    &codec.Node{Op: codec.OpAdd, Args: []*codec.Node{literal}},
+			wantErr: codec.ErrDecode,
+		}, {
+			name:    "nil operand",
+			node:    &codec.Node{Op: codec.OpAdd, Args: []*codec.Node{literal, nil}},
+			wantErr: codec.ErrDecode,
+		}, {
+			name:    "invalid literal",
+			node:    &codec.Node{Op: codec.OpLiteral, Type: codec.TypeInt, Value: "one"},
+			wantErr: codec.ErrDecode,
+		}, {
+			name:    "invalid type",
+			node:    &codec.Node{Op: codec.OpIs, Type: "decimal", Args: []*codec.Node{literal}},
+			wantErr: codec.ErrDecode,
+		}, {
+			name:    "parameter outside of lambda",
+			node:    &codec.Node{Op: codec.OpParam, Name: "x"},
+			wantErr: codec.ErrDecode,
+		}, {
+			name:      "undeclared variable",
+			node:      &codec.Node{Op: codec.OpVariable, Name: "usr"},
+			variables: []string{"user"},
+			wantErr:   errs.ErrUnknownName,
+		}, {
+			name:    "invalid pattern",
+			node:    &codec.Node{Op: codec.OpLiteral, Type: codec.TypeRegexp, Value: "(a"},
+			wantErr: errs.ErrInvalidPattern,
+		}, {
+			name:     "pattern too long",
+			node:     &codec.Node{Op: codec.OpLiteral, Type: codec.TypeRegexp, Value: "^a+$"},
+			patterns: &regex.Policy{MaxLength: 3},
+			wantErr:  errs.ErrInvalidPattern,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			decoder := &codec.Decoder{FuncTable: newTable(), Variables: tc.variables, Patterns: tc.patterns}
+
+			_, err := decoder.Decode(tc.node)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("Decode() error = %v, want %v", got, want)
+			}
+			if got, want := err, codec.ErrDecode; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("Decode() error = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestDecoder_DecodeDocument_UnsupportedVersion(t *testing.T) {
+	t.Parallel()
+	doc := &codec.Document{
+		Version: codec.Version + 1,
+		Expr:    &codec.Node{Op: codec.OpWildcard},
+	}
+	decoder := &codec.Decoder{FuncTable: newTable()}
+
+	_, err := decoder.DecodeDocument(doc)
+
+	if got, want := err, codec.ErrDecode; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Errorf("DecodeDocument() error = %v, want %v", got, want)
+	}
+}
+
+func TestDocument_UnmarshalBinary_Error(t *testing.T) {
+	t.Parallel()
+	doc := &codec.Document{
+		Version: codec.Version,
+		Source:  "a.b",
+		Expr: &codec.Node{Op: codec.OpSequence, Args: []*codec.Node{
+			{Op: codec.OpMember, Name: "a"},
+			{Op: codec.OpMember, Name: "b"},
+		}},
+	}
+	data, err := doc.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+
+	testCases := []struct {
+		name string
+		data []byte
+	}{
+		{name: "empty", data: nil},
+		{name: "bad magic", data: append([]byte("json"), data[4:]...)},
+		{name: "truncated", data: data[:len(data)-3]},
+		{name: "trailing bytes", data: append(data[:len(data):len(data)], 0)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := (&codec.Document{}).UnmarshalBinary(tc.data)
+
+			if got, want := err, codec.ErrDecode; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("UnmarshalBinary() error = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func jsonRoundTrip(doc *codec.Document) (*codec.Document, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var got codec.Document
+	err = json.Unmarshal(data, &got)
+	return &got, err
+}
+
+func binaryRoundTrip(doc *codec.Document) (*codec.Document, error) {
+	data, err := doc.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	var got codec.Document
+	err = got.UnmarshalBinary(data)
+	return &got, err
+}
+
+func eval(t *testing.T, e expr.Expr, input any) any {
+	t.Helper()
+	ctx := expr.NewContext(reflect.ValueOf(input)).WithVars(map[string]reflect.Value{
+		"limit": reflect.ValueOf(65),
+	})
+	got, err := e.Eval(ctx)
+	if err != nil {
+		t.Fatalf("Eval() error = %v", err)
+	}
+	if !got.IsValid() {
+		return nil
+	}
+	return got.Interface()
+}
+
+func TestRoundTrip_Method(t *testing.T) {
+	t.Parallel()
+	sut := expr.Sequence(expr.Member("created"), expr.Method("Format", expr.Literal("2006")))
+
+	node, err := codec.Encode(sut)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	decoder := &codec.Decoder{FuncTable: newTable()}
+	decoded, err := decoder.Decode(node)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	seq, ok := decoded.(expr.SequenceExpr)
+	if !ok || len(seq) != 2 {
+		t.Fatalf("Decode() = %T, want sequence of 2 steps", decoded)
+	}
+	method, ok := seq[1].(*expr.MethodExpr)
+	if !ok {
+		t.Fatalf("Decode() step = %T, want *expr.MethodExpr", seq[1])
+	}
+	if got, want := method.Name, "Format"; got != want {
+		t.Errorf("Decode() method name = %v, want %v", got, want)
+	}
+	if got, want := len(method.Args), 1; got != want {
+		t.Errorf("Decode() method args = %v, want %v", got, want)
+	}
+}
+
+func TestRoundTrip_StrictNulls(t *testing.T) {
+	t.Parallel()
+	program, err := compile.NewProgram(`nested.missing.items`, &compile.Config{
+		FuncTable:   newTable(),
+		StrictNulls: true,
+	})
+	if err != nil {
+		t.Fatalf("NewProgram() error = %v", err)
+	}
+
+	node, err := codec.Encode(program.Expr)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	decoder := &codec.Decoder{FuncTable: newTable()}
+	decoded, err := decoder.Decode(node)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	input := map[string]any{"nested": map[string]any{"missing": nil}}
+	_, err = decoded.Eval(expr.NewContext(reflect.ValueOf(input)))
+	if got, want := err, (&errs.NullError{Path: "nested.missing"}); !cmp.Equal(got, want) {
+		t.Errorf("Eval() error = %v, want %v", got, want)
+	}
+}
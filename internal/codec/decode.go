@@ -0,0 +1,364 @@
+package codec
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+
+	"rodusek.dev/pkg/dcell/internal/errs"
+	"rodusek.dev/pkg/dcell/internal/expr"
+	"rodusek.dev/pkg/dcell/internal/invocation"
+	"rodusek.dev/pkg/dcell/internal/regex"
+)
+
+// Decoder converts the portable form of an expression tree back into an
+// expression tree, linking the functions that it references against a
+// function table.
+type Decoder struct {
+	// FuncTable is the table that function references are linked against.
+	FuncTable *invocation.Table
+
+	// Variables is the set of variable names that the expression may
+	// reference. If nil, any variable name is accepted.
+	Variables []string
+
+	// Patterns is the policy that limits the regular expression patterns of
+	// the expression, both literal patterns, which are compiled when decoding,
+	// and patterns that are compiled when evaluating. If nil, any valid
+	// pattern is allowed.
+	Patterns *regex.Policy
+
+	// params are the parameters of the enclosing lambdas of the node being
+	// decoded.
+	params []string
+}
+
+// Decode converts a node into an expression tree. Errors wrap [ErrDecode].
+func (d *Decoder) Decode(n *Node) (expr.Expr, error) {
+	if n == nil {
+		return nil, fmt.Errorf("%w: missing expression", ErrDecode)
+	}
+
+	switch n.Op {
+	case OpLiteral:
+		if n.Type == TypeRegexp {
+			re, err := d.Patterns.Compile(n.Value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrDecode, err)
+			}
+			return expr.Literal(re), nil
+		}
+		value, err := decodeLiteral(n)
+		if err != nil {
+			return nil, err
+		}
+		return expr.LiteralExpr(value), nil
+	case OpSequence:
+		if len(n.Args) == 0 {
+			return nil, fmt.Errorf("%w: empty sequence", ErrDecode)
+		}
+		args, err := d.decodeArgs(n, len(n.Args))
+		if err != nil {
+			return nil, err
+		}
+		return expr.SequenceExpr(args), nil
+	case OpMember:
+		return expr.Member(n.Name), nil
+	case OpWildcard:
+		return expr.Wildcard(), nil
+	case OpParam:
+		if !slices.Contains(d.params, n.Name) {
+			return nil, fmt.Errorf("%w: parameter '%s' outside of lambda", ErrDecode, n.Name)
+		}
+		return expr.Param(n.Name), nil
+	case OpVariable:
+		if d.Variables != nil && !slices.Contains(d.Variables, n.Name) {
+			err := errs.NewVariableError(n.Name, slices.Values(d.Variables))
+			return nil, fmt.Errorf("%w: %w", ErrDecode, err)
+		}
+		return expr.Variable(n.Name), nil
+	case OpIndex:
+		args, err := d.decodeArgs(n, 1)
+		if err != nil {
+			return nil, err
+		}
+		return expr.Index(args[0]), nil
+	case OpSlice:
+		if len(n.Args) != 2 {
+			return nil, d.arityError(n, 2)
+		}
+		bounds := make([]expr.Expr, 2)
+		for i, arg := range n.Args {
+			if arg == nil {
+				continue
+			}
+			bound, err := d.Decode(arg)
+			if err != nil {
+				return nil, err
+			}
+			bounds[i] = bound
+		}
+		return expr.IndexSlice(bounds[0], bounds[1]), nil
+	case OpCall, OpMethod:
+		return d.decodeFunc(n)
+	case OpGoMethod:
+		args, err := d.decodeArgs(n, len(n.Args))
+		if err != nil {
+			return nil, err
+		}
+		return expr.Method(n.Name, args...), nil
+	case OpLambda:
+		d.params = append(d.params, n.Name)
+		defer func() { d.params = d.params[:len(d.params)-1] }()
+
+		args, err := d.decodeArgs(n, 1)
+		if err != nil {
+			return nil, err
+		}
+		return expr.Lambda(n.Name, args[0]), nil
+	case OpNonNull:
+		args, err := d.decodeArgs(n, 1)
+		if err != nil {
+			return nil, err
+		}
+		return expr.NonNull(args[0], n.Name), nil
+	case OpHas:
+		args, err := d.decodeArgs(n, 1)
+		if err != nil {
+			return nil, err
+		}
+		return expr.Has(args[0]), nil
+	case OpList:
+		args, err := d.decodeArgs(n, len(n.Args))
+		if err != nil {
+			return nil, err
+		}
+		return expr.List(args...), nil
+	case OpObject:
+		return d.decodeObject(n)
+	case OpFormat:
+		args, err := d.decodeArgs(n, 1)
+		if err != nil {
+			return nil, err
+		}
+		e, err := expr.Format(args[0], n.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrDecode, err)
+		}
+		return e, nil
+	case OpPattern:
+		args, err := d.decodeArgs(n, 1)
+		if err != nil {
+			return nil, err
+		}
+		return expr.Pattern(args[0], d.Patterns), nil
+	case OpIs, OpAs:
+		var ty expr.Type
+		if err := ty.UnmarshalText([]byte(n.Type)); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrDecode, err)
+		}
+		args, err := d.decodeArgs(n, 1)
+		if err != nil {
+			return nil, err
+		}
+		if n.Op == OpIs {
+			return expr.Is(args[0], ty), nil
+		}
+		return expr.As(args[0], ty), nil
+	case OpTernary:
+		args, err := d.decodeArgs(n, 3)
+		if err != nil {
+			return nil, err
+		}
+		return expr.Ternary(args[0], args[1], args[2]), nil
+	}
+
+	if unary, ok := unaryOps[n.Op]; ok {
+		args, err := d.decodeArgs(n, 1)
+		if err != nil {
+			return nil, err
+		}
+		return unary(args[0]), nil
+	}
+	if binary, ok := binaryOps[n.Op]; ok {
+		args, err := d.decodeArgs(n, 2)
+		if err != nil {
+			return nil, err
+		}
+		return binary(args[0], args[1]), nil
+	}
+	return nil, fmt.Errorf("%w: unknown operation %q", ErrDecode, n.Op)
+}
+
+var unaryOps = map[Op]func(expr.Expr) expr.Expr{
+	OpNot:    func(e expr.Expr) expr.Expr { return expr.LogicalNot(e) },
+	OpBitNot: func(e expr.Expr) expr.Expr { return expr.BitwiseNot(e) },
+	OpPlus:   expr.PolarityPlus,
+	OpMinus:  func(e expr.Expr) expr.Expr { return expr.PolarityMinus(e) },
+}
+
+var binaryOps = map[Op]func(left, right expr.Expr) expr.Expr{
+	OpPow:      func(l, r expr.Expr) expr.Expr { return expr.Power(l, r) },
+	OpMul:      func(l, r expr.Expr) expr.Expr { return expr.Multiply(l, r) },
+	OpDiv:      func(l, r expr.Expr) expr.Expr { return expr.Divide(l, r) },
+	OpFloorDiv: func(l, r expr.Expr) expr.Expr { return expr.FloorDivide(l, r) },
+	OpMod:      func(l, r expr.Expr) expr.Expr { return expr.Modulus(l, r) },
+	OpAdd:      func(l, r expr.Expr) expr.Expr { return expr.Add(l, r) },
+	OpSub:      func(l, r expr.Expr) expr.Expr { return expr.Subtract(l, r) },
+	OpShl:      func(l, r expr.Expr) expr.Expr { return expr.BitwiseShiftLeft(l, r) },
+	OpShr:      func(l, r expr.Expr) expr.Expr { return expr.BitwiseShiftRight(l, r) },
+	OpBitAnd:   func(l, r expr.Expr) expr.Expr { return expr.BitwiseAnd(l, r) },
+	OpBitOr:    func(l, r expr.Expr) expr.Expr { return expr.BitwiseOr(l, r) },
+	OpBitXor:   func(l, r expr.Expr) expr.Expr { return expr.BitwiseXor(l, r) },
+	OpAnd:      func(l, r expr.Expr) expr.Expr { return expr.LogicalAnd(l, r) },
+	OpOr:       func(l, r expr.Expr) expr.Expr { return expr.LogicalOr(l, r) },
+	OpImplies:  func(l, r expr.Expr) expr.Expr { return expr.Implies(l, r) },
+	OpEq:       func(l, r expr.Expr) expr.Expr { return expr.Equal(l, r) },
+	OpLt:       func(l, r expr.Expr) expr.Expr { return expr.LessThan(l, r) },
+	OpLe:       func(l, r expr.Expr) expr.Expr { return expr.LessThanOrEqual(l, r) },
+	OpGt:       func(l, r expr.Expr) expr.Expr { return expr.GreaterThan(l, r) },
+	OpGe:       func(l, r expr.Expr) expr.Expr { return expr.GreaterThanOrEqual(l, r) },
+	OpIn:       func(l, r expr.Expr) expr.Expr { return expr.In(l, r) },
+	OpNotIn:    expr.NotIn,
+	OpMatch:    func(l, r expr.Expr) expr.Expr { return expr.Match(l, r) },
+	OpNotMatch: func(l, r expr.Expr) expr.Expr { return expr.NotMatch(l, r) },
+	OpElvis:    func(l, r expr.Expr) expr.Expr { return expr.Elvis(l, r) },
+	OpCoalesce: func(l, r expr.Expr) expr.Expr { return expr.Coalesce(l, r) },
+}
+
+// decodeArgs decodes the operands of a node, which must have exactly n
+// operands.
+func (d *Decoder) decodeArgs(node *Node, n int) ([]expr.Expr, error) {
+	if len(node.Args) != n {
+		return nil, d.arityError(node, n)
+	}
+	args := make([]expr.Expr, 0, n)
+	for _, arg := range node.Args {
+		arg, err := d.Decode(arg)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+func (d *Decoder) arityError(node *Node, n int) error {
+	return fmt.Errorf("%w: operation %q expects %d operands, got %d", ErrDecode, node.Op, n, len(node.Args))
+}
+
+func (d *Decoder) decodeFunc(n *Node) (expr.Expr, error) {
+	entry, ok := d.FuncTable.Lookup(n.Name)
+	if !ok {
+		err := errs.NewNameError(n.Name, d.FuncTable.FunctionNames())
+		return nil, fmt.Errorf("%w: %w", ErrDecode, err)
+	}
+
+	args := len(n.Args)
+	if n.Op == OpMethod {
+		args++ // member funcs include the root as the first arg
+	}
+	if err := entry.TestArity(args); err != nil {
+		return nil, fmt.Errorf("%w: function '%s': %w", ErrDecode, n.Name, err)
+	}
+
+	params, err := d.decodeArgs(n, len(n.Args))
+	if err != nil {
+		return nil, err
+	}
+	if n.Op == OpCall {
+		fn := expr.FreeFunc(entry.InvokeContext, params...)
+		fn.Name = n.Name
+		fn.Pure = entry.Pure()
+		return fn, nil
+	}
+	fn := expr.MemberFunc(entry.InvokeContext, params...)
+	fn.Name = n.Name
+	fn.Pure = entry.Pure()
+	return fn, nil
+}
+
+func (d *Decoder) decodeObject(n *Node) (expr.Expr, error) {
+	if len(n.Args)%2 != 0 {
+		return nil, fmt.Errorf("%w: operation %q expects keys and values, got %d operands", ErrDecode, n.Op, len(n.Args))
+	}
+	keys := make([]string, 0, len(n.Args)/2)
+	values := make([]expr.Expr, 0, len(n.Args)/2)
+	for i := 0; i < len(n.Args); i += 2 {
+		key, err := decodeLiteral(n.Args[i])
+		if err != nil {
+			return nil, err
+		}
+		if key.Kind() != reflect.String {
+			return nil, fmt.Errorf("%w: key of operation %q must be a string", ErrDecode, n.Op)
+		}
+		value, err := d.Decode(n.Args[i+1])
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key.String())
+		values = append(values, value)
+	}
+	return expr.Object(keys, values), nil
+}
+
+func decodeLiteral(n *Node) (reflect.Value, error) {
+	if n == nil || n.Op != OpLiteral {
+		return reflect.Value{}, fmt.Errorf("%w: expected literal", ErrDecode)
+	}
+
+	var value any
+	var err error
+	switch n.Type {
+	case TypeNull:
+		return reflect.Value{}, nil
+	case TypeBool:
+		value, err = strconv.ParseBool(n.Value)
+	case TypeInt:
+		value, err = strconv.ParseInt(n.Value, 10, 64)
+	case TypeUint:
+		value, err = strconv.ParseUint(n.Value, 10, 64)
+	case TypeFloat:
+		value, err = strconv.ParseFloat(n.Value, 64)
+	case TypeString:
+		value = n.Value
+	case TypeList:
+		elems := make([]reflect.Value, 0, len(n.Args))
+		for _, arg := range n.Args {
+			elem, err := decodeLiteral(arg)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			elems = append(elems, elem)
+		}
+		return expr.MakeList(elems), nil
+	case TypeMap:
+		keys := make([]string, 0, len(n.Args))
+		values := make([]reflect.Value, 0, len(n.Args))
+		for _, arg := range n.Args {
+			elem, err := decodeLiteral(arg)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			keys = append(keys, arg.Name)
+			values = append(values, elem)
+		}
+		return expr.MakeObject(keys, values), nil
+	default:
+		return reflect.Value{}, fmt.Errorf("%w: unknown literal type %q", ErrDecode, n.Type)
+	}
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("%w: %w", ErrDecode, err)
+	}
+	return reflect.ValueOf(value), nil
+}
+
+// DecodeDocument converts the expression tree of a document into an
+// expression tree, after checking that the document has a supported version.
+func (d *Decoder) DecodeDocument(doc *Document) (expr.Expr, error) {
+	if doc.Version != Version {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrDecode, doc.Version)
+	}
+	return d.Decode(doc.Expr)
+}
@@ -0,0 +1,275 @@
+package codec
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"rodusek.dev/pkg/dcell/internal/expr"
+)
+
+// Encode converts an expression tree into its portable form.
+func Encode(e expr.Expr) (*Node, error) {
+	switch e := e.(type) {
+	case nil:
+		return nil, nil
+	case expr.LiteralExpr:
+		return encodeLiteral(reflect.Value(e))
+	case expr.SequenceExpr:
+		return encode(OpSequence, e...)
+	case expr.MemberExpr:
+		return &Node{Op: OpMember, Name: string(e)}, nil
+	case expr.WildcardExpr:
+		return &Node{Op: OpWildcard}, nil
+	case expr.ParamExpr:
+		return &Node{Op: OpParam, Name: string(e)}, nil
+	case expr.VariableExpr:
+		return &Node{Op: OpVariable, Name: string(e)}, nil
+	case expr.IndexExpr:
+		return encode(OpIndex, e.Index)
+	case *expr.IndexSliceExpr:
+		return encode(OpSlice, e.Begin, e.End)
+	case *expr.FreeFuncExpr:
+		return encodeFunc(OpCall, e.Name, e.Args)
+	case *expr.MemberFuncExpr:
+		return encodeFunc(OpMethod, e.Name, e.Args)
+	case *expr.MethodExpr:
+		return encodeFunc(OpGoMethod, e.Name, e.Args)
+	case *expr.LambdaExpr:
+		node, err := encode(OpLambda, e.Body)
+		if err != nil {
+			return nil, err
+		}
+		node.Name = e.Param
+		return node, nil
+	case *expr.NonNullExpr:
+		node, err := encode(OpNonNull, e.Expr)
+		if err != nil {
+			return nil, err
+		}
+		node.Name = e.Path
+		return node, nil
+	case *expr.HasExpr:
+		return encode(OpHas, e.Expr)
+	case *expr.ListExpr:
+		return encode(OpList, e.Elems...)
+	case *expr.ObjectExpr:
+		return encodeObject(e)
+	case *expr.PatternExpr:
+		return encode(OpPattern, e.Expr)
+	case *expr.FormatExpr:
+		node, err := encode(OpFormat, e.Expr)
+		if err != nil {
+			return nil, err
+		}
+		node.Value = e.Spec
+		return node, nil
+	case expr.LogicalNotExpr:
+		return encode(OpNot, e.Expr)
+	case expr.BitwiseNotExpr:
+		return encode(OpBitNot, e.Expr)
+	case expr.PolarityPlusExpr:
+		return encode(OpPlus, e.Expr)
+	case expr.PolarityMinusExpr:
+		return encode(OpMinus, e.Expr)
+	case *expr.PowerExpr:
+		return encode(OpPow, e.Left, e.Right)
+	case *expr.MultiplyExpr:
+		return encode(OpMul, e.Left, e.Right)
+	case *expr.DivideExpr:
+		return encode(OpDiv, e.Left, e.Right)
+	case *expr.FloorDivideExpr:
+		return encode(OpFloorDiv, e.Left, e.Right)
+	case *expr.ModulusExpr:
+		return encode(OpMod, e.Left, e.Right)
+	case *expr.AddExpr:
+		return encode(OpAdd, e.Left, e.Right)
+	case *expr.SubtractExpr:
+		return encode(OpSub, e.Left, e.Right)
+	case *expr.BitwiseShiftLeftExpr:
+		return encode(OpShl, e.Left, e.Right)
+	case *expr.BitwiseShiftRightExpr:
+		return encode(OpShr, e.Left, e.Right)
+	case *expr.BitwiseAndExpr:
+		return encode(OpBitAnd, e.Left, e.Right)
+	case *expr.BitwiseOrExpr:
+		return encode(OpBitOr, e.Left, e.Right)
+	case *expr.BitwiseXorExpr:
+		return encode(OpBitXor, e.Left, e.Right)
+	case *expr.LogicalAndExpr:
+		return encode(OpAnd, e.Left, e.Right)
+	case *expr.LogicalOrExpr:
+		return encode(OpOr, e.Left, e.Right)
+	case *expr.ImpliesExpr:
+		return encode(OpImplies, e.Left, e.Right)
+	case *expr.EqualityExpr:
+		return encode(OpEq, e.Left, e.Right)
+	case *expr.InequalityExpr:
+		op, ok := inequalityOps[e.Operator]
+		if !ok {
+			return nil, fmt.Errorf("unknown comparison operator %q", e.Operator)
+		}
+		return encode(op, e.Left, e.Right)
+	case *expr.InExpr:
+		if !e.Transform(true) {
+			return encode(OpNotIn, e.Left, e.Right)
+		}
+		return encode(OpIn, e.Left, e.Right)
+	case *expr.MatchExpr:
+		if e.Negate {
+			return encode(OpNotMatch, e.Left, e.Pattern)
+		}
+		return encode(OpMatch, e.Left, e.Pattern)
+	case *expr.IsExpr:
+		return encodeType(OpIs, e.Expr, e.Type)
+	case *expr.AsExpr:
+		return encodeType(OpAs, e.Expr, e.Type)
+	case *expr.TernaryExpr:
+		if isElvis(e) {
+			return encode(OpElvis, e.Condition, e.FalseExpr)
+		}
+		return encode(OpTernary, e.Condition, e.TrueExpr, e.FalseExpr)
+	case *expr.CoalesceExpr:
+		return encode(OpCoalesce, e.Left, e.Right)
+	}
+	return nil, fmt.Errorf("cannot encode expression of type %T", e)
+}
+
+var inequalityOps = map[expr.InequalityOperator]Op{
+	expr.OpLessThan:           OpLt,
+	expr.OpLessThanOrEqual:    OpLe,
+	expr.OpGreaterThan:        OpGt,
+	expr.OpGreaterThanOrEqual: OpGe,
+}
+
+func encode(op Op, args ...expr.Expr) (*Node, error) {
+	node := &Node{Op: op}
+	for _, arg := range args {
+		arg, err := Encode(arg)
+		if err != nil {
+			return nil, err
+		}
+		node.Args = append(node.Args, arg)
+	}
+	return node, nil
+}
+
+func encodeFunc(op Op, name string, args []expr.Expr) (*Node, error) {
+	if name == "" {
+		return nil, fmt.Errorf("cannot encode function without a name")
+	}
+	node, err := encode(op, args...)
+	if err != nil {
+		return nil, err
+	}
+	node.Name = name
+	return node, nil
+}
+
+func encodeObject(e *expr.ObjectExpr) (*Node, error) {
+	if len(e.Keys) != len(e.Values) {
+		return nil, fmt.Errorf("cannot encode object of %d keys and %d values", len(e.Keys), len(e.Values))
+	}
+	node := &Node{Op: OpObject}
+	for i, key := range e.Keys {
+		value, err := Encode(e.Values[i])
+		if err != nil {
+			return nil, err
+		}
+		node.Args = append(node.Args, &Node{Op: OpLiteral, Type: TypeString, Value: key}, value)
+	}
+	return node, nil
+}
+
+func encodeType(op Op, e expr.Expr, ty expr.Type) (*Node, error) {
+	node, err := encode(op, e)
+	if err != nil {
+		return nil, err
+	}
+	node.Type = string(ty)
+	return node, nil
+}
+
+func encodeLiteral(rv reflect.Value) (*Node, error) {
+	if rv.IsValid() && rv.CanInterface() {
+		if re, ok := rv.Interface().(*regexp.Regexp); ok && re != nil {
+			return &Node{Op: OpLiteral, Type: TypeRegexp, Value: re.String()}, nil
+		}
+	}
+	for rv.Kind() == reflect.Interface || rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return &Node{Op: OpLiteral, Type: TypeNull}, nil
+		}
+		rv = rv.Elem()
+	}
+
+	node := &Node{Op: OpLiteral}
+	switch rv.Kind() {
+	case reflect.Invalid:
+		node.Type = TypeNull
+	case reflect.Bool:
+		node.Type = TypeBool
+		node.Value = strconv.FormatBool(rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		node.Type = TypeInt
+		node.Value = strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		node.Type = TypeUint
+		node.Value = strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		node.Type = TypeFloat
+		node.Value = strconv.FormatFloat(rv.Float(), 'g', -1, 64)
+	case reflect.String:
+		node.Type = TypeString
+		node.Value = rv.String()
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			node.Type = TypeNull
+			break
+		}
+		node.Type = TypeList
+		for i := range rv.Len() {
+			elem, err := encodeLiteral(rv.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			node.Args = append(node.Args, elem)
+		}
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("cannot encode literal of type %v", rv.Type())
+		}
+		if rv.IsNil() {
+			node.Type = TypeNull
+			break
+		}
+		node.Type = TypeMap
+		keys := rv.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(a.String(), b.String())
+		})
+		for _, key := range keys {
+			elem, err := encodeLiteral(rv.MapIndex(key))
+			if err != nil {
+				return nil, err
+			}
+			elem.Name = key.String()
+			node.Args = append(node.Args, elem)
+		}
+	default:
+		return nil, fmt.Errorf("cannot encode literal of type %v", rv.Type())
+	}
+	return node, nil
+}
+
+// isElvis reports whether e was created by [expr.Elvis], which evaluates its
+// condition as the true branch.
+func isElvis(e *expr.TernaryExpr) bool {
+	if e.TrueExpr == nil || !reflect.TypeOf(e.TrueExpr).Comparable() {
+		return false
+	}
+	return e.TrueExpr == e.Condition
+}
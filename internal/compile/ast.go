@@ -0,0 +1,469 @@
+package compile
+
+import (
+	"slices"
+
+	antlr "github.com/antlr4-go/antlr/v4"
+	"rodusek.dev/pkg/dcell/ast"
+	"rodusek.dev/pkg/dcell/internal/parser"
+)
+
+// ASTBuilder is a visitor that walks the parse tree to build the public
+// syntax tree of the expression.
+type ASTBuilder struct {
+	// params is the stack of parameter names of the lambdas that enclose the
+	// expression currently being visited.
+	params []string
+
+	// literals parses the values of literals.
+	literals Visitor
+}
+
+// BuildProgram builds the syntax tree of the root of the parse tree.
+func (b *ASTBuilder) BuildProgram(ctx parser.IProgramContext) (ast.Expr, error) {
+	return b.buildExpression(ctx.Expression())
+}
+
+//------------------------------------------------------------------------------
+// Expressions
+//------------------------------------------------------------------------------
+
+var binaryOperators = map[string]ast.Operator{
+	"**":      ast.Pow,
+	"*":       ast.Mul,
+	"/":       ast.Div,
+	"//":      ast.FloorDiv,
+	"%":       ast.Mod,
+	"+":       ast.Add,
+	"-":       ast.Sub,
+	"&&":      ast.And,
+	"and":     ast.And,
+	"||":      ast.Or,
+	"or":      ast.Or,
+	"<->":     ast.Implies,
+	"implies": ast.Implies,
+	"<<":      ast.Shl,
+	">>":      ast.Shr,
+	"&":       ast.BitAnd,
+	"|":       ast.BitOr,
+	"^":       ast.BitXor,
+	"<":       ast.Lt,
+	"<=":      ast.Le,
+	">":       ast.Gt,
+	">=":      ast.Ge,
+	"==":      ast.Eq,
+	"!=":      ast.Ne,
+	"=~":      ast.Match,
+	"!~":      ast.NotMatch,
+	"?:":      ast.Elvis,
+	"??":      ast.Coalesce,
+}
+
+var unaryOperators = map[string]ast.Operator{
+	"!":   ast.Not,
+	"not": ast.Not,
+	"~":   ast.BitNot,
+	"+":   ast.Plus,
+	"-":   ast.Minus,
+}
+
+func (b *ASTBuilder) buildExpression(ctx parser.IExpressionContext) (ast.Expr, error) {
+	switch ctx := ctx.(type) {
+	case *parser.TermExpressionContext:
+		return b.buildTerm(ctx.Term())
+	case *parser.InvocationExpressionContext:
+		x, err := b.buildExpression(ctx.Expression())
+		if err != nil {
+			return nil, err
+		}
+		return b.buildInvocation(ctx, ctx.Invocation(), x)
+	case *parser.SafeInvocationExpressionContext:
+		x, err := b.buildExpression(ctx.Expression())
+		if err != nil {
+			return nil, err
+		}
+		node, err := b.buildInvocation(ctx, ctx.Invocation(), x)
+		if err != nil {
+			return nil, err
+		}
+		switch node := node.(type) {
+		case *ast.Member:
+			node.Safe = true
+		case *ast.Wildcard:
+			node.Safe = true
+		case *ast.Call:
+			node.Safe = true
+		}
+		return node, nil
+	case *parser.IndexExpressionContext:
+		return b.buildIndexExpression(ctx)
+	case *parser.ParenthesisExpressionContext:
+		x, err := b.buildExpression(ctx.Expression())
+		if err != nil {
+			return nil, err
+		}
+		return &ast.Paren{Span: span(ctx), X: x}, nil
+	case *parser.LogicalNotExpressionContext:
+		return b.buildUnary(ctx, ctx.Expression())
+	case *parser.BitwiseNotExpressionContext:
+		return b.buildUnary(ctx, ctx.Expression())
+	case *parser.PolarityExpressionContext:
+		return b.buildUnary(ctx, ctx.Expression())
+	case *parser.ExponentiationExpressionContext:
+		return b.buildBinary(ctx, ctx.AllExpression())
+	case *parser.MultiplicativeExpressionContext:
+		return b.buildBinary(ctx, ctx.AllExpression())
+	case *parser.AdditiveExpressionContext:
+		return b.buildBinary(ctx, ctx.AllExpression())
+	case *parser.LogicalAndExpressionContext:
+		return b.buildBinary(ctx, ctx.AllExpression())
+	case *parser.LogicalOrExpressionContext:
+		return b.buildBinary(ctx, ctx.AllExpression())
+	case *parser.ImplicationExpressionContext:
+		return b.buildBinary(ctx, ctx.AllExpression())
+	case *parser.ShiftExpressionContext:
+		return b.buildBinary(ctx, ctx.AllExpression())
+	case *parser.BitwiseAndExpressionContext:
+		return b.buildBinary(ctx, ctx.AllExpression())
+	case *parser.BitwiseOrExpressionContext:
+		return b.buildBinary(ctx, ctx.AllExpression())
+	case *parser.InequalityExpressionContext:
+		return b.buildBinary(ctx, ctx.AllExpression())
+	case *parser.EqualityExpressionContext:
+		return b.buildBinary(ctx, ctx.AllExpression())
+	case *parser.MatchExpressionContext:
+		return b.buildBinary(ctx, ctx.AllExpression())
+	case *parser.ElvisExpressionContext:
+		return b.buildBinary(ctx, ctx.AllExpression())
+	case *parser.CoalesceExpressionContext:
+		return b.buildBinary(ctx, ctx.AllExpression())
+	case *parser.TernaryExpressionContext:
+		exprs, err := b.buildExpressions(ctx.AllExpression())
+		if err != nil {
+			return nil, err
+		}
+		return &ast.Ternary{Span: span(ctx), Cond: exprs[0], Then: exprs[1], Else: exprs[2]}, nil
+	case *parser.IsExpressionContext:
+		x, err := b.buildExpression(ctx.Expression())
+		if err != nil {
+			return nil, err
+		}
+		not := b.getTreeText(ctx.GetChild(2)) == "not"
+		return &ast.Is{Span: span(ctx), X: x, Type: ctx.Type_().GetText(), Not: not}, nil
+	case *parser.CastExpressionContext:
+		x, err := b.buildExpression(ctx.Expression())
+		if err != nil {
+			return nil, err
+		}
+		return &ast.As{Span: span(ctx), X: x, Type: ctx.Type_().GetText()}, nil
+	case *parser.ContainsExpressionContext:
+		exprs, err := b.buildExpressions(ctx.AllExpression())
+		if err != nil {
+			return nil, err
+		}
+		not := b.getTreeText(ctx.GetChild(1)) == "not"
+		return &ast.In{Span: span(ctx), X: exprs[0], Y: exprs[1], Not: not}, nil
+	}
+	return nil, ErrInternalf(ctx, "unexpected expression type: %T", ctx)
+}
+
+func (b *ASTBuilder) buildUnary(ctx antlr.ParserRuleContext, operand parser.IExpressionContext) (ast.Expr, error) {
+	x, err := b.buildExpression(operand)
+	if err != nil {
+		return nil, err
+	}
+	text := b.getTreeText(ctx.GetChild(0))
+	op, ok := unaryOperators[text]
+	if !ok {
+		return nil, ErrInternalf(ctx, "unexpected unary operator: %s", text)
+	}
+	return &ast.Unary{Span: span(ctx), Op: op, X: x}, nil
+}
+
+func (b *ASTBuilder) buildBinary(ctx antlr.ParserRuleContext, operands []parser.IExpressionContext) (ast.Expr, error) {
+	exprs, err := b.buildExpressions(operands)
+	if err != nil {
+		return nil, err
+	}
+	text := b.getTreeText(ctx.GetChild(1))
+	op, ok := binaryOperators[text]
+	if !ok {
+		return nil, ErrInternalf(ctx, "unexpected binary operator: %s", text)
+	}
+	var opPos ast.Position
+	switch child := ctx.GetChild(1).(type) {
+	case antlr.TerminalNode:
+		opPos = tokenSpan(child.GetSymbol(), child.GetSymbol()).Start
+	case antlr.ParserRuleContext:
+		opPos = span(child).Start
+	}
+	return &ast.Binary{Span: span(ctx), Op: op, OpPos: opPos, X: exprs[0], Y: exprs[1]}, nil
+}
+
+func (b *ASTBuilder) buildIndexExpression(ctx *parser.IndexExpressionContext) (ast.Expr, error) {
+	x, err := b.buildExpression(ctx.Expression())
+	if err != nil {
+		return nil, err
+	}
+	switch index := ctx.Index().(type) {
+	case *parser.SliceIndexContext:
+		result := &ast.Slice{Span: span(ctx), X: x}
+		// Either bound may be omitted, so they are told apart by whether they
+		// appear before or after the colon.
+		afterColon := false
+		for _, child := range index.GetChildren() {
+			e, ok := child.(parser.IExpressionContext)
+			if !ok {
+				afterColon = true
+				continue
+			}
+			bound, err := b.buildExpression(e)
+			if err != nil {
+				return nil, err
+			}
+			if afterColon {
+				result.High = bound
+			} else {
+				result.Low = bound
+			}
+		}
+		return result, nil
+	case *parser.ExpressionIndexContext:
+		i, err := b.buildExpression(index.Expression())
+		if err != nil {
+			return nil, err
+		}
+		return &ast.Index{Span: span(ctx), X: x, Index: i}, nil
+	}
+	return nil, ErrInternalf(ctx, "unexpected index param type: %T", ctx.Index())
+}
+
+func (b *ASTBuilder) buildExpressions(ctxs []parser.IExpressionContext) ([]ast.Expr, error) {
+	var exprs []ast.Expr
+	for _, ctx := range ctxs {
+		e, err := b.buildExpression(ctx)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, e)
+	}
+	return exprs, nil
+}
+
+//------------------------------------------------------------------------------
+// Terms
+//------------------------------------------------------------------------------
+
+func (b *ASTBuilder) buildTerm(ctx parser.ITermContext) (ast.Expr, error) {
+	switch ctx := ctx.(type) {
+	case *parser.LiteralTermContext:
+		return b.buildLiteral(ctx.Literal())
+	case *parser.InvocationTermContext:
+		return b.buildInvocation(ctx, ctx.Invocation(), nil)
+	case *parser.VariableTermContext:
+		name := ctx.VARIABLE().GetText()[1:] // remove $
+		return &ast.Variable{Span: span(ctx), Name: name}, nil
+	}
+	return nil, ErrInternalf(ctx, "unexpected term type: %T", ctx)
+}
+
+//------------------------------------------------------------------------------
+// Invocations
+//------------------------------------------------------------------------------
+
+// buildInvocation builds the invocation on the receiver x, or on the root value
+// if x is nil. The span of the node is that of the parent, which includes the
+// receiver.
+func (b *ASTBuilder) buildInvocation(parent antlr.ParserRuleContext, ctx parser.IInvocationContext, x ast.Expr) (ast.Expr, error) {
+	switch ctx := ctx.(type) {
+	case *parser.FunctionInvocationContext:
+		var args []ast.Expr
+		if list := ctx.ParameterList(); list != nil {
+			for _, param := range list.AllParameter() {
+				arg, err := b.buildParameter(param)
+				if err != nil {
+					return nil, err
+				}
+				args = append(args, arg)
+			}
+		}
+		name := ctx.Identifier().GetText()
+		return &ast.Call{Span: span(parent), X: x, Name: name, Args: args}, nil
+	case *parser.WildcardInvocationContext:
+		return &ast.Wildcard{Span: span(parent), X: x}, nil
+	case *parser.MemberInvocationContext:
+		name := ctx.Identifier().GetText()
+		if x == nil && slices.Contains(b.params, name) {
+			return &ast.Param{Span: span(parent), Name: name}, nil
+		}
+		return &ast.Member{Span: span(parent), X: x, Name: name}, nil
+	}
+	return nil, ErrInternalf(ctx, "unexpected invocation type: %T", ctx)
+}
+
+func (b *ASTBuilder) buildParameter(ctx parser.IParameterContext) (ast.Expr, error) {
+	switch ctx := ctx.(type) {
+	case *parser.LambdaParameterContext:
+		lambda := ctx.Lambda()
+		param := lambda.Identifier().GetText()
+
+		b.params = append(b.params, param)
+		defer func() { b.params = b.params[:len(b.params)-1] }()
+
+		body, err := b.buildExpression(lambda.Expression())
+		if err != nil {
+			return nil, err
+		}
+		return &ast.Lambda{Span: span(lambda), Param: param, Body: body}, nil
+	case *parser.ExpressionParameterContext:
+		return b.buildExpression(ctx.Expression())
+	}
+	return nil, ErrInternalf(ctx, "unexpected parameter type: %T", ctx)
+}
+
+//------------------------------------------------------------------------------
+// Literal
+//------------------------------------------------------------------------------
+
+func (b *ASTBuilder) buildLiteral(ctx parser.ILiteralContext) (ast.Expr, error) {
+	result := &ast.Literal{Span: span(ctx), Raw: ctx.GetText()}
+	var err error
+	switch ctx := ctx.(type) {
+	case *parser.StringLiteralContext:
+		result.Kind = ast.StringLiteral
+		result.Value, err = b.literals.visitStringLiteral(ctx)
+	case *parser.IntegerLiteralContext:
+		result.Kind = ast.IntLiteral
+		result.Value, err = b.literals.visitIntegerLiteral(ctx)
+	case *parser.FloatLiteralContext:
+		result.Kind = ast.FloatLiteral
+		result.Value, err = b.literals.visitFloatLiteral(ctx)
+	case *parser.BooleanLiteralContext:
+		result.Kind = ast.BoolLiteral
+		result.Value = b.literals.visitBooleanLiteral(ctx)
+	case *parser.NullLiteralContext:
+		result.Kind = ast.NullLiteral
+	case *parser.ListLiteralContext:
+		list := &ast.List{Span: span(ctx)}
+		for _, item := range ctx.List().AllExpression() {
+			elem, err := b.buildExpression(item)
+			if err != nil {
+				return nil, err
+			}
+			list.Elems = append(list.Elems, elem)
+		}
+		return list, nil
+	case *parser.ObjectLiteralContext:
+		object := &ast.Object{Span: span(ctx)}
+		for _, property := range ctx.Object().AllProperty() {
+			prop, err := b.buildProperty(property)
+			if err != nil {
+				return nil, err
+			}
+			object.Props = append(object.Props, prop)
+		}
+		return object, nil
+	case *parser.FormatStringLiteralContext:
+		return b.buildFormatString(ctx)
+	default:
+		return nil, ErrInternalf(ctx, "unexpected literal type: %T", ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (b *ASTBuilder) buildProperty(ctx parser.IPropertyContext) (*ast.Property, error) {
+	key, err := b.literals.visitPropertyKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	value, err := b.buildExpression(ctx.Expression())
+	if err != nil {
+		return nil, err
+	}
+	raw := ctx.GetChild(0).(antlr.ParseTree).GetText()
+	return &ast.Property{Span: span(ctx), Key: key, Raw: raw, Value: value}, nil
+}
+
+func (b *ASTBuilder) buildFormatString(ctx *parser.FormatStringLiteralContext) (*ast.FormatString, error) {
+	parts, err := formatParts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	token := ctx.FORMAT_STRING().GetSymbol()
+	raw := []rune(token.GetText())
+	result := &ast.FormatString{Span: span(ctx)}
+	for _, part := range parts {
+		partSpan := ast.Span{
+			Start: tokenOffset(token, raw, part.start),
+			Stop:  tokenOffset(token, raw, part.stop),
+		}
+		if part.Expr == nil {
+			result.Parts = append(result.Parts, &ast.Literal{
+				Span:  partSpan,
+				Kind:  ast.StringLiteral,
+				Value: part.Text,
+				Raw:   string(raw[part.start:part.stop]),
+			})
+			continue
+		}
+		x, err := b.buildExpression(part.Expr)
+		if err != nil {
+			return nil, err
+		}
+		result.Parts = append(result.Parts, &ast.Interpolation{Span: partSpan, X: x, Spec: part.Spec})
+	}
+	return result, nil
+}
+
+func (b *ASTBuilder) getTreeText(tree antlr.Tree) string {
+	return tree.(interface{ GetText() string }).GetText()
+}
+
+// span returns the span of source text that the parse tree node covers.
+func span(ctx antlr.ParserRuleContext) ast.Span {
+	return tokenSpan(ctx.GetStart(), ctx.GetStop())
+}
+
+// tokenOffset returns the position of the given offset of the text of a token.
+func tokenOffset(token antlr.Token, raw []rune, offset int) ast.Position {
+	line, column := tokenPosition(token, raw, offset)
+	return ast.Position{
+		Offset: token.GetStart() + offset,
+		Line:   line,
+		Column: column + 1,
+	}
+}
+
+// tokenSpan returns the span of source text from the start token through the
+// stop token.
+func tokenSpan(start, stop antlr.Token) ast.Span {
+	result := ast.Span{
+		Start: ast.Position{
+			Offset: start.GetStart(),
+			Line:   start.GetLine(),
+			Column: start.GetColumn() + 1,
+		},
+	}
+	if stop == nil || stop.GetStop() < start.GetStart() {
+		result.Stop = result.Start
+		return result
+	}
+	result.Stop = ast.Position{
+		Offset: stop.GetStop() + 1,
+		Line:   stop.GetLine(),
+		Column: stop.GetColumn() + 1,
+	}
+	// Tokens such as triple-quoted strings may span multiple lines.
+	for _, r := range stop.GetText() {
+		if r == '\n' {
+			result.Stop.Line++
+			result.Stop.Column = 1
+		} else {
+			result.Stop.Column++
+		}
+	}
+	return result
+}
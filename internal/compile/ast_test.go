@@ -0,0 +1,323 @@
+package compile_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"rodusek.dev/pkg/dcell/ast"
+	"rodusek.dev/pkg/dcell/internal/compile"
+	"rodusek.dev/pkg/dcell/internal/invocation"
+)
+
+func buildAST(t *testing.T, str string) ast.Expr {
+	t.Helper()
+	table := invocation.NewTable()
+	table.AddFunc("func", func(...any) (any, error) {
+		return nil, nil
+	})
+	program, err := compile.NewProgram(str, &compile.Config{
+		FuncTable: table,
+	})
+	if err != nil {
+		t.Fatalf("NewProgram(%q) error = %v", str, err)
+	}
+	return program.AST
+}
+
+func TestNewProgram_AST(t *testing.T) {
+	t.Parallel()
+	intLit := func(v int64, raw string) *ast.Literal {
+		return &ast.Literal{Kind: ast.IntLiteral, Value: v, Raw: raw}
+	}
+	member := func(x ast.Expr, name string) *ast.Member {
+		return &ast.Member{X: x, Name: name}
+	}
+
+	testCases := []struct {
+		name string
+		expr string
+		want ast.Expr
+	}{
+		{
+			name: "string literal",
+			expr: `'hello'`,
+			want: &ast.Literal{Kind: ast.StringLiteral, Value: "hello", Raw: `'hello'`},
+		}, {
+			name: "hex literal",
+			expr: `0x1F`,
+			want: intLit(31, "0x1F"),
+		}, {
+			name: "float literal",
+			expr: `1.5`,
+			want: &ast.Literal{Kind: ast.FloatLiteral, Value: 1.5, Raw: "1.5"},
+		}, {
+			name: "bool literal",
+			expr: `true`,
+			want: &ast.Literal{Kind: ast.BoolLiteral, Value: true, Raw: "true"},
+		}, {
+			name: "null literal",
+			expr: `null`,
+			want: &ast.Literal{Kind: ast.NullLiteral, Raw: "null"},
+		}, {
+			name: "list literal",
+			expr: `[1, 2]`,
+			want: &ast.List{Elems: []ast.Expr{intLit(1, "1"), intLit(2, "2")}},
+		}, {
+			name: "list of expressions",
+			expr: `[a.b, 1]`,
+			want: &ast.List{Elems: []ast.Expr{member(member(nil, "a"), "b"), intLit(1, "1")}},
+		}, {
+			name: "object literal",
+			expr: `{a: 1, 'b-c': d}`,
+			want: &ast.Object{Props: []*ast.Property{
+				{Key: "a", Raw: "a", Value: intLit(1, "1")},
+				{Key: "b-c", Raw: "'b-c'", Value: member(nil, "d")},
+			}},
+		}, {
+			name: "format string",
+			expr: `f"#{a:>5} {{b}}"`,
+			want: &ast.FormatString{Parts: []ast.Node{
+				&ast.Literal{Kind: ast.StringLiteral, Value: "#", Raw: "#"},
+				&ast.Interpolation{X: member(nil, "a"), Spec: ">5"},
+				&ast.Literal{Kind: ast.StringLiteral, Value: " {b}", Raw: " {{b}}"},
+			}},
+		}, {
+			name: "nested member",
+			expr: `a.b.c`,
+			want: member(member(member(nil, "a"), "b"), "c"),
+		}, {
+			name: "wildcard",
+			expr: `a.*`,
+			want: &ast.Wildcard{X: member(nil, "a")},
+		}, {
+			name: "safe member",
+			expr: `a?.b.c`,
+			want: member(&ast.Member{X: member(nil, "a"), Name: "b", Safe: true}, "c"),
+		}, {
+			name: "safe call",
+			expr: `a?.func()`,
+			want: &ast.Call{X: member(nil, "a"), Name: "func", Safe: true},
+		}, {
+			name: "variable member",
+			expr: `$user.role`,
+			want: member(&ast.Variable{Name: "user"}, "role"),
+		}, {
+			name: "index",
+			expr: `a[0]`,
+			want: &ast.Index{X: member(nil, "a"), Index: intLit(0, "0")},
+		}, {
+			name: "slice with high bound only",
+			expr: `a[:3]`,
+			want: &ast.Slice{X: member(nil, "a"), High: intLit(3, "3")},
+		}, {
+			name: "slice with low bound only",
+			expr: `a[1:]`,
+			want: &ast.Slice{X: member(nil, "a"), Low: intLit(1, "1")},
+		}, {
+			name: "free function",
+			expr: `func(a, 1)`,
+			want: &ast.Call{Name: "func", Args: []ast.Expr{member(nil, "a"), intLit(1, "1")}},
+		}, {
+			name: "member function with lambda",
+			expr: `a.func(x => x.b)`,
+			want: &ast.Call{
+				X:    member(nil, "a"),
+				Name: "func",
+				Args: []ast.Expr{&ast.Lambda{Param: "x", Body: member(&ast.Param{Name: "x"}, "b")}},
+			},
+		}, {
+			name: "floor division",
+			expr: `a // 2`,
+			want: &ast.Binary{Op: ast.FloorDiv, X: member(nil, "a"), Y: intLit(2, "2")},
+		}, {
+			name: "keyword operator",
+			expr: `a and b`,
+			want: &ast.Binary{Op: ast.And, X: member(nil, "a"), Y: member(nil, "b")},
+		}, {
+			name: "match binds tighter than logical operators",
+			expr: `a =~ "x" && b !~ c`,
+			want: &ast.Binary{
+				Op: ast.And,
+				X:  &ast.Binary{Op: ast.Match, X: member(nil, "a"), Y: &ast.Literal{Kind: ast.StringLiteral, Value: "x", Raw: `"x"`}},
+				Y:  &ast.Binary{Op: ast.NotMatch, X: member(nil, "b"), Y: member(nil, "c")},
+			},
+		}, {
+			name: "coalesce",
+			expr: `a ?? b`,
+			want: &ast.Binary{Op: ast.Coalesce, X: member(nil, "a"), Y: member(nil, "b")},
+		}, {
+			name: "unary",
+			expr: `not a`,
+			want: &ast.Unary{Op: ast.Not, X: member(nil, "a")},
+		}, {
+			name: "parenthesis",
+			expr: `(a)`,
+			want: &ast.Paren{X: member(nil, "a")},
+		}, {
+			name: "ternary",
+			expr: `a ? b : c`,
+			want: &ast.Ternary{Cond: member(nil, "a"), Then: member(nil, "b"), Else: member(nil, "c")},
+		}, {
+			name: "is not",
+			expr: `a is not int`,
+			want: &ast.Is{X: member(nil, "a"), Type: "int", Not: true},
+		}, {
+			name: "as",
+			expr: `a as float`,
+			want: &ast.As{X: member(nil, "a"), Type: "float"},
+		}, {
+			name: "not in",
+			expr: `a not in b`,
+			want: &ast.In{X: member(nil, "a"), Y: member(nil, "b"), Not: true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := buildAST(t, tc.expr)
+
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreTypes(ast.Span{}, ast.Position{})); diff != "" {
+				t.Errorf("NewProgram(%q).AST mismatch (-want +got):\n%s", tc.expr, diff)
+			}
+		})
+	}
+}
+
+func TestNewProgram_ASTSpans(t *testing.T) {
+	t.Parallel()
+	got := buildAST(t, "a.b +\n  cd")
+
+	binary := got.(*ast.Binary)
+	testCases := []struct {
+		name string
+		node ast.Node
+		want ast.Span
+	}{
+		{
+			name: "binary",
+			node: binary,
+			want: ast.Span{
+				Start: ast.Position{Offset: 0, Line: 1, Column: 1},
+				Stop:  ast.Position{Offset: 10, Line: 2, Column: 5},
+			},
+		}, {
+			name: "member",
+			node: binary.X,
+			want: ast.Span{
+				Start: ast.Position{Offset: 0, Line: 1, Column: 1},
+				Stop:  ast.Position{Offset: 3, Line: 1, Column: 4},
+			},
+		}, {
+			name: "second line",
+			node: binary.Y,
+			want: ast.Span{
+				Start: ast.Position{Offset: 8, Line: 2, Column: 3},
+				Stop:  ast.Position{Offset: 10, Line: 2, Column: 5},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := ast.Span{Start: tc.node.Pos(), Stop: tc.node.End()}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("span mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+	t.Run("operator", func(t *testing.T) {
+		t.Parallel()
+		want := ast.Position{Offset: 4, Line: 1, Column: 5}
+
+		if diff := cmp.Diff(want, binary.OpPos); diff != "" {
+			t.Errorf("operator position mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestNewProgram_FormatStringSpans(t *testing.T) {
+	t.Parallel()
+	got := buildAST(t, "x +\n  f\"ab{c.d:>4}\"")
+
+	format := got.(*ast.Binary).Y.(*ast.FormatString)
+	interpolation := format.Parts[1].(*ast.Interpolation)
+	testCases := []struct {
+		name string
+		node ast.Node
+		want ast.Span
+	}{
+		{
+			name: "format string",
+			node: format,
+			want: ast.Span{
+				Start: ast.Position{Offset: 6, Line: 2, Column: 3},
+				Stop:  ast.Position{Offset: 19, Line: 2, Column: 16},
+			},
+		}, {
+			name: "text",
+			node: format.Parts[0],
+			want: ast.Span{
+				Start: ast.Position{Offset: 8, Line: 2, Column: 5},
+				Stop:  ast.Position{Offset: 10, Line: 2, Column: 7},
+			},
+		}, {
+			name: "interpolation",
+			node: interpolation,
+			want: ast.Span{
+				Start: ast.Position{Offset: 10, Line: 2, Column: 7},
+				Stop:  ast.Position{Offset: 18, Line: 2, Column: 15},
+			},
+		}, {
+			name: "interpolated expression",
+			node: interpolation.X,
+			want: ast.Span{
+				Start: ast.Position{Offset: 11, Line: 2, Column: 8},
+				Stop:  ast.Position{Offset: 14, Line: 2, Column: 11},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := ast.Span{Start: tc.node.Pos(), Stop: tc.node.End()}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("span mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseAST_Comments(t *testing.T) {
+	t.Parallel()
+	input := "# first\nunknown($x) # second"
+
+	_, got, err := compile.ParseAST(input)
+	if err != nil {
+		t.Fatalf("ParseAST(%q) error = %v", input, err)
+	}
+
+	want := []*ast.Comment{
+		{
+			Span: ast.Span{
+				Start: ast.Position{Offset: 0, Line: 1, Column: 1},
+				Stop:  ast.Position{Offset: 7, Line: 1, Column: 8},
+			},
+			Text: "# first",
+		}, {
+			Span: ast.Span{
+				Start: ast.Position{Offset: 20, Line: 2, Column: 13},
+				Stop:  ast.Position{Offset: 28, Line: 2, Column: 21},
+			},
+			Text: "# second",
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ParseAST(%q) comments mismatch (-want +got):\n%s", input, diff)
+	}
+}
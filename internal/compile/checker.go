@@ -0,0 +1,797 @@
+package compile
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+
+	antlr "github.com/antlr4-go/antlr/v4"
+	"rodusek.dev/pkg/dcell/internal/errs"
+	"rodusek.dev/pkg/dcell/internal/expr"
+	"rodusek.dev/pkg/dcell/internal/invocation"
+	"rodusek.dev/pkg/dcell/internal/members"
+	"rodusek.dev/pkg/dcell/internal/parser"
+	"rodusek.dev/pkg/dcell/internal/reflectconv"
+)
+
+// Checker is a visitor that walks the parse tree to check an expression
+// against the static Go type of the value that it is evaluated against.
+//
+// Types that cannot be known statically, such as the values of variables,
+// interface values, and the results of functions without a declared result
+// type, are represented by a nil [reflect.Type] and are accepted by every
+// check.
+type Checker struct {
+	FuncTable *invocation.Table
+
+	// Members is the policy that names the fields of structs.
+	Members *members.Policy
+
+	// params is the stack of parameters of the lambdas that enclose the
+	// expression currently being checked.
+	params []checkedParam
+
+	// presence is the number of `has` or `exists` calls that enclose the
+	// expression currently being checked, in which unknown members make the
+	// path absent rather than failing.
+	presence int
+}
+
+type checkedParam struct {
+	name string
+	rt   reflect.Type
+}
+
+// CheckProgram checks the root of the parse tree against the root type, and
+// returns the static type of the result of the expression.
+func (c *Checker) CheckProgram(ctx parser.IProgramContext, root reflect.Type) (reflect.Type, error) {
+	return c.checkExpression(ctx.Expression(), root)
+}
+
+//------------------------------------------------------------------------------
+// Expressions
+//------------------------------------------------------------------------------
+
+func (c *Checker) checkExpression(ctx parser.IExpressionContext, current reflect.Type) (reflect.Type, error) {
+	switch ctx := ctx.(type) {
+	case *parser.TermExpressionContext:
+		return c.checkTerm(ctx.Term(), current)
+	case *parser.InvocationExpressionContext:
+		return c.checkInvocationExpression(ctx, current)
+	case *parser.SafeInvocationExpressionContext:
+		left, err := c.checkExpression(ctx.Expression(), current)
+		if err != nil {
+			return nil, err
+		}
+		return c.checkInvocation(ctx.Invocation(), left, false)
+	case *parser.IndexExpressionContext:
+		return c.checkIndexExpression(ctx, current)
+	case *parser.ParenthesisExpressionContext:
+		return c.checkExpression(ctx.Expression(), current)
+	case *parser.LogicalNotExpressionContext:
+		return c.checkLogical(current, ctx.Expression())
+	case *parser.BitwiseNotExpressionContext:
+		return c.checkBitwiseNot(ctx, current)
+	case *parser.PolarityExpressionContext:
+		return c.checkPolarity(ctx, current)
+	case *parser.ExponentiationExpressionContext:
+		return c.checkArithmetic(ctx, current, ctx.AllExpression(), "**")
+	case *parser.MultiplicativeExpressionContext:
+		return c.checkArithmetic(ctx, current, ctx.AllExpression(), c.getTreeText(ctx.GetChild(1)))
+	case *parser.AdditiveExpressionContext:
+		return c.checkArithmetic(ctx, current, ctx.AllExpression(), c.getTreeText(ctx.GetChild(1)))
+	case *parser.LogicalAndExpressionContext:
+		return c.checkLogical(current, ctx.AllExpression()...)
+	case *parser.LogicalOrExpressionContext:
+		return c.checkLogical(current, ctx.AllExpression()...)
+	case *parser.ImplicationExpressionContext:
+		return c.checkLogical(current, ctx.AllExpression()...)
+	case *parser.ShiftExpressionContext:
+		return c.checkBitwise(ctx, current, ctx.AllExpression(), c.getTreeText(ctx.GetChild(1)))
+	case *parser.BitwiseAndExpressionContext:
+		return c.checkBitwise(ctx, current, ctx.AllExpression(), "&")
+	case *parser.BitwiseOrExpressionContext:
+		return c.checkBitwise(ctx, current, ctx.AllExpression(), c.getTreeText(ctx.GetChild(1)))
+	case *parser.InequalityExpressionContext:
+		return c.checkComparison(ctx, current, ctx.AllExpression(), c.getTreeText(ctx.GetChild(1)))
+	case *parser.EqualityExpressionContext:
+		return c.checkComparison(ctx, current, ctx.AllExpression(), c.getTreeText(ctx.GetChild(1)))
+	case *parser.TernaryExpressionContext:
+		types, err := c.checkExpressions(ctx.AllExpression(), current)
+		if err != nil {
+			return nil, err
+		}
+		return commonType(types[1], types[2]), nil
+	case *parser.ElvisExpressionContext:
+		types, err := c.checkExpressions(ctx.AllExpression(), current)
+		if err != nil {
+			return nil, err
+		}
+		return commonType(types[0], types[1]), nil
+	case *parser.CoalesceExpressionContext:
+		types, err := c.checkExpressions(ctx.AllExpression(), current)
+		if err != nil {
+			return nil, err
+		}
+		return commonType(types[0], types[1]), nil
+	case *parser.IsExpressionContext:
+		return c.checkLogical(current, ctx.Expression())
+	case *parser.CastExpressionContext:
+		return c.checkCastExpression(ctx, current)
+	case *parser.ContainsExpressionContext:
+		return c.checkContainsExpression(ctx, current)
+	case *parser.MatchExpressionContext:
+		return c.checkMatchExpression(ctx, current)
+	}
+	return nil, ErrInternalf(ctx, "unexpected expression type: %T", ctx)
+}
+
+func (c *Checker) checkInvocationExpression(ctx *parser.InvocationExpressionContext, current reflect.Type) (reflect.Type, error) {
+	left, err := c.checkExpression(ctx.Expression(), current)
+	if err != nil {
+		return nil, err
+	}
+	return c.checkInvocation(ctx.Invocation(), left, false)
+}
+
+func (c *Checker) checkIndexExpression(ctx *parser.IndexExpressionContext, current reflect.Type) (reflect.Type, error) {
+	left, err := c.checkExpression(ctx.Expression(), current)
+	if err != nil {
+		return nil, err
+	}
+	return c.checkIndex(ctx, ctx.Index(), left)
+}
+
+// checkLogical checks the operands of an operator that accepts operands of
+// any type and produces a bool.
+func (c *Checker) checkLogical(current reflect.Type, operands ...parser.IExpressionContext) (reflect.Type, error) {
+	if _, err := c.checkExpressions(operands, current); err != nil {
+		return nil, err
+	}
+	return reflect.TypeFor[bool](), nil
+}
+
+func (c *Checker) checkBitwiseNot(ctx *parser.BitwiseNotExpressionContext, current reflect.Type) (reflect.Type, error) {
+	operand, err := c.checkExpression(ctx.Expression(), current)
+	if err != nil {
+		return nil, err
+	}
+	if cls := classOf(operand); cls != classDynamic && cls != classInt {
+		return nil, NewSemanticErrorf(ctx, "%w: operation for ~%v is undefined", errs.ErrIncompatible, operand)
+	}
+	return reflect.TypeFor[uint64](), nil
+}
+
+func (c *Checker) checkPolarity(ctx *parser.PolarityExpressionContext, current reflect.Type) (reflect.Type, error) {
+	operand, err := c.checkExpression(ctx.Expression(), current)
+	if err != nil {
+		return nil, err
+	}
+	op := c.getTreeText(ctx.GetChild(0))
+	switch classOf(operand) {
+	case classDynamic:
+		return nil, nil
+	case classInt:
+		if op == "+" {
+			return derefType(operand), nil
+		}
+		return reflect.TypeFor[int64](), nil
+	case classFloat:
+		if op == "+" {
+			return derefType(operand), nil
+		}
+		return reflect.TypeFor[float64](), nil
+	}
+	return nil, NewSemanticErrorf(ctx, "%w: operation for %s%v is undefined", errs.ErrIncompatible, op, operand)
+}
+
+// checkArithmetic checks the operands of an arithmetic operator, which are
+// either both integers, producing an int64, or numbers where at least one is a
+// float, producing a float64. Strings may also be added together.
+func (c *Checker) checkArithmetic(ctx parser.IExpressionContext, current reflect.Type, operands []parser.IExpressionContext, op string) (reflect.Type, error) {
+	types, err := c.checkExpressions(operands, current)
+	if err != nil {
+		return nil, err
+	}
+	lhs, rhs := classOf(types[0]), classOf(types[1])
+	valid := func(cls typeClass) bool {
+		return cls == classDynamic || cls == classInt || cls == classFloat || (op == "+" && cls == classString)
+	}
+	switch {
+	case !valid(lhs) || !valid(rhs):
+	case lhs == classDynamic || rhs == classDynamic:
+		return nil, nil
+	case lhs == classInt && rhs == classInt:
+		return reflect.TypeFor[int64](), nil
+	case lhs == classString && rhs == classString:
+		return reflect.TypeFor[string](), nil
+	case lhs != classString && rhs != classString:
+		return reflect.TypeFor[float64](), nil
+	}
+	return nil, NewSemanticErrorf(ctx, "%w: operation for %v %s %v is undefined", errs.ErrIncompatible, types[0], op, types[1])
+}
+
+// checkComparison checks the operands of an equality or inequality operator,
+// which must be of the same class: both numbers, both strings, both bools, or
+// both other values, since values of different classes are not meaningfully
+// comparable.
+func (c *Checker) checkComparison(ctx parser.IExpressionContext, current reflect.Type, operands []parser.IExpressionContext, op string) (reflect.Type, error) {
+	types, err := c.checkExpressions(operands, current)
+	if err != nil {
+		return nil, err
+	}
+	lhs, rhs := classOf(types[0]), classOf(types[1])
+	numeric := func(cls typeClass) bool {
+		return cls == classInt || cls == classFloat
+	}
+	switch {
+	case lhs == classDynamic || rhs == classDynamic:
+	case lhs == rhs, numeric(lhs) && numeric(rhs):
+	default:
+		return nil, NewSemanticErrorf(ctx, "%w: operation for %v %s %v is undefined", errs.ErrIncompatible, types[0], op, types[1])
+	}
+	return reflect.TypeFor[bool](), nil
+}
+
+// checkBitwise checks the operands of a bitwise operator, which must both be
+// integers.
+func (c *Checker) checkBitwise(ctx parser.IExpressionContext, current reflect.Type, operands []parser.IExpressionContext, op string) (reflect.Type, error) {
+	types, err := c.checkExpressions(operands, current)
+	if err != nil {
+		return nil, err
+	}
+	for _, rt := range types {
+		if cls := classOf(rt); cls != classDynamic && cls != classInt {
+			return nil, NewSemanticErrorf(ctx, "%w: operation for %v %s %v is undefined", errs.ErrIncompatible, types[0], op, types[1])
+		}
+	}
+	return reflect.TypeFor[uint64](), nil
+}
+
+func (c *Checker) checkCastExpression(ctx *parser.CastExpressionContext, current reflect.Type) (reflect.Type, error) {
+	if _, err := c.checkExpression(ctx.Expression(), current); err != nil {
+		return nil, err
+	}
+	var ty expr.Type
+	if err := ty.UnmarshalText([]byte(ctx.Type_().GetText())); err != nil {
+		return nil, err
+	}
+	switch ty {
+	case expr.TypeString:
+		return reflect.TypeFor[string](), nil
+	case expr.TypeInt:
+		return reflect.TypeFor[int64](), nil
+	case expr.TypeUint:
+		return reflect.TypeFor[uint64](), nil
+	case expr.TypeFloat:
+		return reflect.TypeFor[float64](), nil
+	case expr.TypeBool:
+		return reflect.TypeFor[bool](), nil
+	}
+	return nil, nil
+}
+
+func (c *Checker) checkContainsExpression(ctx *parser.ContainsExpressionContext, current reflect.Type) (reflect.Type, error) {
+	types, err := c.checkExpressions(ctx.AllExpression(), current)
+	if err != nil {
+		return nil, err
+	}
+	if rhs := derefType(types[1]); rhs != nil && rhs.Kind() != reflect.Interface {
+		if rhs.Kind() != reflect.Slice && rhs.Kind() != reflect.Array {
+			return nil, NewSemanticErrorf(ctx, "%w: right operand must be a slice or array, got %v", errs.ErrIncompatible, types[1])
+		}
+	}
+	return reflect.TypeFor[bool](), nil
+}
+
+func (c *Checker) checkMatchExpression(ctx *parser.MatchExpressionContext, current reflect.Type) (reflect.Type, error) {
+	types, err := c.checkExpressions(ctx.AllExpression(), current)
+	if err != nil {
+		return nil, err
+	}
+	for i, operand := range []string{"left operand", "pattern"} {
+		rt := derefType(types[i])
+		if rt != nil && rt.Kind() != reflect.Interface && rt.Kind() != reflect.String {
+			return nil, NewSemanticErrorf(ctx, "%w: %s must be a string, got %v", errs.ErrIncompatible, operand, types[i])
+		}
+	}
+	return reflect.TypeFor[bool](), nil
+}
+
+func (c *Checker) checkExpressions(ctxs []parser.IExpressionContext, current reflect.Type) ([]reflect.Type, error) {
+	var types []reflect.Type
+	for _, ctx := range ctxs {
+		rt, err := c.checkExpression(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, rt)
+	}
+	return types, nil
+}
+
+//------------------------------------------------------------------------------
+// Terms
+//------------------------------------------------------------------------------
+
+func (c *Checker) checkTerm(ctx parser.ITermContext, current reflect.Type) (reflect.Type, error) {
+	switch ctx := ctx.(type) {
+	case *parser.LiteralTermContext:
+		switch literal := ctx.Literal().(type) {
+		case *parser.ListLiteralContext:
+			return c.checkList(literal.List(), current)
+		case *parser.ObjectLiteralContext:
+			return c.checkObject(literal.Object(), current)
+		case *parser.FormatStringLiteralContext:
+			return c.checkFormatString(literal, current)
+		}
+		literal, err := (&Visitor{}).visitLiteral(ctx.Literal())
+		if err != nil {
+			return nil, err
+		}
+		return reflect.TypeOf(literal), nil
+	case *parser.InvocationTermContext:
+		return c.checkInvocation(ctx.Invocation(), current, true)
+	case *parser.VariableTermContext:
+		return nil, nil
+	}
+	return nil, ErrInternalf(ctx, "unexpected term type: %T", ctx)
+}
+
+// checkList returns the type of a list literal, which is a slice of the type
+// of its elements if they all have the same type, or a slice of any
+// otherwise. It returns nil if the type of an element is dynamic.
+func (c *Checker) checkList(ctx parser.IListContext, current reflect.Type) (reflect.Type, error) {
+	var types []reflect.Type
+	for _, item := range ctx.AllExpression() {
+		rt, err := c.checkExpression(item, current)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, rt)
+	}
+	elem, ok := entryType(types)
+	if !ok {
+		return nil, nil
+	}
+	return reflect.SliceOf(elem), nil
+}
+
+// checkFormatString checks the expressions interpolated by a format string
+// literal, which is a string.
+func (c *Checker) checkFormatString(ctx *parser.FormatStringLiteralContext, current reflect.Type) (reflect.Type, error) {
+	parts, err := formatParts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, part := range parts {
+		if part.Expr == nil {
+			continue
+		}
+		if _, err := c.checkExpression(part.Expr, current); err != nil {
+			return nil, err
+		}
+	}
+	return reflect.TypeFor[string](), nil
+}
+
+// checkObject returns the type of an object literal, which is a map from
+// strings to the type of its values if they all have the same type, or to any
+// otherwise. It returns nil if the type of a value is dynamic.
+func (c *Checker) checkObject(ctx parser.IObjectContext, current reflect.Type) (reflect.Type, error) {
+	var types []reflect.Type
+	for _, property := range ctx.AllProperty() {
+		rt, err := c.checkExpression(property.Expression(), current)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, rt)
+	}
+	elem, ok := entryType(types)
+	if !ok {
+		return nil, nil
+	}
+	return reflect.MapOf(reflect.TypeFor[string](), elem), nil
+}
+
+// entryType returns the type of the entries of a list or object literal with
+// entries of the given types, and false if any of them is dynamic.
+func entryType(types []reflect.Type) (reflect.Type, bool) {
+	if slices.ContainsFunc(types, isDynamic) {
+		return nil, false
+	}
+	if len(types) == 0 || slices.ContainsFunc(types[1:], func(rt reflect.Type) bool { return rt != types[0] }) {
+		return reflect.TypeFor[any](), true
+	}
+	return types[0], true
+}
+
+//------------------------------------------------------------------------------
+// Invocations
+//------------------------------------------------------------------------------
+
+func (c *Checker) checkInvocation(ctx parser.IInvocationContext, current reflect.Type, isRoot bool) (reflect.Type, error) {
+	switch ctx := ctx.(type) {
+	case *parser.FunctionInvocationContext:
+		return c.checkFunctionInvocation(ctx, current, isRoot)
+	case *parser.WildcardInvocationContext:
+		return nil, nil
+	case *parser.MemberInvocationContext:
+		return c.checkMemberInvocation(ctx, current, isRoot)
+	}
+	return nil, ErrInternalf(ctx, "unexpected invocation type: %T", ctx)
+}
+
+func (c *Checker) checkFunctionInvocation(ctx *parser.FunctionInvocationContext, current reflect.Type, isRoot bool) (reflect.Type, error) {
+	name := ctx.Identifier().GetText()
+	entry, ok := c.FuncTable.Lookup(name)
+	isMethod := false
+	if !ok && !isRoot && c.Members.HasMethods() {
+		var err error
+		if entry, err = c.methodEntry(ctx, current, name); err != nil {
+			return nil, err
+		}
+		ok = entry != nil
+		isMethod = ok
+	}
+	if !ok && isRoot && isPresenceFunc(name) {
+		return c.checkPresence(ctx, current)
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	// Arguments of member functions are preceded by the receiver, and are
+	// evaluated against it. The receiver of a Go method is the value that the
+	// method was found on, and so needs no check.
+	var args []reflect.Type
+	if !isRoot {
+		if want, known := entry.ParamType(0); known && !isMethod && !isDynamic(current) && !isConvertibleArgument(current, want) {
+			return nil, NewSemanticErrorf(ctx, "%w: argument 0 must be of type %v, got %v", invocation.ErrBadArgument, want, current)
+		}
+		args = append(args, current)
+	}
+	var params []parser.IParameterContext
+	if list := ctx.ParameterList(); list != nil {
+		params = list.AllParameter()
+	}
+	for _, param := range params {
+		i := len(args)
+		want, known := entry.ParamType(i)
+		var got reflect.Type
+		var err error
+		switch param := param.(type) {
+		case *parser.LambdaParameterContext:
+			got, err = c.checkLambdaArgument(param.Lambda(), current, entry, args, i)
+		case *parser.ExpressionParameterContext:
+			got, err = c.checkExpression(param.Expression(), current)
+			if err == nil && known && !isDynamic(got) && !isConvertibleArgument(got, want) {
+				err = NewSemanticErrorf(param, "%w: argument %d must be of type %v, got %v", invocation.ErrBadArgument, i, want, got)
+			}
+		default:
+			err = ErrInternalf(param, "unexpected parameter type: %T", param)
+		}
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, got)
+	}
+	return entry.ResultType(), nil
+}
+
+// checkPresence checks the argument of the built-in functions `has` and
+// `exists`, whose result is a bool.
+func (c *Checker) checkPresence(ctx *parser.FunctionInvocationContext, current reflect.Type) (reflect.Type, error) {
+	c.presence++
+	defer func() { c.presence-- }()
+	if list := ctx.ParameterList(); list != nil {
+		for _, param := range list.AllParameter() {
+			param, ok := param.(*parser.ExpressionParameterContext)
+			if !ok {
+				continue
+			}
+			if _, err := c.checkExpression(param.Expression(), current); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return reflect.TypeFor[bool](), nil
+}
+
+// methodEntry returns the function entry of the Go method that a member
+// function call matching no function of the table calls on a value of type
+// rt, whose first argument is the receiver. It returns nil if the type of the
+// value is only known at evaluation time.
+func (c *Checker) methodEntry(ctx *parser.FunctionInvocationContext, rt reflect.Type, name string) (*invocation.Entry, error) {
+	if isDynamic(derefType(rt)) {
+		return nil, nil
+	}
+	method, ok := c.Members.Method(rt, name)
+	if !ok {
+		return nil, NewSemanticErrorf(ctx, "%w", errs.NewNameError(name, c.Members.MethodNames(rt)))
+	}
+	entry, err := invocation.NewFunc(method.Func.Interface())
+	if err != nil {
+		return nil, NewSemanticErrorf(ctx, "method %s: %w", name, err)
+	}
+	args := 1
+	if list := ctx.ParameterList(); list != nil {
+		args += len(list.AllParameter())
+	}
+	if err := entry.TestArity(args); err != nil {
+		return nil, NewSemanticErrorf(ctx, "%w", err)
+	}
+	return entry, nil
+}
+
+// checkLambdaArgument checks a lambda passed as the i-th argument of a
+// function. If the function declares a function type for the argument, the
+// lambda parameter has the type of its argument and the body must produce its
+// result type. Otherwise, the parameter is assumed to be an element of the
+// first argument, which is how the built-in collection functions invoke their
+// lambdas.
+func (c *Checker) checkLambdaArgument(ctx parser.ILambdaContext, current reflect.Type, entry *invocation.Entry, args []reflect.Type, i int) (reflect.Type, error) {
+	var param, result reflect.Type
+	if want, ok := entry.ParamType(i); ok {
+		if want.Kind() != reflect.Func || want.NumIn() != 1 || want.NumOut() == 0 {
+			if want.Kind() != reflect.Interface {
+				return nil, NewSemanticErrorf(ctx, "%w: argument %d must be of type %v, got lambda", invocation.ErrBadArgument, i, want)
+			}
+		} else {
+			param, result = want.In(0), want.Out(0)
+		}
+	} else if len(args) > 0 {
+		param = elemType(args[0])
+	}
+
+	name := ctx.Identifier().GetText()
+	c.params = append(c.params, checkedParam{name: name, rt: param})
+	defer func() { c.params = c.params[:len(c.params)-1] }()
+
+	body, err := c.checkExpression(ctx.Expression(), current)
+	if err != nil {
+		return nil, err
+	}
+	if result != nil && !isDynamic(body) && !isConvertibleResult(body, result) {
+		return nil, NewSemanticErrorf(ctx, "%w: lambda must return %v, got %v", invocation.ErrBadArgument, result, body)
+	}
+	return nil, nil
+}
+
+func (c *Checker) checkMemberInvocation(ctx *parser.MemberInvocationContext, current reflect.Type, isRoot bool) (reflect.Type, error) {
+	name := ctx.Identifier().GetText()
+	if isRoot {
+		for _, param := range slices.Backward(c.params) {
+			if param.name == name {
+				return param.rt, nil
+			}
+		}
+	}
+	rt, err := c.memberType(current, name)
+	if errors.Is(err, errs.ErrUnknownName) && (c.Members.AllowsMissing() || c.presence > 0) {
+		// The member evaluates to null, or makes the path absent.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, NewSemanticErrorf(ctx, "%w", err)
+	}
+	return rt, nil
+}
+
+// memberType returns the type of the named member of a value of type rt,
+// following the same rules as [expr.MemberExpr].
+func (c *Checker) memberType(rt reflect.Type, name string) (reflect.Type, error) {
+	rt = derefType(rt)
+	if isDynamic(rt) || expr.IsMemberResolver(rt) {
+		return nil, nil
+	}
+	noMembers := errs.NewNameError(name, slices.Values([]string{}))
+	switch rt.Kind() {
+	case reflect.Map:
+		if rt.Key().Kind() != reflect.String {
+			return nil, noMembers
+		}
+		return rt.Elem(), nil
+	case reflect.Struct:
+		if field, ok := c.Members.Field(rt, name); ok {
+			return rt.FieldByIndex(field.Index).Type, nil
+		}
+		if err := c.Members.Ambiguous(rt, name); err != nil {
+			return nil, err
+		}
+		if getter, ok := c.Members.Getter(rt, name); ok {
+			return getter.Type.Out(0), nil
+		}
+		if rt.NumField() == 0 {
+			return nil, nil
+		}
+		return nil, errs.NewNameError(name, c.Members.MemberNames(rt))
+	case reflect.Slice, reflect.Array:
+		elem := derefType(rt.Elem())
+		if isDynamic(elem) || expr.IsMemberResolver(elem) {
+			return nil, nil
+		}
+		if elem.Kind() != reflect.Map && elem.Kind() != reflect.Struct {
+			return nil, noMembers
+		}
+		field, err := c.memberType(elem, name)
+		if err != nil || field == nil {
+			return nil, err
+		}
+		return reflect.SliceOf(field), nil
+	}
+	if getter, ok := c.Members.Getter(rt, name); ok {
+		return getter.Type.Out(0), nil
+	}
+	return nil, errs.NewNameError(name, c.Members.MemberNames(rt))
+}
+
+//------------------------------------------------------------------------------
+// Index
+//------------------------------------------------------------------------------
+
+// checkIndex checks the index of the index expression parent, which is
+// applied to a value of the current type.
+func (c *Checker) checkIndex(parent parser.IExpressionContext, ctx parser.IIndexContext, current reflect.Type) (reflect.Type, error) {
+	switch ctx := ctx.(type) {
+	case *parser.SliceIndexContext:
+		return c.checkSliceIndex(parent, ctx, current)
+	case *parser.ExpressionIndexContext:
+		return c.checkExpressionIndex(parent, ctx, current)
+	}
+	return nil, ErrInternalf(ctx, "unexpected index param type: %T", ctx)
+}
+
+func (c *Checker) checkSliceIndex(parent parser.IExpressionContext, ctx *parser.SliceIndexContext, current reflect.Type) (reflect.Type, error) {
+	types, err := c.checkExpressions(ctx.AllExpression(), current)
+	if err != nil {
+		return nil, err
+	}
+	for _, rt := range types {
+		if cls := classOf(rt); cls != classDynamic && cls != classInt {
+			return nil, NewSemanticErrorf(parent, "%w: index must be an integer, got %v", errs.ErrIncompatible, rt)
+		}
+	}
+
+	rt := derefType(current)
+	switch {
+	case isDynamic(rt):
+		return nil, nil
+	case rt.Kind() == reflect.String:
+		return reflect.TypeFor[string](), nil
+	case rt.Kind() == reflect.Slice || rt.Kind() == reflect.Array:
+		return reflect.SliceOf(rt.Elem()), nil
+	}
+	return nil, NewSemanticErrorf(parent, "%w: cannot slice %v", errs.ErrIncompatible, current)
+}
+
+func (c *Checker) checkExpressionIndex(parent parser.IExpressionContext, ctx *parser.ExpressionIndexContext, current reflect.Type) (reflect.Type, error) {
+	index, err := c.checkExpression(ctx.Expression(), current)
+	if err != nil {
+		return nil, err
+	}
+	cls := classOf(index)
+
+	rt := derefType(current)
+	switch {
+	case isDynamic(rt):
+		return nil, nil
+	case cls == classString && expr.IsMemberResolver(rt):
+		return nil, nil
+	case rt.Kind() == reflect.Map:
+		if !isDynamic(index) && !isConvertibleKey(index, rt.Key()) {
+			return nil, NewSemanticErrorf(parent, "%w: map key must be of type %v, got %v", errs.ErrIncompatible, rt.Key(), index)
+		}
+		return rt.Elem(), nil
+	}
+	if cls != classDynamic && cls != classInt {
+		return nil, NewSemanticErrorf(parent, "%w: index must be an integer, got %v", errs.ErrIncompatible, index)
+	}
+	switch {
+	case expr.IsIndexResolver(rt):
+		return nil, nil
+	case rt.Kind() == reflect.String:
+		return reflect.TypeFor[string](), nil
+	case rt.Kind() == reflect.Slice || rt.Kind() == reflect.Array:
+		return rt.Elem(), nil
+	}
+	return nil, NewSemanticErrorf(parent, "%w: cannot index %v", errs.ErrIncompatible, current)
+}
+
+//------------------------------------------------------------------------------
+// Types
+//------------------------------------------------------------------------------
+
+type typeClass int
+
+const (
+	classDynamic typeClass = iota
+	classInt
+	classFloat
+	classString
+	classBool
+	classOther
+)
+
+// classOf classifies the type by how operators treat its values.
+func classOf(rt reflect.Type) typeClass {
+	rt = derefType(rt)
+	switch {
+	case isDynamic(rt):
+		return classDynamic
+	case reflectconv.IsInt(rt):
+		return classInt
+	case reflectconv.IsFloat(rt):
+		return classFloat
+	case reflectconv.IsString(rt):
+		return classString
+	case reflectconv.IsBool(rt):
+		return classBool
+	}
+	return classOther
+}
+
+// derefType returns the type that pointers of type rt point to.
+func derefType(rt reflect.Type) reflect.Type {
+	for rt != nil && rt.Kind() == reflect.Pointer {
+		rt = rt.Elem()
+	}
+	return rt
+}
+
+// isDynamic reports whether values of the type may hold values of any type.
+func isDynamic(rt reflect.Type) bool {
+	return rt == nil || rt.Kind() == reflect.Interface
+}
+
+// elemType returns the type of the elements of a slice or array type, or nil
+// if rt is not a slice or array type.
+func elemType(rt reflect.Type) reflect.Type {
+	rt = derefType(rt)
+	if rt == nil || (rt.Kind() != reflect.Slice && rt.Kind() != reflect.Array) {
+		return nil
+	}
+	return rt.Elem()
+}
+
+// commonType returns the type shared by both operands, or nil if they differ.
+func commonType(lhs, rhs reflect.Type) reflect.Type {
+	if lhs == rhs {
+		return lhs
+	}
+	return nil
+}
+
+// isConvertibleKey reports whether values of type got can be converted into
+// the key type of a map, following the conversions of [expr.IndexExpr].
+func isConvertibleKey(got, key reflect.Type) bool {
+	if got.AssignableTo(key) || isDynamic(key) {
+		return true
+	}
+	from, to := classOf(got), classOf(key)
+	return (from == to && to != classOther) || (from == classInt && to == classFloat)
+}
+
+// isConvertibleArgument reports whether values of type got can be passed as
+// arguments of type want, following the conversions made by the functions of
+// [invocation.Table.AddFunc].
+func isConvertibleArgument(got, want reflect.Type) bool {
+	return got.AssignableTo(want) || invocation.IsConvertibleNumber(got, want)
+}
+
+// isConvertibleResult reports whether the result of a lambda can be returned
+// as the wanted type, following the conversions made by the callables of
+// [invocation.Table.AddFunc].
+func isConvertibleResult(got, want reflect.Type) bool {
+	if got.AssignableTo(want) {
+		return true
+	}
+	sameClass := got.Kind() == want.Kind() ||
+		(reflectconv.IsInt(got) && reflectconv.IsInt(want)) ||
+		(reflectconv.IsFloat(got) && reflectconv.IsFloat(want))
+	return sameClass && got.ConvertibleTo(want)
+}
+
+func (c *Checker) getTreeText(tree antlr.Tree) string {
+	return tree.(interface{ GetText() string }).GetText()
+}
@@ -0,0 +1,610 @@
+package compile_test
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"rodusek.dev/pkg/dcell/internal/compile"
+	"rodusek.dev/pkg/dcell/internal/errs"
+	"rodusek.dev/pkg/dcell/internal/invocation"
+	"rodusek.dev/pkg/dcell/internal/invocation/arity"
+	"rodusek.dev/pkg/dcell/internal/members"
+)
+
+type checkedLabel struct {
+	Name  string `dcell:"name"`
+	Color string
+}
+
+type checkedUser struct {
+	Login string `dcell:"login"`
+	Admin bool   `dcell:"admin"`
+}
+
+type checkedPullRequest struct {
+	Title  string            `dcell:"title"`
+	Number int               `dcell:"number"`
+	User   *checkedUser      `dcell:"user"`
+	Labels []checkedLabel    `dcell:"labels"`
+	Meta   map[string]string `dcell:"meta"`
+	Codes  map[int]string    `dcell:"codes"`
+	Extra  any               `dcell:"extra"`
+	Score  float64           `dcell:"score"`
+	Doc    checkedDocument   `dcell:"doc"`
+
+	unexported string
+}
+
+type checkedDocument struct {
+	fields map[string]any
+}
+
+func (d *checkedDocument) DCellMember(name string) (any, bool) {
+	value, ok := d.fields[name]
+	return value, ok
+}
+
+func (d *checkedDocument) DCellIndex(index int) (any, bool) {
+	return nil, false
+}
+
+type checkedEvent struct {
+	PullRequest *checkedPullRequest `dcell:"pull_request"`
+}
+
+func checkerTable() *invocation.Table {
+	table := invocation.NewTable()
+	table.AddFunc("double", func(i int64) int64 { return i * 2 })
+	table.AddFunc("apply", func(v int64, fn func(int64) int64) int64 { return fn(v) })
+	table.AddFunc("half", func(f float64) float64 { return f / 2 })
+	table.AddFunc("dynamic", func(...any) (any, error) { return nil, nil })
+	table.Add("untyped", func(...reflect.Value) (reflect.Value, error) {
+		return reflect.Value{}, nil
+	}).SetArity(arity.Any())
+	return table
+}
+
+func TestNewProgram_Schema(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		expr string
+		want reflect.Type
+	}{
+		{
+			name: "nested member",
+			expr: "pull_request.title",
+			want: reflect.TypeFor[string](),
+		}, {
+			name: "member of pointer",
+			expr: "pull_request.user.login",
+			want: reflect.TypeFor[string](),
+		}, {
+			name: "field without tag",
+			expr: "pull_request.labels[0].Color",
+			want: reflect.TypeFor[string](),
+		}, {
+			name: "projection over slice",
+			expr: "pull_request.labels.name",
+			want: reflect.TypeFor[[]string](),
+		}, {
+			name: "map element",
+			expr: "pull_request.meta.anything",
+			want: reflect.TypeFor[string](),
+		}, {
+			name: "member of interface is dynamic",
+			expr: "pull_request.extra.anything.at.all",
+			want: nil,
+		}, {
+			name: "member of resolver is dynamic",
+			expr: "pull_request.doc.anything.at.all",
+			want: nil,
+		}, {
+			name: "index of resolver is dynamic",
+			expr: "pull_request.doc[0]",
+			want: nil,
+		}, {
+			name: "key of map",
+			expr: `pull_request.meta["Content-Type"]`,
+			want: reflect.TypeFor[string](),
+		}, {
+			name: "key converted to map key type",
+			expr: "pull_request.codes[404]",
+			want: reflect.TypeFor[string](),
+		}, {
+			name: "rune of string",
+			expr: "pull_request.title[0]",
+			want: reflect.TypeFor[string](),
+		}, {
+			name: "runes of string",
+			expr: "pull_request.title[1:3]",
+			want: reflect.TypeFor[string](),
+		}, {
+			name: "slice of slice",
+			expr: "pull_request.labels[1:]",
+			want: reflect.TypeFor[[]checkedLabel](),
+		}, {
+			name: "integer arithmetic",
+			expr: "pull_request.number + 1",
+			want: reflect.TypeFor[int64](),
+		}, {
+			name: "float arithmetic",
+			expr: "pull_request.number * pull_request.score",
+			want: reflect.TypeFor[float64](),
+		}, {
+			name: "string concatenation",
+			expr: `pull_request.title + "!"`,
+			want: reflect.TypeFor[string](),
+		}, {
+			name: "comparison",
+			expr: "pull_request.number > 10",
+			want: reflect.TypeFor[bool](),
+		}, {
+			name: "comparison of int and float",
+			expr: "pull_request.score >= pull_request.number",
+			want: reflect.TypeFor[bool](),
+		}, {
+			name: "equality with null",
+			expr: "pull_request.user == null",
+			want: reflect.TypeFor[bool](),
+		}, {
+			name: "equality with dynamic value",
+			expr: `pull_request.extra != "a"`,
+			want: reflect.TypeFor[bool](),
+		}, {
+			name: "cast",
+			expr: "pull_request.title as int",
+			want: reflect.TypeFor[int64](),
+		}, {
+			name: "function result",
+			expr: "double(pull_request.number as int)",
+			want: reflect.TypeFor[int64](),
+		}, {
+			name: "converted function argument",
+			expr: "half(pull_request.number as int)",
+			want: reflect.TypeFor[float64](),
+		}, {
+			name: "member function result",
+			expr: "(pull_request.number as int).double()",
+			want: reflect.TypeFor[int64](),
+		}, {
+			name: "lambda parameter from signature",
+			expr: "apply(2, x => x * 3)",
+			want: reflect.TypeFor[int64](),
+		}, {
+			name: "lambda parameter from receiver elements",
+			expr: "pull_request.labels.untyped(l => l.name)",
+			want: nil,
+		}, {
+			name: "list of the same types",
+			expr: "[pull_request.title, pull_request.user.login]",
+			want: reflect.TypeFor[[]string](),
+		}, {
+			name: "list of different types",
+			expr: "[pull_request.title, pull_request.number]",
+			want: reflect.TypeFor[[]any](),
+		}, {
+			name: "list with dynamic element",
+			expr: "[pull_request.title, $v]",
+			want: nil,
+		}, {
+			name: "object",
+			expr: "{title: pull_request.title, login: pull_request.user.login}",
+			want: reflect.TypeFor[map[string]string](),
+		}, {
+			name: "empty object",
+			expr: "{}",
+			want: reflect.TypeFor[map[string]any](),
+		}, {
+			name: "format string",
+			expr: `f"#{pull_request.number} by {pull_request.user.login}"`,
+			want: reflect.TypeFor[string](),
+		}, {
+			name: "match",
+			expr: `pull_request.title =~ "^WIP" || pull_request.user.login !~ pull_request.meta.bot`,
+			want: reflect.TypeFor[bool](),
+		}, {
+			name: "presence",
+			expr: "has(pull_request.user.login)",
+			want: reflect.TypeFor[bool](),
+		}, {
+			name: "presence of unknown member",
+			expr: "has(pull_request.user.logn)",
+			want: reflect.TypeFor[bool](),
+		}, {
+			name: "receiver of member function",
+			expr: "(pull_request.number as int).double()",
+			want: reflect.TypeFor[int64](),
+		}, {
+			name: "ternary with same types",
+			expr: `pull_request.user.admin ? "yes" : "no"`,
+			want: reflect.TypeFor[string](),
+		}, {
+			name: "ternary with different types",
+			expr: `pull_request.user.admin ? "yes" : 1`,
+			want: nil,
+		}, {
+			name: "variables are dynamic",
+			expr: "$anything.at.all + 1",
+			want: nil,
+		}, {
+			name: "in list",
+			expr: `"bug" in pull_request.labels.name`,
+			want: reflect.TypeFor[bool](),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			program, err := compile.NewProgram(tc.expr, &compile.Config{
+				FuncTable: checkerTable(),
+				Schema:    reflect.TypeFor[*checkedEvent](),
+			})
+
+			if err != nil {
+				t.Fatalf("NewProgram(%q) error = %v", tc.expr, err)
+			}
+			if got := program.Type; got != tc.want {
+				t.Errorf("NewProgram(%q).Type = %v, want %v", tc.expr, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewProgram_SchemaMembers(t *testing.T) {
+	t.Parallel()
+	type repository struct {
+		FullName string `json:"full_name"`
+		Secret   string `dcell:"-"`
+	}
+	cfg := &compile.Config{
+		FuncTable: checkerTable(),
+		Schema:    reflect.TypeFor[repository](),
+		Members:   &members.Policy{Tags: []string{"dcell", "json"}, FoldSeparators: true},
+	}
+
+	program, err := compile.NewProgram("full-name", cfg)
+	if err != nil {
+		t.Fatalf("NewProgram() error = %v", err)
+	}
+	if got, want := program.Type, reflect.TypeFor[string](); got != want {
+		t.Errorf("NewProgram().Type = %v, want %v", got, want)
+	}
+
+	_, err = compile.NewProgram("Secret", cfg)
+	if got, want := err, errs.ErrUnknownName; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Errorf("NewProgram() error = %v, want %v", got, want)
+	}
+}
+
+func TestNewProgram_SchemaMissingAsNull(t *testing.T) {
+	t.Parallel()
+	cfg := &compile.Config{
+		FuncTable: checkerTable(),
+		Schema:    reflect.TypeFor[checkedEvent](),
+		Members:   &members.Policy{MissingAsNull: true},
+	}
+
+	program, err := compile.NewProgram("pull_request.titel ?? pull_request.title", cfg)
+
+	if err != nil {
+		t.Fatalf("NewProgram() error = %v", err)
+	}
+	if got := program.Type; got != nil {
+		t.Errorf("NewProgram().Type = %v, want nil", got)
+	}
+}
+
+type checkedMessage struct {
+	title string
+}
+
+func (m *checkedMessage) GetTitle() string {
+	return m.title
+}
+
+func TestNewProgram_SchemaMethods(t *testing.T) {
+	t.Parallel()
+	type event struct {
+		CreatedAt time.Time
+		Message   *checkedMessage
+	}
+	cfg := &compile.Config{
+		FuncTable: checkerTable(),
+		Schema:    reflect.TypeFor[event](),
+		Members: &members.Policy{
+			Methods: &members.TypeSet{Types: []reflect.Type{reflect.TypeFor[time.Time]()}},
+			Getters: &members.TypeSet{Types: []reflect.Type{reflect.TypeFor[checkedMessage]()}},
+		},
+	}
+
+	testCases := []struct {
+		name    string
+		expr    string
+		want    reflect.Type
+		wantErr error
+	}{
+		{
+			name: "method result type",
+			expr: "CreatedAt.Year()",
+			want: reflect.TypeFor[int](),
+		}, {
+			name: "method with arguments",
+			expr: `CreatedAt.Format("2006")`,
+			want: reflect.TypeFor[string](),
+		}, {
+			name: "getter result type",
+			expr: "Message.title",
+			want: reflect.TypeFor[string](),
+		}, {
+			name:    "unknown method",
+			expr:    "CreatedAt.Yaer()",
+			wantErr: errs.ErrUnknownName,
+		}, {
+			name:    "method of type not allowed",
+			expr:    "Year()",
+			wantErr: errs.ErrUnknownName,
+		}, {
+			name:    "wrong number of arguments",
+			expr:    "CreatedAt.Year(1)",
+			wantErr: compile.ErrCompile,
+		}, {
+			name:    "argument of wrong type",
+			expr:    "CreatedAt.Format(1)",
+			wantErr: invocation.ErrBadArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			program, err := compile.NewProgram(tc.expr, cfg)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("NewProgram(%q) error = %v, want %v", tc.expr, got, want)
+			}
+			if err != nil {
+				return
+			}
+			if got, want := program.Type, tc.want; got != want {
+				t.Errorf("NewProgram(%q).Type = %v, want %v", tc.expr, got, want)
+			}
+		})
+	}
+}
+
+type CheckedAccount struct {
+	Login string `dcell:"login"`
+	ID    int    `dcell:"id"`
+}
+
+type CheckedTeam struct {
+	ID   int    `dcell:"id"`
+	Slug string `dcell:"slug"`
+}
+
+func TestNewProgram_SchemaEmbedded(t *testing.T) {
+	t.Parallel()
+	type moderator struct {
+		CheckedAccount
+		*CheckedTeam
+	}
+	cfg := &compile.Config{
+		FuncTable: checkerTable(),
+		Schema:    reflect.TypeFor[moderator](),
+	}
+
+	program, err := compile.NewProgram("slug", cfg)
+	if err != nil {
+		t.Fatalf("NewProgram() error = %v", err)
+	}
+	if got, want := program.Type, reflect.TypeFor[string](); got != want {
+		t.Errorf("NewProgram().Type = %v, want %v", got, want)
+	}
+
+	_, err = compile.NewProgram("id", cfg)
+	if got, want := err, errs.ErrAmbiguousName; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Errorf("NewProgram() error = %v, want %v", got, want)
+	}
+}
+
+func TestNewProgram_SchemaError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		expr      string
+		wantErr   error
+		wantTrace string
+	}{
+		{
+			name:      "misspelled member",
+			expr:      "pull_request.titel",
+			wantErr:   errs.ErrUnknownName,
+			wantTrace: "titel",
+		}, {
+			name:      "misspelled nested member",
+			expr:      "pull_requst.title",
+			wantErr:   errs.ErrUnknownName,
+			wantTrace: "pull_requst",
+		}, {
+			name:      "unexported member",
+			expr:      "pull_request.unexported",
+			wantErr:   errs.ErrUnknownName,
+			wantTrace: "unexported",
+		}, {
+			name:      "member of string",
+			expr:      "pull_request.title.length",
+			wantErr:   errs.ErrUnknownName,
+			wantTrace: "length",
+		}, {
+			name:      "misspelled member in projection",
+			expr:      "pull_request.labels.nmae",
+			wantErr:   errs.ErrUnknownName,
+			wantTrace: "nmae",
+		}, {
+			name:      "misspelled member in lambda",
+			expr:      "pull_request.labels.untyped(l => l.nmae)",
+			wantErr:   errs.ErrUnknownName,
+			wantTrace: "nmae",
+		}, {
+			name:      "index of struct",
+			expr:      "pull_request.user[0]",
+			wantErr:   errs.ErrIncompatible,
+			wantTrace: "pull_request.user[0]",
+		}, {
+			name:      "non-integer index",
+			expr:      `pull_request.labels["a"]`,
+			wantErr:   errs.ErrIncompatible,
+			wantTrace: `pull_request.labels["a"]`,
+		}, {
+			name:      "key not convertible to map key type",
+			expr:      `pull_request.codes["a"]`,
+			wantErr:   errs.ErrIncompatible,
+			wantTrace: `pull_request.codes["a"]`,
+		}, {
+			name:      "slice of struct",
+			expr:      "pull_request.user[0:1]",
+			wantErr:   errs.ErrIncompatible,
+			wantTrace: "pull_request.user[0:1]",
+		}, {
+			name:      "incompatible operands",
+			expr:      `pull_request.number - pull_request.title`,
+			wantErr:   errs.ErrIncompatible,
+			wantTrace: "pull_request.number-pull_request.title",
+		}, {
+			name:      "incompatible bitwise operands",
+			expr:      `pull_request.score & 1`,
+			wantErr:   errs.ErrIncompatible,
+			wantTrace: "pull_request.score&1",
+		}, {
+			name:      "incompatible dynamic operand",
+			expr:      `$value + pull_request.user`,
+			wantErr:   errs.ErrIncompatible,
+			wantTrace: "$value+pull_request.user",
+		}, {
+			name:      "ordering of string and number",
+			expr:      `pull_request.title < 1`,
+			wantErr:   errs.ErrIncompatible,
+			wantTrace: "pull_request.title<1",
+		}, {
+			name:      "equality of number and string",
+			expr:      `pull_request.number == "1"`,
+			wantErr:   errs.ErrIncompatible,
+			wantTrace: `pull_request.number=="1"`,
+		}, {
+			name:      "inequality of bool and struct",
+			expr:      `pull_request.user.admin != pull_request.user`,
+			wantErr:   errs.ErrIncompatible,
+			wantTrace: "pull_request.user.admin!=pull_request.user",
+		}, {
+			name:      "in non-list",
+			expr:      `"bug" in pull_request.title`,
+			wantErr:   errs.ErrIncompatible,
+			wantTrace: `"bug"inpull_request.title`,
+		}, {
+			name:      "match non-string",
+			expr:      `pull_request.number =~ "^1"`,
+			wantErr:   errs.ErrIncompatible,
+			wantTrace: `pull_request.number=~"^1"`,
+		}, {
+			name:      "invalid literal pattern",
+			expr:      `pull_request.title =~ "("`,
+			wantErr:   errs.ErrInvalidPattern,
+			wantTrace: `"("`,
+		}, {
+			name:      "invalid constant pattern",
+			expr:      `pull_request.title !~ "(" + "a"`,
+			wantErr:   errs.ErrInvalidPattern,
+			wantTrace: `"("+"a"`,
+		}, {
+			name:      "wrong argument type",
+			expr:      `double(pull_request.title)`,
+			wantErr:   invocation.ErrBadArgument,
+			wantTrace: "pull_request.title",
+		}, {
+			name:      "float argument for integer parameter",
+			expr:      `double(pull_request.number as float)`,
+			wantErr:   invocation.ErrBadArgument,
+			wantTrace: "pull_request.numberasfloat",
+		}, {
+			name:      "wrong lambda result type",
+			expr:      `apply(1, x => "a")`,
+			wantErr:   invocation.ErrBadArgument,
+			wantTrace: `x=>"a"`,
+		}, {
+			name:      "lambda for non-function parameter",
+			expr:      `apply(x => x, x => x)`,
+			wantErr:   invocation.ErrBadArgument,
+			wantTrace: "x=>x",
+		}, {
+			name:      "misspelled member in argument",
+			expr:      `dynamic(pull_request.nubmer)`,
+			wantErr:   errs.ErrUnknownName,
+			wantTrace: "nubmer",
+		}, {
+			name:      "misspelled member in object",
+			expr:      `{number: pull_request.nubmer}`,
+			wantErr:   errs.ErrUnknownName,
+			wantTrace: "nubmer",
+		}, {
+			name:      "misspelled member in format string",
+			expr:      `f"#{pull_request.nubmer}"`,
+			wantErr:   errs.ErrUnknownName,
+			wantTrace: "nubmer",
+		}, {
+			name:      "incompatible operands in presence",
+			expr:      `has(pull_request.title - 1)`,
+			wantErr:   errs.ErrIncompatible,
+			wantTrace: "pull_request.title-1",
+		}, {
+			name:      "receiver of wrong type",
+			expr:      `pull_request.title.double()`,
+			wantErr:   invocation.ErrBadArgument,
+			wantTrace: "double()",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := compile.NewProgram(tc.expr, &compile.Config{
+				FuncTable: checkerTable(),
+				Schema:    reflect.TypeFor[checkedEvent](),
+			})
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("NewProgram(%q) error = %v, want %v", tc.expr, got, want)
+			}
+			if got, want := err, compile.ErrCompile; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("NewProgram(%q) error = %v, want %v", tc.expr, got, want)
+			}
+			if got, want := err.Error(), tc.wantTrace; !strings.Contains(got, strconv.Quote(want)) {
+				t.Errorf("NewProgram(%q) error = %v, want trace %q", tc.expr, got, want)
+			}
+		})
+	}
+}
+
+func TestNewProgram_NoSchema(t *testing.T) {
+	t.Parallel()
+
+	program, err := compile.NewProgram("pull_request.titel", &compile.Config{
+		FuncTable: checkerTable(),
+	})
+
+	if err != nil {
+		t.Fatalf("NewProgram() error = %v", err)
+	}
+	if got := program.Type; got != nil {
+		t.Errorf("NewProgram().Type = %v, want nil", got)
+	}
+}
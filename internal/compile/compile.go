@@ -3,17 +3,67 @@ package compile
 import (
 	"errors"
 	"io"
+	"reflect"
 	"strings"
 
 	antlr "github.com/antlr4-go/antlr/v4"
+	"rodusek.dev/pkg/dcell/ast"
 	"rodusek.dev/pkg/dcell/internal/expr"
 	"rodusek.dev/pkg/dcell/internal/invocation"
+	"rodusek.dev/pkg/dcell/internal/members"
 	"rodusek.dev/pkg/dcell/internal/parser"
+	"rodusek.dev/pkg/dcell/internal/regex"
 )
 
 // Config provides compilation configuration to the [NewTree] function.
 type Config struct {
 	FuncTable *invocation.Table
+
+	// Variables is the set of variable names that expressions may reference,
+	// without the leading '$'. If nil, any variable name is accepted and is
+	// only resolved at evaluation time.
+	Variables []string
+
+	// Budget is the evaluation budget of the compiled expression. It does not
+	// affect compilation itself. If nil, evaluation is not limited.
+	Budget *expr.Budget
+
+	// Schema is the static type of the values that expressions are evaluated
+	// against. If set, expressions are type-checked against it at compile
+	// time.
+	Schema reflect.Type
+
+	// Members is the policy that names the fields of structs, and that
+	// allows the methods of Go values to be called, both when compiling and
+	// when evaluating. If nil, the default policy is used.
+	Members *members.Policy
+
+	// StrictNulls makes navigating through a null value with `.`, `[]`, or
+	// `*` an error, rather than evaluating to null.
+	StrictNulls bool
+
+	// Patterns is the policy that limits the regular expression patterns
+	// that expressions compile, both when compiling and when evaluating. If
+	// nil, any valid pattern is allowed.
+	Patterns *regex.Policy
+
+	// VM evaluates compiled expressions on the virtual machine of package vm,
+	// rather than by walking their trees.
+	VM bool
+}
+
+// Program is a compiled dcell expression.
+type Program struct {
+	// Expr is the expression tree that is evaluated.
+	Expr expr.Expr
+
+	// AST is the syntax tree that the expression was compiled from.
+	AST ast.Expr
+
+	// Type is the static type of the result of the expression. It is nil if
+	// the config has no schema, or if the type is only known at evaluation
+	// time.
+	Type reflect.Type
 }
 
 // NewTree converts a string dcell expression into the proper Expression
@@ -25,8 +75,107 @@ func NewTree(str string, cfg *Config) (expr.Expr, error) {
 // NewTreeFromReader converts a dcell expression from an io.Reader into the
 // proper Expression tree.
 func NewTreeFromReader(r io.Reader, cfg *Config) (expr.Expr, error) {
-	input := antlr.NewIoStream(r)
+	program, err := NewProgramFromReader(r, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return program.Expr, nil
+}
+
+// NewProgram compiles a string dcell expression into a [Program].
+func NewProgram(str string, cfg *Config) (*Program, error) {
+	return NewProgramFromReader(strings.NewReader(str), cfg)
+}
+
+// NewProgramFromReader compiles a dcell expression from an io.Reader into a
+// [Program].
+func NewProgramFromReader(r io.Reader, cfg *Config) (*Program, error) {
+	tree, _, err := parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	visitor := &Visitor{
+		FuncTable:   cfg.FuncTable,
+		Variables:   cfg.Variables,
+		Members:     cfg.Members,
+		StrictNulls: cfg.StrictNulls,
+		Patterns:    cfg.Patterns,
+	}
+	e, err := visitor.VisitProgram(tree)
+	if err != nil {
+		return nil, err
+	}
 
+	builder := &ASTBuilder{}
+	node, err := builder.BuildProgram(tree)
+	if err != nil {
+		return nil, err
+	}
+
+	program := &Program{
+		Expr: e,
+		AST:  node,
+	}
+	if cfg.Schema != nil {
+		checker := &Checker{
+			FuncTable: cfg.FuncTable,
+			Members:   cfg.Members,
+		}
+		program.Type, err = checker.CheckProgram(tree, cfg.Schema)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	optimizer := &Optimizer{
+		Origins: visitor.Origins,
+		Budget:  cfg.Budget,
+	}
+	program.Expr, err = optimizer.OptimizeProgram(tree, e)
+	if err != nil {
+		return nil, err
+	}
+	return program, nil
+}
+
+// ParseAST parses a string dcell expression into its syntax tree and the
+// comments of its source text, in source order.
+//
+// Unlike [NewProgram], functions and variables are not resolved, so any
+// syntactically valid expression is accepted.
+func ParseAST(str string) (ast.Expr, []*ast.Comment, error) {
+	tree, stream, err := parse(strings.NewReader(str))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	builder := &ASTBuilder{}
+	node, err := builder.BuildProgram(tree)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var comments []*ast.Comment
+	for _, token := range stream.GetAllTokens() {
+		if token.GetTokenType() == parser.DCellLexerCOMMENT {
+			comments = append(comments, &ast.Comment{
+				Span: tokenSpan(token, token),
+				Text: token.GetText(),
+			})
+		}
+	}
+	return node, comments, nil
+}
+
+// parse parses a dcell expression from an io.Reader, returning the parse tree
+// along with the token stream that it was parsed from.
+func parse(r io.Reader) (parser.IProgramContext, *antlr.CommonTokenStream, error) {
+	return parseStream(antlr.NewIoStream(r))
+}
+
+// parseStream parses a dcell expression from a character stream.
+func parseStream(input antlr.CharStream) (parser.IProgramContext, *antlr.CommonTokenStream, error) {
 	lexerErrors := &ErrorListener{}
 	lexer := parser.NewDCellLexer(input)
 	lexer.RemoveErrorListeners()
@@ -40,17 +189,13 @@ func NewTreeFromReader(r io.Reader, cfg *Config) (expr.Expr, error) {
 	parser.AddErrorListener(parserErrors)
 
 	parser.BuildParseTrees = true
-	program := parser.Program()
+	tree := parser.Program()
 
 	var errs []error
 	errs = append(errs, lexerErrors.Errors...)
 	errs = append(errs, parserErrors.Errors...)
 	if len(errs) > 0 {
-		return nil, errors.Join(errs...)
-	}
-
-	visitor := &Visitor{
-		FuncTable: cfg.FuncTable,
+		return nil, nil, errors.Join(errs...)
 	}
-	return visitor.VisitProgram(program)
+	return tree, stream, nil
 }
@@ -58,3 +58,40 @@ func TestNewTree_ValidExpressions(t *testing.T) {
 		})
 	}
 }
+
+func TestNewProgram_SemanticErrorTrace(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		expr string
+		want string
+	}{
+		{
+			name: "invalid pattern",
+			expr: `title =~ "("`,
+			want: "compile: invalid pattern: error parsing regexp: missing closing ): `(`\n" +
+				`    occurring in expression: "\"(\""` + "\n",
+		}, {
+			name: "division by zero",
+			expr: `1 / 0`,
+			want: "compile: division by zero\n" +
+				`    occurring in expression: "1/0"` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := compile.NewProgram(tc.expr, &compile.Config{FuncTable: invocation.NewTable()})
+
+			if err == nil {
+				t.Fatalf("NewProgram(%q) error = nil, want error", tc.expr)
+			}
+			if got, want := err.Error(), tc.want; got != want {
+				t.Errorf("NewProgram(%q) error = %q, want %q", tc.expr, got, want)
+			}
+		})
+	}
+}
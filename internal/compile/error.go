@@ -3,7 +3,7 @@ package compile
 import (
 	"errors"
 	"fmt"
-	"math/rand"
+	"strconv"
 	"strings"
 
 	antlr "github.com/antlr4-go/antlr/v4"
@@ -46,10 +46,7 @@ func (e *SemanticError) Error() string {
 	if len(e.Trace) == 0 {
 		return fmt.Sprintf("%v: %v", ErrCompile, e.Err)
 	}
-	space := "    "
-	if rand.Intn(2) == 0 {
-		space = "   "
-	}
+	const space = "    "
 
 	builder := &strings.Builder{}
 	builder.WriteString(ErrCompile.Error())
@@ -58,14 +55,14 @@ func (e *SemanticError) Error() string {
 	builder.WriteString("\n")
 
 	builder.WriteString(space)
-	builder.WriteString("occurring in expression: \"")
-	builder.WriteString(e.Trace[0])
-	builder.WriteString("\"\n")
+	builder.WriteString("occurring in expression: ")
+	builder.WriteString(strconv.Quote(e.Trace[0]))
+	builder.WriteString("\n")
 	for _, trace := range e.Trace[1:] {
 		builder.WriteString(space)
-		builder.WriteString("which is a sub-expression of \"")
-		builder.WriteString(trace)
-		builder.WriteString("\"\n")
+		builder.WriteString("which is a sub-expression of ")
+		builder.WriteString(strconv.Quote(trace))
+		builder.WriteString("\n")
 	}
 
 	return builder.String()
@@ -0,0 +1,208 @@
+package compile
+
+import (
+	"strconv"
+	"strings"
+
+	antlr "github.com/antlr4-go/antlr/v4"
+	"rodusek.dev/pkg/dcell/internal/parser"
+)
+
+// formatPart is a part of a format string literal, such as `f"PR #{number}"`,
+// which is either text or an expression that is interpolated.
+type formatPart struct {
+	// Text is the text of the part, with escapes and doubled braces decoded.
+	Text string
+
+	// Expr is the expression that is interpolated, or nil if the part is
+	// text.
+	Expr parser.IExpressionContext
+
+	// Spec is the format spec of the interpolation, such as `.2f`, or empty.
+	Spec string
+
+	// start and stop are the offsets of the first character of the part,
+	// and of the character after it, in the text of the literal.
+	start, stop int
+}
+
+// formatParts returns the parts of a format string literal, in order. The
+// expressions that it interpolates are parsed from the source text of the
+// literal, so that their parse trees have the same positions as if they were
+// written outside of it.
+func formatParts(ctx *parser.FormatStringLiteralContext) ([]*formatPart, error) {
+	token := ctx.FORMAT_STRING().GetSymbol()
+	raw := []rune(token.GetText())
+
+	var parts []*formatPart
+	var text strings.Builder
+	start := 2 // skip the f and the quote
+	flush := func(stop int) error {
+		if stop == start {
+			return nil
+		}
+		str, err := strconv.Unquote("\"" + text.String() + "\"")
+		if err != nil {
+			return syntaxError(token, raw, start, "invalid escape in format string")
+		}
+		parts = append(parts, &formatPart{Text: str, start: start, stop: stop})
+		text.Reset()
+		return nil
+	}
+
+	for i := start; i < len(raw)-1; {
+		switch r := raw[i]; {
+		case r == '\\':
+			// Escapes of quotes and slashes are decoded here, since they are
+			// not valid in Go string literals.
+			switch next := raw[i+1]; next {
+			case '\'', '`', '/':
+				text.WriteRune(next)
+			default:
+				text.WriteRune(r)
+				text.WriteRune(next)
+			}
+			i += 2
+		case (r == '{' || r == '}') && raw[i+1] == r:
+			text.WriteRune(r)
+			i += 2
+		case r == '{':
+			if err := flush(i); err != nil {
+				return nil, err
+			}
+			part, err := interpolation(token, raw, i)
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, part)
+			i, start = part.stop, part.stop
+		case r == '"':
+			text.WriteString(`\"`)
+			i++
+		default:
+			text.WriteRune(r)
+			i++
+		}
+	}
+	if err := flush(len(raw) - 1); err != nil {
+		return nil, err
+	}
+	return parts, nil
+}
+
+// interpolation returns the part of a format string literal for the
+// interpolation that starts with the brace at offset open of its text.
+func interpolation(token antlr.Token, raw []rune, open int) (*formatPart, error) {
+	colon, end := scanInterpolation(raw, open)
+	stop := end
+	if colon >= 0 {
+		stop = colon
+	}
+	if strings.TrimSpace(string(raw[open+1:stop])) == "" {
+		return nil, syntaxError(token, raw, open, "empty expression in format string")
+	}
+
+	// Blank out the source text before the expression, keeping its line
+	// breaks, so that the expression is parsed at its position in the source.
+	prefix := []rune(token.GetInputStream().GetText(0, token.GetStart()-1))
+	prefix = append(prefix, raw[:open+1]...)
+	for i, r := range prefix {
+		if r != '\n' {
+			prefix[i] = ' '
+		}
+	}
+	source := string(prefix) + string(raw[open+1:stop])
+	tree, _, err := parseStream(antlr.NewInputStream(source))
+	if err != nil {
+		return nil, err
+	}
+
+	part := &formatPart{
+		Expr:  tree.Expression(),
+		start: open,
+		stop:  end + 1,
+	}
+	if colon >= 0 {
+		part.Spec = string(raw[colon+1 : end])
+	}
+	return part, nil
+}
+
+// scanInterpolation returns the offsets of the colon that starts the format
+// spec of the interpolation that starts with the brace at offset open, or -1
+// if it has none, and of the brace that closes it.
+//
+// The colon of the spec is the first colon outside of brackets and strings
+// that does not belong to a ternary, as in `{ok ? "yes" : "no":>5}`.
+func scanInterpolation(raw []rune, open int) (colon, end int) {
+	colon = -1
+	depth, ternaries := 0, 0
+	for i := open; i < len(raw); i++ {
+		switch raw[i] {
+		case '"', '\'':
+			i = skipString(raw, i)
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			if depth--; depth == 0 {
+				return colon, i
+			}
+		case '?':
+			if i+1 < len(raw) && strings.ContainsRune(".:?", raw[i+1]) {
+				i++ // `?.`, `?:`, or `??`
+			} else if depth == 1 && colon < 0 {
+				ternaries++
+			}
+		case ':':
+			if depth != 1 || colon >= 0 {
+				break
+			}
+			if ternaries > 0 {
+				ternaries--
+			} else {
+				colon = i
+			}
+		}
+	}
+	return colon, len(raw) - 1
+}
+
+// skipString returns the offset of the quote that closes the string literal
+// that starts with the quote at offset open.
+func skipString(raw []rune, open int) int {
+	for i := open + 1; i < len(raw); i++ {
+		switch raw[i] {
+		case '\\':
+			i++
+		case raw[open]:
+			return i
+		}
+	}
+	return len(raw) - 1
+}
+
+// syntaxError returns a [CompileError] at the given offset of the text of a
+// token.
+func syntaxError(token antlr.Token, raw []rune, offset int, message string) *CompileError {
+	line, column := tokenPosition(token, raw, offset)
+	return &CompileError{
+		Message: message,
+		Line:    line,
+		Column:  column,
+	}
+}
+
+// tokenPosition returns the line, starting at 1, and the column, starting at
+// 0, of the given offset of the text of a token.
+func tokenPosition(token antlr.Token, raw []rune, offset int) (line, column int) {
+	line, column = token.GetLine(), token.GetColumn()
+	for _, r := range raw[:offset] {
+		if r == '\n' {
+			line++
+			column = 0
+		} else {
+			column++
+		}
+	}
+	return line, column
+}
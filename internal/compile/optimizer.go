@@ -0,0 +1,382 @@
+package compile
+
+import (
+	"errors"
+	"reflect"
+
+	antlr "github.com/antlr4-go/antlr/v4"
+	"rodusek.dev/pkg/dcell/internal/errs"
+	"rodusek.dev/pkg/dcell/internal/expr"
+	"rodusek.dev/pkg/dcell/internal/parser"
+	"rodusek.dev/pkg/dcell/internal/reflectconv"
+)
+
+// Optimizer is a pass over the expression tree produced by the [Visitor] that
+// simplifies it ahead of evaluation:
+//
+//   - operations whose operands are all literals are folded into a literal,
+//     as are calls to pure functions with literal arguments, and list and
+//     object literals whose entries are all literals;
+//   - `&&`, `||`, `??`, and ternaries with a literal left operand or
+//     condition are short-circuited;
+//   - nested sequences are collapsed into a single sequence.
+//
+// Errors raised while folding, and divisions by a literal zero, are reported
+// as a [SemanticError] at compile time rather than on every evaluation.
+type Optimizer struct {
+	// Origins maps expressions to the parse tree nodes that they were visited
+	// from, as recorded by [Visitor.Origins].
+	Origins map[expr.Expr]antlr.ParseTree
+
+	// Budget is the evaluation budget of the expression. Folding that would
+	// exceed it is left to evaluation time. If nil, folding is not limited.
+	Budget *expr.Budget
+
+	// origin is the parse tree node of the innermost expression being
+	// optimized that has a recorded origin.
+	origin antlr.ParseTree
+}
+
+// OptimizeProgram optimizes the expression tree of the root of the parse tree.
+func (o *Optimizer) OptimizeProgram(ctx parser.IProgramContext, e expr.Expr) (expr.Expr, error) {
+	o.origin = ctx.Expression()
+	return o.optimize(e)
+}
+
+func (o *Optimizer) optimize(e expr.Expr) (expr.Expr, error) {
+	if reflect.ValueOf(e).Kind() == reflect.Pointer {
+		if origin, ok := o.Origins[e]; ok {
+			parent := o.origin
+			o.origin = origin
+			defer func() { o.origin = parent }()
+		}
+	}
+
+	switch e := e.(type) {
+	case expr.SequenceExpr:
+		return o.optimizeSequence(e)
+	case *expr.LogicalAndExpr:
+		return o.optimizeLogical(e, &e.Left, &e.Right, false)
+	case *expr.LogicalOrExpr:
+		return o.optimizeLogical(e, &e.Left, &e.Right, true)
+	case *expr.TernaryExpr:
+		return o.optimizeTernary(e)
+	case *expr.CoalesceExpr:
+		return o.optimizeCoalesce(e)
+	case *expr.DivideExpr:
+		return o.optimizeDivision(e, &e.Left, &e.Right)
+	case *expr.FloorDivideExpr:
+		return o.optimizeDivision(e, &e.Left, &e.Right)
+	case *expr.ModulusExpr:
+		return o.optimizeDivision(e, &e.Left, &e.Right)
+	case *expr.AddExpr:
+		return o.optimizeOperation(e, &e.Left, &e.Right)
+	case *expr.SubtractExpr:
+		return o.optimizeOperation(e, &e.Left, &e.Right)
+	case *expr.MultiplyExpr:
+		return o.optimizeOperation(e, &e.Left, &e.Right)
+	case *expr.PowerExpr:
+		return o.optimizeOperation(e, &e.Left, &e.Right)
+	case *expr.BitwiseAndExpr:
+		return o.optimizeOperation(e, &e.Left, &e.Right)
+	case *expr.BitwiseOrExpr:
+		return o.optimizeOperation(e, &e.Left, &e.Right)
+	case *expr.BitwiseXorExpr:
+		return o.optimizeOperation(e, &e.Left, &e.Right)
+	case *expr.BitwiseShiftLeftExpr:
+		return o.optimizeOperation(e, &e.Left, &e.Right)
+	case *expr.BitwiseShiftRightExpr:
+		return o.optimizeOperation(e, &e.Left, &e.Right)
+	case *expr.ImpliesExpr:
+		return o.optimizeOperation(e, &e.Left, &e.Right)
+	case *expr.EqualityExpr:
+		return o.optimizeOperation(e, &e.Left, &e.Right)
+	case *expr.InequalityExpr:
+		return o.optimizeOperation(e, &e.Left, &e.Right)
+	case *expr.InExpr:
+		return o.optimizeOperation(e, &e.Left, &e.Right)
+	case *expr.MatchExpr:
+		return o.optimizeOperation(e, &e.Left, &e.Pattern)
+	case *expr.IsExpr:
+		return o.optimizeOperation(e, &e.Expr)
+	case *expr.AsExpr:
+		return o.optimizeOperation(e, &e.Expr)
+	case *expr.FormatExpr:
+		return o.optimizeOperation(e, &e.Expr)
+	case *expr.PatternExpr:
+		return o.optimizeOperation(e, &e.Expr)
+	case expr.LogicalNotExpr:
+		if err := o.optimizeAll(&e.Expr); err != nil {
+			return nil, err
+		}
+		return o.fold(e, e.Expr)
+	case expr.BitwiseNotExpr:
+		if err := o.optimizeAll(&e.Expr); err != nil {
+			return nil, err
+		}
+		return o.fold(e, e.Expr)
+	case expr.PolarityPlusExpr:
+		if err := o.optimizeAll(&e.Expr); err != nil {
+			return nil, err
+		}
+		return o.fold(e, e.Expr)
+	case expr.PolarityMinusExpr:
+		if err := o.optimizeAll(&e.Expr); err != nil {
+			return nil, err
+		}
+		return o.fold(e, e.Expr)
+	case expr.IndexExpr:
+		if err := o.optimizeAll(&e.Index); err != nil {
+			return nil, err
+		}
+		return e, nil
+	case *expr.IndexSliceExpr:
+		if err := o.optimizeAll(&e.Begin, &e.End); err != nil {
+			return nil, err
+		}
+		return e, nil
+	case *expr.FreeFuncExpr:
+		if err := o.optimizeArgs(e.Args); err != nil {
+			return nil, err
+		}
+		if !e.Pure {
+			return e, nil
+		}
+		return o.fold(e, e.Args...)
+	case *expr.ListExpr:
+		if err := o.optimizeArgs(e.Elems); err != nil {
+			return nil, err
+		}
+		return o.fold(e, e.Elems...)
+	case *expr.ObjectExpr:
+		if err := o.optimizeArgs(e.Values); err != nil {
+			return nil, err
+		}
+		return o.fold(e, e.Values...)
+	case *expr.MemberFuncExpr:
+		if err := o.optimizeArgs(e.Args); err != nil {
+			return nil, err
+		}
+		return e, nil
+	case *expr.MethodExpr:
+		if err := o.optimizeArgs(e.Args); err != nil {
+			return nil, err
+		}
+		return e, nil
+	case *expr.LambdaExpr:
+		if err := o.optimizeAll(&e.Body); err != nil {
+			return nil, err
+		}
+		return e, nil
+	case *expr.NonNullExpr:
+		if err := o.optimizeAll(&e.Expr); err != nil {
+			return nil, err
+		}
+		if value, ok := constant(e.Expr); ok && !reflectconv.IsNil(value) {
+			return e.Expr, nil
+		}
+		return e, nil
+	case *expr.HasExpr:
+		// The path is not optimized, since errors raised while folding it
+		// would be reported at compile time, rather than make it absent.
+		return e, nil
+	}
+	return e, nil
+}
+
+// optimizeAll optimizes each of the non-nil expressions in place.
+func (o *Optimizer) optimizeAll(exprs ...*expr.Expr) error {
+	for _, e := range exprs {
+		if *e == nil {
+			continue
+		}
+		optimized, err := o.optimize(*e)
+		if err != nil {
+			return err
+		}
+		*e = optimized
+	}
+	return nil
+}
+
+func (o *Optimizer) optimizeArgs(args []expr.Expr) error {
+	for i := range args {
+		if err := o.optimizeAll(&args[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *Optimizer) optimizeOperation(e expr.Expr, operands ...*expr.Expr) (expr.Expr, error) {
+	if err := o.optimizeAll(operands...); err != nil {
+		return nil, err
+	}
+	values := make([]expr.Expr, 0, len(operands))
+	for _, operand := range operands {
+		values = append(values, *operand)
+	}
+	return o.fold(e, values...)
+}
+
+// optimizeLogical optimizes a logical operation that evaluates to
+// shortCircuit without evaluating its right operand when the truthiness of
+// its left operand is shortCircuit.
+func (o *Optimizer) optimizeLogical(e expr.Expr, left, right *expr.Expr, shortCircuit bool) (expr.Expr, error) {
+	if err := o.optimizeAll(left); err != nil {
+		return nil, err
+	}
+	if lhs, ok := constant(*left); ok && reflectconv.IsTruthy(lhs) == shortCircuit {
+		return expr.Literal(shortCircuit), nil
+	}
+	return o.optimizeOperation(e, left, right)
+}
+
+func (o *Optimizer) optimizeTernary(e *expr.TernaryExpr) (expr.Expr, error) {
+	if err := o.optimizeAll(&e.Condition); err != nil {
+		return nil, err
+	}
+	if condition, ok := constant(e.Condition); ok {
+		if reflectconv.IsTruthy(condition) {
+			return o.optimize(e.TrueExpr)
+		}
+		return o.optimize(e.FalseExpr)
+	}
+	if err := o.optimizeAll(&e.TrueExpr, &e.FalseExpr); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (o *Optimizer) optimizeCoalesce(e *expr.CoalesceExpr) (expr.Expr, error) {
+	if err := o.optimizeAll(&e.Left); err != nil {
+		return nil, err
+	}
+	if lhs, ok := constant(e.Left); ok {
+		if !reflectconv.IsNil(lhs) {
+			return e.Left, nil
+		}
+		return o.optimize(e.Right)
+	}
+	if err := o.optimizeAll(&e.Right); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (o *Optimizer) optimizeDivision(e expr.Expr, left, right *expr.Expr) (expr.Expr, error) {
+	if err := o.optimizeAll(left, right); err != nil {
+		return nil, err
+	}
+	if rhs, ok := constant(*right); ok && isZero(rhs) {
+		return nil, NewSemanticError(o.origin, errs.ErrDivisionByZero)
+	}
+	return o.fold(e, *left, *right)
+}
+
+// optimizeSequence collapses nested sequences, and folds the leading steps of
+// the sequence that are applied to a literal and only depend on it.
+func (o *Optimizer) optimizeSequence(e expr.SequenceExpr) (expr.Expr, error) {
+	seq := make(expr.SequenceExpr, 0, len(e))
+	for _, step := range e {
+		step, err := o.optimize(step)
+		if err != nil {
+			return nil, err
+		}
+		if inner, ok := step.(expr.SequenceExpr); ok {
+			seq = append(seq, inner...)
+		} else {
+			seq = append(seq, step)
+		}
+	}
+
+	n := 1
+	if _, ok := constant(seq[0]); ok {
+		for n < len(seq) && isConstantStep(seq[n]) {
+			n++
+		}
+	}
+	if n > 1 {
+		head, err := o.eval(seq[:n])
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := head.(expr.LiteralExpr); ok {
+			seq = append(expr.SequenceExpr{head}, seq[n:]...)
+		}
+	}
+	if len(seq) == 1 {
+		return seq[0], nil
+	}
+	return seq, nil
+}
+
+// fold evaluates e into a literal if all of its operands are literals.
+func (o *Optimizer) fold(e expr.Expr, operands ...expr.Expr) (expr.Expr, error) {
+	for _, operand := range operands {
+		if _, ok := constant(operand); !ok && operand != nil {
+			return e, nil
+		}
+	}
+	return o.eval(e)
+}
+
+// eval evaluates e, which must not depend on the evaluation context, into a
+// literal. If the evaluation exceeds the budget, e is returned unchanged so
+// that the error is raised when the expression is evaluated.
+func (o *Optimizer) eval(e expr.Expr) (expr.Expr, error) {
+	ctx := expr.NewContext(reflect.Value{})
+	if o.Budget != nil {
+		ctx = ctx.WithBudget(*o.Budget)
+	}
+	result, err := e.Eval(ctx)
+	if errors.Is(err, errs.ErrBudgetExceeded) {
+		return e, nil
+	}
+	if err != nil {
+		return nil, NewSemanticError(o.origin, err)
+	}
+	return expr.LiteralExpr(result), nil
+}
+
+// constant returns the value of e if it is a literal.
+func constant(e expr.Expr) (reflect.Value, bool) {
+	literal, ok := e.(expr.LiteralExpr)
+	return reflect.Value(literal), ok
+}
+
+// isConstantStep reports whether a step of a sequence only depends on the
+// value that it is applied to.
+func isConstantStep(step expr.Expr) bool {
+	isConstant := func(e expr.Expr) bool {
+		_, ok := constant(e)
+		return ok || e == nil
+	}
+	switch step := step.(type) {
+	case expr.IndexExpr:
+		return isConstant(step.Index)
+	case *expr.IndexSliceExpr:
+		return isConstant(step.Begin) && isConstant(step.End)
+	case *expr.MemberFuncExpr:
+		if !step.Pure {
+			return false
+		}
+		for _, arg := range step.Args {
+			if !isConstant(arg) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func isZero(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return rv.IsZero()
+	}
+	return false
+}
@@ -0,0 +1,282 @@
+package compile_test
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"rodusek.dev/pkg/dcell/internal/compile"
+	"rodusek.dev/pkg/dcell/internal/errs"
+	"rodusek.dev/pkg/dcell/internal/expr"
+	"rodusek.dev/pkg/dcell/internal/invocation"
+)
+
+func optimizerTable(calls *atomic.Int64) *invocation.Table {
+	table := invocation.NewTable()
+	shout := func(s string) string {
+		calls.Add(1)
+		return strings.ToUpper(s) + "!"
+	}
+	_ = table.AddFunc("shout", shout)
+	_ = table.AddFunc("impure", shout)
+	entry, _ := table.Lookup("shout")
+	entry.SetPure(true)
+	return table
+}
+
+func TestNewProgram_Folding(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		expr string
+		want any
+	}{
+		{
+			name: "arithmetic",
+			expr: `60 * 60 * 24`,
+			want: int64(86400),
+		}, {
+			name: "string concatenation",
+			expr: `"a" + "b"`,
+			want: "ab",
+		}, {
+			name: "nested operations",
+			expr: `-(2 ** 3) + 10 // 3`,
+			want: int64(-5),
+		}, {
+			name: "comparison",
+			expr: `1 < 2 && !(3 in [1, 2])`,
+			want: true,
+		}, {
+			name: "or short-circuits",
+			expr: `true || x`,
+			want: true,
+		}, {
+			name: "and short-circuits",
+			expr: `false && x`,
+			want: false,
+		}, {
+			name: "ternary with constant condition",
+			expr: `1 > 2 ? x : "no"`,
+			want: "no",
+		}, {
+			name: "coalesce with constant left",
+			expr: `"a" ?? x`,
+			want: "a",
+		}, {
+			name: "cast",
+			expr: `"42" as int`,
+			want: int64(42),
+		}, {
+			name: "pure free function",
+			expr: `shout("a" + "b")`,
+			want: "AB!",
+		}, {
+			name: "pure member function",
+			expr: `"abc".shout()`,
+			want: "ABC!",
+		}, {
+			name: "list index",
+			expr: `[1, 2, 3][2]`,
+			want: int64(3),
+		}, {
+			name: "list slice",
+			expr: `[1, 2, 3][1:]`,
+			want: []int64{2, 3},
+		}, {
+			name: "list of operations",
+			expr: `[1 + 1, "a" + "b", null]`,
+			want: []any{int64(2), "ab", nil},
+		}, {
+			name: "object",
+			expr: `{a: 1 + 1, "b c": 3}`,
+			want: map[string]int64{"a": 2, "b c": 3},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			program, err := compile.NewProgram(tc.expr, &compile.Config{
+				FuncTable: optimizerTable(&atomic.Int64{}),
+			})
+			if err != nil {
+				t.Fatalf("NewProgram(%q) error = %v", tc.expr, err)
+			}
+
+			literal, ok := program.Expr.(expr.LiteralExpr)
+			if !ok {
+				t.Fatalf("NewProgram(%q) = %T, want LiteralExpr", tc.expr, program.Expr)
+			}
+			if got, want := reflect.Value(literal).Interface(), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("NewProgram(%q) = %v, want %v", tc.expr, got, want)
+			}
+		})
+	}
+}
+
+func TestNewProgram_NotFolded(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		expr string
+	}{
+		{name: "member operand", expr: `x + 1`},
+		{name: "and with true left operand", expr: `true && x`},
+		{name: "or with false left operand", expr: `false || x`},
+		{name: "coalesce with null left operand", expr: `null ?? x`},
+		{name: "impure function", expr: `impure("a")`},
+		{name: "variable", expr: `$v * 2`},
+		{name: "lambda", expr: `where(x, y => 1 + 1)`},
+		{name: "list with member", expr: `[1, x]`},
+		{name: "object with member", expr: `{a: 1, b: x}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			table := optimizerTable(&atomic.Int64{})
+			_ = table.AddFunc("where", func(v any, f invocation.Callable) any { return v })
+
+			program, err := compile.NewProgram(tc.expr, &compile.Config{
+				FuncTable: table,
+			})
+			if err != nil {
+				t.Fatalf("NewProgram(%q) error = %v", tc.expr, err)
+			}
+
+			if got, ok := program.Expr.(expr.LiteralExpr); ok {
+				t.Errorf("NewProgram(%q) = %v, want non-literal", tc.expr, reflect.Value(got))
+			}
+		})
+	}
+}
+
+func TestNewProgram_CollapsesSequences(t *testing.T) {
+	t.Parallel()
+	input := `("a".shout() + "b").shout().x[0]`
+
+	program, err := compile.NewProgram(input, &compile.Config{
+		FuncTable: optimizerTable(&atomic.Int64{}),
+	})
+	if err != nil {
+		t.Fatalf("NewProgram(%q) error = %v", input, err)
+	}
+
+	seq, ok := program.Expr.(expr.SequenceExpr)
+	if !ok {
+		t.Fatalf("NewProgram(%q) = %T, want SequenceExpr", input, program.Expr)
+	}
+	if got, want := len(seq), 3; got != want {
+		t.Fatalf("NewProgram(%q) steps = %v, want %v", input, got, want)
+	}
+	head, ok := seq[0].(expr.LiteralExpr)
+	if !ok {
+		t.Fatalf("NewProgram(%q) head = %T, want LiteralExpr", input, seq[0])
+	}
+	if got, want := reflect.Value(head).Interface(), "A!B!"; got != want {
+		t.Errorf("NewProgram(%q) head = %v, want %v", input, got, want)
+	}
+}
+
+func TestNewProgram_PureFunctionsFoldedOnce(t *testing.T) {
+	t.Parallel()
+	calls := &atomic.Int64{}
+	program, err := compile.NewProgram(`shout("a") + impure("b")`, &compile.Config{
+		FuncTable: optimizerTable(calls),
+	})
+	if err != nil {
+		t.Fatalf("NewProgram() error = %v", err)
+	}
+
+	for range 3 {
+		if _, err := program.Expr.Eval(expr.NewContext(reflect.Value{})); err != nil {
+			t.Fatalf("Eval() error = %v", err)
+		}
+	}
+
+	if got, want := calls.Load(), int64(4); got != want {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+}
+
+func TestNewProgram_FoldingError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		expr      string
+		wantErr   error
+		wantTrace string
+	}{
+		{
+			name:      "division by literal zero",
+			expr:      `x / 0`,
+			wantErr:   errs.ErrDivisionByZero,
+			wantTrace: "x/0",
+		}, {
+			name:      "floor division by folded zero",
+			expr:      `1 + x // (2 - 2)`,
+			wantErr:   errs.ErrDivisionByZero,
+			wantTrace: "x//(2-2)",
+		}, {
+			name:      "modulo by float zero",
+			expr:      `x % 0.0`,
+			wantErr:   errs.ErrDivisionByZero,
+			wantTrace: "x%0.0",
+		}, {
+			name:      "constant division by zero",
+			expr:      `x ? 1 / 0 : 2`,
+			wantErr:   errs.ErrDivisionByZero,
+			wantTrace: "1/0",
+		}, {
+			name:      "failing pure function",
+			expr:      `x && shout(1)`,
+			wantErr:   invocation.ErrBadArgument,
+			wantTrace: "shout(1)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := compile.NewProgram(tc.expr, &compile.Config{
+				FuncTable: optimizerTable(&atomic.Int64{}),
+			})
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("NewProgram(%q) error = %v, want %v", tc.expr, got, want)
+			}
+			if got, want := err, compile.ErrCompile; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("NewProgram(%q) error = %v, want %v", tc.expr, got, want)
+			}
+			if got, want := err.Error(), tc.wantTrace; !strings.Contains(got, strconv.Quote(want)) {
+				t.Errorf("NewProgram(%q) error = %v, want trace %q", tc.expr, got, want)
+			}
+		})
+	}
+}
+
+func TestNewProgram_FoldingOverBudget(t *testing.T) {
+	t.Parallel()
+	budget := &expr.Budget{MaxStringLength: 3}
+
+	program, err := compile.NewProgram(`"ab" + "cd"`, &compile.Config{
+		FuncTable: optimizerTable(&atomic.Int64{}),
+		Budget:    budget,
+	})
+	if err != nil {
+		t.Fatalf("NewProgram() error = %v", err)
+	}
+
+	_, err = program.Expr.Eval(expr.NewContext(reflect.Value{}).WithBudget(*budget))
+	if got, want := err, errs.ErrBudgetExceeded; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+		t.Errorf("Eval() error = %v, want %v", got, want)
+	}
+}
@@ -1,18 +1,58 @@
 package compile
 
 import (
+	"reflect"
+	"slices"
 	"strconv"
 
 	antlr "github.com/antlr4-go/antlr/v4"
 	"rodusek.dev/pkg/dcell/internal/errs"
 	"rodusek.dev/pkg/dcell/internal/expr"
 	"rodusek.dev/pkg/dcell/internal/invocation"
+	"rodusek.dev/pkg/dcell/internal/invocation/arity"
+	"rodusek.dev/pkg/dcell/internal/members"
 	"rodusek.dev/pkg/dcell/internal/parser"
+	"rodusek.dev/pkg/dcell/internal/regex"
 )
 
 // Visitor is a visitor that walks the parse tree to generate an expression
 type Visitor struct {
 	FuncTable *invocation.Table
+
+	// Variables is the set of declared variable names. If nil, variables are
+	// not checked at compile time.
+	Variables []string
+
+	// Members is the policy that names the members of values. If it allows
+	// methods to be called, member function calls that match no function of
+	// the table call the methods of their receiver instead.
+	Members *members.Policy
+
+	// StrictNulls is whether navigating through a null value with `.`, `[]`,
+	// or `*` is an error rather than evaluating to null. Navigation with `?.`,
+	// and within the left operand of `??` and `?:`, may still pass through
+	// null.
+	StrictNulls bool
+
+	// Patterns is the policy that limits the regular expression patterns of
+	// `=~`, `!~`, and functions that take patterns. If nil, any valid pattern
+	// is allowed.
+	Patterns *regex.Policy
+
+	// Origins records the parse tree node that each expression was visited
+	// from, which is used to trace errors raised by later passes. It is
+	// populated while visiting, and only records expressions of pointer
+	// types, since other expressions have no identity.
+	Origins map[expr.Expr]antlr.ParseTree
+
+	// params is the stack of parameter names of the lambdas that enclose the
+	// expression currently being visited.
+	params []string
+
+	// nullable is the number of operands of `??` and `?:` that enclose the
+	// expression currently being visited, within which navigation may pass
+	// through null.
+	nullable int
 }
 
 // VisitProgram visits the root of the parse tree
@@ -25,11 +65,36 @@ func (v *Visitor) VisitProgram(ctx parser.IProgramContext) (expr.Expr, error) {
 //------------------------------------------------------------------------------
 
 func (v *Visitor) visitExpression(ctx parser.IExpressionContext) (expr.Expr, error) {
+	e, err := v.dispatchExpression(ctx)
+	if err != nil {
+		return nil, err
+	}
+	v.recordOrigin(e, ctx)
+	return e, nil
+}
+
+// recordOrigin records ctx as the origin of e, unless e was already recorded
+// by a nested expression, such as the inside of parentheses.
+func (v *Visitor) recordOrigin(e expr.Expr, ctx antlr.ParseTree) {
+	if reflect.ValueOf(e).Kind() != reflect.Pointer {
+		return
+	}
+	if v.Origins == nil {
+		v.Origins = make(map[expr.Expr]antlr.ParseTree)
+	}
+	if _, ok := v.Origins[e]; !ok {
+		v.Origins[e] = ctx
+	}
+}
+
+func (v *Visitor) dispatchExpression(ctx parser.IExpressionContext) (expr.Expr, error) {
 	switch ctx := ctx.(type) {
 	case *parser.TermExpressionContext:
 		return v.visitTermExpression(ctx)
 	case *parser.InvocationExpressionContext:
 		return v.visitInvocationExpression(ctx)
+	case *parser.SafeInvocationExpressionContext:
+		return v.visitSafeInvocationExpression(ctx)
 	case *parser.IndexExpressionContext:
 		return v.visitIndexExpression(ctx)
 	case *parser.ParenthesisExpressionContext:
@@ -62,6 +127,8 @@ func (v *Visitor) visitExpression(ctx parser.IExpressionContext) (expr.Expr, err
 		return v.visitInequalityExpression(ctx)
 	case *parser.EqualityExpressionContext:
 		return v.visitEqualityExpression(ctx)
+	case *parser.MatchExpressionContext:
+		return v.visitMatchExpression(ctx)
 	case *parser.TernaryExpressionContext:
 		return v.visitTernaryExpression(ctx)
 	case *parser.ElvisExpressionContext:
@@ -83,6 +150,18 @@ func (v *Visitor) visitTermExpression(ctx *parser.TermExpressionContext) (expr.E
 }
 
 func (v *Visitor) visitInvocationExpression(ctx *parser.InvocationExpressionContext) (expr.Expr, error) {
+	left, err := v.visitExpression(ctx.Expression())
+	if err != nil {
+		return nil, err
+	}
+	right, err := v.visitInvocation(ctx.Invocation(), false)
+	if err != nil {
+		return nil, err
+	}
+	return v.navigate(left, ctx.Expression(), right), nil
+}
+
+func (v *Visitor) visitSafeInvocationExpression(ctx *parser.SafeInvocationExpressionContext) (expr.Expr, error) {
 	left, err := v.visitExpression(ctx.Expression())
 	if err != nil {
 		return nil, err
@@ -100,7 +179,28 @@ func (v *Visitor) visitIndexExpression(ctx *parser.IndexExpressionContext) (expr
 		return nil, err
 	}
 	right, err := v.visitIndex(ctx.Index())
-	return expr.Sequence(left, right), nil
+	if err != nil {
+		return nil, err
+	}
+	return v.navigate(left, ctx.Expression(), right), nil
+}
+
+// navigate returns the sequence that applies right to the result of left,
+// whose parse tree is tree. With strict nulls, the result of left must not be
+// null; if left is itself a sequence, only the result of its last step is
+// checked, so that a sequence that stops at a null from `?.` still evaluates
+// to null.
+func (v *Visitor) navigate(left expr.Expr, tree antlr.Tree, right expr.Expr) expr.Expr {
+	if !v.StrictNulls || v.nullable > 0 {
+		return expr.Sequence(left, right)
+	}
+	path := v.getTreeText(tree)
+	if seq, ok := left.(expr.SequenceExpr); ok {
+		seq = slices.Clone(seq)
+		seq[len(seq)-1] = expr.NonNull(seq[len(seq)-1], path)
+		return append(seq, right)
+	}
+	return expr.Sequence(expr.NonNull(left, path), right)
 }
 
 func (v *Visitor) visitParenthesisExpression(ctx *parser.ParenthesisExpressionContext) (expr.Expr, error) {
@@ -291,6 +391,43 @@ func (v *Visitor) visitEqualityExpression(ctx *parser.EqualityExpressionContext)
 	return nil, ErrInternalf(ctx, "equality expression %q not implemented", op)
 }
 
+func (v *Visitor) visitMatchExpression(ctx *parser.MatchExpressionContext) (expr.Expr, error) {
+	exprs, err := v.visitExpressions(ctx.AllExpression())
+	if err != nil {
+		return nil, err
+	}
+	left := exprs[0]
+	pattern, err := v.pattern(ctx.Expression(1), exprs[1])
+	if err != nil {
+		return nil, err
+	}
+	op := v.getTreeText(ctx.GetChild(1))
+	switch op {
+	case "=~":
+		return expr.Match(left, pattern), nil
+	case "!~":
+		return expr.NotMatch(left, pattern), nil
+	}
+	return nil, ErrInternalf(ctx, "match expression %q not implemented", op)
+}
+
+// pattern returns the expression that compiles e, which was visited from ctx,
+// as a regular expression pattern. Literal patterns are compiled once here, so
+// that invalid patterns are compile errors; other patterns are compiled when
+// they are evaluated.
+func (v *Visitor) pattern(ctx antlr.ParseTree, e expr.Expr) (expr.Expr, error) {
+	if literal, ok := e.(expr.LiteralExpr); ok && reflect.Value(literal).Kind() == reflect.String {
+		re, err := v.Patterns.Compile(reflect.Value(literal).String())
+		if err != nil {
+			return nil, NewSemanticError(ctx, err)
+		}
+		return expr.Literal(re), nil
+	}
+	pattern := expr.Pattern(e, v.Patterns)
+	v.recordOrigin(pattern, ctx)
+	return pattern, nil
+}
+
 func (v *Visitor) visitTernaryExpression(ctx *parser.TernaryExpressionContext) (expr.Expr, error) {
 	exprs, err := v.visitExpressions(ctx.AllExpression())
 	if err != nil {
@@ -301,23 +438,37 @@ func (v *Visitor) visitTernaryExpression(ctx *parser.TernaryExpressionContext) (
 }
 
 func (v *Visitor) visitElvisExpression(ctx *parser.ElvisExpressionContext) (expr.Expr, error) {
-	exprs, err := v.visitExpressions(ctx.AllExpression())
+	condition, err := v.visitNullable(ctx.Expression(0))
+	if err != nil {
+		return nil, err
+	}
+	falseExpr, err := v.visitExpression(ctx.Expression(1))
 	if err != nil {
 		return nil, err
 	}
-	condition, falseExpr := exprs[0], exprs[1]
 	return expr.Elvis(condition, falseExpr), nil
 }
 
 func (v *Visitor) visitCoalesceExpression(ctx *parser.CoalesceExpressionContext) (expr.Expr, error) {
-	exprs, err := v.visitExpressions(ctx.AllExpression())
+	left, err := v.visitNullable(ctx.Expression(0))
+	if err != nil {
+		return nil, err
+	}
+	right, err := v.visitExpression(ctx.Expression(1))
 	if err != nil {
 		return nil, err
 	}
-	left, right := exprs[0], exprs[1]
 	return expr.Coalesce(left, right), nil
 }
 
+// visitNullable visits the left operand of `??` or `?:`, within which
+// navigation may pass through null even with strict nulls.
+func (v *Visitor) visitNullable(ctx parser.IExpressionContext) (expr.Expr, error) {
+	v.nullable++
+	defer func() { v.nullable-- }()
+	return v.visitExpression(ctx)
+}
+
 func (v *Visitor) visitIsExpression(ctx *parser.IsExpressionContext) (expr.Expr, error) {
 	left, err := v.visitExpression(ctx.Expression())
 	if err != nil {
@@ -381,11 +532,21 @@ func (v *Visitor) visitTerm(ctx parser.ITermContext) (expr.Expr, error) {
 		return v.visitLiteralTerm(ctx)
 	case *parser.InvocationTermContext:
 		return v.visitInvocationTerm(ctx)
+	case *parser.VariableTermContext:
+		return v.visitVariableTerm(ctx)
 	}
 	return nil, ErrInternalf(ctx, "unexpected term type: %T", ctx)
 }
 
 func (v *Visitor) visitLiteralTerm(ctx *parser.LiteralTermContext) (expr.Expr, error) {
+	switch literal := ctx.Literal().(type) {
+	case *parser.ListLiteralContext:
+		return v.visitListLiteral(literal)
+	case *parser.ObjectLiteralContext:
+		return v.visitObjectLiteral(literal)
+	case *parser.FormatStringLiteralContext:
+		return v.visitFormatStringLiteral(literal)
+	}
 	literal, err := v.visitLiteral(ctx.Literal())
 	if err != nil {
 		return nil, err
@@ -397,6 +558,15 @@ func (v *Visitor) visitInvocationTerm(ctx *parser.InvocationTermContext) (expr.E
 	return v.visitInvocation(ctx.Invocation(), true)
 }
 
+func (v *Visitor) visitVariableTerm(ctx *parser.VariableTermContext) (expr.Expr, error) {
+	name := ctx.VARIABLE().GetText()[1:] // remove $
+	if v.Variables != nil && !slices.Contains(v.Variables, name) {
+		err := errs.NewVariableError(name, slices.Values(v.Variables))
+		return nil, NewSemanticErrorf(ctx, "%w", err)
+	}
+	return expr.Variable(name), nil
+}
+
 //------------------------------------------------------------------------------
 // Invocations
 //------------------------------------------------------------------------------
@@ -408,7 +578,7 @@ func (v *Visitor) visitInvocation(ctx parser.IInvocationContext, isRoot bool) (e
 	case *parser.WildcardInvocationContext:
 		return v.visitWildcardInvocation(ctx)
 	case *parser.MemberInvocationContext:
-		return v.visitMemberInvocation(ctx), nil
+		return v.visitMemberInvocation(ctx, isRoot), nil
 	}
 	return nil, ErrInternalf(ctx, "unexpected invocation type: %T", ctx)
 }
@@ -421,6 +591,15 @@ func (v *Visitor) visitFunctionInvocation(ctx *parser.FunctionInvocationContext,
 		return nil, err
 	}
 	entry, ok := v.FuncTable.Lookup(funcName)
+	if !ok && !isRoot && v.Members.HasMethods() {
+		return expr.Method(funcName, params...), nil
+	}
+	if !ok && isRoot && isPresenceFunc(funcName) {
+		if err := arity.Exactly(1).Check(len(params)); err != nil {
+			return nil, err
+		}
+		return expr.Has(params[0]), nil
+	}
 	if !ok {
 		err := errs.NewNameError(funcName, v.FuncTable.FunctionNames())
 		return nil, NewSemanticErrorf(ctx, "%w", err)
@@ -433,20 +612,51 @@ func (v *Visitor) visitFunctionInvocation(ctx *parser.FunctionInvocationContext,
 	if err := entry.TestArity(args); err != nil {
 		return nil, err
 	}
+	for i, param := range params {
+		arg := i
+		if !isRoot {
+			arg++
+		}
+		if !entry.PatternArg(arg) {
+			continue
+		}
+		tree := ctx.ParameterList().Parameter(i)
+		if params[i], err = v.pattern(tree, param); err != nil {
+			return nil, err
+		}
+	}
 
 	if isRoot {
-		return expr.FreeFunc(entry.Invoke, params...), nil
+		fn := expr.FreeFunc(entry.InvokeContext, params...)
+		fn.Name = funcName
+		fn.Pure = entry.Pure()
+		return fn, nil
 	}
-	return expr.MemberFunc(entry.Invoke, params...), nil
+	fn := expr.MemberFunc(entry.InvokeContext, params...)
+	fn.Name = funcName
+	fn.Pure = entry.Pure()
+	return fn, nil
+}
+
+// isPresenceFunc reports whether name is a built-in function that reports
+// whether a path is present, which a function of the same name in the
+// function table takes precedence over.
+func isPresenceFunc(name string) bool {
+	return name == "has" || name == "exists"
 }
 
 func (v *Visitor) visitWildcardInvocation(*parser.WildcardInvocationContext) (expr.Expr, error) {
 	return expr.Wildcard(), nil
 }
 
-func (v *Visitor) visitMemberInvocation(ctx *parser.MemberInvocationContext) expr.Expr {
+func (v *Visitor) visitMemberInvocation(ctx *parser.MemberInvocationContext, isRoot bool) expr.Expr {
 	memberName := v.visitIdentifier(ctx.Identifier())
 
+	// A root identifier that names the parameter of an enclosing lambda refers
+	// to that parameter rather than a member of the current value.
+	if isRoot && slices.Contains(v.params, memberName) {
+		return expr.Param(memberName)
+	}
 	return expr.MemberExpr(memberName)
 }
 
@@ -455,8 +665,8 @@ func (v *Visitor) visitParameterList(ctx parser.IParameterListContext) ([]expr.E
 		return nil, nil
 	}
 	var params []expr.Expr
-	for _, param := range ctx.AllExpression() {
-		expr, err := v.visitExpression(param)
+	for _, param := range ctx.AllParameter() {
+		expr, err := v.visitParameter(param)
 		if err != nil {
 			return nil, err
 		}
@@ -465,6 +675,29 @@ func (v *Visitor) visitParameterList(ctx parser.IParameterListContext) ([]expr.E
 	return params, nil
 }
 
+func (v *Visitor) visitParameter(ctx parser.IParameterContext) (expr.Expr, error) {
+	switch ctx := ctx.(type) {
+	case *parser.LambdaParameterContext:
+		return v.visitLambda(ctx.Lambda())
+	case *parser.ExpressionParameterContext:
+		return v.visitExpression(ctx.Expression())
+	}
+	return nil, ErrInternalf(ctx, "unexpected parameter type: %T", ctx)
+}
+
+func (v *Visitor) visitLambda(ctx parser.ILambdaContext) (expr.Expr, error) {
+	param := v.visitIdentifier(ctx.Identifier())
+
+	v.params = append(v.params, param)
+	defer func() { v.params = v.params[:len(v.params)-1] }()
+
+	body, err := v.visitExpression(ctx.Expression())
+	if err != nil {
+		return nil, err
+	}
+	return expr.Lambda(param, body), nil
+}
+
 func (v *Visitor) visitIdentifier(ctx parser.IIdentifierContext) string {
 	return ctx.GetText()
 }
@@ -528,15 +761,17 @@ func (v *Visitor) visitLiteral(ctx parser.ILiteralContext) (any, error) {
 		return v.visitBooleanLiteral(ctx), nil
 	case *parser.NullLiteralContext:
 		return v.visitNullLiteral(ctx), nil
-	case *parser.ListLiteralContext:
-		return v.visitListLiteral(ctx)
 	}
 	return nil, ErrInternalf(ctx, "unexpected literal type: %T", ctx)
 }
 
 func (v *Visitor) visitStringLiteral(ctx *parser.StringLiteralContext) (string, error) {
+	return v.visitString(ctx.String_())
+}
+
+func (v *Visitor) visitString(ctx parser.IStringContext) (string, error) {
 	var str string
-	switch ctx := ctx.String_().(type) {
+	switch ctx := ctx.(type) {
 	case *parser.SingleQuoteStringContext:
 		raw := ctx.SINGLE_QUOTE_STRING().GetText()
 		str = "\"" + raw[1:len(raw)-1] + "\""
@@ -592,18 +827,81 @@ func (v *Visitor) visitNullLiteral(ctx *parser.NullLiteralContext) any {
 	return nil
 }
 
-func (v *Visitor) visitListLiteral(ctx *parser.ListLiteralContext) ([]any, error) {
-	var result []any
-	for _, item := range ctx.List().AllLiteral() {
-		literal, err := v.visitLiteral(item)
+func (v *Visitor) visitListLiteral(ctx *parser.ListLiteralContext) (expr.Expr, error) {
+	var elems []expr.Expr
+	for _, item := range ctx.List().AllExpression() {
+		elem, err := v.visitExpression(item)
 		if err != nil {
 			return nil, err
 		}
-		result = append(result, literal)
+		elems = append(elems, elem)
+	}
+	return expr.List(elems...), nil
+}
+
+func (v *Visitor) visitObjectLiteral(ctx *parser.ObjectLiteralContext) (expr.Expr, error) {
+	var keys []string
+	var values []expr.Expr
+	for _, property := range ctx.Object().AllProperty() {
+		key, err := v.visitPropertyKey(property)
+		if err != nil {
+			return nil, err
+		}
+		if slices.Contains(keys, key) {
+			return nil, NewSemanticErrorf(property, "%w: '%s'", errs.ErrDuplicateKey, key)
+		}
+		value, err := v.visitExpression(property.Expression())
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	return expr.Object(keys, values), nil
+}
+
+// visitFormatStringLiteral visits a format string literal, which is the
+// concatenation of its text and of its interpolated expressions formatted as
+// strings.
+func (v *Visitor) visitFormatStringLiteral(ctx *parser.FormatStringLiteralContext) (expr.Expr, error) {
+	parts, err := formatParts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var result expr.Expr
+	for _, part := range parts {
+		var e expr.Expr = expr.Literal(part.Text)
+		if part.Expr != nil {
+			value, err := v.visitExpression(part.Expr)
+			if err != nil {
+				return nil, err
+			}
+			if e, err = expr.Format(value, part.Spec); err != nil {
+				return nil, NewSemanticErrorf(ctx, "%w", err)
+			}
+			v.recordOrigin(e, part.Expr)
+		}
+		if result == nil {
+			result = e
+		} else {
+			result = expr.Add(result, e)
+		}
+	}
+	if result == nil {
+		return expr.Literal(""), nil
 	}
 	return result, nil
 }
 
+// visitPropertyKey returns the key of a property of an object literal, which
+// is either an identifier or a string.
+func (v *Visitor) visitPropertyKey(ctx parser.IPropertyContext) (string, error) {
+	if ctx.Identifier() != nil {
+		return v.visitIdentifier(ctx.Identifier()), nil
+	}
+	return v.visitString(ctx.String_())
+}
+
 //------------------------------------------------------------------------------
 // Type
 //------------------------------------------------------------------------------
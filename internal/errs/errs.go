@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"iter"
 	"slices"
+	"strconv"
 	"strings"
 
 	"rodusek.dev/pkg/dcell/internal/levenshtein"
@@ -28,8 +29,75 @@ var (
 	// ErrUnknownName is an error returned when a name is not found in the
 	// current context.
 	ErrUnknownName = errors.New("unknown name")
+
+	// ErrBudgetExceeded is returned when an evaluation exceeds one of the
+	// limits of its budget.
+	ErrBudgetExceeded = errors.New("budget exceeded")
+
+	// ErrDivisionByZero is returned when the divisor of a division or modulo
+	// operation is zero.
+	ErrDivisionByZero = errors.New("division by zero")
+
+	// ErrConvert is returned when a value cannot be converted into a Go type.
+	ErrConvert = errors.New("cannot convert")
+
+	// ErrKeyNotFound is returned when an index expression looks up a key that
+	// does not exist in a map.
+	ErrKeyNotFound = errors.New("key not found")
+
+	// ErrNullNavigation is returned when an expression that is compiled with
+	// strict nulls navigates through a null value.
+	ErrNullNavigation = errors.New("null navigation")
+
+	// ErrOutOfBounds is returned when an index expression looks up an index
+	// that is out of the bounds of a list or string.
+	ErrOutOfBounds = errors.New("out of bounds")
+
+	// ErrDuplicateKey is returned when an object literal has more than one
+	// value for the same key.
+	ErrDuplicateKey = errors.New("duplicate key")
+
+	// ErrAmbiguousName is returned when a name refers to more than one field
+	// promoted from embedded structs at the same depth.
+	ErrAmbiguousName = errors.New("ambiguous name")
+
+	// ErrInvalidPattern is returned when a regular expression pattern fails to
+	// compile, or is longer than the policy it is compiled with allows.
+	ErrInvalidPattern = errors.New("invalid pattern")
+)
+
+// Limit names the evaluation limit that was exceeded in a [BudgetError].
+type Limit string
+
+const (
+	// LimitEvaluations is the limit on the number of node evaluations.
+	LimitEvaluations Limit = "evaluations"
+
+	// LimitStringLength is the limit on the length of produced strings.
+	LimitStringLength Limit = "string length"
+
+	// LimitSliceLength is the limit on the length of produced slices.
+	LimitSliceLength Limit = "slice length"
+
+	// LimitDepth is the limit on the recursion depth of the evaluation.
+	LimitDepth Limit = "depth"
 )
 
+// BudgetError is an error that indicates which limit of an evaluation budget
+// was exceeded.
+type BudgetError struct {
+	Limit Limit
+	Max   int
+}
+
+func (e *BudgetError) Error() string {
+	return fmt.Sprintf("%v: %s limit of %d exceeded", ErrBudgetExceeded, e.Limit, e.Max)
+}
+
+func (e *BudgetError) Unwrap() error {
+	return ErrBudgetExceeded
+}
+
 // NameError is an error that indicates that a name does not exist in the
 // current context. It provides suggestions for likely candidate names based on
 // what is closest via Levenshtein distance.
@@ -65,3 +133,119 @@ func NewNameError(input string, options iter.Seq[string]) *NameError {
 		Suggestions: suggestions,
 	}
 }
+
+// NewVariableError creates a new [NameError] for an unbound variable with the
+// given name and candidate variable names. Names are given without the leading
+// '$', which is added to both the input and the suggestions.
+func NewVariableError(name string, names iter.Seq[string]) *NameError {
+	err := NewNameError(name, names)
+	err.Input = "$" + err.Input
+	for i, suggestion := range err.Suggestions {
+		err.Suggestions[i] = "$" + suggestion
+	}
+	return err
+}
+
+// KeyError is an error that indicates that a key does not exist in a map that
+// is indexed by it. For keys that are strings, it provides suggestions for
+// likely candidate keys, like [NameError].
+type KeyError struct {
+	// Key is the key that was looked up, formatted as it is written in
+	// expressions.
+	Key string
+
+	Suggestions []string
+}
+
+func (e *KeyError) Error() string {
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "%v: %s", ErrKeyNotFound, e.Key)
+	if len(e.Suggestions) == 1 {
+		_, _ = fmt.Fprintf(&sb, ", did you mean %q?", e.Suggestions[0])
+	}
+	if len(e.Suggestions) > 1 {
+		quoted := make([]string, len(e.Suggestions))
+		for i, suggestion := range e.Suggestions {
+			quoted[i] = strconv.Quote(suggestion)
+		}
+		_, _ = fmt.Fprintf(&sb, ", did you mean one of %s?", strings.Join(quoted, ", "))
+	}
+	return sb.String()
+}
+
+func (e *KeyError) Unwrap() error {
+	return ErrKeyNotFound
+}
+
+// NewKeyError creates a new [KeyError] for the given key. If the key is a
+// string, suggestions are made from the given string keys.
+func NewKeyError(key any, keys iter.Seq[string]) *KeyError {
+	s, ok := key.(string)
+	if !ok {
+		return &KeyError{Key: fmt.Sprint(key)}
+	}
+	if keys == nil {
+		return &KeyError{Key: strconv.Quote(s)}
+	}
+	err := NewNameError(s, keys)
+	return &KeyError{Key: strconv.Quote(s), Suggestions: err.Suggestions}
+}
+
+// AmbiguousNameError is an error that indicates that a name refers to more
+// than one field promoted from embedded structs at the same depth, which Go
+// itself rejects as an ambiguous selector.
+type AmbiguousNameError struct {
+	Input string
+
+	// Candidates are the paths of the fields that the name refers to, through
+	// the embedded structs that promote them, such as `User.id`.
+	Candidates []string
+}
+
+func (e *AmbiguousNameError) Error() string {
+	candidates := strings.Join(e.Candidates, "', '")
+	return fmt.Sprintf("%v: '%s' may refer to any of '%s'", ErrAmbiguousName, e.Input, candidates)
+}
+
+func (e *AmbiguousNameError) Unwrap() error {
+	return ErrAmbiguousName
+}
+
+// NullError is an error that indicates that an expression navigated through a
+// null value with `.`, `[]`, or `*`, in an expression that is compiled with
+// strict nulls.
+type NullError struct {
+	// Path is the source text of the expression that was null, such as
+	// `event.pull_request`.
+	Path string
+}
+
+func (e *NullError) Error() string {
+	return fmt.Sprintf("%v: '%s' is null", ErrNullNavigation, e.Path)
+}
+
+func (e *NullError) Unwrap() error {
+	return ErrNullNavigation
+}
+
+// ConvertError is an error that indicates that an element of a value could not
+// be converted into a Go type.
+type ConvertError struct {
+	// Path is the path of the element that failed to convert, such as
+	// `users[1].name`. It is empty if the value itself failed to convert.
+	Path string
+
+	// Err is the reason that the element failed to convert.
+	Err error
+}
+
+func (e *ConvertError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("%v: %v", ErrConvert, e.Err)
+	}
+	return fmt.Sprintf("%v %s: %v", ErrConvert, e.Path, e.Err)
+}
+
+func (e *ConvertError) Unwrap() []error {
+	return []error{ErrConvert, e.Err}
+}
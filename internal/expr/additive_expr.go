@@ -20,11 +20,21 @@ func Add(left, right Expr) *AddExpr {
 }
 
 func (e *AddExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	lhs, rhs, err := evalTwo(ctx, e.Left, e.Right)
 	if err != nil {
 		return reflect.Value{}, err
 	}
+	return e.Apply(ctx, lhs, rhs)
+}
 
+// Apply applies the operation to the evaluated operands, which must already
+// be dereferenced.
+func (e *AddExpr) Apply(ctx *Context, lhs, rhs reflect.Value) (reflect.Value, error) {
 	if reflectconv.IsInt(lhs.Type()) && reflectconv.IsInt(rhs.Type()) {
 		ints, err := reflectconv.Int64s(lhs, rhs)
 		if err != nil {
@@ -40,6 +50,9 @@ func (e *AddExpr) Eval(ctx *Context) (reflect.Value, error) {
 		lhsFloat, rhsFloat := floats[0], floats[1]
 		return reflect.ValueOf(lhsFloat + rhsFloat), nil
 	} else if reflectconv.IsString(lhs.Type()) && reflectconv.IsString(rhs.Type()) {
+		if err := ctx.CheckStringLength(lhs.Len() + rhs.Len()); err != nil {
+			return reflect.Value{}, err
+		}
 		result := lhs.String() + rhs.String()
 		return reflect.ValueOf(result), nil
 	}
@@ -60,10 +73,21 @@ func Subtract(left, right Expr) *SubtractExpr {
 }
 
 func (e *SubtractExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	lhs, rhs, err := evalTwo(ctx, e.Left, e.Right)
 	if err != nil {
 		return reflect.Value{}, err
 	}
+	return e.Apply(ctx, lhs, rhs)
+}
+
+// Apply applies the operation to the evaluated operands, which must already
+// be dereferenced.
+func (e *SubtractExpr) Apply(ctx *Context, lhs, rhs reflect.Value) (reflect.Value, error) {
 	if reflectconv.IsInt(lhs.Type()) && reflectconv.IsInt(rhs.Type()) {
 		ints, err := reflectconv.Int64s(lhs, rhs)
 		if err != nil {
@@ -65,11 +65,10 @@ func TestAddExpr(t *testing.T) {
 	}}
 
 	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
 			sut := expr.Add(tc.left, tc.right)
 
-			got, err := sut.Eval(nil)
+			got, err := backend.Eval(sut, nil)
 
 			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) && (tc.wantErr != cmpopts.AnyError || got == nil) {
 				t.Errorf("Eval() error = %v, want %v", got, want)
@@ -130,11 +129,10 @@ func TestSubtractExpr(t *testing.T) {
 	}}
 
 	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
 			sut := expr.Subtract(tc.left, tc.right)
 
-			got, err := sut.Eval(nil)
+			got, err := backend.Eval(sut, nil)
 
 			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) && (tc.wantErr != cmpopts.AnyError || got == nil) {
 				t.Errorf("Eval() error = %v, want %v", got, want)
@@ -8,9 +8,11 @@ import (
 	"strings"
 
 	"rodusek.dev/pkg/dcell/internal/intconv"
+	"rodusek.dev/pkg/dcell/internal/reflectconv"
 )
 
-// AsExpr is an expression that converts a value to a different type.
+// AsExpr is an expression that converts a value to a different type. Values
+// held by pointers or interfaces are converted as the values that they hold.
 type AsExpr struct {
 	Expr Expr
 	Type Type
@@ -26,10 +28,21 @@ func As(expr Expr, ty Type) *AsExpr {
 
 // Eval evaluates the AsExpr and converts the value to the specified type.
 func (e *AsExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	rv, err := e.Expr.Eval(ctx)
 	if err != nil {
 		return reflect.Value{}, err
 	}
+	return e.Apply(ctx, rv)
+}
+
+// Apply applies the operation to the evaluated operand.
+func (e *AsExpr) Apply(ctx *Context, rv reflect.Value) (reflect.Value, error) {
+	rv = reflectconv.Deref(rv)
 	switch e.Type {
 	case TypeInt:
 		return e.asInt(rv)
@@ -144,22 +157,31 @@ func (e *AsExpr) asFloat(rv reflect.Value) (reflect.Value, error) {
 }
 
 func (e *AsExpr) asString(rv reflect.Value) (reflect.Value, error) {
+	if rv.Kind() == reflect.String {
+		return rv, nil
+	}
+	if str, ok := stringOf(rv); ok {
+		return reflect.ValueOf(str), nil
+	}
+	return reflect.Value{}, fmt.Errorf("cannot convert %s to string", rv.Type().Name())
+}
+
+// stringOf returns the string form of a bool, number, or string value, as
+// converted to string with [AsExpr]. It returns false for other values.
+func stringOf(rv reflect.Value) (string, bool) {
 	switch rv.Kind() {
 	case reflect.Bool:
-		if rv.Bool() {
-			return reflect.ValueOf("true"), nil
-		}
-		return reflect.ValueOf("false"), nil
+		return strconv.FormatBool(rv.Bool()), true
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return reflect.ValueOf(strconv.FormatInt(rv.Int(), 10)), nil
+		return strconv.FormatInt(rv.Int(), 10), true
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return reflect.ValueOf(strconv.FormatUint(rv.Uint(), 10)), nil
+		return strconv.FormatUint(rv.Uint(), 10), true
 	case reflect.Float32, reflect.Float64:
-		return reflect.ValueOf(strconv.FormatFloat(rv.Float(), 'f', -1, 64)), nil
+		return strconv.FormatFloat(rv.Float(), 'f', -1, 64), true
 	case reflect.String:
-		return rv, nil
+		return rv.String(), true
 	}
-	return reflect.Value{}, fmt.Errorf("cannot convert %s to string", rv.Type().Name())
+	return "", false
 }
 
 func (e *AsExpr) asBool(rv reflect.Value) (reflect.Value, error) {
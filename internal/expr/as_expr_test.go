@@ -233,6 +233,21 @@ func TestAsExpr(t *testing.T) {
 			expr: exprtest.String("hello"),
 			as:   expr.TypeString,
 			want: reflect.ValueOf("hello"),
+		}, {
+			name: "Unsigned integer in interface as String",
+			expr: exprtest.Func(func(*expr.Context) (reflect.Value, error) {
+				return reflect.ValueOf([]any{uint64(42)}).Index(0), nil
+			}),
+			as:   expr.TypeString,
+			want: reflect.ValueOf("42"),
+		}, {
+			name: "Pointer to float as String",
+			expr: exprtest.Func(func(*expr.Context) (reflect.Value, error) {
+				f := float32(0.5)
+				return reflect.ValueOf(&f), nil
+			}),
+			as:   expr.TypeString,
+			want: reflect.ValueOf("0.5"),
 		}, {
 			name: "Struct as Integer",
 			expr: exprtest.Func(func(*expr.Context) (reflect.Value, error) {
@@ -270,11 +285,10 @@ func TestAsExpr(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
 			sut := expr.As(tc.expr, tc.as)
 
-			result, err := sut.Eval(nil)
+			result, err := backend.Eval(sut, nil)
 
 			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
 				t.Fatalf("AsExpr.Eval(...) error = %v, want %v", got, want)
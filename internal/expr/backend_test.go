@@ -0,0 +1,55 @@
+package expr_test
+
+import (
+	"reflect"
+	"testing"
+
+	"rodusek.dev/pkg/dcell/internal/expr"
+	"rodusek.dev/pkg/dcell/internal/vm"
+)
+
+// backend is a way of evaluating an expression tree. The tests of the
+// expression nodes run against every backend, so that the VM is held to the
+// semantics of the tree.
+type backend struct {
+	name string
+	eval func(e expr.Expr, ctx *expr.Context) (reflect.Value, error)
+}
+
+// Eval evaluates e with the backend.
+func (b backend) Eval(e expr.Expr, ctx *expr.Context) (reflect.Value, error) {
+	return b.eval(e, ctx)
+}
+
+var backends = []backend{
+	{
+		name: "tree",
+		eval: func(e expr.Expr, ctx *expr.Context) (reflect.Value, error) {
+			return e.Eval(ctx)
+		},
+	}, {
+		name: "vm",
+		eval: func(e expr.Expr, ctx *expr.Context) (reflect.Value, error) {
+			program, err := vm.Compile(e)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return program.Eval(ctx)
+		},
+	},
+}
+
+// runBackends runs f as a parallel subtest with the given name for each
+// backend.
+func runBackends(t *testing.T, name string, f func(t *testing.T, backend backend)) {
+	t.Helper()
+	t.Run(name, func(t *testing.T) {
+		t.Parallel()
+		for _, backend := range backends {
+			t.Run(backend.name, func(t *testing.T) {
+				t.Parallel()
+				f(t, backend)
+			})
+		}
+	})
+}
@@ -23,11 +23,21 @@ func BitwiseAnd(left, right Expr) *BitwiseAndExpr {
 
 // Eval evaluates the BitwiseAndExpr.
 func (e *BitwiseAndExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	lhs, rhs, err := evalTwo(ctx, e.Left, e.Right)
 	if err != nil {
 		return reflect.Value{}, err
 	}
+	return e.Apply(ctx, lhs, rhs)
+}
 
+// Apply applies the operation to the evaluated operands, which must already
+// be dereferenced.
+func (e *BitwiseAndExpr) Apply(ctx *Context, lhs, rhs reflect.Value) (reflect.Value, error) {
 	is, err := reflectconv.Uint64s(lhs, rhs)
 	if err != nil {
 		return reflect.Value{}, err
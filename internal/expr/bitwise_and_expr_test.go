@@ -81,11 +81,10 @@ func TestBitwiseAndEval(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
 			sut := expr.BitwiseAnd(tc.left, tc.right)
 
-			got, err := sut.Eval(nil)
+			got, err := backend.Eval(sut, nil)
 
 			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
 				t.Errorf("Eval() error = %v, want %v", got, want)
@@ -22,10 +22,20 @@ func BitwiseNot(expr Expr) BitwiseNotExpr {
 
 // Eval evaluates the BitwiseNotExpr.
 func (e BitwiseNotExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	val, err := e.Expr.Eval(ctx)
 	if err != nil {
 		return reflect.Value{}, err
 	}
+	return e.Apply(ctx, val)
+}
+
+// Apply applies the operation to the evaluated operand.
+func (e BitwiseNotExpr) Apply(ctx *Context, val reflect.Value) (reflect.Value, error) {
 	i, err := reflectconv.Uint64(val)
 	if err != nil {
 		return reflect.Value{}, err
@@ -18,10 +18,21 @@ func BitwiseOr(left, right Expr) *BitwiseOrExpr {
 }
 
 func (e *BitwiseOrExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	lhs, rhs, err := evalTwo(ctx, e.Left, e.Right)
 	if err != nil {
 		return reflect.Value{}, err
 	}
+	return e.Apply(ctx, lhs, rhs)
+}
+
+// Apply applies the operation to the evaluated operands, which must already
+// be dereferenced.
+func (e *BitwiseOrExpr) Apply(ctx *Context, lhs, rhs reflect.Value) (reflect.Value, error) {
 	is, err := reflectconv.Uint64s(lhs, rhs)
 	if err != nil {
 		return reflect.Value{}, err
@@ -44,10 +55,21 @@ func BitwiseXor(left, right Expr) *BitwiseXorExpr {
 }
 
 func (e *BitwiseXorExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	lhs, rhs, err := evalTwo(ctx, e.Left, e.Right)
 	if err != nil {
 		return reflect.Value{}, err
 	}
+	return e.Apply(ctx, lhs, rhs)
+}
+
+// Apply applies the operation to the evaluated operands, which must already
+// be dereferenced.
+func (e *BitwiseXorExpr) Apply(ctx *Context, lhs, rhs reflect.Value) (reflect.Value, error) {
 	is, err := reflectconv.Uint64s(lhs, rhs)
 	if err != nil {
 		return reflect.Value{}, err
@@ -61,11 +61,10 @@ func TestBitwiseOrExpr_Eval(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
 			sut := expr.BitwiseOr(tc.left, tc.right)
 
-			got, err := sut.Eval(nil)
+			got, err := backend.Eval(sut, nil)
 
 			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
 				t.Errorf("Eval() error = %v, want %v", got, want)
@@ -131,11 +130,10 @@ func TestBitwiseXorExpr_Eval(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
 			sut := expr.BitwiseXor(tc.left, tc.right)
 
-			got, err := sut.Eval(nil)
+			got, err := backend.Eval(sut, nil)
 
 			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
 				t.Errorf("Eval() error = %v, want %v", got, want)
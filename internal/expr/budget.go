@@ -0,0 +1,157 @@
+package expr
+
+import (
+	"reflect"
+
+	"rodusek.dev/pkg/dcell/internal/errs"
+	"rodusek.dev/pkg/dcell/internal/invocation"
+)
+
+// Budget limits the amount of work that a single evaluation may perform.
+// A zero value for any limit means that the limit is not enforced.
+type Budget struct {
+	// MaxEvaluations is the maximum number of expression nodes that may be
+	// evaluated.
+	MaxEvaluations int
+
+	// MaxStringLength is the maximum length, in bytes, of any string produced
+	// during evaluation.
+	MaxStringLength int
+
+	// MaxSliceLength is the maximum length of any slice produced during
+	// evaluation.
+	MaxSliceLength int
+
+	// MaxDepth is the maximum depth of nested expression evaluations.
+	MaxDepth int
+}
+
+// budgetState tracks the consumption of a [Budget] across a single evaluation.
+// It is shared by every Context derived from the same evaluation.
+type budgetState struct {
+	Budget
+	evaluations int
+	depth       int
+}
+
+// WithBudget returns a new Context that enforces the given budget on the
+// evaluation. Consumption is tracked from zero. The string length limit is
+// also carried by [Context.GoContext], so that functions can check it before
+// building their results.
+func (c *Context) WithBudget(b Budget) *Context {
+	result := c.clone()
+	result.budget = &budgetState{Budget: b}
+	if b.MaxStringLength > 0 {
+		result.ctx = invocation.WithMaxStringLength(result.GoContext(), b.MaxStringLength)
+	}
+	return result
+}
+
+// Enter charges a single node evaluation against the budget, and increases the
+// evaluation depth. Every successful call must be paired with a call to
+// [Context.Leave] once the node has been evaluated.
+//
+// If either limit is exceeded, an [errs.BudgetError] is returned and the depth
+// is left unchanged.
+func (c *Context) Enter() error {
+	if c == nil || c.budget == nil {
+		return nil
+	}
+	b := c.budget
+	if b.MaxEvaluations > 0 && b.evaluations >= b.MaxEvaluations {
+		return &errs.BudgetError{Limit: errs.LimitEvaluations, Max: b.MaxEvaluations}
+	}
+	if b.MaxDepth > 0 && b.depth >= b.MaxDepth {
+		return &errs.BudgetError{Limit: errs.LimitDepth, Max: b.MaxDepth}
+	}
+	b.evaluations++
+	b.depth++
+	return nil
+}
+
+// Leave decreases the evaluation depth after a node has been evaluated.
+func (c *Context) Leave() {
+	if c == nil || c.budget == nil {
+		return
+	}
+	c.budget.depth--
+}
+
+// CheckStringLength returns an [errs.BudgetError] if a string of length n
+// would exceed the budget.
+func (c *Context) CheckStringLength(n int) error {
+	if c == nil || c.budget == nil {
+		return nil
+	}
+	if limit := c.budget.MaxStringLength; limit > 0 && n > limit {
+		return &errs.BudgetError{Limit: errs.LimitStringLength, Max: limit}
+	}
+	return nil
+}
+
+// CheckSliceLength returns an [errs.BudgetError] if a slice of length n would
+// exceed the budget.
+func (c *Context) CheckSliceLength(n int) error {
+	if c == nil || c.budget == nil {
+		return nil
+	}
+	if limit := c.budget.MaxSliceLength; limit > 0 && n > limit {
+		return &errs.BudgetError{Limit: errs.LimitSliceLength, Max: limit}
+	}
+	return nil
+}
+
+// CheckSize returns an [errs.BudgetError] if the given value is a string or
+// slice that exceeds the budget.
+func (c *Context) CheckSize(rv reflect.Value) error {
+	if c == nil || c.budget == nil || !rv.IsValid() {
+		return nil
+	}
+	switch rv.Kind() {
+	case reflect.String:
+		return c.CheckStringLength(rv.Len())
+	case reflect.Slice, reflect.Array:
+		return c.CheckSliceLength(rv.Len())
+	}
+	return nil
+}
+
+// EnterAt charges a single node evaluation against the budget, as if the node
+// were nested depth levels below the current evaluation depth. Unlike
+// [Context.Enter], it does not change the depth, and needs no matching call to
+// [Context.Leave].
+//
+// This lets an evaluator that knows the static depth of each node charge it
+// without tracking the depth at run time.
+func (c *Context) EnterAt(depth int) error {
+	if c == nil || c.budget == nil {
+		return nil
+	}
+	b := c.budget
+	if b.MaxEvaluations > 0 && b.evaluations >= b.MaxEvaluations {
+		return &errs.BudgetError{Limit: errs.LimitEvaluations, Max: b.MaxEvaluations}
+	}
+	if b.MaxDepth > 0 && b.depth+depth >= b.MaxDepth {
+		return &errs.BudgetError{Limit: errs.LimitDepth, Max: b.MaxDepth}
+	}
+	b.evaluations++
+	return nil
+}
+
+// Descend increases the evaluation depth by n without charging any
+// evaluation. Every call must be paired with a call to [Context.Ascend].
+func (c *Context) Descend(n int) {
+	if c == nil || c.budget == nil {
+		return
+	}
+	c.budget.depth += n
+}
+
+// Ascend decreases the evaluation depth by n after a call to
+// [Context.Descend].
+func (c *Context) Ascend(n int) {
+	if c == nil || c.budget == nil {
+		return
+	}
+	c.budget.depth -= n
+}
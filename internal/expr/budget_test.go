@@ -0,0 +1,304 @@
+package expr_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"rodusek.dev/pkg/dcell/internal/errs"
+	"rodusek.dev/pkg/dcell/internal/expr"
+	"rodusek.dev/pkg/dcell/internal/expr/exprtest"
+	"rodusek.dev/pkg/dcell/internal/invocation"
+)
+
+func TestContext_Enter(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		budget    expr.Budget
+		enters    int
+		leaves    bool
+		wantLimit errs.Limit
+	}{
+		{
+			name:   "No limits",
+			budget: expr.Budget{},
+			enters: 100,
+		}, {
+			name:   "Evaluations within limit",
+			budget: expr.Budget{MaxEvaluations: 3},
+			enters: 3,
+			leaves: true,
+		}, {
+			name:      "Evaluations exceed limit",
+			budget:    expr.Budget{MaxEvaluations: 3},
+			enters:    4,
+			leaves:    true,
+			wantLimit: errs.LimitEvaluations,
+		}, {
+			name:   "Depth within limit",
+			budget: expr.Budget{MaxDepth: 3},
+			enters: 3,
+		}, {
+			name:      "Depth exceeds limit",
+			budget:    expr.Budget{MaxDepth: 3},
+			enters:    4,
+			wantLimit: errs.LimitDepth,
+		}, {
+			name:   "Depth is released on leave",
+			budget: expr.Budget{MaxDepth: 1},
+			enters: 4,
+			leaves: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			sut := expr.NewContext(reflect.Value{}).WithBudget(tc.budget)
+
+			var err error
+			for range tc.enters {
+				if err = sut.Enter(); err != nil {
+					break
+				}
+				if tc.leaves {
+					sut.Leave()
+				}
+			}
+
+			if tc.wantLimit == "" {
+				if err != nil {
+					t.Fatalf("Context.Enter() error = %v, want nil", err)
+				}
+				return
+			}
+			var budgetErr *errs.BudgetError
+			if !errors.As(err, &budgetErr) {
+				t.Fatalf("Context.Enter() error = %v, want BudgetError", err)
+			}
+			if got, want := budgetErr.Limit, tc.wantLimit; got != want {
+				t.Errorf("Context.Enter() limit = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestContext_CheckSize(t *testing.T) {
+	t.Parallel()
+	budget := expr.Budget{MaxStringLength: 4, MaxSliceLength: 2}
+
+	testCases := []struct {
+		name    string
+		input   any
+		wantErr error
+	}{
+		{
+			name:  "Value is nil",
+			input: nil,
+		}, {
+			name:  "Value is not sized",
+			input: 12345,
+		}, {
+			name:  "String within limit",
+			input: "abcd",
+		}, {
+			name:    "String exceeds limit",
+			input:   "abcde",
+			wantErr: errs.ErrBudgetExceeded,
+		}, {
+			name:  "Slice within limit",
+			input: []int{1, 2},
+		}, {
+			name:    "Slice exceeds limit",
+			input:   []int{1, 2, 3},
+			wantErr: errs.ErrBudgetExceeded,
+		}, {
+			name:    "Array exceeds limit",
+			input:   [3]int{1, 2, 3},
+			wantErr: errs.ErrBudgetExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			sut := expr.NewContext(reflect.Value{}).WithBudget(budget)
+
+			err := sut.CheckSize(reflect.ValueOf(tc.input))
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("Context.CheckSize() error = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestBudget_Eval(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		expr    expr.Expr
+		input   any
+		budget  expr.Budget
+		wantErr error
+	}{
+		{
+			name:   "Evaluations within budget",
+			expr:   expr.Add(exprtest.Integer(1), exprtest.Integer(2)),
+			budget: expr.Budget{MaxEvaluations: 1},
+		}, {
+			name:    "Evaluations exceed budget",
+			expr:    expr.Add(expr.Literal(1), expr.Literal(2)),
+			budget:  expr.Budget{MaxEvaluations: 2},
+			wantErr: errs.ErrBudgetExceeded,
+		}, {
+			name:    "Depth exceeds budget",
+			expr:    expr.LogicalNot(expr.LogicalNot(expr.LogicalNot(expr.Literal(true)))),
+			budget:  expr.Budget{MaxDepth: 3},
+			wantErr: errs.ErrBudgetExceeded,
+		}, {
+			name:    "Concatenation exceeds budget",
+			expr:    expr.Add(exprtest.String(strings.Repeat("a", 8)), exprtest.String(strings.Repeat("b", 8))),
+			budget:  expr.Budget{MaxStringLength: 15},
+			wantErr: errs.ErrBudgetExceeded,
+		}, {
+			name:    "Wildcard exceeds budget",
+			expr:    expr.Wildcard(),
+			input:   map[string]int{"a": 1, "b": 2, "c": 3},
+			budget:  expr.Budget{MaxSliceLength: 2},
+			wantErr: errs.ErrBudgetExceeded,
+		}, {
+			name: "Function result exceeds budget",
+			expr: expr.FreeFunc(func(context.Context, ...reflect.Value) (reflect.Value, error) {
+				return reflect.ValueOf("abc"), nil
+			}),
+			budget:  expr.Budget{MaxStringLength: 2},
+			wantErr: errs.ErrBudgetExceeded,
+		}, {
+			name:    "Projection exceeds budget",
+			expr:    expr.Member("a"),
+			input:   []map[string]int{{"a": 1}, {"a": 2}, {"a": 3}},
+			budget:  expr.Budget{MaxSliceLength: 2},
+			wantErr: errs.ErrBudgetExceeded,
+		}, {
+			name:   "Sequence within budget",
+			expr:   expr.Sequence(expr.Member("a"), expr.Member("b")),
+			input:  map[string]any{"a": map[string]any{"b": 1}},
+			budget: expr.Budget{MaxEvaluations: 3, MaxDepth: 2},
+		}, {
+			name:    "Sequence exceeds depth",
+			expr:    expr.Sequence(expr.Member("a"), expr.Index(expr.Literal(0))),
+			input:   map[string]any{"a": []int{1}},
+			budget:  expr.Budget{MaxDepth: 2},
+			wantErr: errs.ErrBudgetExceeded,
+		}, {
+			name:   "Lambda body within budget",
+			expr:   expr.FreeFunc(callWith(1), expr.Lambda("x", expr.Param("x"))),
+			budget: expr.Budget{MaxEvaluations: 3, MaxDepth: 2},
+		}, {
+			name:    "Lambda body exceeds depth",
+			expr:    expr.FreeFunc(callWith(1), expr.Lambda("x", expr.LogicalNot(expr.Param("x")))),
+			budget:  expr.Budget{MaxDepth: 2},
+			wantErr: errs.ErrBudgetExceeded,
+		}, {
+			name:    "Lambda body exceeds evaluations",
+			expr:    expr.FreeFunc(callWith(1), expr.Lambda("x", expr.Param("x"))),
+			budget:  expr.Budget{MaxEvaluations: 2},
+			wantErr: errs.ErrBudgetExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
+			ctx := expr.NewContext(reflect.ValueOf(tc.input)).WithBudget(tc.budget)
+
+			_, err := backend.Eval(tc.expr, ctx)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("Eval() error = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+// callWith returns a function that calls its callable argument with arg.
+func callWith(arg any) func(context.Context, ...reflect.Value) (reflect.Value, error) {
+	return func(_ context.Context, args ...reflect.Value) (reflect.Value, error) {
+		return args[0].Interface().(invocation.Callable)(reflect.ValueOf(arg))
+	}
+}
+
+func TestContext_EnterAt(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		budget    expr.Budget
+		descend   int
+		depth     int
+		enters    int
+		wantLimit errs.Limit
+	}{
+		{
+			name:   "Depth within limit",
+			budget: expr.Budget{MaxDepth: 3},
+			depth:  2,
+			enters: 5,
+		}, {
+			name:      "Depth exceeds limit",
+			budget:    expr.Budget{MaxDepth: 3},
+			depth:     3,
+			enters:    1,
+			wantLimit: errs.LimitDepth,
+		}, {
+			name:      "Depth is relative to descent",
+			budget:    expr.Budget{MaxDepth: 3},
+			descend:   2,
+			depth:     1,
+			enters:    1,
+			wantLimit: errs.LimitDepth,
+		}, {
+			name:      "Evaluations exceed limit",
+			budget:    expr.Budget{MaxEvaluations: 3},
+			enters:    4,
+			wantLimit: errs.LimitEvaluations,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			sut := expr.NewContext(reflect.Value{}).WithBudget(tc.budget)
+			sut.Descend(tc.descend)
+			defer sut.Ascend(tc.descend)
+
+			var err error
+			for range tc.enters {
+				if err = sut.EnterAt(tc.depth); err != nil {
+					break
+				}
+			}
+
+			if tc.wantLimit == "" {
+				if err != nil {
+					t.Fatalf("Context.EnterAt() error = %v, want nil", err)
+				}
+				return
+			}
+			var budgetErr *errs.BudgetError
+			if !errors.As(err, &budgetErr) {
+				t.Fatalf("Context.EnterAt() error = %v, want BudgetError", err)
+			}
+			if got, want := budgetErr.Limit, tc.wantLimit; got != want {
+				t.Errorf("Context.EnterAt() limit = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -24,6 +24,11 @@ func Coalesce(left, right Expr) *CoalesceExpr {
 
 // Eval evaluates the CoalesceExpr.
 func (e *CoalesceExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	lhs, err := e.Left.Eval(ctx)
 	if err != nil {
 		return reflect.Value{}, err
@@ -1,6 +1,12 @@
 package expr
 
-import "reflect"
+import (
+	"context"
+	"reflect"
+
+	"rodusek.dev/pkg/dcell/internal/invocation"
+	"rodusek.dev/pkg/dcell/internal/members"
+)
 
 // Context is used to keep track of the current evaluation context
 // during expression evaluation. It holds the root value and the current
@@ -13,6 +19,26 @@ type Context struct {
 	// Current is the current value being evaluated; typically a sub-value of
 	// Root.
 	Current reflect.Value
+
+	// Vars is the set of named variables bound for this evaluation, keyed by
+	// name without the leading '$'.
+	Vars map[string]reflect.Value
+
+	// ctx is the Go context of the evaluation, which is used to propagate
+	// cancellation and deadlines.
+	ctx context.Context
+
+	// params is the innermost scope of lambda parameters.
+	params *paramScope
+
+	// budget is the budget of the evaluation, shared by all contexts derived
+	// from it. If nil, the evaluation is not limited.
+	budget *budgetState
+
+	// members is the policy that names the fields of structs and allows the
+	// methods of values to be called. If nil, the default policy of
+	// [members.Policy] is used.
+	members *members.Policy
 }
 
 // NewContext creates a new Context with the given root value.
@@ -23,6 +49,79 @@ func NewContext(root reflect.Value) *Context {
 	}
 }
 
+// WithVars returns a new Context that binds the given variables in addition
+// to any variables already bound in this context. Variables in vars shadow
+// existing variables of the same name.
+func (c *Context) WithVars(vars map[string]reflect.Value) *Context {
+	result := c.clone()
+	if len(c.Vars) == 0 {
+		result.Vars = vars
+		return result
+	}
+	merged := make(map[string]reflect.Value, len(c.Vars)+len(vars))
+	for name, value := range c.Vars {
+		merged[name] = value
+	}
+	for name, value := range vars {
+		merged[name] = value
+	}
+	result.Vars = merged
+	return result
+}
+
+// WithContext returns a new Context that carries the given Go context for
+// cancellation and deadlines.
+func (c *Context) WithContext(ctx context.Context) *Context {
+	result := c.clone()
+	result.ctx = ctx
+	if c.budget != nil {
+		result.ctx = invocation.WithMaxStringLength(ctx, c.budget.MaxStringLength)
+	}
+	return result
+}
+
+// WithMembers returns a new Context that names the fields of structs by the
+// given policy.
+func (c *Context) WithMembers(policy *members.Policy) *Context {
+	result := c.clone()
+	result.members = policy
+	return result
+}
+
+// Members returns the policy that names the fields of structs and allows the
+// methods of values to be called.
+func (c *Context) Members() *members.Policy {
+	if c == nil {
+		return nil
+	}
+	return c.members
+}
+
+// GoContext returns the Go context of the evaluation. If no context was
+// provided, [context.Background] is returned.
+func (c *Context) GoContext() context.Context {
+	if c == nil || c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// Err returns a non-nil error if the Go context of the evaluation has been
+// cancelled or its deadline has been exceeded.
+func (c *Context) Err() error {
+	if c == nil || c.ctx == nil {
+		return nil
+	}
+	return c.ctx.Err()
+}
+
+// Var returns the value of the variable with the given name, and whether it
+// was bound in this context.
+func (c *Context) Var(name string) (reflect.Value, bool) {
+	value, ok := c.Vars[name]
+	return value, ok
+}
+
 // Next creates a new Context based on the current context,
 func (c *Context) Next(v reflect.Value) *Context {
 	result := c.clone()
@@ -34,5 +133,41 @@ func (c *Context) clone() *Context {
 	return &Context{
 		Root:    c.Root,
 		Current: c.Current,
+		Vars:    c.Vars,
+		ctx:     c.ctx,
+		budget:  c.budget,
+		params:  c.params,
+		members: c.members,
+	}
+}
+
+// paramScope is a single lambda parameter binding, linked to the bindings of
+// the enclosing lambdas.
+type paramScope struct {
+	name   string
+	value  reflect.Value
+	parent *paramScope
+}
+
+// WithParam returns a new Context that binds the lambda parameter with the
+// given name, shadowing any enclosing parameter of the same name.
+func (c *Context) WithParam(name string, value reflect.Value) *Context {
+	result := c.clone()
+	result.params = &paramScope{
+		name:   name,
+		value:  value,
+		parent: c.params,
+	}
+	return result
+}
+
+// Param returns the value of the innermost lambda parameter with the given
+// name, and whether it is bound in this context.
+func (c *Context) Param(name string) (reflect.Value, bool) {
+	for scope := c.params; scope != nil; scope = scope.parent {
+		if scope.name == name {
+			return scope.value, true
+		}
 	}
+	return reflect.Value{}, false
 }
@@ -28,10 +28,21 @@ func NotEqual(left, right Expr) Expr {
 
 // Eval evaluates the EqualityExpr.
 func (e *EqualityExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	lhs, rhs, err := evalTwo(ctx, e.Left, e.Right)
 	if err != nil {
 		return reflect.Value{}, err
 	}
+	return e.Apply(ctx, lhs, rhs)
+}
+
+// Apply applies the operation to the evaluated operands, which must already
+// be dereferenced.
+func (e *EqualityExpr) Apply(ctx *Context, lhs, rhs reflect.Value) (reflect.Value, error) {
 	return reflect.ValueOf(reflectcmp.Equal(lhs, rhs)), nil
 }
 
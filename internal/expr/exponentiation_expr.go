@@ -23,10 +23,21 @@ func Power(left, right Expr) *PowerExpr {
 
 // Eval evaluates the PowerExpr.
 func (e *PowerExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	lhs, rhs, err := evalTwo(ctx, e.Left, e.Right)
 	if err != nil {
 		return reflect.Value{}, err
 	}
+	return e.Apply(ctx, lhs, rhs)
+}
+
+// Apply applies the operation to the evaluated operands, which must already
+// be dereferenced.
+func (e *PowerExpr) Apply(ctx *Context, lhs, rhs reflect.Value) (reflect.Value, error) {
 	if reflectconv.IsFloat(lhs.Type()) || reflectconv.IsFloat(rhs.Type()) {
 		fs, err := reflectconv.Float64s(lhs, rhs)
 		if err != nil {
@@ -83,11 +83,10 @@ func TestPower(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
 			sut := expr.Power(tc.left, tc.right)
 
-			result, err := sut.Eval(nil)
+			result, err := backend.Eval(sut, nil)
 
 			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
 				t.Errorf("PowerExpr.Eval() error = %v, want %v", got, want)
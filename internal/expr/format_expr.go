@@ -0,0 +1,334 @@
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"rodusek.dev/pkg/dcell/internal/reflectconv"
+)
+
+// FormatExpr is an expression that formats the result of another expression
+// as a string, for the expressions that format strings interpolate, such as
+// `{price:.2f}` in `f"Total: {price:.2f}"`.
+//
+// Without a spec, values are formatted as they are converted to string by
+// [AsExpr], including values held by pointers or interfaces, and null is
+// formatted as "null". The format spec follows the
+// format specification mini-language of Python:
+//
+//	[[fill]align][sign][#][0][width][.precision][type]
+//
+// where align is '<', '>', or '^'; sign is '+', '-', or ' ' for numbers; '#'
+// prefixes binary, octal, and hexadecimal integers with their base; and type
+// is 's' for any value, 'd', 'b', 'o', 'x', or 'X' for integers, or 'e', 'E',
+// 'f', 'F', 'g', 'G', or '%' for integers and floats. The precision of strings
+// is the number of characters that they are truncated to.
+type FormatExpr struct {
+	Expr Expr
+
+	// Spec is the format spec, such as `.2f` or `>8`, or empty if the value
+	// is formatted as is.
+	Spec string
+
+	spec formatSpec
+}
+
+// Format returns a [FormatExpr] that formats the result of e with the given
+// format spec. It returns an error if the spec is invalid.
+func Format(e Expr, spec string) (*FormatExpr, error) {
+	parsed, err := parseFormatSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	return &FormatExpr{
+		Expr: e,
+		Spec: spec,
+		spec: parsed,
+	}, nil
+}
+
+// Eval evaluates the expression. It returns the result of the expression
+// formatted as a string.
+func (e *FormatExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
+	result, err := e.Expr.Eval(ctx)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return e.Apply(ctx, result)
+}
+
+// Apply formats the evaluated operand as a string.
+func (e *FormatExpr) Apply(ctx *Context, rv reflect.Value) (reflect.Value, error) {
+	str, err := e.spec.format(reflectconv.Deref(rv))
+	if err != nil {
+		if e.Spec != "" {
+			err = fmt.Errorf("%w with format spec '%s'", err, e.Spec)
+		}
+		return reflect.Value{}, err
+	}
+	result := reflect.ValueOf(str)
+	if err := ctx.CheckSize(result); err != nil {
+		return reflect.Value{}, err
+	}
+	return result, nil
+}
+
+// maxFormatWidth is the largest width and precision of format specs, which
+// keeps a short spec from formatting an arbitrarily large string.
+const maxFormatWidth = 1024
+
+// formatSpec is a parsed format spec.
+type formatSpec struct {
+	fill      rune
+	align     rune // 0 for the default alignment of the value
+	sign      rune // 0 for the default of '-'
+	alternate bool
+	zero      bool
+	width     int
+	precision int  // -1 if there is no precision
+	verb      rune // 0 if there is no type
+}
+
+func parseFormatSpec(spec string) (formatSpec, error) {
+	result := formatSpec{fill: ' ', precision: -1}
+	rs := []rune(spec)
+	i := 0
+	switch {
+	case len(rs) >= 2 && strings.ContainsRune("<>^", rs[1]):
+		result.fill, result.align = rs[0], rs[1]
+		i = 2
+	case len(rs) >= 1 && strings.ContainsRune("<>^", rs[0]):
+		result.align = rs[0]
+		i = 1
+	}
+	if i < len(rs) && strings.ContainsRune("+- ", rs[i]) {
+		result.sign = rs[i]
+		i++
+	}
+	if i < len(rs) && rs[i] == '#' {
+		result.alternate = true
+		i++
+	}
+	if i < len(rs) && rs[i] == '0' {
+		result.zero = true
+		i++
+	}
+
+	var err error
+	if result.width, i, err = parseFormatInt(rs, i); err != nil {
+		return formatSpec{}, fmt.Errorf("invalid format spec '%s': %w", spec, err)
+	}
+	if i < len(rs) && rs[i] == '.' {
+		start := i + 1
+		if result.precision, i, err = parseFormatInt(rs, start); err != nil {
+			return formatSpec{}, fmt.Errorf("invalid format spec '%s': %w", spec, err)
+		}
+		if i == start {
+			return formatSpec{}, fmt.Errorf("invalid format spec '%s': missing precision", spec)
+		}
+	}
+	if i < len(rs) && strings.ContainsRune("sdboxXeEfFgG%", rs[i]) {
+		result.verb = rs[i]
+		i++
+	}
+	if i < len(rs) {
+		return formatSpec{}, fmt.Errorf("invalid format spec '%s': unexpected '%c'", spec, rs[i])
+	}
+
+	switch {
+	case result.verb == 's' && (result.sign != 0 || result.alternate):
+		return formatSpec{}, fmt.Errorf("invalid format spec '%s': sign and '#' are not allowed with 's'", spec)
+	case result.isIntVerb() && result.verb != 0 && result.precision >= 0:
+		return formatSpec{}, fmt.Errorf("invalid format spec '%s': precision is not allowed with '%c'", spec, result.verb)
+	}
+	return result, nil
+}
+
+// parseFormatInt parses the decimal digits of rs that start at i, returning
+// the value and the index after the digits, or 0 and i if there are none.
+func parseFormatInt(rs []rune, i int) (int, int, error) {
+	start := i
+	for i < len(rs) && rs[i] >= '0' && rs[i] <= '9' {
+		i++
+	}
+	if i == start {
+		return 0, i, nil
+	}
+	n, err := strconv.Atoi(string(rs[start:i]))
+	if err != nil || n > maxFormatWidth {
+		return 0, i, fmt.Errorf("'%s' exceeds %d", string(rs[start:i]), maxFormatWidth)
+	}
+	return n, i, nil
+}
+
+// isIntVerb reports whether the type of the spec formats integers only, or
+// is absent.
+func (s *formatSpec) isIntVerb() bool {
+	return s.verb == 0 || strings.ContainsRune("dboxX", s.verb)
+}
+
+// isFloatVerb reports whether the type of the spec formats integers and
+// floats as floats.
+func (s *formatSpec) isFloatVerb() bool {
+	return s.verb != 0 && strings.ContainsRune("eEfFgG%", s.verb)
+}
+
+// format formats a value, which has no pointers or interfaces to unwrap.
+func (s *formatSpec) format(rv reflect.Value) (string, error) {
+	if reflectconv.IsNil(rv) {
+		if !s.isText() {
+			return "", errors.New("cannot format null")
+		}
+		return s.text("null"), nil
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch {
+		case s.isFloatVerb():
+			return s.float(float64(rv.Int())), nil
+		case s.isIntVerb():
+			n := rv.Int()
+			if n < 0 {
+				return s.integer("-", uint64(-n)), nil
+			}
+			return s.integer("", uint64(n)), nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		switch {
+		case s.isFloatVerb():
+			return s.float(float64(rv.Uint())), nil
+		case s.isIntVerb():
+			return s.integer("", rv.Uint()), nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if s.verb == 0 || s.isFloatVerb() {
+			return s.float(rv.Float()), nil
+		}
+	}
+	str, ok := stringOf(rv)
+	if !ok || !s.isText() {
+		return "", fmt.Errorf("cannot format %s", rv.Type())
+	}
+	return s.text(str), nil
+}
+
+// isText reports whether the spec formats values other than numbers.
+func (s *formatSpec) isText() bool {
+	return s.sign == 0 && !s.alternate && (s.verb == 0 || s.verb == 's')
+}
+
+// text formats text that is not a number, which is truncated to the
+// precision of the spec.
+func (s *formatSpec) text(str string) string {
+	if s.precision >= 0 && utf8.RuneCountInString(str) > s.precision {
+		str = string([]rune(str)[:s.precision])
+	}
+	return s.pad("", str, '<')
+}
+
+// integer formats an integer with the given sign and magnitude.
+func (s *formatSpec) integer(sign string, n uint64) string {
+	base, prefix := 10, ""
+	switch s.verb {
+	case 'b':
+		base, prefix = 2, "0b"
+	case 'o':
+		base, prefix = 8, "0o"
+	case 'x':
+		base, prefix = 16, "0x"
+	case 'X':
+		base, prefix = 16, "0X"
+	}
+	digits := strconv.FormatUint(n, base)
+	if s.verb == 'X' {
+		digits = strings.ToUpper(digits)
+	}
+	if !s.alternate {
+		prefix = ""
+	}
+	return s.pad(s.signOf(sign), prefix+digits, '>')
+}
+
+// float formats a float, or an integer with a float type.
+func (s *formatSpec) float(f float64) string {
+	var str string
+	switch verb := s.verb; verb {
+	case 0:
+		if s.precision < 0 {
+			str = strconv.FormatFloat(f, 'f', -1, 64)
+		} else {
+			str = strconv.FormatFloat(f, 'g', max(s.precision, 1), 64)
+		}
+	case '%':
+		str = strconv.FormatFloat(f*100, 'f', s.precisionOr(6), 64) + "%"
+	case 'F':
+		str = strings.ToUpper(strconv.FormatFloat(f, 'f', s.precisionOr(6), 64))
+	case 'g', 'G':
+		str = strconv.FormatFloat(f, byte(verb), max(s.precisionOr(6), 1), 64)
+	default:
+		str = strconv.FormatFloat(f, byte(verb), s.precisionOr(6), 64)
+	}
+
+	var sign string
+	if str[0] == '-' || str[0] == '+' {
+		sign, str = str[:1], str[1:]
+	}
+	return s.pad(s.signOf(sign), str, '>')
+}
+
+func (s *formatSpec) precisionOr(precision int) int {
+	if s.precision >= 0 {
+		return s.precision
+	}
+	return precision
+}
+
+// signOf returns the sign of a number, given the sign that it was formatted
+// with, which is empty if the number is not negative.
+func (s *formatSpec) signOf(sign string) string {
+	if sign != "" {
+		return sign
+	}
+	switch s.sign {
+	case '+':
+		return "+"
+	case ' ':
+		return " "
+	}
+	return ""
+}
+
+// pad pads the sign and body of a formatted value to the width of the spec.
+// Numbers with the '0' flag and no alignment are padded with zeros between
+// their sign and body.
+func (s *formatSpec) pad(sign, body string, align rune) string {
+	n := s.width - utf8.RuneCountInString(sign) - utf8.RuneCountInString(body)
+	if n <= 0 {
+		return sign + body
+	}
+	if s.zero && s.align == 0 && align == '>' {
+		return sign + strings.Repeat("0", n) + body
+	}
+	if s.align != 0 {
+		align = s.align
+	}
+	fill := string(s.fill)
+	switch align {
+	case '<':
+		return sign + body + strings.Repeat(fill, n)
+	case '^':
+		return strings.Repeat(fill, n/2) + sign + body + strings.Repeat(fill, n-n/2)
+	}
+	return strings.Repeat(fill, n) + sign + body
+}
+
+var _ Expr = (*FormatExpr)(nil)
@@ -0,0 +1,109 @@
+package expr_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"rodusek.dev/pkg/dcell/internal/expr"
+	"rodusek.dev/pkg/dcell/internal/expr/exprtest"
+	"rodusek.dev/pkg/dcell/internal/reflectcmp"
+)
+
+func TestFormatExpr_Eval(t *testing.T) {
+	t.Parallel()
+	testErr := errors.New("test error")
+	name := "octocat"
+	testCases := []struct {
+		name    string
+		value   any
+		spec    string
+		operand expr.Expr
+		want    reflect.Value
+		wantErr bool
+	}{
+		{name: "String", value: "octocat", want: reflect.ValueOf("octocat")},
+		{name: "Int", value: 42, want: reflect.ValueOf("42")},
+		{name: "Uint", value: uint8(7), want: reflect.ValueOf("7")},
+		{name: "Float", value: 2.5, want: reflect.ValueOf("2.5")},
+		{name: "Whole float", value: 3.0, want: reflect.ValueOf("3")},
+		{name: "Bool", value: true, want: reflect.ValueOf("true")},
+		{name: "Null", value: nil, want: reflect.ValueOf("null")},
+		{name: "Pointer", value: &name, want: reflect.ValueOf("octocat")},
+		{name: "Fixed precision", value: 3.14159, spec: ".2f", want: reflect.ValueOf("3.14")},
+		{name: "Fixed precision of int", value: 3, spec: ".1f", want: reflect.ValueOf("3.0")},
+		{name: "General precision", value: 3.14159, spec: ".3", want: reflect.ValueOf("3.14")},
+		{name: "Exponent", value: 1234.5, spec: ".1e", want: reflect.ValueOf("1.2e+03")},
+		{name: "Percentage", value: 0.25, spec: ".0%", want: reflect.ValueOf("25%")},
+		{name: "Right aligned", value: "ab", spec: ">5", want: reflect.ValueOf("   ab")},
+		{name: "Centered with fill", value: "ab", spec: "*^6", want: reflect.ValueOf("**ab**")},
+		{name: "Numbers align right", value: 42, spec: "5", want: reflect.ValueOf("   42")},
+		{name: "Left aligned number", value: 42, spec: "<5", want: reflect.ValueOf("42   ")},
+		{name: "Zero padded", value: -42, spec: "06d", want: reflect.ValueOf("-00042")},
+		{name: "Explicit sign", value: 42, spec: "+", want: reflect.ValueOf("+42")},
+		{name: "Space sign", value: 1.5, spec: " .1f", want: reflect.ValueOf(" 1.5")},
+		{name: "Hexadecimal", value: 255, spec: "#x", want: reflect.ValueOf("0xff")},
+		{name: "Upper hexadecimal", value: 255, spec: "X", want: reflect.ValueOf("FF")},
+		{name: "Binary", value: uint(5), spec: "08b", want: reflect.ValueOf("00000101")},
+		{name: "Truncated string", value: "octocat", spec: ".4", want: reflect.ValueOf("octo")},
+		{name: "Padded null", value: nil, spec: "^6s", want: reflect.ValueOf(" null ")},
+		{name: "Int with string type", value: 42, spec: "s", want: reflect.ValueOf("42")},
+		{name: "String with int type", value: "a", spec: "d", wantErr: true},
+		{name: "Float with int type", value: 1.5, spec: "x", wantErr: true},
+		{name: "Bool with sign", value: true, spec: "+", wantErr: true},
+		{name: "Null with float type", value: nil, spec: ".2f", wantErr: true},
+		{name: "List", value: []string{"a"}, wantErr: true},
+		{
+			name:    "Expression returns error",
+			operand: exprtest.Error(testErr),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		operand := tc.operand
+		if operand == nil {
+			operand = expr.Literal(tc.value)
+		}
+		sut, err := expr.Format(operand, tc.spec)
+		if err != nil {
+			t.Fatalf("Format(%q) error = %v", tc.spec, err)
+		}
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
+			got, err := backend.Eval(sut, nil)
+
+			if got, want := err != nil, tc.wantErr; got != want {
+				t.Errorf("FormatExpr.Eval() error = %v, want error %v", err, want)
+			}
+			if got, want := got, tc.want; !reflectcmp.Equal(got, want) {
+				t.Errorf("FormatExpr.Eval() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestFormat_InvalidSpec(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name string
+		spec string
+	}{
+		{name: "Unknown type", spec: "q"},
+		{name: "Missing precision", spec: ".f"},
+		{name: "Trailing text", spec: "5dx"},
+		{name: "Sign with string type", spec: "+s"},
+		{name: "Precision with int type", spec: ".2d"},
+		{name: "Width too large", spec: "100000"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := expr.Format(expr.Literal("a"), tc.spec)
+
+			if err == nil {
+				t.Errorf("Format(%q) error = nil, want error", tc.spec)
+			}
+		})
+	}
+}
@@ -1,14 +1,22 @@
 package expr
 
 import (
+	"context"
 	"reflect"
 )
 
-type fn = func(args ...reflect.Value) (reflect.Value, error)
+type fn = func(ctx context.Context, args ...reflect.Value) (reflect.Value, error)
 
 type FreeFuncExpr struct {
 	Args []Expr
 	Fn   fn
+
+	// Name is the name of the function in the function table that Fn was
+	// resolved from.
+	Name string
+
+	// Pure is whether the result of Fn only depends on its arguments.
+	Pure bool
 }
 
 func FreeFunc(fn fn, args ...Expr) *FreeFuncExpr {
@@ -19,6 +27,14 @@ func FreeFunc(fn fn, args ...Expr) *FreeFuncExpr {
 }
 
 func (f *FreeFuncExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
+	if err := ctx.Err(); err != nil {
+		return reflect.Value{}, err
+	}
 	args := make([]reflect.Value, 0, len(f.Args))
 	for _, arg := range f.Args {
 		result, err := arg.Eval(ctx)
@@ -27,10 +43,13 @@ func (f *FreeFuncExpr) Eval(ctx *Context) (reflect.Value, error) {
 		}
 		args = append(args, result)
 	}
-	got, err := f.Fn(args...)
+	got, err := f.Fn(ctx.GoContext(), args...)
 	if err != nil {
 		return reflect.Value{}, err
 	}
+	if err := ctx.CheckSize(got); err != nil {
+		return reflect.Value{}, err
+	}
 	return got, nil
 }
 
@@ -39,6 +58,13 @@ var _ Expr = (*FreeFuncExpr)(nil)
 type MemberFuncExpr struct {
 	Args []Expr
 	Fn   fn
+
+	// Name is the name of the function in the function table that Fn was
+	// resolved from.
+	Name string
+
+	// Pure is whether the result of Fn only depends on its arguments.
+	Pure bool
 }
 
 func MemberFunc(fn fn, args ...Expr) *MemberFuncExpr {
@@ -49,6 +75,11 @@ func MemberFunc(fn fn, args ...Expr) *MemberFuncExpr {
 }
 
 func (e *MemberFuncExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	current := ctx.Current
 	if !current.IsValid() {
 		return reflect.Value{}, nil
@@ -62,5 +93,12 @@ func (e *MemberFuncExpr) Eval(ctx *Context) (reflect.Value, error) {
 		}
 		args = append(args, result)
 	}
-	return e.Fn(args...)
+	got, err := e.Fn(ctx.GoContext(), args...)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if err := ctx.CheckSize(got); err != nil {
+		return reflect.Value{}, err
+	}
+	return got, nil
 }
@@ -1,6 +1,7 @@
 package expr_test
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"testing"
@@ -17,42 +18,42 @@ func TestFreeFuncExpr_Eval(t *testing.T) {
 	testErr := errors.New("test error")
 	testCases := []struct {
 		name    string
-		fn      func(args ...reflect.Value) (reflect.Value, error)
+		fn      func(context.Context, ...reflect.Value) (reflect.Value, error)
 		args    []expr.Expr
 		want    reflect.Value
 		wantErr error
 	}{
 		{
 			name: "no args, returns constant",
-			fn: func(_ ...reflect.Value) (reflect.Value, error) {
+			fn: func(_ context.Context, _ ...reflect.Value) (reflect.Value, error) {
 				return reflect.ValueOf(42), nil
 			},
 			args: nil,
 			want: reflect.ValueOf(42),
 		}, {
 			name: "one arg, returns arg",
-			fn: func(args ...reflect.Value) (reflect.Value, error) {
+			fn: func(_ context.Context, args ...reflect.Value) (reflect.Value, error) {
 				return args[0], nil
 			},
 			args: []expr.Expr{exprtest.Integer(7)},
 			want: reflect.ValueOf(7),
 		}, {
 			name: "two args, returns sum",
-			fn: func(args ...reflect.Value) (reflect.Value, error) {
+			fn: func(_ context.Context, args ...reflect.Value) (reflect.Value, error) {
 				return reflect.ValueOf(args[0].Int() + args[1].Int()), nil
 			},
 			args: []expr.Expr{exprtest.Integer(3), exprtest.Integer(4)},
 			want: reflect.ValueOf(int64(7)),
 		}, {
 			name: "fn returns error",
-			fn: func(_ ...reflect.Value) (reflect.Value, error) {
+			fn: func(_ context.Context, _ ...reflect.Value) (reflect.Value, error) {
 				return reflect.Value{}, testErr
 			},
 			args:    nil,
 			wantErr: testErr,
 		}, {
 			name: "arg returns error",
-			fn: func(_ ...reflect.Value) (reflect.Value, error) {
+			fn: func(_ context.Context, _ ...reflect.Value) (reflect.Value, error) {
 				return reflect.ValueOf(0), nil
 			},
 			args:    []expr.Expr{exprtest.Error(testErr)},
@@ -61,11 +62,10 @@ func TestFreeFuncExpr_Eval(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
 			sut := expr.FreeFunc(tc.fn, tc.args...)
 
-			got, err := sut.Eval(nil)
+			got, err := backend.Eval(sut, nil)
 
 			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
 				t.Errorf("FreeFuncExpr.Eval() error = %v, want %v", got, want)
@@ -77,13 +77,61 @@ func TestFreeFuncExpr_Eval(t *testing.T) {
 	}
 }
 
+func TestFreeFuncExpr_Eval_Context(t *testing.T) {
+	t.Parallel()
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			t.Parallel()
+			type key struct{}
+			goctx := context.WithValue(context.Background(), key{}, "value")
+			sut := expr.FreeFunc(func(ctx context.Context, _ ...reflect.Value) (reflect.Value, error) {
+				return reflect.ValueOf(ctx.Value(key{})), nil
+			})
+
+			got, err := backend.Eval(sut, expr.NewContext(reflect.Value{}).WithContext(goctx))
+
+			if err != nil {
+				t.Fatalf("FreeFuncExpr.Eval() error = %v", err)
+			}
+			if got, want := got, reflect.ValueOf("value"); !reflectcmp.Equal(got, want) {
+				t.Errorf("FreeFuncExpr.Eval() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestFreeFuncExpr_Eval_Cancelled(t *testing.T) {
+	t.Parallel()
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			t.Parallel()
+			goctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			called := false
+			sut := expr.FreeFunc(func(context.Context, ...reflect.Value) (reflect.Value, error) {
+				called = true
+				return reflect.ValueOf(42), nil
+			})
+
+			_, err := backend.Eval(sut, expr.NewContext(reflect.Value{}).WithContext(goctx))
+
+			if got, want := err, context.Canceled; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("FreeFuncExpr.Eval() error = %v, want %v", got, want)
+			}
+			if called {
+				t.Errorf("FreeFuncExpr.Eval() called the function after cancellation")
+			}
+		})
+	}
+}
+
 func TestMemberFuncExpr_Eval(t *testing.T) {
 	t.Parallel()
 	testErr := errors.New("test error")
 	testCases := []struct {
 		name    string
 		current any
-		fn      func(args ...reflect.Value) (reflect.Value, error)
+		fn      func(context.Context, ...reflect.Value) (reflect.Value, error)
 		args    []expr.Expr
 		want    reflect.Value
 		wantErr error
@@ -91,7 +139,7 @@ func TestMemberFuncExpr_Eval(t *testing.T) {
 		{
 			name:    "current is nil",
 			current: nil,
-			fn: func(_ ...reflect.Value) (reflect.Value, error) {
+			fn: func(_ context.Context, _ ...reflect.Value) (reflect.Value, error) {
 				return reflect.ValueOf("should not be called"), nil
 			},
 			args: nil,
@@ -99,7 +147,7 @@ func TestMemberFuncExpr_Eval(t *testing.T) {
 		}, {
 			name:    "current and one arg, returns sum",
 			current: 5,
-			fn: func(args ...reflect.Value) (reflect.Value, error) {
+			fn: func(_ context.Context, args ...reflect.Value) (reflect.Value, error) {
 				return reflect.ValueOf(args[0].Int() + args[1].Int()), nil
 			},
 			args: []expr.Expr{exprtest.Integer(7)},
@@ -107,7 +155,7 @@ func TestMemberFuncExpr_Eval(t *testing.T) {
 		}, {
 			name:    "current and two args, returns product",
 			current: 2,
-			fn: func(args ...reflect.Value) (reflect.Value, error) {
+			fn: func(_ context.Context, args ...reflect.Value) (reflect.Value, error) {
 				return reflect.ValueOf(args[0].Int() * args[1].Int() * args[2].Int()), nil
 			},
 			args: []expr.Expr{exprtest.Integer(3), exprtest.Integer(4)},
@@ -115,7 +163,7 @@ func TestMemberFuncExpr_Eval(t *testing.T) {
 		}, {
 			name:    "fn returns error",
 			current: 1,
-			fn: func(_ ...reflect.Value) (reflect.Value, error) {
+			fn: func(_ context.Context, _ ...reflect.Value) (reflect.Value, error) {
 				return reflect.Value{}, testErr
 			},
 			args:    nil,
@@ -123,7 +171,7 @@ func TestMemberFuncExpr_Eval(t *testing.T) {
 		}, {
 			name:    "arg returns error",
 			current: 1,
-			fn: func(_ ...reflect.Value) (reflect.Value, error) {
+			fn: func(_ context.Context, _ ...reflect.Value) (reflect.Value, error) {
 				return reflect.ValueOf(0), nil
 			},
 			args:    []expr.Expr{exprtest.Error(testErr)},
@@ -132,12 +180,11 @@ func TestMemberFuncExpr_Eval(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
 			ctx := &expr.Context{Current: reflect.ValueOf(tc.current)}
 			sut := expr.MemberFunc(tc.fn, tc.args...)
 
-			got, err := sut.Eval(ctx)
+			got, err := backend.Eval(sut, ctx)
 
 			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
 				t.Errorf("MemberFuncExpr.Eval() error = %v, want %v", got, want)
@@ -0,0 +1,62 @@
+package expr
+
+import (
+	"errors"
+	"reflect"
+
+	"rodusek.dev/pkg/dcell/internal/errs"
+	"rodusek.dev/pkg/dcell/internal/reflectconv"
+)
+
+// HasExpr is an expression that reports whether a path, such as
+// `event.label`, is present, for the built-in functions `has` and `exists`. A
+// path is present if it evaluates to a value that is not null.
+//
+// Members and keys that do not exist, indices that are out of bounds, unbound
+// variables, and navigation through null make the path absent rather than
+// failing. All other errors are returned.
+type HasExpr struct {
+	Expr Expr
+}
+
+// Has returns a [HasExpr] that reports whether the path e is present.
+func Has(e Expr) *HasExpr {
+	return &HasExpr{
+		Expr: e,
+	}
+}
+
+// Eval evaluates the expression. It returns true if the path is present.
+func (e *HasExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
+	result, err := e.Expr.Eval(ctx)
+	if isAbsent(err) {
+		return reflect.ValueOf(false), nil
+	}
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(!reflectconv.IsNil(result)), nil
+}
+
+// isAbsent reports whether err is raised by evaluating a path that is not
+// present.
+func isAbsent(err error) bool {
+	for _, target := range []error{
+		errs.ErrUnknownName,
+		errs.ErrKeyNotFound,
+		errs.ErrOutOfBounds,
+		errs.ErrNullNavigation,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+var _ Expr = (*HasExpr)(nil)
@@ -0,0 +1,83 @@
+package expr_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"rodusek.dev/pkg/dcell/internal/expr"
+	"rodusek.dev/pkg/dcell/internal/expr/exprtest"
+	"rodusek.dev/pkg/dcell/internal/reflectcmp"
+)
+
+func TestHasExpr(t *testing.T) {
+	t.Parallel()
+	testErr := errors.New("test error")
+	input := map[string]any{
+		"label":  map[string]any{"name": "bug"},
+		"labels": []string{"bug"},
+		"draft":  false,
+		"title":  nil,
+	}
+	testCases := []struct {
+		name    string
+		sut     expr.Expr
+		want    reflect.Value
+		wantErr error
+	}{
+		{
+			name: "Member exists",
+			sut:  expr.Has(expr.Sequence(expr.Member("label"), expr.Member("name"))),
+			want: reflect.ValueOf(true),
+		}, {
+			name: "Member is zero but not null",
+			sut:  expr.Has(expr.Member("draft")),
+			want: reflect.ValueOf(true),
+		}, {
+			name: "Member is null",
+			sut:  expr.Has(expr.Member("title")),
+			want: reflect.ValueOf(false),
+		}, {
+			name: "Member does not exist",
+			sut:  expr.Has(expr.Sequence(expr.Member("label"), expr.Member("color"))),
+			want: reflect.ValueOf(false),
+		}, {
+			name: "Index out of bounds",
+			sut:  expr.Has(expr.Sequence(expr.Member("labels"), expr.Index(expr.Literal(1)))),
+			want: reflect.ValueOf(false),
+		}, {
+			name: "Key does not exist",
+			sut:  expr.Has(expr.Sequence(expr.Member("label"), expr.Index(expr.Literal("color")))),
+			want: reflect.ValueOf(false),
+		}, {
+			name: "Variable is not bound",
+			sut:  expr.Has(expr.Variable("missing")),
+			want: reflect.ValueOf(false),
+		}, {
+			name: "Navigation through null",
+			sut:  expr.Has(expr.Sequence(expr.NonNull(expr.Member("title"), "title"), expr.Member("text"))),
+			want: reflect.ValueOf(false),
+		}, {
+			name:    "Expression returns error",
+			sut:     expr.Has(exprtest.Error(testErr)),
+			wantErr: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
+			ctx := expr.NewContext(reflect.ValueOf(input))
+
+			got, err := backend.Eval(tc.sut, ctx)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("HasExpr.Eval() error = %v, want %v", got, want)
+			}
+			if got, want := got, tc.want; !reflectcmp.Equal(got, want) {
+				t.Errorf("HasExpr.Eval() = %v, want %v", got, want)
+			}
+		})
+	}
+}
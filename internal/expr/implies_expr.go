@@ -23,10 +23,20 @@ func Implies(left, right Expr) *ImpliesExpr {
 
 // Eval evaluates the implication expression.
 func (e *ImpliesExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	left, right, err := evalTwo(ctx, e.Left, e.Right)
 	if err != nil {
 		return reflect.Value{}, err
 	}
+	return e.Apply(ctx, left, right)
+}
 
+// Apply applies the operation to the evaluated operands, which must already
+// be dereferenced.
+func (e *ImpliesExpr) Apply(ctx *Context, left, right reflect.Value) (reflect.Value, error) {
 	return reflect.ValueOf(reflectconv.IsTruthy(left) == reflectconv.IsTruthy(right)), nil
 }
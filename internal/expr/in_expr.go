@@ -40,10 +40,24 @@ func NotIn(left, right Expr) Expr {
 
 // Eval evaluates the InExpr.
 func (e *InExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	lhs, rhs, err := evalTwo(ctx, e.Left, e.Right)
-	if err != nil || reflectconv.IsNil(lhs) || reflectconv.IsNil(rhs) {
+	if err != nil {
 		return reflect.Value{}, err
 	}
+	return e.Apply(ctx, lhs, rhs)
+}
+
+// Apply applies the operation to the evaluated operands, which must already
+// be dereferenced.
+func (e *InExpr) Apply(ctx *Context, lhs, rhs reflect.Value) (reflect.Value, error) {
+	if reflectconv.IsNil(lhs) || reflectconv.IsNil(rhs) {
+		return reflect.Value{}, nil
+	}
 	kind := rhs.Kind()
 	if kind != reflect.Slice && kind != reflect.Array {
 		return reflect.Value{}, fmt.Errorf(
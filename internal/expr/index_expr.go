@@ -3,10 +3,19 @@ package expr
 import (
 	"fmt"
 	"reflect"
+	"slices"
 
+	"rodusek.dev/pkg/dcell/internal/errs"
+	"rodusek.dev/pkg/dcell/internal/reflectcmp"
 	"rodusek.dev/pkg/dcell/internal/reflectconv"
 )
 
+// IndexExpr is an expression that accesses the element of a slice or array at
+// an index, counting from the end for negative indices, the rune of a string
+// at an index, or the value of a map at a key. Keys are converted into the key
+// type of the map, and a key that does not exist is an [errs.KeyError]. Values
+// that implement [IndexResolver] resolve integer indices themselves, and
+// values that implement [MemberResolver] resolve string keys themselves.
 type IndexExpr struct {
 	Index Expr
 }
@@ -18,25 +27,54 @@ func Index(ex Expr) IndexExpr {
 }
 
 func (e IndexExpr) Eval(ctx *Context) (reflect.Value, error) {
-	_ = ctx
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	current := ctx.Current
 	if reflectconv.IsNil(current) {
 		return reflect.Value{}, nil
 	}
 
-	rv := reflectconv.Deref(current)
 	got, err := e.Index.Eval(ctx)
 	if err != nil {
 		return reflect.Value{}, err
 	}
+	return e.Apply(ctx, current, got)
+}
+
+// Apply indexes the given value, which must not be nil, with the evaluated
+// index.
+func (e IndexExpr) Apply(ctx *Context, current, got reflect.Value) (reflect.Value, error) {
+	rv := reflectconv.Deref(current)
+	if key := reflectconv.Deref(got); key.Kind() == reflect.String {
+		if r, ok := resolverOf[MemberResolver](current); ok {
+			return e.resolveKey(r, key.String())
+		}
+	}
+	if rv.Kind() == reflect.Map {
+		return e.applyMap(ctx, rv, got)
+	}
+
 	index, err := reflectconv.Int(got)
 	if err != nil {
 		return reflect.Value{}, err
 	}
 
 	rt := rv.Type()
+	if r, ok := resolverOf[IndexResolver](current); ok {
+		value, ok := r.DCellIndex(index)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("index %d %w for %s", index, errs.ErrOutOfBounds, rt.String())
+		}
+		return reflect.ValueOf(value), nil
+	}
+	if rv.Kind() == reflect.String {
+		return e.applyString(rv, index)
+	}
 	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
-		return reflect.Value{}, fmt.Errorf("index %d does not exist in %s", index, rt.Name())
+		return reflect.Value{}, fmt.Errorf("index %d does not exist in %s", index, rt.String())
 	}
 	i := index
 	if i < 0 {
@@ -44,11 +82,94 @@ func (e IndexExpr) Eval(ctx *Context) (reflect.Value, error) {
 	}
 
 	if i < 0 || i >= rv.Len() {
-		return reflect.Value{}, fmt.Errorf("index %d out of bounds for %s", index, rt.Name())
+		return reflect.Value{}, fmt.Errorf("index %d %w for %s", index, errs.ErrOutOfBounds, rt.String())
 	}
 
 	rfield := rv.Index(i)
 	return rfield, nil
 }
 
+// applyMap looks up the value of the map at the key, which is converted into
+// the key type of the map. Null keys are rejected, and integer keys of maps
+// with interface keys match the stored keys of any integer type, as in the
+// map[any]any values decoded from YAML.
+func (e IndexExpr) applyMap(ctx *Context, rv, got reflect.Value) (reflect.Value, error) {
+	if reflectconv.IsNil(got) {
+		return reflect.Value{}, fmt.Errorf("%w: map key must not be null", errs.ErrIncompatible)
+	}
+	key := reflect.New(rv.Type().Key()).Elem()
+	if err := reflectconv.Decode(key, got, ctx.Members()); err != nil {
+		return reflect.Value{}, fmt.Errorf("%w: map key must be of type %v: %w", errs.ErrIncompatible, key.Type(), err)
+	}
+	if !key.Comparable() {
+		return reflect.Value{}, fmt.Errorf("%w: map key of type %v is not comparable", errs.ErrIncompatible, reflectconv.Deref(key).Type())
+	}
+	value := rv.MapIndex(key)
+	if !value.IsValid() && key.Kind() == reflect.Interface {
+		value = e.lookupInt(rv, got)
+	}
+	if !value.IsValid() {
+		return reflect.Value{}, e.keyError(rv, key)
+	}
+	return value, nil
+}
+
+// lookupInt looks up the value of a map with interface keys at the stored key
+// that is an integer equal to the given integer key, whatever its type. It
+// returns the zero value if the key is not an integer or is not in the map.
+func (e IndexExpr) lookupInt(rv, got reflect.Value) reflect.Value {
+	got = reflectconv.Deref(got)
+	if !reflectconv.IsInt(got.Type()) {
+		return reflect.Value{}
+	}
+	for _, k := range rv.MapKeys() {
+		stored := reflectconv.Deref(k)
+		if stored.IsValid() && reflectconv.IsInt(stored.Type()) && reflectcmp.Equal(stored, got) {
+			return rv.MapIndex(k)
+		}
+	}
+	return reflect.Value{}
+}
+
+// keyError returns the [errs.KeyError] of a key that does not exist in the
+// map, with suggestions from its keys if they are strings.
+func (e IndexExpr) keyError(rv, key reflect.Value) error {
+	if rv.Type().Key().Kind() != reflect.String {
+		return errs.NewKeyError(key.Interface(), nil)
+	}
+	keys := func(yield func(string) bool) {
+		for _, k := range rv.MapKeys() {
+			if !yield(k.String()) {
+				return
+			}
+		}
+	}
+	return errs.NewKeyError(key.String(), keys)
+}
+
+// resolveKey resolves the string key of a [MemberResolver].
+func (e IndexExpr) resolveKey(r MemberResolver, key string) (reflect.Value, error) {
+	if value, ok := r.DCellMember(key); ok {
+		return reflect.ValueOf(value), nil
+	}
+	var keys []string
+	if lister, ok := r.(KeyLister); ok {
+		keys = lister.DCellKeys()
+	}
+	return reflect.Value{}, errs.NewKeyError(key, slices.Values(keys))
+}
+
+// applyString returns the rune of the string at the index, as a string.
+func (e IndexExpr) applyString(rv reflect.Value, index int) (reflect.Value, error) {
+	runes := []rune(rv.String())
+	i := index
+	if i < 0 {
+		i = len(runes) + i
+	}
+	if i < 0 || i >= len(runes) {
+		return reflect.Value{}, fmt.Errorf("index %d %w for string of length %d", index, errs.ErrOutOfBounds, len(runes))
+	}
+	return reflect.ValueOf(string(runes[i])), nil
+}
+
 var _ Expr = (*IndexExpr)(nil)
@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"rodusek.dev/pkg/dcell/internal/errs"
 	"rodusek.dev/pkg/dcell/internal/expr"
 	"rodusek.dev/pkg/dcell/internal/expr/exprtest"
 	"rodusek.dev/pkg/dcell/internal/reflectcmp"
@@ -47,6 +48,16 @@ func TestIndexExpr_Eval(t *testing.T) {
 			current:   []int{1, 2},
 			indexExpr: exprtest.Integer(-3),
 			wantErr:   cmpopts.AnyError,
+		}, {
+			name:      "index in resolver",
+			current:   ring{1, 2, 3},
+			indexExpr: exprtest.Integer(4),
+			want:      reflect.ValueOf(2),
+		}, {
+			name:      "index not in resolver",
+			current:   ring{},
+			indexExpr: exprtest.Integer(0),
+			wantErr:   cmpopts.AnyError,
 		}, {
 			name:      "not a slice or array",
 			current:   42,
@@ -67,16 +78,85 @@ func TestIndexExpr_Eval(t *testing.T) {
 			current:   []int{1, 2, 3},
 			indexExpr: exprtest.String("foo"),
 			wantErr:   cmpopts.AnyError,
+		}, {
+			name:      "key in map",
+			current:   map[string]int{"Content-Type": 1, "b": 2},
+			indexExpr: exprtest.String("Content-Type"),
+			want:      reflect.ValueOf(1),
+		}, {
+			name:      "key converted to map key type",
+			current:   map[int64]string{404: "not found"},
+			indexExpr: exprtest.Integer(404),
+			want:      reflect.ValueOf("not found"),
+		}, {
+			name:      "key converted to named map key type",
+			current:   map[status]string{"open": "o"},
+			indexExpr: exprtest.String("open"),
+			want:      reflect.ValueOf("o"),
+		}, {
+			name:      "key not in map",
+			current:   map[string]int{"a": 1},
+			indexExpr: exprtest.String("b"),
+			wantErr:   errs.ErrKeyNotFound,
+		}, {
+			name:      "integer key not in map",
+			current:   map[int]string{1: "a"},
+			indexExpr: exprtest.Integer(2),
+			wantErr:   errs.ErrKeyNotFound,
+		}, {
+			name:      "key not convertible to map key type",
+			current:   map[int]string{1: "a"},
+			indexExpr: exprtest.String("one"),
+			wantErr:   errs.ErrIncompatible,
+		}, {
+			name:      "null key",
+			current:   map[int]string{0: "zero"},
+			indexExpr: exprtest.Empty(),
+			wantErr:   errs.ErrIncompatible,
+		}, {
+			name:      "integer key in map with interface keys",
+			current:   map[any]any{1: "one", "two": 2},
+			indexExpr: exprtest.Integer(int64(1)),
+			want:      reflect.ValueOf("one"),
+		}, {
+			name:      "integer key not in map with interface keys",
+			current:   map[any]any{1: "one"},
+			indexExpr: exprtest.Integer(int64(2)),
+			wantErr:   errs.ErrKeyNotFound,
+		}, {
+			name:      "key in resolver",
+			current:   document{"name": "dcell"},
+			indexExpr: exprtest.String("name"),
+			want:      reflect.ValueOf("dcell"),
+		}, {
+			name:      "key not in resolver",
+			current:   document{"name": "dcell"},
+			indexExpr: exprtest.String("nmae"),
+			wantErr:   errs.ErrKeyNotFound,
+		}, {
+			name:      "rune in string",
+			current:   "héllo",
+			indexExpr: exprtest.Integer(1),
+			want:      reflect.ValueOf("é"),
+		}, {
+			name:      "negative rune in string",
+			current:   "héllo",
+			indexExpr: exprtest.Integer(-1),
+			want:      reflect.ValueOf("o"),
+		}, {
+			name:      "rune out of bounds in string",
+			current:   "héllo",
+			indexExpr: exprtest.Integer(5),
+			wantErr:   cmpopts.AnyError,
 		},
 	}
 
 	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
 			ctx := &expr.Context{Current: reflect.ValueOf(tc.current)}
 			sut := expr.Index(tc.indexExpr)
 
-			got, err := sut.Eval(ctx)
+			got, err := backend.Eval(sut, ctx)
 
 			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
 				t.Errorf("Eval() error = %v, want %v", got, want)
@@ -87,3 +167,45 @@ func TestIndexExpr_Eval(t *testing.T) {
 		})
 	}
 }
+
+// status is a named string type, used as the key type of maps.
+type status string
+
+func TestIndexExpr_KeySuggestions(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name    string
+		current any
+		key     string
+		want    []string
+	}{
+		{
+			name:    "map",
+			current: map[string]int{"Content-Type": 1, "Accept": 2},
+			key:     "Content-Typo",
+			want:    []string{"Content-Type"},
+		}, {
+			name:    "resolver",
+			current: document{"name": 1, "age": 2},
+			key:     "nmae",
+			want:    []string{"name"},
+		},
+	}
+
+	for _, tc := range testCases {
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
+			sut := expr.Index(exprtest.String(tc.key))
+			ctx := &expr.Context{Current: reflect.ValueOf(tc.current)}
+
+			_, err := backend.Eval(sut, ctx)
+
+			var keyErr *errs.KeyError
+			if !errors.As(err, &keyErr) {
+				t.Fatalf("Eval() error = %v, want KeyError", err)
+			}
+			if got, want := keyErr.Suggestions, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Eval() suggestions = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -7,6 +7,9 @@ import (
 	"rodusek.dev/pkg/dcell/internal/reflectconv"
 )
 
+// IndexSliceExpr is an expression that slices a slice or array between a begin
+// index and an optional end index, counting from the end for a negative end
+// index. Strings are sliced by runes.
 type IndexSliceExpr struct {
 	Begin, End Expr
 }
@@ -19,27 +22,59 @@ func IndexSlice(begin, end Expr) *IndexSliceExpr {
 }
 
 func (e *IndexSliceExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	rv := ctx.Current
 	if reflectconv.IsNil(rv) {
 		return reflect.Value{}, nil
 	}
-	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
-		return reflect.Value{}, fmt.Errorf("index slice: unable to slice %s", rv.Type().Name())
+	if err := e.Check(rv); err != nil {
+		return reflect.Value{}, err
 	}
 	low, err := e.Begin.Eval(ctx)
 	if err != nil {
 		return reflect.Value{}, err
 	}
+	var high reflect.Value
+	if e.End != nil {
+		high, err = e.End.Eval(ctx)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+	}
+	return e.Apply(ctx, rv, low, high)
+}
+
+// Check returns an error if the given non-nil value cannot be sliced. Values
+// held in pointers or interfaces are checked by the value they refer to.
+func (e *IndexSliceExpr) Check(rv reflect.Value) error {
+	rv = reflectconv.Deref(rv)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array && rv.Kind() != reflect.String {
+		return fmt.Errorf("index slice: unable to slice %s", rv.Type().String())
+	}
+	return nil
+}
+
+// Apply slices the given value, which must have passed [IndexSliceExpr.Check],
+// with the evaluated bounds. The high bound is only used if the expression
+// has an End.
+func (e *IndexSliceExpr) Apply(ctx *Context, rv, low, high reflect.Value) (reflect.Value, error) {
+	rv = reflectconv.Deref(rv)
+	isString := rv.Kind() == reflect.String
+	var runes []rune
+	if isString {
+		runes = []rune(rv.String())
+		rv = reflect.ValueOf(runes)
+	}
 	begin, err := reflectconv.Int(low)
 	if err != nil {
 		return reflect.Value{}, err
 	}
 	end := rv.Len()
 	if e.End != nil {
-		high, err := e.End.Eval(ctx)
-		if err != nil {
-			return reflect.Value{}, err
-		}
 		end, err = reflectconv.Int(high)
 		if err != nil {
 			return reflect.Value{}, err
@@ -58,6 +93,9 @@ func (e *IndexSliceExpr) Eval(ctx *Context) (reflect.Value, error) {
 	if begin > endIndex {
 		return reflect.Value{}, fmt.Errorf("begin index %d is greater than end index %d", begin, endIndex)
 	}
+	if isString {
+		return reflect.ValueOf(string(runes[begin:endIndex])), nil
+	}
 	return rv.Slice(begin, endIndex), nil
 }
 
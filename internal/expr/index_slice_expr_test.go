@@ -112,16 +112,63 @@ func TestIndexSliceEval(t *testing.T) {
 			begin:   exprtest.Integer(0),
 			end:     exprtest.String("foo"),
 			wantErr: cmpopts.AnyError,
+		}, {
+			name:    "runes of string",
+			current: reflect.ValueOf("héllo"),
+			begin:   exprtest.Integer(1),
+			end:     exprtest.Integer(3),
+			want:    reflect.ValueOf("él"),
+		}, {
+			name:    "runes of string to end",
+			current: reflect.ValueOf("héllo"),
+			begin:   exprtest.Integer(1),
+			end:     nil,
+			want:    reflect.ValueOf("éllo"),
+		}, {
+			name:    "runes of string with negative end",
+			current: reflect.ValueOf("héllo"),
+			begin:   exprtest.Integer(0),
+			end:     exprtest.Integer(-3),
+			want:    reflect.ValueOf("hé"),
+		}, {
+			name:    "runes of string out of bounds",
+			current: reflect.ValueOf("héllo"),
+			begin:   exprtest.Integer(0),
+			end:     exprtest.Integer(6),
+			wantErr: cmpopts.AnyError,
+		}, {
+			name:    "empty string to end",
+			current: reflect.ValueOf(""),
+			begin:   exprtest.Integer(0),
+			end:     nil,
+			want:    reflect.ValueOf(""),
+		}, {
+			name:    "empty string with end",
+			current: reflect.ValueOf(""),
+			begin:   exprtest.Integer(0),
+			end:     exprtest.Integer(0),
+			want:    reflect.ValueOf(""),
+		}, {
+			name:    "string held in interface",
+			current: reflect.ValueOf(map[string]any{"s": "héllo"}).MapIndex(reflect.ValueOf("s")),
+			begin:   exprtest.Integer(0),
+			end:     exprtest.Integer(2),
+			want:    reflect.ValueOf("hé"),
+		}, {
+			name:    "slice held in interface",
+			current: reflect.ValueOf([]any{[]int{1, 2, 3}}).Index(0),
+			begin:   exprtest.Integer(1),
+			end:     nil,
+			want:    reflect.ValueOf([]int{2, 3}),
 		},
 	}
 
 	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
 			sut := expr.IndexSlice(tc.begin, tc.end)
 			ctx := &expr.Context{Current: tc.current}
 
-			got, err := sut.Eval(ctx)
+			got, err := backend.Eval(sut, ctx)
 
 			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
 				t.Errorf("Eval() error = %v, want %v", got, want)
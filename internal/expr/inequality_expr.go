@@ -9,12 +9,42 @@ import (
 type InequalityExpr struct {
 	Left, Right Expr
 	Compare     func(left, right reflect.Value) bool
+
+	// Operator is the comparison operator that Compare implements.
+	Operator InequalityOperator
+}
+
+// InequalityOperator is the comparison operator of an [InequalityExpr].
+type InequalityOperator string
+
+const (
+	OpLessThan           InequalityOperator = "<"
+	OpLessThanOrEqual    InequalityOperator = "<="
+	OpGreaterThan        InequalityOperator = ">"
+	OpGreaterThanOrEqual InequalityOperator = ">="
+)
+
+// Inequality creates an [InequalityExpr] that compares the operands with the
+// given operator. It returns nil if the operator is unknown.
+func Inequality(op InequalityOperator, left, right Expr) *InequalityExpr {
+	switch op {
+	case OpLessThan:
+		return LessThan(left, right)
+	case OpLessThanOrEqual:
+		return LessThanOrEqual(left, right)
+	case OpGreaterThan:
+		return GreaterThan(left, right)
+	case OpGreaterThanOrEqual:
+		return GreaterThanOrEqual(left, right)
+	}
+	return nil
 }
 
 func LessThan(left, right Expr) *InequalityExpr {
 	return &InequalityExpr{
-		Left:  left,
-		Right: right,
+		Left:     left,
+		Right:    right,
+		Operator: OpLessThan,
 		Compare: func(lhs, rhs reflect.Value) bool {
 			return reflectcmp.Compare(lhs, rhs) < 0
 		},
@@ -23,8 +53,9 @@ func LessThan(left, right Expr) *InequalityExpr {
 
 func LessThanOrEqual(left, right Expr) *InequalityExpr {
 	return &InequalityExpr{
-		Left:  left,
-		Right: right,
+		Left:     left,
+		Right:    right,
+		Operator: OpLessThanOrEqual,
 		Compare: func(lhs, rhs reflect.Value) bool {
 			return reflectcmp.Compare(lhs, rhs) <= 0
 		},
@@ -32,8 +63,9 @@ func LessThanOrEqual(left, right Expr) *InequalityExpr {
 }
 func GreaterThan(left, right Expr) *InequalityExpr {
 	return &InequalityExpr{
-		Left:  left,
-		Right: right,
+		Left:     left,
+		Right:    right,
+		Operator: OpGreaterThan,
 		Compare: func(lhs, rhs reflect.Value) bool {
 			return reflectcmp.Compare(lhs, rhs) > 0
 		},
@@ -41,8 +73,9 @@ func GreaterThan(left, right Expr) *InequalityExpr {
 }
 func GreaterThanOrEqual(left, right Expr) *InequalityExpr {
 	return &InequalityExpr{
-		Left:  left,
-		Right: right,
+		Left:     left,
+		Right:    right,
+		Operator: OpGreaterThanOrEqual,
 		Compare: func(lhs, rhs reflect.Value) bool {
 			return reflectcmp.Compare(lhs, rhs) >= 0
 		},
@@ -50,10 +83,21 @@ func GreaterThanOrEqual(left, right Expr) *InequalityExpr {
 }
 
 func (e *InequalityExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	lhs, rhs, err := evalTwo(ctx, e.Left, e.Right)
 	if err != nil {
 		return reflect.Value{}, err
 	}
+	return e.Apply(ctx, lhs, rhs)
+}
+
+// Apply applies the operation to the evaluated operands, which must already
+// be dereferenced.
+func (e *InequalityExpr) Apply(ctx *Context, lhs, rhs reflect.Value) (reflect.Value, error) {
 	got := e.Compare(lhs, rhs)
 	return reflect.ValueOf(got), nil
 }
@@ -27,11 +27,24 @@ func IsNot(ex Expr, ty Type) Expr {
 
 // Eval evaluates the 'is' expression.
 func (e *IsExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	rv, err := e.Expr.Eval(ctx)
-	if err != nil || reflectconv.IsNil(rv) {
+	if err != nil {
 		return reflect.Value{}, err
 	}
-	switch rv.Kind() {
+	return e.Apply(ctx, rv)
+}
+
+// Apply applies the operation to the evaluated operand.
+func (e *IsExpr) Apply(ctx *Context, rv reflect.Value) (reflect.Value, error) {
+	if reflectconv.IsNil(rv) {
+		return reflect.Value{}, nil
+	}
+	switch unwrapInterface(rv).Kind() {
 	case reflect.String:
 		return reflect.ValueOf(e.Type == TypeString), nil
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
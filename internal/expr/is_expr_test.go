@@ -81,15 +81,21 @@ func TestIsExpr(t *testing.T) {
 			}),
 			ty:   expr.TypeString,
 			want: reflect.ValueOf(false),
+		}, {
+			name: "input is uint in interface, type is uint",
+			expr: exprtest.Func(func(*expr.Context) (reflect.Value, error) {
+				return reflect.ValueOf([]any{uint64(42)}).Index(0), nil
+			}),
+			ty:   expr.TypeUint,
+			want: reflect.ValueOf(true),
 		},
 	}
 
 	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
 			sut := expr.Is(tc.expr, tc.ty)
 
-			got, err := sut.Eval(nil)
+			got, err := backend.Eval(sut, nil)
 
 			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
 				t.Fatalf("IsExpr() error = %v, want %v", got, want)
@@ -0,0 +1,82 @@
+package expr
+
+import (
+	"fmt"
+	"reflect"
+
+	"rodusek.dev/pkg/dcell/internal/invocation"
+)
+
+// LambdaExpr is an expression of the form `param => body`. Evaluating it does
+// not evaluate the body; instead it produces an [invocation.Callable] that
+// evaluates the body once per call, with the parameter bound to the argument
+// of the call.
+//
+// The callable captures the context in which the lambda was evaluated, so the
+// body may refer to the parameters of enclosing lambdas.
+type LambdaExpr struct {
+	Param string
+	Body  Expr
+}
+
+// Lambda creates a new LambdaExpr with the given parameter name and body.
+func Lambda(param string, body Expr) *LambdaExpr {
+	return &LambdaExpr{
+		Param: param,
+		Body:  body,
+	}
+}
+
+// Eval evaluates the lambda expression into a callable value.
+func (e *LambdaExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
+	return reflect.ValueOf(e.Bind(ctx, e.Body.Eval)), nil
+}
+
+// Bind returns a callable that evaluates the body of the lambda with eval,
+// in the given context with the parameter bound to the argument of the call.
+func (e *LambdaExpr) Bind(ctx *Context, eval func(*Context) (reflect.Value, error)) invocation.Callable {
+	return func(args ...reflect.Value) (reflect.Value, error) {
+		if len(args) != 1 {
+			return reflect.Value{}, fmt.Errorf("lambda: expected 1 argument, got %d", len(args))
+		}
+		return eval(ctx.WithParam(e.Param, args[0]))
+	}
+}
+
+var _ Expr = (*LambdaExpr)(nil)
+
+// ParamExpr is an expression that refers to the parameter of an enclosing
+// [LambdaExpr].
+type ParamExpr string
+
+// Param returns a [ParamExpr] with the given name.
+func Param(name string) ParamExpr {
+	return ParamExpr(name)
+}
+
+// Eval evaluates the parameter expression. It returns the value bound to the
+// parameter by the innermost enclosing lambda call.
+func (e ParamExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
+	return e.Lookup(ctx)
+}
+
+// Lookup returns the value bound to the parameter in the given context.
+func (e ParamExpr) Lookup(ctx *Context) (reflect.Value, error) {
+	value, ok := ctx.Param(string(e))
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("lambda: parameter '%s' is not bound", string(e))
+	}
+	return value, nil
+}
+
+var _ Expr = (*ParamExpr)(nil)
@@ -0,0 +1,98 @@
+package expr_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"rodusek.dev/pkg/dcell/internal/expr"
+	"rodusek.dev/pkg/dcell/internal/expr/exprtest"
+	"rodusek.dev/pkg/dcell/internal/invocation"
+	"rodusek.dev/pkg/dcell/internal/reflectcmp"
+)
+
+func TestLambdaExpr(t *testing.T) {
+	t.Parallel()
+	testErr := errors.New("test error")
+	testCases := []struct {
+		name    string
+		body    expr.Expr
+		args    []reflect.Value
+		want    reflect.Value
+		wantErr error
+	}{
+		{
+			name: "body refers to parameter",
+			body: expr.Sequence(expr.Param("x"), expr.Member("foo")),
+			args: []reflect.Value{reflect.ValueOf(map[string]any{"foo": "bar"})},
+			want: reflect.ValueOf("bar"),
+		}, {
+			name: "body ignores parameter",
+			body: exprtest.Integer(42),
+			args: []reflect.Value{reflect.ValueOf(1)},
+			want: reflect.ValueOf(42),
+		}, {
+			name: "nested lambda refers to outer parameter",
+			body: exprtest.Func(func(ctx *expr.Context) (reflect.Value, error) {
+				inner, err := expr.Lambda("y", expr.Param("x")).Eval(ctx)
+				if err != nil {
+					return reflect.Value{}, err
+				}
+				return inner.Interface().(invocation.Callable)(reflect.ValueOf(2))
+			}),
+			args: []reflect.Value{reflect.ValueOf(1)},
+			want: reflect.ValueOf(1),
+		}, {
+			name:    "body returns error",
+			body:    exprtest.Error(testErr),
+			args:    []reflect.Value{reflect.ValueOf(1)},
+			wantErr: testErr,
+		}, {
+			name:    "called with wrong number of arguments",
+			body:    exprtest.Integer(42),
+			args:    []reflect.Value{reflect.ValueOf(1), reflect.ValueOf(2)},
+			wantErr: cmpopts.AnyError,
+		},
+	}
+
+	for _, tc := range testCases {
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
+			sut := expr.Lambda("x", tc.body)
+
+			rv, err := backend.Eval(sut, expr.NewContext(reflect.Value{}))
+			if err != nil {
+				t.Fatalf("Eval() error = %v", err)
+			}
+			callable, ok := rv.Interface().(invocation.Callable)
+			if !ok {
+				t.Fatalf("Eval() = %v, want invocation.Callable", rv.Type())
+			}
+			got, err := callable(tc.args...)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("Callable() error = %v, want %v", got, want)
+			}
+			if got, want := got, tc.want; !reflectcmp.Equal(got, want) {
+				t.Errorf("Callable() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestParamExpr_Unbound(t *testing.T) {
+	t.Parallel()
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			t.Parallel()
+			sut := expr.Param("x")
+
+			_, err := backend.Eval(sut, expr.NewContext(reflect.Value{}))
+
+			if err == nil {
+				t.Errorf("Eval() error = nil, want error")
+			}
+		})
+	}
+}
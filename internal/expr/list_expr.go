@@ -0,0 +1,93 @@
+package expr
+
+import (
+	"context"
+	"reflect"
+)
+
+// ListExpr is an expression that builds a list from the results of its
+// elements, such as `[user.id, owner.id]`.
+//
+// The list is a slice of the type of the elements if they all have the same
+// type, such as []string, or a slice of any otherwise. Null elements are of
+// type any.
+type ListExpr struct {
+	Elems []Expr
+}
+
+// List returns a [ListExpr] that builds a list from the given elements.
+func List(elems ...Expr) *ListExpr {
+	return &ListExpr{
+		Elems: elems,
+	}
+}
+
+// Eval evaluates the list expression. It returns the list of the results of
+// the elements.
+func (e *ListExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
+	elems := make([]reflect.Value, 0, len(e.Elems))
+	for _, elem := range e.Elems {
+		result, err := elem.Eval(ctx)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		elems = append(elems, result)
+	}
+	got, err := e.Build(ctx.GoContext(), elems...)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if err := ctx.CheckSize(got); err != nil {
+		return reflect.Value{}, err
+	}
+	return got, nil
+}
+
+// Build returns the list of the given results of the elements.
+func (e *ListExpr) Build(_ context.Context, elems ...reflect.Value) (reflect.Value, error) {
+	return MakeList(elems), nil
+}
+
+// MakeList returns a list of the given values, as built by a [ListExpr].
+func MakeList(elems []reflect.Value) reflect.Value {
+	values := make([]reflect.Value, 0, len(elems))
+	for _, elem := range elems {
+		values = append(values, entryValue(elem))
+	}
+	list := reflect.MakeSlice(reflect.SliceOf(commonType(values)), 0, len(values))
+	return reflect.Append(list, values...)
+}
+
+// entryValue returns the value that an entry of a list or object literal
+// holds, which is the zero value of any if it is null.
+func entryValue(rv reflect.Value) reflect.Value {
+	rv = unwrapInterface(rv)
+	if !rv.IsValid() {
+		return reflect.Zero(anyType)
+	}
+	return rv
+}
+
+// commonType returns the type of the values if they all have the same type,
+// or the type of any otherwise.
+func commonType(values []reflect.Value) reflect.Type {
+	if len(values) == 0 {
+		return anyType
+	}
+	current := values[0].Type()
+	for _, value := range values[1:] {
+		if value.Type() != current {
+			return anyType
+		}
+	}
+	return current
+}
+
+var anyType = reflect.TypeFor[any]()
+
+var _ Expr = (*ListExpr)(nil)
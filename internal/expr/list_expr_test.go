@@ -0,0 +1,77 @@
+package expr_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"rodusek.dev/pkg/dcell/internal/expr"
+	"rodusek.dev/pkg/dcell/internal/expr/exprtest"
+)
+
+func TestListExpr(t *testing.T) {
+	t.Parallel()
+	testErr := errors.New("test error")
+	input := map[string]any{
+		"user":  map[string]any{"id": 1.0, "login": "octocat"},
+		"owner": map[string]any{"id": 2.0, "login": "hubot"},
+	}
+	testCases := []struct {
+		name    string
+		sut     expr.Expr
+		want    any
+		wantErr error
+	}{
+		{
+			name: "Empty list",
+			sut:  expr.List(),
+			want: []any{},
+		}, {
+			name: "Elements of the same type",
+			sut: expr.List(
+				expr.Sequence(expr.Member("user"), expr.Member("id")),
+				expr.Sequence(expr.Member("owner"), expr.Member("id")),
+			),
+			want: []float64{1, 2},
+		}, {
+			name: "Elements of different types",
+			sut: expr.List(
+				expr.Sequence(expr.Member("user"), expr.Member("id")),
+				expr.Sequence(expr.Member("user"), expr.Member("login")),
+			),
+			want: []any{1.0, "octocat"},
+		}, {
+			name: "Null element",
+			sut:  expr.List(expr.Literal("a"), expr.Literal(nil)),
+			want: []any{"a", nil},
+		}, {
+			name: "Nested list",
+			sut:  expr.List(expr.List(expr.Literal(1)), expr.List(expr.Literal(2))),
+			want: [][]int{{1}, {2}},
+		}, {
+			name:    "Element returns error",
+			sut:     expr.List(expr.Literal(1), exprtest.Error(testErr)),
+			wantErr: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
+			ctx := expr.NewContext(reflect.ValueOf(input))
+
+			got, err := backend.Eval(tc.sut, ctx)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("ListExpr.Eval() error = %v, want %v", got, want)
+			}
+			if err != nil {
+				return
+			}
+			if got, want := got.Interface(), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("ListExpr.Eval() = %#v, want %#v", got, want)
+			}
+		})
+	}
+}
@@ -14,7 +14,12 @@ func Literal(v any) LiteralExpr {
 }
 
 // Eval evaluates the literal expression and returns its value.
-func (e LiteralExpr) Eval(*Context) (reflect.Value, error) {
+func (e LiteralExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	rv := reflect.Value(e)
 	if !rv.IsValid() {
 		return reflect.Value{}, nil
@@ -18,6 +18,11 @@ func LogicalAnd(left, right Expr) *LogicalAndExpr {
 }
 
 func (e *LogicalAndExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	left, err := e.Left.Eval(ctx)
 	if err != nil {
 		return reflect.Value{}, err
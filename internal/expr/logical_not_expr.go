@@ -21,10 +21,20 @@ func LogicalNot(expr Expr) LogicalNotExpr {
 
 // Eval evaluates the logical NOT expression.
 func (e LogicalNotExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	got, err := e.Expr.Eval(ctx)
 	if err != nil {
 		return reflect.Value{}, err
 	}
+	return e.Apply(ctx, got)
+}
+
+// Apply applies the operation to the evaluated operand.
+func (e LogicalNotExpr) Apply(ctx *Context, got reflect.Value) (reflect.Value, error) {
 	return reflect.ValueOf(!reflectconv.IsTruthy(got)), nil
 }
 
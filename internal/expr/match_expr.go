@@ -0,0 +1,155 @@
+package expr
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"rodusek.dev/pkg/dcell/internal/errs"
+	"rodusek.dev/pkg/dcell/internal/reflectconv"
+	"rodusek.dev/pkg/dcell/internal/regex"
+)
+
+// MatchExpr implements the `=~` and `!~` operators, which check if the left
+// operand is a string that matches the regular expression of the right
+// operand. The right operand must evaluate to a compiled pattern, which is
+// either a literal or the result of a [PatternExpr].
+type MatchExpr struct {
+	Left, Pattern Expr
+
+	// Negate is whether the result is negated, for the `!~` operator.
+	Negate bool
+}
+
+// Match returns a [MatchExpr].
+func Match(left, pattern Expr) *MatchExpr {
+	return &MatchExpr{
+		Left:    left,
+		Pattern: pattern,
+	}
+}
+
+// NotMatch returns a [MatchExpr] that negates the result of the `=~`
+// operator.
+func NotMatch(left, pattern Expr) *MatchExpr {
+	return &MatchExpr{
+		Left:    left,
+		Pattern: pattern,
+		Negate:  true,
+	}
+}
+
+// Eval evaluates the MatchExpr.
+func (e *MatchExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
+	lhs, rhs, err := evalTwo(ctx, e.Left, e.Pattern)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return e.Apply(ctx, lhs, rhs)
+}
+
+// Apply applies the operation to the evaluated operands, which must already
+// be dereferenced. Null does not match any pattern, and no string matches a
+// null pattern.
+func (e *MatchExpr) Apply(_ *Context, lhs, rhs reflect.Value) (reflect.Value, error) {
+	if reflectconv.IsNil(lhs) || reflectconv.IsNil(rhs) {
+		return reflect.ValueOf(e.Negate), nil
+	}
+	re, ok := regexpOf(rhs)
+	if !ok {
+		return reflect.Value{}, fmt.Errorf(
+			"%w: right operand must be a pattern, got %v",
+			errs.ErrIncompatible,
+			rhs.Type(),
+		)
+	}
+	if lhs.Kind() != reflect.String {
+		return reflect.Value{}, fmt.Errorf(
+			"%w: left operand must be a string, got %v",
+			errs.ErrIncompatible,
+			lhs.Type(),
+		)
+	}
+	return reflect.ValueOf(re.MatchString(lhs.String()) != e.Negate), nil
+}
+
+// PatternExpr is an expression that compiles the result of another expression
+// as a regular expression, for patterns that are not literals, such as
+// `$pattern` in `ref =~ $pattern`. Literal patterns are compiled when the
+// expression is compiled instead.
+type PatternExpr struct {
+	Expr Expr
+
+	// Policy limits the patterns that can be compiled. If nil, any valid
+	// pattern can be compiled.
+	Policy *regex.Policy
+}
+
+// Pattern returns a [PatternExpr] that compiles the result of e with the
+// given policy.
+func Pattern(e Expr, policy *regex.Policy) *PatternExpr {
+	return &PatternExpr{
+		Expr:   e,
+		Policy: policy,
+	}
+}
+
+// Eval evaluates the expression. It returns the compiled pattern, or null if
+// the result of the expression is null.
+func (e *PatternExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
+	result, err := e.Expr.Eval(ctx)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return e.Apply(ctx, result)
+}
+
+// Apply compiles the evaluated operand, which must be a string. Patterns that
+// are already compiled are returned as is.
+func (e *PatternExpr) Apply(_ *Context, rv reflect.Value) (reflect.Value, error) {
+	rv = reflectconv.Deref(rv)
+	if reflectconv.IsNil(rv) {
+		return reflect.Value{}, nil
+	}
+	if re, ok := regexpOf(rv); ok {
+		return reflect.ValueOf(re), nil
+	}
+	if rv.Kind() != reflect.String {
+		return reflect.Value{}, fmt.Errorf("%w: pattern must be a string, got %v", errs.ErrIncompatible, rv.Type())
+	}
+	re, err := e.Policy.Compile(rv.String())
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(re), nil
+}
+
+var regexpType = reflect.TypeFor[regexp.Regexp]()
+
+// regexpOf returns the compiled pattern of a value, which may have been
+// dereferenced from a *regexp.Regexp.
+func regexpOf(rv reflect.Value) (*regexp.Regexp, bool) {
+	if rv.Type() == regexpType && rv.CanAddr() {
+		return rv.Addr().Interface().(*regexp.Regexp), true
+	}
+	if !rv.CanInterface() {
+		return nil, false
+	}
+	re, ok := rv.Interface().(*regexp.Regexp)
+	return re, ok && re != nil
+}
+
+var (
+	_ Expr = (*MatchExpr)(nil)
+	_ Expr = (*PatternExpr)(nil)
+)
@@ -0,0 +1,151 @@
+package expr_test
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"rodusek.dev/pkg/dcell/internal/errs"
+	"rodusek.dev/pkg/dcell/internal/expr"
+	"rodusek.dev/pkg/dcell/internal/expr/exprtest"
+	"rodusek.dev/pkg/dcell/internal/reflectcmp"
+	"rodusek.dev/pkg/dcell/internal/regex"
+)
+
+func TestMatchExpr(t *testing.T) {
+	t.Parallel()
+	testErr := errors.New("test error")
+	release := expr.Literal(regexp.MustCompile(`^refs/heads/release-.*`))
+	testCases := []struct {
+		name    string
+		left    expr.Expr
+		pattern expr.Expr
+		want    bool
+		wantErr error
+	}{
+		{
+			name:    "string matches pattern",
+			left:    exprtest.String("refs/heads/release-1.0"),
+			pattern: release,
+			want:    true,
+		}, {
+			name:    "string does not match pattern",
+			left:    exprtest.String("refs/heads/main"),
+			pattern: release,
+			want:    false,
+		}, {
+			name:    "dynamic pattern",
+			left:    exprtest.String("abc"),
+			pattern: expr.Pattern(exprtest.String("b+"), nil),
+			want:    true,
+		}, {
+			name:    "left is nil",
+			left:    exprtest.Empty(),
+			pattern: release,
+			want:    false,
+		}, {
+			name:    "pattern is nil",
+			left:    exprtest.String("abc"),
+			pattern: expr.Pattern(exprtest.Empty(), nil),
+			want:    false,
+		}, {
+			name:    "left is not a string",
+			left:    exprtest.Integer(1),
+			pattern: release,
+			wantErr: errs.ErrIncompatible,
+		}, {
+			name:    "pattern is not compiled",
+			left:    exprtest.String("abc"),
+			pattern: exprtest.String("abc"),
+			wantErr: errs.ErrIncompatible,
+		}, {
+			name:    "invalid dynamic pattern",
+			left:    exprtest.String("abc"),
+			pattern: expr.Pattern(exprtest.String("(abc"), nil),
+			wantErr: errs.ErrInvalidPattern,
+		}, {
+			name:    "left returns error",
+			left:    exprtest.Error(testErr),
+			pattern: release,
+			wantErr: testErr,
+		}, {
+			name:    "pattern returns error",
+			left:    exprtest.String("abc"),
+			pattern: exprtest.Error(testErr),
+			wantErr: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		for _, negate := range []bool{false, true} {
+			name, op := tc.name, expr.Match
+			if negate {
+				name, op = "not "+tc.name, expr.NotMatch
+			}
+			runBackends(t, name, func(t *testing.T, backend backend) {
+				sut := op(tc.left, tc.pattern)
+
+				got, err := backend.Eval(sut, nil)
+
+				if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+					t.Errorf("Eval() error = %v, want %v", got, want)
+				}
+				want := reflect.ValueOf(tc.want != negate)
+				if tc.wantErr != nil {
+					want = reflect.Value{}
+				}
+				if !reflectcmp.Equal(got, want) {
+					t.Errorf("Eval() = %v, want %v", got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestPatternExpr(t *testing.T) {
+	t.Parallel()
+	compiled := regexp.MustCompile(`^a+$`)
+	testCases := []struct {
+		name    string
+		operand expr.Expr
+		policy  *regex.Policy
+		want    string
+		wantNil bool
+		wantErr error
+	}{
+		{name: "String", operand: exprtest.String("^a+$"), want: "^a+$"},
+		{name: "Compiled pattern", operand: expr.Literal(compiled), want: "^a+$"},
+		{name: "Null", operand: exprtest.Empty(), wantNil: true},
+		{name: "Within max length", operand: exprtest.String("^a+$"), policy: &regex.Policy{MaxLength: 4}, want: "^a+$"},
+		{name: "Exceeds max length", operand: exprtest.String("^a+$"), policy: &regex.Policy{MaxLength: 3}, wantErr: errs.ErrInvalidPattern},
+		{name: "Invalid pattern", operand: exprtest.String("a)"), wantErr: errs.ErrInvalidPattern},
+		{name: "Not a string", operand: exprtest.Integer(1), wantErr: errs.ErrIncompatible},
+	}
+
+	for _, tc := range testCases {
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
+			sut := expr.Pattern(tc.operand, tc.policy)
+
+			got, err := backend.Eval(sut, nil)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Eval() error = %v, want %v", got, want)
+			}
+			if err != nil {
+				return
+			}
+			if tc.wantNil {
+				if got.IsValid() {
+					t.Errorf("Eval() = %v, want nil", got)
+				}
+				return
+			}
+			if re, ok := got.Interface().(*regexp.Regexp); !ok || re.String() != tc.want {
+				t.Errorf("Eval() = %v, want pattern %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package expr
 
 import (
+	"iter"
 	"reflect"
 	"slices"
 
@@ -8,12 +9,16 @@ import (
 	"rodusek.dev/pkg/dcell/internal/reflectconv"
 )
 
-// MemberExpr is an expression that accesses a member field of a struct or a
-// map key. If the input context is a nil value, the output will also be a
-// nil value.
+// MemberExpr is an expression that accesses a member field of a struct, as
+// named by the policy of [Context.Members], or a map key. Fields of embedded
+// structs are promoted as in Go, and a name that refers to more than one
+// promoted field is an [errs.AmbiguousNameError]. If the input context
+// is a nil value, the output will also be a nil value. Values that implement
+// [MemberResolver] resolve the member themselves. If the policy allows it, a
+// member that is not a field is read from a getter method of the value.
 //
 // If the field does not exist in the input context, an [errs.NameError] is
-// returned.
+// returned, unless the policy evaluates missing members to null.
 type MemberExpr string
 
 // Member returns a [MemberExpr] with the given name.
@@ -24,38 +29,64 @@ func Member(name string) MemberExpr {
 // Eval evaluates the member expression. It returns the value of the member
 // field in the current context.
 func (e MemberExpr) Eval(ctx *Context) (reflect.Value, error) {
-	rv := reflectconv.Deref(ctx.Current)
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
+	return e.Apply(ctx, ctx.Current)
+}
+
+// Apply accesses the member of the given value.
+func (e MemberExpr) Apply(ctx *Context, current reflect.Value) (reflect.Value, error) {
+	rv := reflectconv.Deref(current)
 	if reflectconv.IsNil(rv) {
 		return reflect.Value{}, nil
 	}
+	if r, ok := resolverOf[MemberResolver](current); ok {
+		return resolveMember(ctx, r, string(e))
+	}
 
 	rv = reflectconv.Deref(rv)
 	rt := rv.Type()
 	switch rt.Kind() {
 	case reflect.Map:
-		return e.evalMap(rv)
+		return e.evalMap(ctx, rv)
 	case reflect.Struct:
-		return e.evalStruct(rv, rt)
+		return e.evalStruct(ctx, rv, rt)
 	case reflect.Slice, reflect.Array:
-		return e.evalSlice(rv)
+		return e.evalSlice(ctx, rv)
+	}
+	if value, ok, err := e.evalGetter(ctx, rv); ok {
+		return value, err
+	}
+	return e.missing(ctx, ctx.Members().MemberNames(rt))
+}
+
+// missing returns the result of a member that does not exist, which is null if
+// the policy of [Context.Members] allows missing members, or else an
+// [errs.NameError] with suggestions from the given names.
+func (e MemberExpr) missing(ctx *Context, names iter.Seq[string]) (reflect.Value, error) {
+	if ctx.Members().AllowsMissing() {
+		return reflect.Value{}, nil
 	}
-	return reflect.Value{}, errs.NewNameError(string(e), slices.Values([]string{}))
+	return reflect.Value{}, errs.NewNameError(string(e), names)
 }
 
-func (e MemberExpr) evalMap(rv reflect.Value) (reflect.Value, error) {
+func (e MemberExpr) evalMap(ctx *Context, rv reflect.Value) (reflect.Value, error) {
 	if rv.IsNil() {
 		return reflect.Value{}, nil
 	}
 	keyType := rv.Type().Key()
 	if keyType.Kind() != reflect.String {
-		return reflect.Value{}, errs.NewNameError(string(e), slices.Values([]string{}))
+		return e.missing(ctx, slices.Values([]string{}))
 	}
 
 	key := reflect.ValueOf(string(e)).Convert(keyType)
 	value := rv.MapIndex(key)
 	if !value.IsValid() {
 		keys := e.mapKeys(rv)
-		return reflect.Value{}, errs.NewNameError(string(e), slices.Values(keys))
+		return e.missing(ctx, slices.Values(keys))
 	}
 
 	return value, nil
@@ -72,73 +103,87 @@ func (e MemberExpr) mapKeys(rv reflect.Value) []string {
 	return result
 }
 
-func (e MemberExpr) evalStruct(rv reflect.Value, rt reflect.Type) (reflect.Value, error) {
-	numFields := rt.NumField()
-	if numFields == 0 {
+func (e MemberExpr) evalStruct(ctx *Context, rv reflect.Value, rt reflect.Type) (reflect.Value, error) {
+	policy := ctx.Members()
+	field, ok, err := policy.Lookup(rt, string(e))
+	if ok {
+		value, err := rv.FieldByIndexErr(field.Index)
+		if err != nil {
+			// The field is promoted through a nil embedded pointer.
+			return reflect.Value{}, nil
+		}
+		return value, nil
+	}
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if value, ok, err := e.evalGetter(ctx, rv); ok {
+		return value, err
+	}
+	if rt.NumField() == 0 {
 		return reflect.Value{}, nil
 	}
+	return e.missing(ctx, policy.MemberNames(rt))
+}
 
-	var names []string
-	for i := range numFields {
-		field := rt.Field(i)
-		if !field.IsExported() {
-			continue
-		}
-
-		tag := field.Tag.Get("dcell")
-		if tag == "" {
-			tag = field.Name
-		}
-		if tag == string(e) {
-			rfield := rv.Field(i)
-			return rfield, nil
-		}
-		names = append(names, tag)
+// evalGetter reads the member from the getter of rv that the policy of
+// [Context.Members] resolves it to. It returns false if there is no such
+// getter.
+func (e MemberExpr) evalGetter(ctx *Context, rv reflect.Value) (reflect.Value, bool, error) {
+	getter, ok := ctx.Members().Getter(rv.Type(), string(e))
+	if !ok {
+		return reflect.Value{}, false, nil
+	}
+	recv, ok := receiver(rv, getter)
+	if !ok {
+		return reflect.Value{}, true, nil
+	}
+	out := getter.Func.Call([]reflect.Value{recv})
+	if len(out) == 2 && !out[1].IsNil() {
+		return reflect.Value{}, true, out[1].Interface().(error)
 	}
-	return reflect.Value{}, errs.NewNameError(string(e), slices.Values(names))
+	return out[0], true, nil
 }
 
-func (e MemberExpr) evalSlice(rv reflect.Value) (reflect.Value, error) {
+func (e MemberExpr) evalSlice(ctx *Context, rv reflect.Value) (reflect.Value, error) {
+	if err := ctx.CheckSliceLength(rv.Len()); err != nil {
+		return reflect.Value{}, err
+	}
 	var entries []reflect.Value
 	for i := range rv.Len() {
-		entry := rv.Index(i)
-		entry = reflectconv.Deref(entry)
-		switch entry.Kind() {
-		case reflect.Map:
-			value, err := e.evalMap(entry)
-			if err != nil {
-				return reflect.Value{}, err
-			}
-			entries = append(entries, value)
-		case reflect.Struct:
-			value, err := e.evalStruct(entry, entry.Type())
-			if err != nil {
-				return reflect.Value{}, err
-			}
-			entries = append(entries, value)
-		default:
-			return reflect.Value{}, errs.NewNameError(string(e), slices.Values([]string{}))
+		value, err := e.evalEntry(ctx, rv.Index(i))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if !value.IsValid() {
+			value = reflect.Zero(reflect.TypeFor[any]())
 		}
+		entries = append(entries, value)
 	}
 	if len(entries) == 0 {
 		return reflect.Value{}, nil
 	}
-	sliceType := e.computeSliceType(entries)
-	slice := reflect.MakeSlice(sliceType, 0, len(entries))
+	slice := reflect.MakeSlice(reflect.SliceOf(commonType(entries)), 0, len(entries))
 	for _, entry := range entries {
 		slice = reflect.Append(slice, entry)
 	}
 	return slice, nil
 }
 
-func (e MemberExpr) computeSliceType(entries []reflect.Value) reflect.Type {
-	current := entries[0].Type()
-	for _, field := range entries[1:] {
-		if field.Type() != current {
-			return reflect.TypeFor[[]any]()
-		}
+// evalEntry accesses the member of an entry of a slice or array that the
+// member is projected over.
+func (e MemberExpr) evalEntry(ctx *Context, entry reflect.Value) (reflect.Value, error) {
+	if r, ok := resolverOf[MemberResolver](entry); ok && !reflectconv.IsNil(entry) {
+		return resolveMember(ctx, r, string(e))
+	}
+	entry = reflectconv.Deref(entry)
+	switch entry.Kind() {
+	case reflect.Map:
+		return e.evalMap(ctx, entry)
+	case reflect.Struct:
+		return e.evalStruct(ctx, entry, entry.Type())
 	}
-	return reflect.SliceOf(current)
+	return e.missing(ctx, slices.Values([]string{}))
 }
 
 var _ Expr = (*MemberExpr)(nil)
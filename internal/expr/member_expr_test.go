@@ -8,6 +8,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"rodusek.dev/pkg/dcell/internal/errs"
 	"rodusek.dev/pkg/dcell/internal/expr"
+	"rodusek.dev/pkg/dcell/internal/members"
 	"rodusek.dev/pkg/dcell/internal/reflectcmp"
 )
 
@@ -100,12 +101,43 @@ func TestMemberExpr(t *testing.T) {
 				},
 			},
 			want: []string{"bar"},
+		}, {
+			name: "Input is struct, field is hidden",
+			input: struct {
+				Foo string `dcell:"-"`
+			}{
+				Foo: "bar",
+			},
+			wantErr: errs.ErrUnknownName,
+		}, {
+			name:  "Input is resolver, member exists",
+			input: document{"foo": "bar"},
+			want:  "bar",
+		}, {
+			name:  "Input is resolver, member is nil",
+			input: document{"foo": nil},
+			want:  nil,
+		}, {
+			name:    "Input is resolver, member does not exist",
+			input:   document{"bar": "baz"},
+			wantErr: errs.ErrUnknownName,
+		}, {
+			name:  "Input is pointer to resolver with pointer receiver",
+			input: &lazy{},
+			want:  "foo!",
+		}, {
+			name:  "Input is slice of resolvers",
+			input: []any{document{"foo": "bar"}, &lazy{}, map[string]any{"foo": 1}},
+			want:  []any{"bar", "foo!", 1},
+		}, {
+			name:    "Input is slice of resolvers, member does not exist",
+			input:   []document{{"foo": 1}, {}},
+			wantErr: errs.ErrUnknownName,
 		},
 	}
 
 	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
 			sut := expr.Member(member)
 			input := expr.NewContext(reflect.ValueOf(tc.input))
 			var expect reflect.Value
@@ -113,7 +145,7 @@ func TestMemberExpr(t *testing.T) {
 				expect = reflect.ValueOf(tc.want)
 			}
 
-			got, err := sut.Eval(input)
+			got, err := backend.Eval(sut, input)
 
 			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
 				t.Errorf("MemberEval(%q) error = %v, want %v", member, got, want)
@@ -124,3 +156,204 @@ func TestMemberExpr(t *testing.T) {
 		})
 	}
 }
+
+func TestMemberExpr_Policy(t *testing.T) {
+	t.Parallel()
+	type input struct {
+		PullRequest string `json:"pull_request"`
+	}
+
+	testCases := []struct {
+		name    string
+		member  string
+		policy  *members.Policy
+		want    any
+		wantErr error
+	}{
+		{
+			name:    "Default policy",
+			member:  "pull_request",
+			wantErr: errs.ErrUnknownName,
+		}, {
+			name:   "Fallback tag",
+			member: "pull_request",
+			policy: &members.Policy{Tags: []string{"dcell", "json"}},
+			want:   "title",
+		}, {
+			name:   "Case and separators folded",
+			member: "Pull-Request",
+			policy: &members.Policy{Tags: []string{"json"}, IgnoreCase: true, FoldSeparators: true},
+			want:   "title",
+		},
+	}
+
+	for _, tc := range testCases {
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
+			sut := expr.Member(tc.member)
+			ctx := expr.NewContext(reflect.ValueOf(input{PullRequest: "title"})).WithMembers(tc.policy)
+			var expect reflect.Value
+			if tc.want != nil {
+				expect = reflect.ValueOf(tc.want)
+			}
+
+			got, err := backend.Eval(sut, ctx)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("MemberEval(%q) error = %v, want %v", tc.member, got, want)
+			}
+			if got, want := got, expect; !reflectcmp.Equal(got, want) {
+				t.Errorf("MemberEval(%q) = %v, want %v", tc.member, got, want)
+			}
+		})
+	}
+}
+
+type Account struct {
+	Login string `dcell:"login"`
+	ID    int    `dcell:"id"`
+}
+
+type Team struct {
+	ID   int    `dcell:"id"`
+	Slug string `dcell:"slug"`
+}
+
+type Moderator struct {
+	Account
+	*Team
+	Level int `dcell:"level"`
+}
+
+func TestMemberExpr_Embedded(t *testing.T) {
+	t.Parallel()
+	input := Moderator{
+		Account: Account{Login: "octocat", ID: 1},
+		Team:    &Team{ID: 2, Slug: "core"},
+		Level:   3,
+	}
+
+	testCases := []struct {
+		name    string
+		input   any
+		member  string
+		want    any
+		wantErr error
+	}{
+		{
+			name:   "Field promoted from embedded struct",
+			input:  input,
+			member: "login",
+			want:   "octocat",
+		}, {
+			name:   "Field promoted from embedded pointer",
+			input:  input,
+			member: "slug",
+			want:   "core",
+		}, {
+			name:   "Field promoted from nil embedded pointer",
+			input:  Moderator{},
+			member: "slug",
+		}, {
+			name:   "Embedded struct by name",
+			input:  input,
+			member: "Account",
+			want:   Account{Login: "octocat", ID: 1},
+		}, {
+			name:    "Ambiguous promoted field",
+			input:   input,
+			member:  "id",
+			wantErr: errs.ErrAmbiguousName,
+		}, {
+			name:   "Promoted fields over slice",
+			input:  []Moderator{input, {Account: Account{Login: "hubot"}}},
+			member: "login",
+			want:   []string{"octocat", "hubot"},
+		},
+	}
+
+	for _, tc := range testCases {
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
+			sut := expr.Member(tc.member)
+			ctx := expr.NewContext(reflect.ValueOf(tc.input))
+			var expect reflect.Value
+			if tc.want != nil {
+				expect = reflect.ValueOf(tc.want)
+			}
+
+			got, err := backend.Eval(sut, ctx)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("MemberEval(%q) error = %v, want %v", tc.member, got, want)
+			}
+			if got, want := got, expect; !reflectcmp.Equal(got, want) {
+				t.Errorf("MemberEval(%q) = %v, want %v", tc.member, got, want)
+			}
+		})
+	}
+}
+
+func TestMemberExpr_MissingAsNull(t *testing.T) {
+	t.Parallel()
+	type input struct {
+		Title string `dcell:"title"`
+	}
+
+	testCases := []struct {
+		name   string
+		input  any
+		member string
+		want   any
+	}{
+		{
+			name:   "Map key does not exist",
+			input:  map[string]any{"title": "fix"},
+			member: "label",
+		}, {
+			name:   "Map key is not a string",
+			input:  map[int]any{1: "fix"},
+			member: "label",
+		}, {
+			name:   "Struct field does not exist",
+			input:  input{Title: "fix"},
+			member: "label",
+		}, {
+			name:   "Resolver member does not exist",
+			input:  document{"title": "fix"},
+			member: "label",
+		}, {
+			name:   "Scalar has no members",
+			input:  42,
+			member: "label",
+		}, {
+			name:   "Member exists",
+			input:  map[string]any{"label": "bug"},
+			member: "label",
+			want:   "bug",
+		}, {
+			name:   "Member missing from some entries of slice",
+			input:  []any{map[string]any{"label": "bug"}, map[string]any{}},
+			member: "label",
+			want:   []any{"bug", nil},
+		},
+	}
+
+	for _, tc := range testCases {
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
+			sut := expr.Member(tc.member)
+			ctx := expr.NewContext(reflect.ValueOf(tc.input)).WithMembers(&members.Policy{MissingAsNull: true})
+
+			got, err := backend.Eval(sut, ctx)
+
+			if err != nil {
+				t.Fatalf("MemberEval(%q) error = %v, want nil", tc.member, err)
+			}
+			var value any
+			if got.IsValid() {
+				value = got.Interface()
+			}
+			if got, want := value, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("MemberEval(%q) = %v, want %v", tc.member, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package expr
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"rodusek.dev/pkg/dcell/internal/errs"
+	"rodusek.dev/pkg/dcell/internal/invocation"
+	"rodusek.dev/pkg/dcell/internal/reflectconv"
+)
+
+// MethodExpr is an expression that calls an exported Go method of the current
+// value, for member function calls that match no function of the function
+// table. Only the methods of types allowed by the policy of [Context.Members]
+// may be called, and arguments are converted as for functions added with
+// [invocation.Table.AddFunc]. If the current value is nil, the output will
+// also be nil.
+//
+// If the method does not exist, or the methods of the current value may not
+// be called, an [errs.NameError] is returned.
+type MethodExpr struct {
+	// Name is the Go name of the method.
+	Name string
+
+	Args []Expr
+}
+
+// Method returns a [MethodExpr] that calls the named method with the given
+// arguments.
+func Method(name string, args ...Expr) *MethodExpr {
+	return &MethodExpr{
+		Name: name,
+		Args: args,
+	}
+}
+
+// Eval evaluates the method expression. It returns the result of calling the
+// method on the current value.
+func (e *MethodExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
+	rv := unwrapInterface(ctx.Current)
+	if reflectconv.IsNil(rv) {
+		return reflect.Value{}, nil
+	}
+	policy := ctx.Members()
+	method, ok := policy.Method(rv.Type(), e.Name)
+	if !ok {
+		return reflect.Value{}, errs.NewNameError(e.Name, policy.MethodNames(rv.Type()))
+	}
+	recv, ok := receiver(rv, method)
+	if !ok {
+		return reflect.Value{}, nil
+	}
+	entry, err := methodEntry(method)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	args := make([]reflect.Value, 0, len(e.Args)+1)
+	args = append(args, recv)
+	for _, arg := range e.Args {
+		result, err := arg.Eval(ctx)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		args = append(args, result)
+	}
+	got, err := entry.InvokeContext(ctx.GoContext(), args...)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if err := ctx.CheckSize(got); err != nil {
+		return reflect.Value{}, err
+	}
+	return got, nil
+}
+
+// unwrapInterface returns the value that an interface value holds.
+func unwrapInterface(rv reflect.Value) reflect.Value {
+	for rv.Kind() == reflect.Interface && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	return rv
+}
+
+// receiver returns the receiver of a method of the pointer type of rv, which
+// is rv itself if it is such a pointer, or else its address. Values that are
+// not addressable are copied, so that methods with pointer receivers cannot
+// modify the value. It returns false if rv is a nil pointer.
+func receiver(rv reflect.Value, method reflect.Method) (reflect.Value, bool) {
+	want := method.Type.In(0)
+	for rv.Type() != want && rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return reflect.Value{}, false
+		}
+		rv = rv.Elem()
+	}
+	switch {
+	case rv.Type() == want:
+		return rv, !rv.IsNil()
+	case rv.CanAddr():
+		return rv.Addr(), true
+	}
+	ptr := reflect.New(rv.Type())
+	ptr.Elem().Set(rv)
+	return ptr, true
+}
+
+type methodKey struct {
+	recv reflect.Type
+	name string
+}
+
+// methodEntries caches the function entries of methods, keyed by methodKey.
+var methodEntries sync.Map
+
+// methodEntry returns the function entry that calls the method, whose first
+// argument is the receiver.
+func methodEntry(method reflect.Method) (*invocation.Entry, error) {
+	key := methodKey{recv: method.Type.In(0), name: method.Name}
+	if entry, ok := methodEntries.Load(key); ok {
+		return entry.(*invocation.Entry), nil
+	}
+	entry, err := invocation.NewFunc(method.Func.Interface())
+	if err != nil {
+		return nil, fmt.Errorf("method %s: %w", method.Name, err)
+	}
+	methodEntries.Store(key, entry)
+	return entry, nil
+}
+
+var _ Expr = (*MethodExpr)(nil)
@@ -0,0 +1,264 @@
+package expr_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"rodusek.dev/pkg/dcell/internal/errs"
+	"rodusek.dev/pkg/dcell/internal/expr"
+	"rodusek.dev/pkg/dcell/internal/expr/exprtest"
+	"rodusek.dev/pkg/dcell/internal/invocation"
+	"rodusek.dev/pkg/dcell/internal/members"
+	"rodusek.dev/pkg/dcell/internal/reflectcmp"
+)
+
+var errBelowZero = errors.New("below absolute zero")
+
+type temperature struct {
+	Celsius float64
+}
+
+func (t temperature) Fahrenheit() float64 {
+	return t.Celsius*9/5 + 32
+}
+
+func (t temperature) Add(delta float64) temperature {
+	return temperature{Celsius: t.Celsius + delta}
+}
+
+func (t temperature) Check() (bool, error) {
+	if t.Celsius < -273.15 {
+		return false, errBelowZero
+	}
+	return true, nil
+}
+
+func (t *temperature) Reset() float64 {
+	t.Celsius = 0
+	return t.Celsius
+}
+
+func (t *temperature) GetKelvin() float64 {
+	return t.Celsius + 273.15
+}
+
+func (t temperature) GetValid() (bool, error) {
+	return t.Check()
+}
+
+func TestMethodExpr_Eval(t *testing.T) {
+	t.Parallel()
+	allowed := &members.Policy{
+		Methods: &members.TypeSet{Types: []reflect.Type{reflect.TypeFor[temperature]()}},
+	}
+
+	testCases := []struct {
+		name    string
+		input   any
+		policy  *members.Policy
+		method  string
+		args    []expr.Expr
+		want    any
+		wantErr error
+	}{
+		{
+			name:   "Value receiver",
+			input:  temperature{Celsius: 100},
+			policy: allowed,
+			method: "Fahrenheit",
+			want:   212.0,
+		}, {
+			name:   "Pointer to value receiver",
+			input:  &temperature{Celsius: 100},
+			policy: allowed,
+			method: "Fahrenheit",
+			want:   212.0,
+		}, {
+			name:   "Pointer receiver on value",
+			input:  temperature{Celsius: 100},
+			policy: allowed,
+			method: "Reset",
+			want:   0.0,
+		}, {
+			name:   "Method with arguments",
+			input:  temperature{Celsius: 100},
+			policy: allowed,
+			method: "Add",
+			args:   []expr.Expr{exprtest.Float(5.0)},
+			want:   temperature{Celsius: 105},
+		}, {
+			name:    "Method returns error",
+			input:   temperature{Celsius: -300},
+			policy:  allowed,
+			method:  "Check",
+			wantErr: errBelowZero,
+		}, {
+			name:    "Argument of wrong type",
+			input:   temperature{Celsius: 100},
+			policy:  allowed,
+			method:  "Add",
+			args:    []expr.Expr{exprtest.String("5")},
+			wantErr: invocation.ErrBadArgument,
+		}, {
+			name:    "Wrong number of arguments",
+			input:   temperature{Celsius: 100},
+			policy:  allowed,
+			method:  "Fahrenheit",
+			args:    []expr.Expr{exprtest.Float(5.0)},
+			wantErr: cmpopts.AnyError,
+		}, {
+			name:    "Unknown method",
+			input:   temperature{Celsius: 100},
+			policy:  allowed,
+			method:  "Celsius",
+			wantErr: errs.ErrUnknownName,
+		}, {
+			name:    "Methods not allowed",
+			input:   temperature{Celsius: 100},
+			method:  "Fahrenheit",
+			wantErr: errs.ErrUnknownName,
+		}, {
+			name:    "Type not allowed",
+			input:   struct{ temperature }{temperature{Celsius: 100}},
+			policy:  allowed,
+			method:  "Fahrenheit",
+			wantErr: errs.ErrUnknownName,
+		}, {
+			name:   "Nil input",
+			input:  (*temperature)(nil),
+			policy: allowed,
+			method: "Fahrenheit",
+		},
+	}
+
+	for _, tc := range testCases {
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
+			sut := expr.Method(tc.method, tc.args...)
+			ctx := expr.NewContext(reflect.ValueOf(tc.input)).WithMembers(tc.policy)
+			var expect reflect.Value
+			if tc.want != nil {
+				expect = reflect.ValueOf(tc.want)
+			}
+
+			got, err := backend.Eval(sut, ctx)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("MethodExpr.Eval() error = %v, want %v", got, want)
+			}
+			if got, want := got, expect; !reflectcmp.Equal(got, want) {
+				t.Errorf("MethodExpr.Eval() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestMethodExpr_Eval_DoesNotModifyInput(t *testing.T) {
+	t.Parallel()
+	input := temperature{Celsius: 100}
+	policy := &members.Policy{Methods: &members.TypeSet{All: true}}
+	sut := expr.Method("Reset")
+
+	if _, err := sut.Eval(expr.NewContext(reflect.ValueOf(input)).WithMembers(policy)); err != nil {
+		t.Fatalf("MethodExpr.Eval() error = %v", err)
+	}
+
+	if got, want := input.Celsius, 100.0; got != want {
+		t.Errorf("MethodExpr.Eval() modified input to %v, want %v", got, want)
+	}
+}
+
+func TestMemberExpr_Getters(t *testing.T) {
+	t.Parallel()
+	allowed := &members.Policy{
+		Getters: &members.TypeSet{Types: []reflect.Type{reflect.TypeFor[temperature]()}},
+	}
+
+	testCases := []struct {
+		name    string
+		input   any
+		policy  *members.Policy
+		member  string
+		want    any
+		wantErr error
+	}{
+		{
+			name:   "Getter with pointer receiver",
+			input:  temperature{Celsius: 0},
+			policy: allowed,
+			member: "Kelvin",
+			want:   273.15,
+		}, {
+			name:   "Getter with lower case name",
+			input:  &temperature{Celsius: 0},
+			policy: allowed,
+			member: "kelvin",
+			want:   273.15,
+		}, {
+			name:    "Getter returns error",
+			input:   temperature{Celsius: -300},
+			policy:  allowed,
+			member:  "valid",
+			wantErr: errBelowZero,
+		}, {
+			name:   "Field takes precedence",
+			input:  temperature{Celsius: 10},
+			policy: allowed,
+			member: "Celsius",
+			want:   10.0,
+		}, {
+			name:   "Over slice",
+			input:  []temperature{{Celsius: 0}, {Celsius: 100}},
+			policy: allowed,
+			member: "kelvin",
+			want:   []float64{273.15, 373.15},
+		}, {
+			name:    "Getters not allowed",
+			input:   temperature{Celsius: 0},
+			member:  "kelvin",
+			wantErr: errs.ErrUnknownName,
+		},
+	}
+
+	for _, tc := range testCases {
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
+			sut := expr.Member(tc.member)
+			ctx := expr.NewContext(reflect.ValueOf(tc.input)).WithMembers(tc.policy)
+			var expect reflect.Value
+			if tc.want != nil {
+				expect = reflect.ValueOf(tc.want)
+			}
+
+			got, err := backend.Eval(sut, ctx)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("MemberExpr.Eval(%q) error = %v, want %v", tc.member, got, want)
+			}
+			if got, want := got, expect; !reflectcmp.Equal(got, want) {
+				t.Errorf("MemberExpr.Eval(%q) = %v, want %v", tc.member, got, want)
+			}
+		})
+	}
+}
+
+func TestMemberExpr_GetterSuggestions(t *testing.T) {
+	t.Parallel()
+	policy := &members.Policy{Getters: &members.TypeSet{All: true}}
+
+	runBackends(t, "Suggestions from getters", func(t *testing.T, backend backend) {
+		sut := expr.Member("Kelvn")
+		input := expr.NewContext(reflect.ValueOf(temperature{})).WithMembers(policy)
+
+		_, err := backend.Eval(sut, input)
+
+		var nameErr *errs.NameError
+		if !errors.As(err, &nameErr) {
+			t.Fatalf("MemberExpr.Eval() error = %v, want NameError", err)
+		}
+		if got, want := nameErr.Suggestions, []string{"Kelvin"}; !cmp.Equal(got, want) {
+			t.Errorf("MemberExpr.Eval() suggestions = %v, want %v", got, want)
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"math"
 	"reflect"
 
+	"rodusek.dev/pkg/dcell/internal/errs"
 	"rodusek.dev/pkg/dcell/internal/reflectconv"
 )
 
@@ -20,10 +21,21 @@ func Multiply(left, right Expr) *MultiplyExpr {
 }
 
 func (e *MultiplyExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	lhs, rhs, err := evalTwo(ctx, e.Left, e.Right)
 	if err != nil {
 		return reflect.Value{}, err
 	}
+	return e.Apply(ctx, lhs, rhs)
+}
+
+// Apply applies the operation to the evaluated operands, which must already
+// be dereferenced.
+func (e *MultiplyExpr) Apply(ctx *Context, lhs, rhs reflect.Value) (reflect.Value, error) {
 	if reflectconv.IsFloat(lhs.Type()) || reflectconv.IsFloat(rhs.Type()) {
 		floats, err := reflectconv.Float64s(lhs, rhs)
 		if err != nil {
@@ -57,10 +69,21 @@ func Divide(left, right Expr) *DivideExpr {
 }
 
 func (e *DivideExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	lhs, rhs, err := evalTwo(ctx, e.Left, e.Right)
 	if err != nil {
 		return reflect.Value{}, err
 	}
+	return e.Apply(ctx, lhs, rhs)
+}
+
+// Apply applies the operation to the evaluated operands, which must already
+// be dereferenced.
+func (e *DivideExpr) Apply(ctx *Context, lhs, rhs reflect.Value) (reflect.Value, error) {
 	if reflectconv.IsFloat(lhs.Type()) || reflectconv.IsFloat(rhs.Type()) {
 		floats, err := reflectconv.Float64s(lhs, rhs)
 		if err != nil {
@@ -68,7 +91,7 @@ func (e *DivideExpr) Eval(ctx *Context) (reflect.Value, error) {
 		}
 		lhsFloat, rhsFloat := floats[0], floats[1]
 		if rhsFloat == 0 {
-			return reflect.Value{}, fmt.Errorf("division by zero")
+			return reflect.Value{}, errs.ErrDivisionByZero
 		}
 		return reflect.ValueOf(lhsFloat / rhsFloat), nil
 	}
@@ -78,7 +101,7 @@ func (e *DivideExpr) Eval(ctx *Context) (reflect.Value, error) {
 	}
 	lhsInt, rhsInt := ints[0], ints[1]
 	if rhsInt == 0 {
-		return reflect.Value{}, fmt.Errorf("division by zero")
+		return reflect.Value{}, errs.ErrDivisionByZero
 	}
 	return reflect.ValueOf(lhsInt / rhsInt), nil
 }
@@ -97,10 +120,21 @@ func FloorDivide(left, right Expr) *FloorDivideExpr {
 }
 
 func (e *FloorDivideExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	lhs, rhs, err := evalTwo(ctx, e.Left, e.Right)
 	if err != nil {
 		return reflect.Value{}, err
 	}
+	return e.Apply(ctx, lhs, rhs)
+}
+
+// Apply applies the operation to the evaluated operands, which must already
+// be dereferenced.
+func (e *FloorDivideExpr) Apply(ctx *Context, lhs, rhs reflect.Value) (reflect.Value, error) {
 	if reflectconv.IsFloat(lhs.Type()) || reflectconv.IsFloat(rhs.Type()) {
 		floats, err := reflectconv.Float64s(lhs, rhs)
 		if err != nil {
@@ -108,7 +142,7 @@ func (e *FloorDivideExpr) Eval(ctx *Context) (reflect.Value, error) {
 		}
 		lhsFloat, rhsFloat := floats[0], floats[1]
 		if rhsFloat == 0 {
-			return reflect.Value{}, fmt.Errorf("division by zero")
+			return reflect.Value{}, errs.ErrDivisionByZero
 		}
 		return reflect.ValueOf(math.Floor(lhsFloat / rhsFloat)), nil
 	}
@@ -118,7 +152,7 @@ func (e *FloorDivideExpr) Eval(ctx *Context) (reflect.Value, error) {
 	}
 	lhsInt, rhsInt := ints[0], ints[1]
 	if rhsInt == 0 {
-		return reflect.Value{}, fmt.Errorf("division by zero")
+		return reflect.Value{}, errs.ErrDivisionByZero
 	}
 	return reflect.ValueOf(lhsInt / rhsInt), nil
 }
@@ -140,10 +174,21 @@ func Modulus(left, right Expr) *ModulusExpr {
 
 // Eval evaluates the ModulusExpr.
 func (e *ModulusExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	lhs, rhs, err := evalTwo(ctx, e.Left, e.Right)
 	if err != nil {
 		return reflect.Value{}, err
 	}
+	return e.Apply(ctx, lhs, rhs)
+}
+
+// Apply applies the operation to the evaluated operands, which must already
+// be dereferenced.
+func (e *ModulusExpr) Apply(ctx *Context, lhs, rhs reflect.Value) (reflect.Value, error) {
 	if reflectconv.IsFloat(lhs.Type()) || reflectconv.IsFloat(rhs.Type()) {
 		floats, err := reflectconv.Float64s(lhs, rhs)
 		if err != nil {
@@ -151,7 +196,7 @@ func (e *ModulusExpr) Eval(ctx *Context) (reflect.Value, error) {
 		}
 		lhsFloat, rhsFloat := floats[0], floats[1]
 		if rhsFloat == 0 {
-			return reflect.Value{}, fmt.Errorf("modulo by zero")
+			return reflect.Value{}, errs.ErrDivisionByZero
 		}
 		return reflect.ValueOf(math.Mod(lhsFloat, rhsFloat)), nil
 	}
@@ -161,7 +206,7 @@ func (e *ModulusExpr) Eval(ctx *Context) (reflect.Value, error) {
 	}
 	lhsInt, rhsInt := ints[0], ints[1]
 	if rhsInt == 0 {
-		return reflect.Value{}, fmt.Errorf("modulo by zero")
+		return reflect.Value{}, errs.ErrDivisionByZero
 	}
 	return reflect.ValueOf(lhsInt % rhsInt), nil
 }
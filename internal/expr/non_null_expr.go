@@ -0,0 +1,57 @@
+package expr
+
+import (
+	"reflect"
+
+	"rodusek.dev/pkg/dcell/internal/errs"
+	"rodusek.dev/pkg/dcell/internal/reflectconv"
+)
+
+// NonNullExpr is an expression that requires the result of another expression
+// to not be null, for expressions that are navigated through with `.`, `[]`,
+// or `*` when compiled with strict nulls.
+//
+// If the result is null, an [errs.NullError] with the path of the expression
+// is returned.
+type NonNullExpr struct {
+	Expr Expr
+
+	// Path is the source text of the expression, such as
+	// `event.pull_request`.
+	Path string
+}
+
+// NonNull returns a [NonNullExpr] that requires the result of e, whose source
+// text is path, to not be null.
+func NonNull(e Expr, path string) *NonNullExpr {
+	return &NonNullExpr{
+		Expr: e,
+		Path: path,
+	}
+}
+
+// Eval evaluates the expression. It returns the result of the expression if it
+// is not null.
+func (e *NonNullExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
+	result, err := e.Expr.Eval(ctx)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return e.Apply(ctx, result)
+}
+
+// Apply returns the result of the expression, or an [errs.NullError] if it is
+// null.
+func (e *NonNullExpr) Apply(_ *Context, result reflect.Value) (reflect.Value, error) {
+	if reflectconv.IsNil(result) {
+		return reflect.Value{}, &errs.NullError{Path: e.Path}
+	}
+	return result, nil
+}
+
+var _ Expr = (*NonNullExpr)(nil)
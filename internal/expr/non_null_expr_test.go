@@ -0,0 +1,100 @@
+package expr_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"rodusek.dev/pkg/dcell/internal/errs"
+	"rodusek.dev/pkg/dcell/internal/expr"
+	"rodusek.dev/pkg/dcell/internal/expr/exprtest"
+	"rodusek.dev/pkg/dcell/internal/reflectcmp"
+)
+
+func TestNonNullExpr_Eval(t *testing.T) {
+	t.Parallel()
+	testErr := errors.New("test error")
+	type user struct {
+		Manager *user `dcell:"manager"`
+		Name    string
+	}
+	testCases := []struct {
+		name    string
+		input   any
+		sut     expr.Expr
+		want    reflect.Value
+		wantErr error
+	}{
+		{
+			name:  "Result is not null",
+			input: user{Manager: &user{Name: "Alice"}},
+			sut:   expr.Sequence(expr.NonNull(expr.Member("manager"), "manager"), expr.Member("Name")),
+			want:  reflect.ValueOf("Alice"),
+		}, {
+			name:  "Result is zero but not null",
+			input: map[string]int{"count": 0},
+			sut:   expr.NonNull(expr.Member("count"), "count"),
+			want:  reflect.ValueOf(0),
+		}, {
+			name:    "Result is nil pointer",
+			input:   user{},
+			sut:     expr.Sequence(expr.NonNull(expr.Member("manager"), "manager"), expr.Member("Name")),
+			wantErr: errs.ErrNullNavigation,
+		}, {
+			name:    "Result is missing",
+			input:   nil,
+			sut:     expr.NonNull(expr.Member("manager"), "manager"),
+			wantErr: errs.ErrNullNavigation,
+		}, {
+			name:    "Expression returns error",
+			input:   user{},
+			sut:     expr.NonNull(exprtest.Error(testErr), "manager"),
+			wantErr: testErr,
+		}, {
+			name:  "Unchecked step of sequence stops at null",
+			input: user{},
+			sut: expr.Sequence(
+				expr.Member("manager"),
+				expr.NonNull(expr.Member("manager"), "manager?.manager"),
+				expr.Member("Name"),
+			),
+			want: reflect.Value{},
+		},
+	}
+
+	for _, tc := range testCases {
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
+			ctx := expr.NewContext(reflect.ValueOf(tc.input))
+
+			got, err := backend.Eval(tc.sut, ctx)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("NonNullExpr.Eval() error = %v, want %v", got, want)
+			}
+			if got, want := got, tc.want; !reflectcmp.Equal(got, want) {
+				t.Errorf("NonNullExpr.Eval() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestNonNullExpr_Path(t *testing.T) {
+	t.Parallel()
+
+	runBackends(t, "Error has path", func(t *testing.T, backend backend) {
+		sut := expr.Sequence(expr.NonNull(expr.Member("pull_request"), "event.pull_request"), expr.Member("title"))
+		ctx := expr.NewContext(reflect.ValueOf(map[string]any{"pull_request": nil}))
+
+		_, err := backend.Eval(sut, ctx)
+
+		var nullErr *errs.NullError
+		if !errors.As(err, &nullErr) {
+			t.Fatalf("NonNullExpr.Eval() error = %v, want NullError", err)
+		}
+		if got, want := nullErr.Path, "event.pull_request"; got != want {
+			t.Errorf("NonNullExpr.Eval() path = %v, want %v", got, want)
+		}
+	})
+}
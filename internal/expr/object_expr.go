@@ -0,0 +1,74 @@
+package expr
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// ObjectExpr is an expression that builds an object from the results of its
+// values, such as `{name: user.login, id: user.id}`.
+//
+// The object is a map from string keys to the type of the values if they all
+// have the same type, such as map[string]string, or to any otherwise. Null
+// values are of type any.
+type ObjectExpr struct {
+	// Keys are the keys of the object, in the order of Values.
+	Keys []string
+
+	Values []Expr
+}
+
+// Object returns an [ObjectExpr] that builds an object from the given keys
+// and values, which must be of the same length.
+func Object(keys []string, values []Expr) *ObjectExpr {
+	return &ObjectExpr{
+		Keys:   keys,
+		Values: values,
+	}
+}
+
+// Eval evaluates the object expression. It returns the object of the results
+// of the values.
+func (e *ObjectExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
+	values := make([]reflect.Value, 0, len(e.Values))
+	for _, value := range e.Values {
+		result, err := value.Eval(ctx)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		values = append(values, result)
+	}
+	return e.Build(ctx.GoContext(), values...)
+}
+
+// Build returns the object of the given results of the values.
+func (e *ObjectExpr) Build(_ context.Context, values ...reflect.Value) (reflect.Value, error) {
+	if len(values) != len(e.Keys) {
+		return reflect.Value{}, fmt.Errorf("object of %d keys built from %d values", len(e.Keys), len(values))
+	}
+	return MakeObject(e.Keys, values), nil
+}
+
+// MakeObject returns an object of the given keys and values, which must be of
+// the same length, as built by an [ObjectExpr].
+func MakeObject(keys []string, values []reflect.Value) reflect.Value {
+	entries := make([]reflect.Value, 0, len(values))
+	for _, value := range values {
+		entries = append(entries, entryValue(value))
+	}
+	object := reflect.MakeMapWithSize(reflect.MapOf(stringType, commonType(entries)), len(entries))
+	for i, entry := range entries {
+		object.SetMapIndex(reflect.ValueOf(keys[i]), entry)
+	}
+	return object
+}
+
+var stringType = reflect.TypeFor[string]()
+
+var _ Expr = (*ObjectExpr)(nil)
@@ -0,0 +1,78 @@
+package expr_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"rodusek.dev/pkg/dcell/internal/expr"
+	"rodusek.dev/pkg/dcell/internal/expr/exprtest"
+)
+
+func TestObjectExpr(t *testing.T) {
+	t.Parallel()
+	testErr := errors.New("test error")
+	input := map[string]any{
+		"user": map[string]any{"id": 1.0, "login": "octocat", "name": "Mona"},
+	}
+	testCases := []struct {
+		name    string
+		sut     expr.Expr
+		want    any
+		wantErr error
+	}{
+		{
+			name: "Empty object",
+			sut:  expr.Object(nil, nil),
+			want: map[string]any{},
+		}, {
+			name: "Values of the same type",
+			sut: expr.Object([]string{"login", "name"}, []expr.Expr{
+				expr.Sequence(expr.Member("user"), expr.Member("login")),
+				expr.Sequence(expr.Member("user"), expr.Member("name")),
+			}),
+			want: map[string]string{"login": "octocat", "name": "Mona"},
+		}, {
+			name: "Values of different types",
+			sut: expr.Object([]string{"login", "id"}, []expr.Expr{
+				expr.Sequence(expr.Member("user"), expr.Member("login")),
+				expr.Sequence(expr.Member("user"), expr.Member("id")),
+			}),
+			want: map[string]any{"login": "octocat", "id": 1.0},
+		}, {
+			name: "Null value",
+			sut:  expr.Object([]string{"a", "b"}, []expr.Expr{expr.Literal(1), expr.Literal(nil)}),
+			want: map[string]any{"a": 1, "b": nil},
+		}, {
+			name: "Nested object",
+			sut: expr.Object([]string{"user"}, []expr.Expr{
+				expr.Object([]string{"id"}, []expr.Expr{expr.Sequence(expr.Member("user"), expr.Member("id"))}),
+			}),
+			want: map[string]map[string]float64{"user": {"id": 1}},
+		}, {
+			name:    "Value returns error",
+			sut:     expr.Object([]string{"a"}, []expr.Expr{exprtest.Error(testErr)}),
+			wantErr: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
+			ctx := expr.NewContext(reflect.ValueOf(input))
+
+			got, err := backend.Eval(tc.sut, ctx)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("ObjectExpr.Eval() error = %v, want %v", got, want)
+			}
+			if err != nil {
+				return
+			}
+			if got, want := got.Interface(), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("ObjectExpr.Eval() = %#v, want %#v", got, want)
+			}
+		})
+	}
+}
@@ -17,11 +17,23 @@ func PolarityPlus(e Expr) Expr {
 }
 
 func (e PolarityPlusExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	rv, err := e.Expr.Eval(ctx)
-	if err != nil || reflectconv.IsNil(rv) {
+	if err != nil {
 		return reflect.Value{}, err
 	}
+	return e.Apply(ctx, rv)
+}
 
+// Apply applies the operation to the evaluated operand.
+func (e PolarityPlusExpr) Apply(ctx *Context, rv reflect.Value) (reflect.Value, error) {
+	if reflectconv.IsNil(rv) {
+		return reflect.Value{}, nil
+	}
 	switch rv.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
 		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
@@ -42,11 +54,23 @@ func PolarityMinus(e Expr) PolarityMinusExpr {
 }
 
 func (e PolarityMinusExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	rv, err := e.Expr.Eval(ctx)
-	if err != nil || reflectconv.IsNil(rv) {
+	if err != nil {
 		return reflect.Value{}, err
 	}
+	return e.Apply(ctx, rv)
+}
 
+// Apply applies the operation to the evaluated operand.
+func (e PolarityMinusExpr) Apply(ctx *Context, rv reflect.Value) (reflect.Value, error) {
+	if reflectconv.IsNil(rv) {
+		return reflect.Value{}, nil
+	}
 	switch rv.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return reflect.ValueOf(-rv.Int()), nil
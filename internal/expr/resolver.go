@@ -0,0 +1,104 @@
+package expr
+
+import (
+	"reflect"
+	"slices"
+
+	"rodusek.dev/pkg/dcell/internal/errs"
+)
+
+// MemberResolver is implemented by values that resolve their own members,
+// instead of being traversed as structs or maps.
+type MemberResolver interface {
+	// DCellMember returns the value of the member with the given name, and
+	// whether the member exists.
+	DCellMember(name string) (any, bool)
+}
+
+// KeyLister is implemented by [MemberResolver] values that can list the names
+// of their members, which are enumerated by wildcards and suggested by
+// [errs.NameError].
+type KeyLister interface {
+	// DCellKeys returns the names of the members of the value.
+	DCellKeys() []string
+}
+
+// IndexResolver is implemented by values that resolve their own indices,
+// instead of being traversed as slices.
+type IndexResolver interface {
+	// DCellIndex returns the value at the given index, and whether the index
+	// exists. The index is passed as written in the expression, so negative
+	// indices are only supported if the resolver supports them.
+	DCellIndex(index int) (any, bool)
+}
+
+var (
+	memberResolverType = reflect.TypeFor[MemberResolver]()
+	indexResolverType  = reflect.TypeFor[IndexResolver]()
+)
+
+// resolverOf returns the resolver of type T implemented by rv, or by any value
+// that rv points to.
+func resolverOf[T any](rv reflect.Value) (T, bool) {
+	for rv.IsValid() {
+		if rv.CanInterface() {
+			if r, ok := rv.Interface().(T); ok {
+				return r, true
+			}
+		}
+		if rv.CanAddr() && rv.Addr().CanInterface() {
+			if r, ok := rv.Addr().Interface().(T); ok {
+				return r, true
+			}
+		}
+		if (rv.Kind() != reflect.Pointer && rv.Kind() != reflect.Interface) || rv.IsNil() {
+			break
+		}
+		rv = rv.Elem()
+	}
+	var zero T
+	return zero, false
+}
+
+// IsMemberResolver reports whether values of type rt, or that values of type
+// rt point to, implement [MemberResolver].
+func IsMemberResolver(rt reflect.Type) bool {
+	return implements(rt, memberResolverType)
+}
+
+// IsIndexResolver reports whether values of type rt, or that values of type
+// rt point to, implement [IndexResolver].
+func IsIndexResolver(rt reflect.Type) bool {
+	return implements(rt, indexResolverType)
+}
+
+func implements(rt, iface reflect.Type) bool {
+	for rt != nil {
+		if rt.Implements(iface) || reflect.PointerTo(rt).Implements(iface) {
+			return true
+		}
+		if rt.Kind() != reflect.Pointer {
+			break
+		}
+		rt = rt.Elem()
+	}
+	return false
+}
+
+// resolveMember resolves the named member of a [MemberResolver]. If the member
+// does not exist, an [errs.NameError] is returned, with suggestions from the
+// keys of the resolver if it is a [KeyLister], unless the policy of
+// [Context.Members] evaluates missing members to null.
+func resolveMember(ctx *Context, r MemberResolver, name string) (reflect.Value, error) {
+	if value, ok := r.DCellMember(name); ok {
+		return reflect.ValueOf(value), nil
+	}
+	if ctx.Members().AllowsMissing() {
+		return reflect.Value{}, nil
+	}
+	var keys []string
+	if lister, ok := r.(KeyLister); ok {
+		keys = lister.DCellKeys()
+	}
+	return reflect.Value{}, errs.NewNameError(name, slices.Values(keys))
+}
@@ -0,0 +1,97 @@
+package expr_test
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"rodusek.dev/pkg/dcell/internal/errs"
+	"rodusek.dev/pkg/dcell/internal/expr"
+)
+
+// document is a MemberResolver and KeyLister that resolves its members from
+// a map, listing them in sorted order.
+type document map[string]any
+
+func (d document) DCellMember(name string) (any, bool) {
+	value, ok := d[name]
+	return value, ok
+}
+
+func (d document) DCellKeys() []string {
+	return slices.Sorted(func(yield func(string) bool) {
+		for key := range d {
+			if !yield(key) {
+				return
+			}
+		}
+	})
+}
+
+// lazy is a MemberResolver with a pointer receiver that does not list its
+// members.
+type lazy struct {
+	loads int
+}
+
+func (l *lazy) DCellMember(name string) (any, bool) {
+	l.loads++
+	return name + "!", name != "missing"
+}
+
+// ring is an IndexResolver that wraps around its values for any index.
+type ring []int
+
+func (r ring) DCellIndex(index int) (any, bool) {
+	if len(r) == 0 {
+		return nil, false
+	}
+	return r[((index%len(r))+len(r))%len(r)], true
+}
+
+func TestMemberExpr_ResolverSuggestions(t *testing.T) {
+	t.Parallel()
+
+	runBackends(t, "Suggestions from keys", func(t *testing.T, backend backend) {
+		sut := expr.Member("nmae")
+		input := expr.NewContext(reflect.ValueOf(document{"name": 1, "age": 2}))
+
+		_, err := backend.Eval(sut, input)
+
+		var nameErr *errs.NameError
+		if !errors.As(err, &nameErr) {
+			t.Fatalf("MemberExpr.Eval() error = %v, want NameError", err)
+		}
+		if got, want := nameErr.Suggestions, []string{"name"}; !cmp.Equal(got, want) {
+			t.Errorf("MemberExpr.Eval() suggestions = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestIsMemberResolver(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		rt   reflect.Type
+		want bool
+	}{
+		{name: "Value receiver", rt: reflect.TypeFor[document](), want: true},
+		{name: "Pointer to value receiver", rt: reflect.TypeFor[*document](), want: true},
+		{name: "Pointer receiver", rt: reflect.TypeFor[lazy](), want: true},
+		{name: "Not a resolver", rt: reflect.TypeFor[map[string]any](), want: false},
+		{name: "Index resolver", rt: reflect.TypeFor[ring](), want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got, want := expr.IsMemberResolver(tc.rt), tc.want; got != want {
+				t.Errorf("IsMemberResolver(%v) = %v, want %v", tc.rt, got, want)
+			}
+		})
+	}
+}
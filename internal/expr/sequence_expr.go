@@ -26,9 +26,20 @@ func Sequence(first Expr, rest ...Expr) SequenceExpr {
 // Eval evaluates the sequence of expressions in order, passing the result of
 // each expression to the next one. If any expression returns an error or a nil
 // value, the evaluation stops and returns the error or nil value.
+//
+// The evaluation is also stopped if the Go context of the evaluation is
+// cancelled between steps.
 func (e SequenceExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	current := ctx.Current
 	for _, expr := range e {
+		if err := ctx.Err(); err != nil {
+			return reflect.Value{}, err
+		}
 		result, err := expr.Eval(ctx.Next(current))
 		if err != nil || reflectconv.IsNil(result) {
 			return reflect.Value{}, err
@@ -1,6 +1,7 @@
 package expr_test
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"testing"
@@ -66,12 +67,11 @@ func TestSequenceExpr_Eval(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
 			sut := sequence(tc.exprs...)
 			ctx := &expr.Context{Current: tc.current}
 
-			got, err := sut.Eval(ctx)
+			got, err := backend.Eval(sut, ctx)
 
 			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
 				t.Errorf("Eval() error = %v, want %v", got, want)
@@ -82,3 +82,35 @@ func TestSequenceExpr_Eval(t *testing.T) {
 		})
 	}
 }
+
+func TestSequenceExpr_Eval_Cancelled(t *testing.T) {
+	t.Parallel()
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			t.Parallel()
+			goctx, cancel := context.WithCancel(context.Background())
+			calls := 0
+			sut := sequence(
+				exprtest.Func(func(*expr.Context) (reflect.Value, error) {
+					calls++
+					cancel()
+					return reflect.ValueOf(1), nil
+				}),
+				exprtest.Func(func(*expr.Context) (reflect.Value, error) {
+					calls++
+					return reflect.ValueOf(2), nil
+				}),
+			)
+			ctx := expr.NewContext(reflect.Value{}).WithContext(goctx)
+
+			_, err := backend.Eval(sut, ctx)
+
+			if got, want := err, context.Canceled; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("Eval() error = %v, want %v", got, want)
+			}
+			if got, want := calls, 1; got != want {
+				t.Errorf("Eval() evaluated %d expressions, want %d", got, want)
+			}
+		})
+	}
+}
@@ -25,10 +25,21 @@ func BitwiseShiftLeft(left, right Expr) *BitwiseShiftLeftExpr {
 
 // Eval evaluates the bitwise left shift expression.
 func (e *BitwiseShiftLeftExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	lhs, rhs, err := evalTwo(ctx, e.Left, e.Right)
 	if err != nil {
 		return reflect.Value{}, err
 	}
+	return e.Apply(ctx, lhs, rhs)
+}
+
+// Apply applies the operation to the evaluated operands, which must already
+// be dereferenced.
+func (e *BitwiseShiftLeftExpr) Apply(ctx *Context, lhs, rhs reflect.Value) (reflect.Value, error) {
 	i, err := reflectconv.Uint64s(lhs, rhs)
 	if err != nil {
 		return reflect.Value{}, err
@@ -58,10 +69,21 @@ func BitwiseShiftRight(left, right Expr) *BitwiseShiftRightExpr {
 
 // Eval evaluates the bitwise right shift expression.
 func (e *BitwiseShiftRightExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	lhs, rhs, err := evalTwo(ctx, e.Left, e.Right)
 	if err != nil {
 		return reflect.Value{}, err
 	}
+	return e.Apply(ctx, lhs, rhs)
+}
+
+// Apply applies the operation to the evaluated operands, which must already
+// be dereferenced.
+func (e *BitwiseShiftRightExpr) Apply(ctx *Context, lhs, rhs reflect.Value) (reflect.Value, error) {
 	i, err := reflectconv.Uint64s(lhs, rhs)
 	if err != nil {
 		return reflect.Value{}, err
@@ -38,6 +38,11 @@ func Elvis(condition, falseExpr Expr) *TernaryExpr {
 
 // Eval evaluates the ternary expression.
 func (e *TernaryExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
 	result, err := e.Condition.Eval(ctx)
 	if err != nil {
 		return reflect.Value{}, err
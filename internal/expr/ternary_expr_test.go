@@ -58,11 +58,10 @@ func TestTernaryExpr(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
 			sut := expr.Ternary(tc.condition, tc.trueExpr, tc.falseExpr)
 
-			result, err := sut.Eval(nil)
+			result, err := backend.Eval(sut, nil)
 
 			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
 				t.Fatalf("Ternary() error = %v, want %v", got, want)
@@ -108,11 +107,10 @@ func TestElvisExpr(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
 			sut := expr.Elvis(tc.condition, tc.falseExpr)
 
-			result, err := sut.Eval(nil)
+			result, err := backend.Eval(sut, nil)
 
 			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
 				t.Fatalf("Elvis() error = %v, want %v", got, want)
@@ -0,0 +1,43 @@
+package expr
+
+import (
+	"maps"
+	"reflect"
+
+	"rodusek.dev/pkg/dcell/internal/errs"
+)
+
+// VariableExpr is an expression that resolves a named variable bound to the
+// evaluation context, such as `$user`. The name does not include the leading
+// '$'.
+//
+// If the variable is not bound in the context, an [errs.NameError] is
+// returned.
+type VariableExpr string
+
+// Variable returns a [VariableExpr] with the given name.
+func Variable(name string) VariableExpr {
+	return VariableExpr(name)
+}
+
+// Eval evaluates the variable expression. It returns the value bound to the
+// variable in the current context.
+func (e VariableExpr) Eval(ctx *Context) (reflect.Value, error) {
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
+	return e.Lookup(ctx)
+}
+
+// Lookup returns the value bound to the variable in the given context.
+func (e VariableExpr) Lookup(ctx *Context) (reflect.Value, error) {
+	value, ok := ctx.Var(string(e))
+	if !ok {
+		return reflect.Value{}, errs.NewVariableError(string(e), maps.Keys(ctx.Vars))
+	}
+	return value, nil
+}
+
+var _ Expr = (*VariableExpr)(nil)
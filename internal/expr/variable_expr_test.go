@@ -0,0 +1,85 @@
+package expr_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"rodusek.dev/pkg/dcell/internal/errs"
+	"rodusek.dev/pkg/dcell/internal/expr"
+	"rodusek.dev/pkg/dcell/internal/reflectcmp"
+)
+
+func TestVariableExpr(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		vars    map[string]reflect.Value
+		want    any
+		wantErr error
+	}{
+		{
+			name:    "No variables bound",
+			vars:    nil,
+			wantErr: errs.ErrUnknownName,
+		}, {
+			name: "Variable is bound",
+			vars: map[string]reflect.Value{
+				"user": reflect.ValueOf("octocat"),
+			},
+			want: "octocat",
+		}, {
+			name: "Variable is bound to nil",
+			vars: map[string]reflect.Value{
+				"user": reflect.ValueOf(nil),
+			},
+			want: nil,
+		}, {
+			name: "Variable is not bound",
+			vars: map[string]reflect.Value{
+				"users": reflect.ValueOf("octocat"),
+			},
+			wantErr: errs.ErrUnknownName,
+		},
+	}
+
+	for _, tc := range testCases {
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
+			sut := expr.Variable("user")
+			ctx := expr.NewContext(reflect.Value{}).WithVars(tc.vars)
+
+			got, err := backend.Eval(sut, ctx)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("VariableExpr.Eval() error = %v, want %v", got, want)
+			}
+			if got, want := got, reflect.ValueOf(tc.want); !reflectcmp.Equal(got, want) {
+				t.Errorf("VariableExpr.Eval() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestContext_WithVars(t *testing.T) {
+	t.Parallel()
+	ctx := expr.NewContext(reflect.Value{}).WithVars(map[string]reflect.Value{
+		"a": reflect.ValueOf(1),
+		"b": reflect.ValueOf(2),
+	})
+
+	sut := ctx.WithVars(map[string]reflect.Value{
+		"b": reflect.ValueOf(3),
+	}).Next(reflect.ValueOf("current"))
+
+	if got, ok := sut.Var("a"); !ok || got.Interface() != 1 {
+		t.Errorf("Context.Var(a) = %v, %v, want 1, true", got, ok)
+	}
+	if got, ok := sut.Var("b"); !ok || got.Interface() != 3 {
+		t.Errorf("Context.Var(b) = %v, %v, want 3, true", got, ok)
+	}
+	if got, ok := ctx.Var("b"); !ok || got.Interface() != 2 {
+		t.Errorf("Context.Var(b) on parent = %v, %v, want 2, true", got, ok)
+	}
+}
@@ -3,17 +3,23 @@ package expr
 import (
 	"fmt"
 	"reflect"
+	"slices"
 
+	"rodusek.dev/pkg/dcell/internal/reflectcmp"
 	"rodusek.dev/pkg/dcell/internal/reflectconv"
 )
 
 // WildcardExpr is a wildcard expression that matches any field of a struct
-// that contains a `dcell` struct tag, or any value associated to a key in a
-// map.
+// that is visible under the policy of [Context.Members], including the fields
+// promoted from embedded structs, or any value associated to a key in a map.
+// Map values are collected in the order of their sorted keys. Values that
+// implement [MemberResolver] are enumerated by resolving each of their keys,
+// which requires them to implement [KeyLister].
 //
 // If the current context input is a nil value, the result is nil.
 // If the current context input is not a struct or a map, an
-// error is returned.
+// error is returned. If the Go context of the evaluation is cancelled while
+// the fields are being collected, the context error is returned.
 type WildcardExpr struct{}
 
 // Wildcard returns a wildcard expression.
@@ -23,12 +29,32 @@ func Wildcard() WildcardExpr {
 
 // Eval evaluates the wildcard expression.
 func (e WildcardExpr) Eval(ctx *Context) (reflect.Value, error) {
-	rv := ctx.Current
+	if err := ctx.Enter(); err != nil {
+		return reflect.Value{}, err
+	}
+	defer ctx.Leave()
+
+	return e.Apply(ctx, ctx.Current)
+}
+
+// Apply collects the fields or values of the given value.
+func (e WildcardExpr) Apply(ctx *Context, rv reflect.Value) (reflect.Value, error) {
+	if err := ctx.Err(); err != nil {
+		return reflect.Value{}, err
+	}
 	if reflectconv.IsNil(rv) {
 		return reflect.Value{}, nil
 	}
 
-	for rv.Kind() == reflect.Ptr {
+	if r, ok := resolverOf[MemberResolver](rv); ok {
+		lister, ok := r.(KeyLister)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("wildcard: '*' only usable on resolvers that list their keys, got %T", r)
+		}
+		return e.evalResolver(ctx, r, lister.DCellKeys())
+	}
+
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
 		if rv.IsNil() {
 			return reflect.Value{}, nil
 		}
@@ -37,38 +63,47 @@ func (e WildcardExpr) Eval(ctx *Context) (reflect.Value, error) {
 
 	switch rv.Kind() {
 	case reflect.Struct:
-		return e.evalStruct(rv), nil
+		return e.evalStruct(ctx, rv)
 	case reflect.Map:
-		return e.evalMap(rv), nil
+		return e.evalMap(ctx, rv)
 	}
 
-	return reflect.Value{}, fmt.Errorf("wildcard: '*' only usable on struct and map, got %s", rv.Type().Name())
+	return reflect.Value{}, fmt.Errorf("wildcard: '*' only usable on struct and map, got %s", rv.Type().String())
 }
 
-func (e WildcardExpr) evalStruct(rv reflect.Value) reflect.Value {
-	fields := e.extractFields(rv)
-	if len(fields) == 0 {
-		return reflect.Value{}
+func (e WildcardExpr) evalStruct(ctx *Context, rv reflect.Value) (reflect.Value, error) {
+	fields, err := e.extractFields(ctx, rv)
+	if err != nil || len(fields) == 0 {
+		return reflect.Value{}, err
+	}
+	if err := ctx.CheckSliceLength(len(fields)); err != nil {
+		return reflect.Value{}, err
 	}
 	slice := reflect.MakeSlice(e.fieldSliceType(fields), 0, len(fields))
 	for _, field := range fields {
 		slice = reflect.Append(slice, field)
 	}
 
-	return slice
+	return slice, nil
 }
 
-func (e WildcardExpr) extractFields(rv reflect.Value) []reflect.Value {
+func (e WildcardExpr) extractFields(ctx *Context, rv reflect.Value) ([]reflect.Value, error) {
 	var result []reflect.Value
-	rt := rv.Type()
-	for i := range rt.NumField() {
-		field := rt.Field(i)
-		if !field.IsExported() {
+	for _, field := range ctx.Members().Fields(rv.Type()) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if field.Embedded {
 			continue
 		}
-		result = append(result, rv.Field(i))
+		value, err := rv.FieldByIndexErr(field.Index)
+		if err != nil {
+			// Fields promoted through a nil embedded pointer are null.
+			value = reflect.Zero(reflect.TypeFor[any]())
+		}
+		result = append(result, value)
 	}
-	return result
+	return result, nil
 }
 
 func (e WildcardExpr) fieldSliceType(fields []reflect.Value) reflect.Type {
@@ -81,18 +116,57 @@ func (e WildcardExpr) fieldSliceType(fields []reflect.Value) reflect.Type {
 	return reflect.SliceOf(current)
 }
 
-func (e WildcardExpr) evalMap(rv reflect.Value) reflect.Value {
+func (e WildcardExpr) evalMap(ctx *Context, rv reflect.Value) (reflect.Value, error) {
 	if rv.IsNil() || rv.Len() == 0 {
-		return reflect.Value{}
+		return reflect.Value{}, nil
+	}
+	if err := ctx.CheckSliceLength(rv.Len()); err != nil {
+		return reflect.Value{}, err
 	}
 	rt := rv.Type()
 	valueType := rt.Elem()
 	slice := reflect.MakeSlice(reflect.SliceOf(valueType), 0, rv.Len())
-	for _, key := range rv.MapKeys() {
-		value := rv.MapIndex(key)
+	keys := rv.MapKeys()
+	slices.SortFunc(keys, reflectcmp.Compare)
+	for _, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return reflect.Value{}, err
+		}
+		slice = reflect.Append(slice, rv.MapIndex(key))
+	}
+	return slice, nil
+}
+
+func (e WildcardExpr) evalResolver(ctx *Context, r MemberResolver, keys []string) (reflect.Value, error) {
+	if len(keys) == 0 {
+		return reflect.Value{}, nil
+	}
+	if err := ctx.CheckSliceLength(len(keys)); err != nil {
+		return reflect.Value{}, err
+	}
+	values := make([]reflect.Value, 0, len(keys))
+	for _, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return reflect.Value{}, err
+		}
+		value, ok := r.DCellMember(key)
+		if !ok {
+			continue
+		}
+		rv := reflect.ValueOf(value)
+		if !rv.IsValid() {
+			rv = reflect.Zero(reflect.TypeFor[any]())
+		}
+		values = append(values, rv)
+	}
+	if len(values) == 0 {
+		return reflect.Value{}, nil
+	}
+	slice := reflect.MakeSlice(e.fieldSliceType(values), 0, len(values))
+	for _, value := range values {
 		slice = reflect.Append(slice, value)
 	}
-	return slice
+	return slice, nil
 }
 
 var _ Expr = (*WildcardExpr)(nil)
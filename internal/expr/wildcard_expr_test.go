@@ -1,6 +1,7 @@
 package expr_test
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -55,6 +56,24 @@ func TestWildcardExpr(t *testing.T) {
 				FieldTwo: 42,
 			},
 			want: []any{"value1", 42},
+		}, {
+			name: "Input is struct with hidden field",
+			input: struct {
+				FieldOne string
+				FieldTwo int `dcell:"-"`
+			}{
+				FieldOne: "value1",
+				FieldTwo: 42,
+			},
+			want: []string{"value1"},
+		}, {
+			name:  "Input is struct with embedded struct",
+			input: Moderator{Account: Account{Login: "octocat", ID: 1}, Team: &Team{Slug: "core"}, Level: 2},
+			want:  []any{"octocat", "core", 2},
+		}, {
+			name:  "Input is struct with nil embedded pointer",
+			input: Moderator{Level: 2, Team: nil},
+			want:  []any{"", nil, 2},
 		}, {
 			name:  "Input is empty struct",
 			input: struct{}{},
@@ -66,6 +85,18 @@ func TestWildcardExpr(t *testing.T) {
 				"key2": 42,
 			},
 			want: []any{"value1", 42},
+		}, {
+			name:  "Input is resolver that lists keys",
+			input: document{"b": 2, "a": "1"},
+			want:  []any{"1", 2},
+		}, {
+			name:  "Input is resolver without keys",
+			input: document{},
+			want:  nil,
+		}, {
+			name:    "Input is resolver that does not list keys",
+			input:   &lazy{},
+			wantErr: cmpopts.AnyError,
 		}, {
 			name:    "Input is not struct or map",
 			input:   123,
@@ -74,8 +105,7 @@ func TestWildcardExpr(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
 			sut := expr.Wildcard()
 			input := expr.NewContext(reflect.ValueOf(tc.input))
 			var expect reflect.Value
@@ -83,7 +113,7 @@ func TestWildcardExpr(t *testing.T) {
 				expect = reflect.ValueOf(tc.want)
 			}
 
-			got, err := sut.Eval(input)
+			got, err := backend.Eval(sut, input)
 
 			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
 				t.Errorf("Wildcard.Eval() error = %v, want %v", got, want)
@@ -95,6 +125,37 @@ func TestWildcardExpr(t *testing.T) {
 	}
 }
 
+func TestWildcardExpr_Cancelled(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name  string
+		input any
+	}{
+		{
+			name:  "Input is struct",
+			input: struct{ FieldOne string }{FieldOne: "value1"},
+		}, {
+			name:  "Input is map",
+			input: map[string]any{"key1": "value1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		runBackends(t, tc.name, func(t *testing.T, backend backend) {
+			goctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			sut := expr.Wildcard()
+			input := expr.NewContext(reflect.ValueOf(tc.input)).WithContext(goctx)
+
+			_, err := backend.Eval(sut, input)
+
+			if got, want := err, context.Canceled; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("Wildcard.Eval() error = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
 func reflectEqual(got, want reflect.Value) bool {
 	if got.Kind() == reflect.Slice && want.Kind() == reflect.Slice {
 		return cmp.Equal(got.Interface(), want.Interface())
@@ -0,0 +1,429 @@
+/*
+Package format prints the syntax tree of a dcell expression in a canonical
+form.
+*/
+package format
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"rodusek.dev/pkg/dcell/ast"
+)
+
+// Config is the style that expressions are printed in.
+type Config struct {
+	// KeywordOperators prints the logical operators as the keywords `and`,
+	// `or`, `implies`, and `not` rather than as symbols.
+	KeywordOperators bool
+
+	// SingleQuotes prints string literals with single quotes rather than
+	// double quotes, unless the string contains a single quote.
+	SingleQuotes bool
+
+	// LineWidth is the width, in characters, beyond which chains of `&&` and
+	// `||` operations are wrapped with one operand per line. If 0, chains are
+	// never wrapped.
+	LineWidth int
+
+	// Indent is the indentation of each level of wrapped lines.
+	Indent string
+}
+
+// Node prints the syntax tree of an expression along with its comments, which
+// must be in source order.
+//
+// Each comment is printed before the first node or binary operator that
+// follows it in the source text, and comments that follow every node are
+// printed at the end.
+func Node(node ast.Expr, comments []*ast.Comment, cfg *Config) string {
+	p := &printer{
+		cfg:      cfg,
+		comments: comments,
+	}
+	p.expr(node)
+	p.flush(math.MaxInt)
+	return strings.TrimRight(p.buf.String(), " \t\n")
+}
+
+type printer struct {
+	cfg      *Config
+	buf      strings.Builder
+	comments []*ast.Comment
+
+	// lineStart is the offset in buf of the start of the current line.
+	lineStart int
+
+	// depth is the indentation level of the current line.
+	depth int
+}
+
+func (p *printer) write(s string) {
+	p.buf.WriteString(s)
+}
+
+func (p *printer) newline() {
+	p.write("\n")
+	p.lineStart = p.buf.Len()
+	p.write(strings.Repeat(p.cfg.Indent, p.depth))
+}
+
+// line returns the text printed so far on the current line.
+func (p *printer) line() string {
+	return p.buf.String()[p.lineStart:]
+}
+
+// atLineStart reports whether nothing but indentation is printed on the
+// current line.
+func (p *printer) atLineStart() bool {
+	return strings.TrimSpace(p.line()) == ""
+}
+
+// flush prints the pending comments that start before offset.
+func (p *printer) flush(offset int) {
+	for len(p.comments) > 0 && p.comments[0].Start.Offset < offset {
+		line := p.line()
+		if strings.TrimSpace(line) != "" && !strings.HasSuffix(line, " ") {
+			p.write(" ")
+		}
+		p.write(strings.TrimRight(p.comments[0].Text, " \t"))
+		p.comments = p.comments[1:]
+		p.newline()
+	}
+}
+
+func (p *printer) expr(node ast.Expr) {
+	p.flush(node.Pos().Offset)
+
+	switch n := node.(type) {
+	case *ast.Literal:
+		p.literal(n)
+	case *ast.List:
+		p.write("[")
+		p.exprs(n.Elems)
+		p.write("]")
+	case *ast.FormatString:
+		p.formatString(n)
+	case *ast.Object:
+		p.write("{")
+		for i, prop := range n.Props {
+			if i > 0 {
+				p.write(", ")
+			}
+			p.property(prop)
+		}
+		p.write("}")
+	case *ast.Member:
+		p.receiver(n.X, n.Safe)
+		p.write(n.Name)
+	case *ast.Wildcard:
+		p.receiver(n.X, n.Safe)
+		p.write("*")
+	case *ast.Call:
+		p.receiver(n.X, n.Safe)
+		p.write(n.Name)
+		p.write("(")
+		p.exprs(n.Args)
+		p.write(")")
+	case *ast.Index:
+		p.expr(n.X)
+		p.write("[")
+		p.expr(n.Index)
+		p.write("]")
+	case *ast.Slice:
+		p.expr(n.X)
+		p.write("[")
+		if n.Low != nil {
+			p.expr(n.Low)
+		}
+		p.write(":")
+		if n.High != nil {
+			p.expr(n.High)
+		}
+		p.write("]")
+	case *ast.Unary:
+		p.unary(n)
+	case *ast.Binary:
+		p.binary(n)
+	case *ast.Ternary:
+		p.expr(n.Cond)
+		p.write(" ? ")
+		p.expr(n.Then)
+		p.write(" : ")
+		p.expr(n.Else)
+	case *ast.Is:
+		p.expr(n.X)
+		if n.Not {
+			p.write(" is not ")
+		} else {
+			p.write(" is ")
+		}
+		p.write(n.Type)
+	case *ast.As:
+		p.expr(n.X)
+		p.write(" as ")
+		p.write(n.Type)
+	case *ast.In:
+		p.expr(n.X)
+		if n.Not {
+			p.write(" not in ")
+		} else {
+			p.write(" in ")
+		}
+		p.expr(n.Y)
+	case *ast.Paren:
+		p.write("(")
+		p.expr(n.X)
+		p.write(")")
+	case *ast.Variable:
+		p.write("$")
+		p.write(n.Name)
+	case *ast.Lambda:
+		p.write(n.Param)
+		p.write(" => ")
+		p.expr(n.Body)
+	case *ast.Param:
+		p.write(n.Name)
+	default:
+		panic(fmt.Sprintf("format: unexpected node type %T", n))
+	}
+}
+
+// receiver prints the receiver of a member access or call, followed by `?.`
+// if the access is safe, or `.` otherwise. Accesses of the root value have no
+// receiver.
+func (p *printer) receiver(x ast.Expr, safe bool) {
+	if x == nil {
+		return
+	}
+	p.expr(x)
+	if safe {
+		p.write("?.")
+		return
+	}
+	p.write(".")
+}
+
+func (p *printer) exprs(list []ast.Expr) {
+	for i, x := range list {
+		if i > 0 {
+			p.write(", ")
+		}
+		p.expr(x)
+	}
+}
+
+// property writes a property of an object literal, whose key is written as
+// an identifier if it was written as one, or else as a string.
+func (p *printer) property(n *ast.Property) {
+	if strings.HasPrefix(n.Raw, "'") || strings.HasPrefix(n.Raw, "\"") {
+		p.write(quote(n.Key, p.cfg.SingleQuotes))
+	} else {
+		p.write(n.Raw)
+	}
+	p.write(": ")
+	p.expr(n.Value)
+}
+
+// formatString prints a format string literal, whose interpolations are
+// printed on a single line. Its quotes are chosen as for string literals.
+func (p *printer) formatString(n *ast.FormatString) {
+	single := p.cfg.SingleQuotes
+	for _, part := range n.Parts {
+		if text, ok := part.(*ast.Literal); ok {
+			s, _ := text.Value.(string)
+			if strings.ContainsRune(s, '\'') {
+				single = false
+			}
+		}
+	}
+
+	p.write("f")
+	p.write(quote("", single)[:1])
+	for _, part := range n.Parts {
+		switch part := part.(type) {
+		case *ast.Literal:
+			s, _ := part.Value.(string)
+			s = quote(s, single)
+			s = strings.ReplaceAll(s[1:len(s)-1], "{", "{{")
+			p.write(strings.ReplaceAll(s, "}", "}}"))
+		case *ast.Interpolation:
+			inner := &printer{
+				cfg: &Config{
+					KeywordOperators: p.cfg.KeywordOperators,
+					SingleQuotes:     p.cfg.SingleQuotes,
+				},
+			}
+			inner.expr(part.X)
+			p.write("{")
+			if x := inner.buf.String(); strings.HasPrefix(x, "{") {
+				// Keep an object literal from being read back as an escaped
+				// brace.
+				p.write(" " + x)
+			} else {
+				p.write(x)
+			}
+			if part.Spec != "" {
+				p.write(":" + part.Spec)
+			}
+			p.write("}")
+		}
+	}
+	p.write(quote("", single)[:1])
+}
+
+func (p *printer) literal(n *ast.Literal) {
+	switch {
+	case n.Kind == ast.StringLiteral:
+		s, _ := n.Value.(string)
+		p.write(quote(s, p.cfg.SingleQuotes))
+	case n.Raw != "":
+		p.write(n.Raw)
+	case n.Kind == ast.NullLiteral:
+		p.write("null")
+	default:
+		p.write(fmt.Sprint(n.Value))
+	}
+}
+
+func (p *printer) unary(n *ast.Unary) {
+	if n.Op == ast.Not && p.cfg.KeywordOperators {
+		p.write("not ")
+		p.expr(n.X)
+		return
+	}
+	p.write(n.Op.String())
+	if (n.Op == ast.Plus || n.Op == ast.Minus) && isSigned(n.X) {
+		// Keep `- 1` from being read back as the literal `-1`.
+		p.write(" ")
+	}
+	p.expr(n.X)
+}
+
+// isSigned reports whether an operand of a polarity operator would merge with
+// the operator if it were printed without a space.
+func isSigned(x ast.Expr) bool {
+	switch x := x.(type) {
+	case *ast.Literal:
+		return x.Kind == ast.IntLiteral || x.Kind == ast.FloatLiteral
+	case *ast.Unary:
+		return x.Op == ast.Plus || x.Op == ast.Minus
+	}
+	return false
+}
+
+func (p *printer) binary(n *ast.Binary) {
+	if (n.Op == ast.And || n.Op == ast.Or) && p.cfg.LineWidth > 0 {
+		if utf8.RuneCountInString(p.line())+p.width(n) > p.cfg.LineWidth {
+			p.chain(n)
+			return
+		}
+	}
+	p.expr(n.X)
+	p.write(" ")
+	p.operation(n)
+}
+
+// chain prints a chain of operations of the same operator with each operand
+// after the first on its own line.
+func (p *printer) chain(n *ast.Binary) {
+	operations := []*ast.Binary{n}
+	x := n.X
+	for {
+		next, ok := x.(*ast.Binary)
+		if !ok || next.Op != n.Op {
+			break
+		}
+		operations = append(operations, next)
+		x = next.X
+	}
+
+	p.expr(x)
+	for i := len(operations) - 1; i >= 0; i-- {
+		p.depth++
+		p.flush(operations[i].OpPos.Offset)
+		if !p.atLineStart() {
+			p.newline()
+		}
+		p.depth--
+		p.operation(operations[i])
+	}
+}
+
+// operation prints the operator and the right operand of a binary operation.
+// Comments before the operator, such as the trailing comment of the left
+// operand, are printed before it, and comments after it are printed after it;
+// the operator or the operand then starts a continuation line, which is
+// indented by one level.
+func (p *printer) operation(n *ast.Binary) {
+	p.depth++
+	p.flush(n.OpPos.Offset)
+	p.write(p.operator(n.Op))
+	p.flush(n.Y.Pos().Offset)
+	if !p.atLineStart() {
+		p.write(" ")
+	}
+	p.depth--
+	p.expr(n.Y)
+}
+
+// width returns the width of a node when printed on a single line.
+func (p *printer) width(node ast.Expr) int {
+	flat := &printer{
+		cfg: &Config{
+			KeywordOperators: p.cfg.KeywordOperators,
+			SingleQuotes:     p.cfg.SingleQuotes,
+		},
+	}
+	flat.expr(node)
+	return utf8.RuneCountInString(flat.buf.String())
+}
+
+var keywords = map[ast.Operator]string{
+	ast.And:     "and",
+	ast.Or:      "or",
+	ast.Implies: "implies",
+}
+
+func (p *printer) operator(op ast.Operator) string {
+	if keyword, ok := keywords[op]; ok && p.cfg.KeywordOperators {
+		return keyword
+	}
+	return op.String()
+}
+
+// quote returns the string literal of s. Double quotes are used if s contains
+// a single quote, since quote characters cannot be escaped in string literals.
+func quote(s string, single bool) string {
+	q := '"'
+	if single && !strings.ContainsRune(s, '\'') {
+		q = '\''
+	}
+
+	var sb strings.Builder
+	sb.WriteRune(q)
+	for _, r := range s {
+		switch r {
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\f':
+			sb.WriteString(`\f`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&sb, `\u%04x`, r)
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+	sb.WriteRune(q)
+	return sb.String()
+}
@@ -0,0 +1,160 @@
+package format_test
+
+import (
+	"testing"
+
+	"rodusek.dev/pkg/dcell/internal/compile"
+	"rodusek.dev/pkg/dcell/internal/format"
+)
+
+func TestNode(t *testing.T) {
+	t.Parallel()
+	symbols := &format.Config{Indent: "  "}
+	keywords := &format.Config{KeywordOperators: true, SingleQuotes: true, Indent: "  "}
+	narrow := &format.Config{LineWidth: 20, Indent: "\t"}
+
+	testCases := []struct {
+		name  string
+		input string
+		cfg   *format.Config
+		want  string
+	}{
+		{
+			name:  "spacing",
+			input: "a.b( 1,2 )[ 0 ]+c[1 :]",
+			cfg:   symbols,
+			want:  "a.b(1, 2)[0] + c[1:]",
+		}, {
+			name:  "keywords to symbols",
+			input: "not a and b or c implies d",
+			cfg:   symbols,
+			want:  "!a && b || c <-> d",
+		}, {
+			name:  "symbols to keywords",
+			input: "!a && b || c <-> d",
+			cfg:   keywords,
+			want:  "not a and b or c implies d",
+		}, {
+			name:  "single to double quotes",
+			input: `'it\nis'`,
+			cfg:   symbols,
+			want:  `"it\nis"`,
+		}, {
+			name:  "double to single quotes",
+			input: `"a" + """b"""`,
+			cfg:   keywords,
+			want:  `'a' + 'b'`,
+		}, {
+			name:  "single quote kept in double quotes",
+			input: `"it's"`,
+			cfg:   keywords,
+			want:  `"it's"`,
+		}, {
+			name:  "numbers kept as written",
+			input: "0x1F+1.50",
+			cfg:   symbols,
+			want:  "0x1F + 1.50",
+		}, {
+			name:  "polarity of literal",
+			input: "- 1 - -x",
+			cfg:   symbols,
+			want:  "- 1 - -x",
+		}, {
+			name:  "type and membership",
+			input: "(a is not int)&&(b as float)in c&&d not in e",
+			cfg:   symbols,
+			want:  "(a is not int) && (b as float) in c && d not in e",
+		}, {
+			name:  "match",
+			input: "a=~'^b'&&c!~d",
+			cfg:   keywords,
+			want:  "a =~ '^b' and c !~ d",
+		}, {
+			name:  "lambda, variable, and list",
+			input: "$x.any(y=>y in[1,2])?*:null",
+			cfg:   symbols,
+			want:  "$x.any(y => y in [1, 2]) ? * : null",
+		}, {
+			name:  "list and object of expressions",
+			input: "{a:[b.c,1+2],'d-e' : {}}",
+			cfg:   symbols,
+			want:  `{a: [b.c, 1 + 2], "d-e": {}}`,
+		}, {
+			name:  "format string",
+			input: "f'{a.b:>5} {{x}} { {c:1}.c }{d?\"e\":\"f\"}'",
+			cfg:   symbols,
+			want:  `f"{a.b:>5} {{x}} { {c: 1}.c}{d ? "e" : "f"}"`,
+		}, {
+			name:  "safe navigation",
+			input: "a ?. b.c ?. * ?? d?.e( 1 )",
+			cfg:   symbols,
+			want:  "a?.b.c?.* ?? d?.e(1)",
+		}, {
+			name:  "short chain is not wrapped",
+			input: "a && b",
+			cfg:   narrow,
+			want:  "a && b",
+		}, {
+			name:  "long chain is wrapped",
+			input: "first && second || third && fourth",
+			cfg:   narrow,
+			want:  "first && second\n\t|| third && fourth",
+		}, {
+			name:  "nested chain is wrapped",
+			input: "(alpha || bravo || charlie) && delta",
+			cfg:   narrow,
+			want:  "(alpha\n\t|| bravo\n\t|| charlie)\n\t&& delta",
+		}, {
+			name:  "leading comment",
+			input: "# check the author\n  author  ",
+			cfg:   symbols,
+			want:  "# check the author\nauthor",
+		}, {
+			name:  "trailing comments",
+			input: "a # first  \n# second",
+			cfg:   symbols,
+			want:  "a # first\n# second",
+		}, {
+			name:  "comment between operands",
+			input: "a &&\n  # b matters\n  b",
+			cfg:   symbols,
+			want:  "a && # b matters\n  b",
+		}, {
+			name:  "comment in wrapped chain",
+			input: "first && # why\n second && third",
+			cfg:   narrow,
+			want:  "first\n\t&& # why\n\tsecond\n\t&& third",
+		}, {
+			name:  "trailing comments of operands",
+			input: "a and b # one\n  or c # two",
+			cfg:   symbols,
+			want:  "a && b # one\n  || c # two",
+		}, {
+			name:  "trailing comment in wrapped chain",
+			input: "first && # why\n second # because\n && third",
+			cfg:   narrow,
+			want:  "first\n\t&& # why\n\tsecond # because\n\t&& third",
+		}, {
+			name:  "trailing comment in nested operation",
+			input: "a + b # sum\n  == c",
+			cfg:   keywords,
+			want:  "a + b # sum\n  == c",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			node, comments, err := compile.ParseAST(tc.input)
+			if err != nil {
+				t.Fatalf("ParseAST(%q) error = %v", tc.input, err)
+			}
+
+			got := format.Node(node, comments, tc.cfg)
+
+			if want := tc.want; got != want {
+				t.Errorf("Node() = %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package invocation
+
+import (
+	"context"
+
+	"rodusek.dev/pkg/dcell/internal/errs"
+)
+
+// maxStringLengthKey is the key of the string length limit in the contexts
+// that functions receive.
+type maxStringLengthKey struct{}
+
+// WithMaxStringLength returns a context that limits the length, in bytes, of
+// the strings that functions build to n. A limit of zero or less is not
+// enforced.
+func WithMaxStringLength(ctx context.Context, n int) context.Context {
+	if n <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, maxStringLengthKey{}, n)
+}
+
+// CheckStringLength returns an [errs.BudgetError] if a string of length n
+// would exceed the limit set by [WithMaxStringLength]. Functions that build
+// strings whose length depends on their arguments check it before building
+// them, rather than leaving the check to the result.
+func CheckStringLength(ctx context.Context, n int) error {
+	if limit, ok := ctx.Value(maxStringLengthKey{}).(int); ok && n > limit {
+		return &errs.BudgetError{Limit: errs.LimitStringLength, Max: limit}
+	}
+	return nil
+}
@@ -5,12 +5,15 @@ a yamlpath expression.
 package invocation
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"iter"
 	"reflect"
+	"slices"
 
 	"rodusek.dev/pkg/dcell/internal/invocation/arity"
+	"rodusek.dev/pkg/dcell/internal/reflectconv"
 )
 
 // ErrUnknownFunc is an error that is returned when a function is not found in
@@ -23,13 +26,93 @@ var (
 
 // Entry is a function entry in the function table.
 type Entry struct {
-	fn    funcEntry
+	fn    contextFuncEntry
 	arity arity.Arity
+
+	// signature is the Go function type of functions added with
+	// [Table.AddFunc], and offset is the index of its first parameter that is
+	// provided by the expression.
+	signature reflect.Type
+	offset    int
+
+	// result is the static type of the result of the function, or nil if it
+	// is not known.
+	result reflect.Type
+
+	// pure is whether the result of the function only depends on its
+	// arguments.
+	pure bool
+
+	// patterns are the indices of the arguments that are regular expression
+	// patterns.
+	patterns []int
 }
 
 // SetArity sets the arity of the function entry.
-func (e *Entry) SetArity(a arity.Arity) {
+func (e *Entry) SetArity(a arity.Arity) *Entry {
 	e.arity = a
+	return e
+}
+
+// SetResultType sets the static type of the result of the function, which is
+// used to type-check expressions at compile time.
+func (e *Entry) SetResultType(rt reflect.Type) *Entry {
+	e.result = rt
+	return e
+}
+
+// ResultType returns the static type of the result of the function, or nil if
+// it is not known.
+func (e *Entry) ResultType() reflect.Type {
+	return e.result
+}
+
+// SetPure sets whether the result of the function only depends on its
+// arguments, without side effects. Calls to pure functions with constant
+// arguments are evaluated once at compile time.
+func (e *Entry) SetPure(pure bool) *Entry {
+	e.pure = pure
+	return e
+}
+
+// Pure reports whether the result of the function only depends on its
+// arguments.
+func (e *Entry) Pure() bool {
+	return e.pure
+}
+
+// SetPatternArgs sets the indices of the arguments that are regular
+// expression patterns. Literal patterns are compiled once at compile time, so
+// that invalid literal patterns are compile errors, and other patterns are
+// compiled when they are evaluated. The function receives the compiled
+// patterns as *regexp.Regexp values, or null.
+func (e *Entry) SetPatternArgs(indices ...int) *Entry {
+	e.patterns = indices
+	return e
+}
+
+// PatternArg reports whether the i-th argument of the function is a regular
+// expression pattern.
+func (e *Entry) PatternArg(i int) bool {
+	return slices.Contains(e.patterns, i)
+}
+
+// ParamType returns the static type of the i-th argument of the function. It
+// returns false if the type is not known, which is the case for functions not
+// added with [Table.AddFunc].
+func (e *Entry) ParamType(i int) (reflect.Type, bool) {
+	if e.signature == nil {
+		return nil, false
+	}
+	rt := e.signature
+	i += e.offset
+	if rt.IsVariadic() && i >= rt.NumIn()-1 {
+		return rt.In(rt.NumIn() - 1).Elem(), true
+	}
+	if i >= rt.NumIn() {
+		return nil, false
+	}
+	return rt.In(i), true
 }
 
 // TestArity tests the arity of the function with the given number of arguments.
@@ -37,13 +120,20 @@ func (e *Entry) TestArity(n int) error {
 	return e.arity.Check(n)
 }
 
-// Invoke invokes the function with the given context and arguments.
+// Invoke invokes the function with the given arguments.
 func (e *Entry) Invoke(params ...reflect.Value) (reflect.Value, error) {
+	return e.InvokeContext(context.Background(), params...)
+}
+
+// InvokeContext invokes the function with the given context and arguments.
+// The context is forwarded to functions that accept a [context.Context] as
+// their first parameter.
+func (e *Entry) InvokeContext(ctx context.Context, params ...reflect.Value) (reflect.Value, error) {
 	if err := e.TestArity(len(params)); err != nil {
 		return reflect.Value{}, err
 	}
 
-	return e.fn(params...)
+	return e.fn(ctx, params...)
 }
 
 // var _ Func = (*Entry)(nil)
@@ -76,6 +166,15 @@ func (t *Table) New() *Table {
 // provided to them. To set the arity of the function, use the [Entry.SetArity]
 // method on the returned [Entry].
 func (t *Table) Add(name string, fn funcEntry) *Entry {
+	return t.AddContext(name, func(_ context.Context, params ...reflect.Value) (reflect.Value, error) {
+		return fn(params...)
+	})
+}
+
+// AddContext adds a function to the table that receives the context of the
+// evaluation as its first argument.
+// Like [Table.Add], functions have an arity of zero by default.
+func (t *Table) AddContext(name string, fn contextFuncEntry) *Entry {
 	entry := &Entry{
 		fn:    fn,
 		arity: arity.None(),
@@ -119,7 +218,18 @@ func (t *Table) iterate(yield func(string) bool) {
 
 type funcEntry = func(params ...reflect.Value) (reflect.Value, error)
 
-var errType = reflect.TypeFor[error]()
+// Callable is a function value that can be passed as an argument to a
+// function, such as a lambda expression. Functions in the table receive it as
+// a [reflect.Value] holding a Callable.
+type Callable func(args ...reflect.Value) (reflect.Value, error)
+
+type contextFuncEntry = func(ctx context.Context, params ...reflect.Value) (reflect.Value, error)
+
+var (
+	errType      = reflect.TypeFor[error]()
+	contextType  = reflect.TypeFor[context.Context]()
+	callableType = reflect.TypeFor[Callable]()
+)
 
 // AddFunc is a convenience method for adding a normal Go function to the
 // function table. This will implicitly convert the function to a
@@ -127,42 +237,90 @@ var errType = reflect.TypeFor[error]()
 // arguments and return values of the function. The function must have at
 // least one return value, and at most two return values with the second return
 // value being an [error] type.
+//
+// If the first parameter of the function is a [context.Context], the context
+// of the evaluation is passed to it, and it does not count towards the arity
+// of the function.
+//
+// Parameters of a function type with 1 return value, or 2 return values where
+// the second is an [error], accept a [Callable] argument such as a lambda.
+// Errors raised by the callable through a function type without an error
+// return value are reported as the error of the outer function.
+//
+// Numeric arguments are converted into parameters of other integer or float
+// types, such as an int64 into an int, if they can be without loss.
 func (t *Table) AddFunc(name string, fn any) error {
 	entry, arity, err := t.makeFunc(fn)
 	if err != nil {
 		return err
 	}
-	t.Add(name, entry).SetArity(arity)
+	rt := reflect.TypeOf(fn)
+	e := t.AddContext(name, entry).SetArity(arity).SetResultType(rt.Out(0))
+	e.signature = rt
+	if rt.NumIn() > 0 && rt.In(0) == contextType {
+		e.offset = 1
+	}
 	return nil
 }
 
-func (t *Table) makeFunc(fn any) (funcEntry, arity.Arity, error) {
+// NewFunc returns an entry for a normal Go function that is not part of any
+// function table, such as a method expression, following the same rules as
+// [Table.AddFunc].
+func NewFunc(fn any) (*Entry, error) {
+	t := NewTable()
+	if err := t.AddFunc("", fn); err != nil {
+		return nil, err
+	}
+	return t.entries[""], nil
+}
+
+func (t *Table) makeFunc(fn any) (contextFuncEntry, arity.Arity, error) {
 	rv := reflect.ValueOf(fn)
-	rt := rv.Type()
-	if err := t.validateFuncType(rv, rt); err != nil {
+	if err := t.validateFuncType(rv); err != nil {
 		return nil, nil, err
 	}
+	rt := rv.Type()
 
-	ar := t.getFuncArity(rt)
-	collectArgs := t.getCollectFunc(rt)
+	// Offset of the first parameter provided by the expression, which skips
+	// the context parameter if there is one.
+	offset := 0
+	if rt.NumIn() > 0 && rt.In(0) == contextType {
+		offset = 1
+	}
+
+	ar := t.getFuncArity(rt, offset)
+	collectArgs := t.getCollectFunc(rt, offset)
 	getOut := t.getOutputFunc(rt)
 
-	result := func(in ...reflect.Value) (reflect.Value, error) {
+	result := func(ctx context.Context, in ...reflect.Value) (result reflect.Value, err error) {
 		args, err := collectArgs(in)
 		if err != nil {
 			return reflect.Value{}, err
 		}
+		if offset > 0 {
+			args = append([]reflect.Value{reflect.ValueOf(&ctx).Elem()}, args...)
+		}
 
+		defer func() {
+			if r := recover(); r != nil {
+				cp, ok := r.(callablePanic)
+				if !ok {
+					panic(r)
+				}
+				result, err = reflect.Value{}, cp.err
+			}
+		}()
 		out := rv.Call(args)
 		return getOut(out)
 	}
 	return result, ar, nil
 }
 
-func (t *Table) validateFuncType(rv reflect.Value, rt reflect.Type) error {
+func (t *Table) validateFuncType(rv reflect.Value) error {
 	if rv.Kind() != reflect.Func {
 		return fmt.Errorf("%w: expected a function, got %s", ErrBadFunc, rv.Kind())
 	}
+	rt := rv.Type()
 	if rt.NumOut() > 2 || rt.NumOut() == 0 {
 		return fmt.Errorf("%w: expected a function with 1 or 2 return values, got %d", ErrBadFunc, rt.NumOut())
 	}
@@ -172,28 +330,31 @@ func (t *Table) validateFuncType(rv reflect.Value, rt reflect.Type) error {
 	return nil
 }
 
-func (t *Table) getFuncArity(rt reflect.Type) arity.Arity {
+func (t *Table) getFuncArity(rt reflect.Type, offset int) arity.Arity {
 	if rt.IsVariadic() {
-		return arity.AtLeast(rt.NumIn() - 1)
+		return arity.AtLeast(rt.NumIn() - offset - 1)
 	}
-	return arity.Exactly(rt.NumIn())
+	return arity.Exactly(rt.NumIn() - offset)
 }
 
-func (t *Table) getCollectFunc(rt reflect.Type) func([]reflect.Value) ([]reflect.Value, error) {
+func (t *Table) getCollectFunc(rt reflect.Type, offset int) func([]reflect.Value) ([]reflect.Value, error) {
+	numIn := rt.NumIn() - offset
 	if rt.IsVariadic() {
 		return func(in []reflect.Value) ([]reflect.Value, error) {
 			var args []reflect.Value
-			for i := range rt.NumIn() - 1 {
-				if !in[i].Type().AssignableTo(rt.In(i)) {
-					return nil, conversionError(i, rt.In(i), in[i].Type())
+			for i := range numIn - 1 {
+				in[i] = convertArgument(in[i], rt.In(i+offset))
+				if !in[i].Type().AssignableTo(rt.In(i + offset)) {
+					return nil, conversionError(i, rt.In(i+offset), in[i].Type())
 				}
 				args = append(args, in[i])
 			}
-			rest := in[rt.NumIn()-1:]
+			rest := in[numIn-1:]
 			variadicType := rt.In(rt.NumIn() - 1).Elem()
 			for i := range rest {
+				rest[i] = convertArgument(rest[i], variadicType)
 				if !rest[i].Type().AssignableTo(variadicType) {
-					return nil, conversionError(i+rt.NumIn()-1, variadicType, rest[i].Type())
+					return nil, conversionError(i+numIn-1, variadicType, rest[i].Type())
 				}
 			}
 			args = append(args, rest...)
@@ -201,9 +362,10 @@ func (t *Table) getCollectFunc(rt reflect.Type) func([]reflect.Value) ([]reflect
 		}
 	}
 	return func(in []reflect.Value) ([]reflect.Value, error) {
-		for i := range rt.NumIn() {
-			if !in[i].Type().AssignableTo(rt.In(i)) {
-				return nil, conversionError(i, rt.In(i), in[i].Type())
+		for i := range numIn {
+			in[i] = convertArgument(in[i], rt.In(i+offset))
+			if !in[i].Type().AssignableTo(rt.In(i + offset)) {
+				return nil, conversionError(i, rt.In(i+offset), in[i].Type())
 			}
 		}
 		return in, nil
@@ -224,6 +386,96 @@ func (t *Table) getOutputFunc(rt reflect.Type) func([]reflect.Value) (reflect.Va
 	}
 }
 
+// callablePanic carries an error raised by a [Callable] through a function
+// type that has no error return value.
+type callablePanic struct {
+	err error
+}
+
+// convertArgument converts an argument into the wanted type, if it is a
+// [Callable] or a number that converts into it as described in
+// [Table.AddFunc]. Otherwise, the argument is returned unchanged.
+func convertArgument(arg reflect.Value, want reflect.Type) reflect.Value {
+	if !arg.IsValid() || arg.Type().AssignableTo(want) {
+		return arg
+	}
+	src := arg
+	for src.Kind() == reflect.Interface && !src.IsNil() {
+		src = src.Elem()
+	}
+	if !src.IsValid() || !IsConvertibleNumber(src.Type(), want) {
+		return convertCallable(arg, want)
+	}
+	dst := reflect.New(want).Elem()
+	if err := reflectconv.Decode(dst, src, nil); err != nil {
+		return arg
+	}
+	return dst
+}
+
+// IsConvertibleNumber reports whether numbers of type got are converted into
+// parameters of type want, which is the case if both are integers or both
+// are floats, or if got is an integer and want a float.
+func IsConvertibleNumber(got, want reflect.Type) bool {
+	switch {
+	case reflectconv.IsInt(got):
+		return reflectconv.IsInt(want) || reflectconv.IsFloat(want)
+	case reflectconv.IsFloat(got):
+		return reflectconv.IsFloat(want)
+	}
+	return false
+}
+
+// convertCallable converts a [Callable] argument into a function of the
+// wanted type. If the argument is not a Callable, or the wanted type is not a
+// supported function type, the argument is returned unchanged.
+func convertCallable(arg reflect.Value, want reflect.Type) reflect.Value {
+	if !arg.IsValid() || arg.Type() != callableType || want == callableType || want.Kind() != reflect.Func {
+		return arg
+	}
+	if want.NumOut() == 0 || want.NumOut() > 2 || (want.NumOut() == 2 && want.Out(1) != errType) {
+		return arg
+	}
+	fn := arg.Interface().(Callable)
+	return reflect.MakeFunc(want, func(in []reflect.Value) []reflect.Value {
+		got, err := fn(in...)
+		var result reflect.Value
+		if err == nil {
+			result, err = convertResult(got, want.Out(0))
+		}
+		if err != nil {
+			if want.NumOut() == 1 {
+				panic(callablePanic{err: err})
+			}
+			return []reflect.Value{reflect.Zero(want.Out(0)), reflect.ValueOf(&err).Elem()}
+		}
+		if want.NumOut() == 1 {
+			return []reflect.Value{result}
+		}
+		return []reflect.Value{result, reflect.Zero(errType)}
+	})
+}
+
+// convertResult converts the result of a [Callable] to the wanted type.
+func convertResult(rv reflect.Value, want reflect.Type) (reflect.Value, error) {
+	for rv.IsValid() && rv.Kind() == reflect.Interface && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() || (rv.Kind() == reflect.Interface && rv.IsNil()) {
+		return reflect.Zero(want), nil
+	}
+	if rv.Type().AssignableTo(want) {
+		return rv, nil
+	}
+	sameClass := rv.Kind() == want.Kind() ||
+		(reflectconv.IsInt(rv.Type()) && reflectconv.IsInt(want)) ||
+		(reflectconv.IsFloat(rv.Type()) && reflectconv.IsFloat(want))
+	if sameClass && rv.Type().ConvertibleTo(want) {
+		return rv.Convert(want), nil
+	}
+	return reflect.Value{}, fmt.Errorf("%w: callable must return %s, got %s", ErrBadArgument, want, rv.Type())
+}
+
 func conversionError(i int, want reflect.Type, got reflect.Type) error {
 	return fmt.Errorf("%w: argument %d must be of type %s, got %s", ErrBadArgument, i, want.Name(), got.Name())
 }
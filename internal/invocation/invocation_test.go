@@ -1,6 +1,7 @@
 package invocation_test
 
 import (
+	"context"
 	"reflect"
 	"slices"
 	"strings"
@@ -127,6 +128,12 @@ func TestTable_AddFunc(t *testing.T) {
 }
 
 func TestTable_AddFunc_Invoke(t *testing.T) {
+	isOdd := invocation.Callable(func(args ...reflect.Value) (reflect.Value, error) {
+		return reflect.ValueOf(args[0].Int()%2 == 1), nil
+	})
+	failingCallable := invocation.Callable(func(...reflect.Value) (reflect.Value, error) {
+		return reflect.Value{}, arity.ErrBadArity
+	})
 	testCases := []struct {
 		name    string
 		fn      any
@@ -170,6 +177,30 @@ func TestTable_AddFunc_Invoke(t *testing.T) {
 			params:  []reflect.Value{reflect.ValueOf("42")},
 			want:    reflect.Value{},
 			wantErr: invocation.ErrBadArgument,
+		}, {
+			name:    "function accepting int param called with int64",
+			fn:      func(i int) int { return i },
+			params:  []reflect.Value{reflect.ValueOf(int64(42))},
+			want:    reflect.ValueOf(42),
+			wantErr: nil,
+		}, {
+			name:    "function accepting float param called with int64",
+			fn:      func(f float32) float32 { return f },
+			params:  []reflect.Value{reflect.ValueOf(int64(42))},
+			want:    reflect.ValueOf(float32(42)),
+			wantErr: nil,
+		}, {
+			name:    "function accepting int8 param called with overflowing int64",
+			fn:      func(i int8) int8 { return i },
+			params:  []reflect.Value{reflect.ValueOf(int64(300))},
+			want:    reflect.Value{},
+			wantErr: invocation.ErrBadArgument,
+		}, {
+			name:    "function accepting int param called with float64",
+			fn:      func(i int) int { return i },
+			params:  []reflect.Value{reflect.ValueOf(1.5)},
+			want:    reflect.Value{},
+			wantErr: invocation.ErrBadArgument,
 		}, {
 			name:    "function accepting variadic param returning two values",
 			fn:      func(i int, _ ...int) (int, error) { return i, nil },
@@ -194,12 +225,78 @@ func TestTable_AddFunc_Invoke(t *testing.T) {
 			params:  []reflect.Value{reflect.ValueOf(42), reflect.ValueOf(1), reflect.ValueOf(2)},
 			want:    reflect.ValueOf(42),
 			wantErr: nil,
+		}, {
+			name:    "function accepting variadic param called with int64 params",
+			fn:      func(i int, rest ...int) int { return i + rest[0] },
+			params:  []reflect.Value{reflect.ValueOf(int64(40)), reflect.ValueOf(int64(2))},
+			want:    reflect.ValueOf(42),
+			wantErr: nil,
 		}, {
 			name:    "function accepting variadic param called with multiple wrong types",
 			fn:      func(i int, _ ...int) int { return i },
 			params:  []reflect.Value{reflect.ValueOf(1), reflect.ValueOf(2), reflect.ValueOf("42")},
 			want:    reflect.Value{},
 			wantErr: invocation.ErrBadArgument,
+		}, {
+			name:    "function accepting context and one param",
+			fn:      func(_ context.Context, i int) int { return i },
+			params:  []reflect.Value{reflect.ValueOf(42)},
+			want:    reflect.ValueOf(42),
+			wantErr: nil,
+		}, {
+			name:    "function accepting context called with too many params",
+			fn:      func(_ context.Context, i int) int { return i },
+			params:  []reflect.Value{reflect.ValueOf(42), reflect.ValueOf(1)},
+			want:    reflect.Value{},
+			wantErr: arity.ErrBadArity,
+		}, {
+			name:    "function accepting context and variadic param",
+			fn:      func(_ context.Context, i int, _ ...int) int { return i },
+			params:  []reflect.Value{reflect.ValueOf(42), reflect.ValueOf(1)},
+			want:    reflect.ValueOf(42),
+			wantErr: nil,
+		}, {
+			name:    "function accepting context called with wrong type",
+			fn:      func(_ context.Context, i int) int { return i },
+			params:  []reflect.Value{reflect.ValueOf("42")},
+			want:    reflect.Value{},
+			wantErr: invocation.ErrBadArgument,
+		}, {
+			name: "function accepting func param called with callable",
+			fn: func(items []int, pred func(int) bool) int {
+				n := 0
+				for _, item := range items {
+					if pred(item) {
+						n++
+					}
+				}
+				return n
+			},
+			params:  []reflect.Value{reflect.ValueOf([]int{1, 2, 3}), reflect.ValueOf(isOdd)},
+			want:    reflect.ValueOf(2),
+			wantErr: nil,
+		}, {
+			name: "function accepting func param returning error called with failing callable",
+			fn: func(pred func(int) (bool, error)) (bool, error) {
+				return pred(1)
+			},
+			params:  []reflect.Value{reflect.ValueOf(failingCallable)},
+			want:    reflect.Value{},
+			wantErr: arity.ErrBadArity,
+		}, {
+			name: "function accepting func param called with failing callable",
+			fn: func(pred func(int) bool) bool {
+				return pred(1)
+			},
+			params:  []reflect.Value{reflect.ValueOf(failingCallable)},
+			want:    reflect.Value{},
+			wantErr: arity.ErrBadArity,
+		}, {
+			name:    "function accepting func param called with non-callable",
+			fn:      func(pred func(int) bool) bool { return pred(1) },
+			params:  []reflect.Value{reflect.ValueOf(42)},
+			want:    reflect.Value{},
+			wantErr: invocation.ErrBadArgument,
 		}, {
 			name:    "function returns an error",
 			fn:      func() (int, error) { return 0, arity.ErrBadArity },
@@ -275,3 +372,143 @@ func TestEntry_Invoke(t *testing.T) {
 	}
 
 }
+
+func TestEntry_InvokeContext(t *testing.T) {
+	type key struct{}
+	sut := invocation.NewTable()
+	err := sut.AddFunc("example", func(ctx context.Context, suffix string) string {
+		return ctx.Value(key{}).(string) + suffix
+	})
+	if err != nil {
+		t.Fatalf("failed to add function: %v", err)
+	}
+	entry, ok := sut.Lookup("example")
+	if !ok {
+		t.Fatalf("failed to lookup entry")
+	}
+	ctx := context.WithValue(context.Background(), key{}, "hello")
+
+	result, err := entry.InvokeContext(ctx, reflect.ValueOf(" world"))
+
+	if err != nil {
+		t.Fatalf("InvokeContext() error = %v", err)
+	}
+	if got, want := result.Interface(), "hello world"; got != want {
+		t.Errorf("InvokeContext() = %v, want %v", got, want)
+	}
+}
+
+func TestEntry_ParamType(t *testing.T) {
+	t.Parallel()
+	sut := invocation.NewTable()
+	sut.AddFunc("fixed", func(_ context.Context, s string, i int) bool { return false })
+	sut.AddFunc("variadic", func(s string, rest ...int) bool { return false })
+	sut.Add("raw", func(...reflect.Value) (reflect.Value, error) {
+		return reflect.Value{}, nil
+	}).SetArity(arity.Any())
+
+	testCases := []struct {
+		name   string
+		fn     string
+		i      int
+		want   reflect.Type
+		wantOK bool
+	}{
+		{
+			name:   "first param after context",
+			fn:     "fixed",
+			i:      0,
+			want:   reflect.TypeFor[string](),
+			wantOK: true,
+		}, {
+			name:   "second param after context",
+			fn:     "fixed",
+			i:      1,
+			want:   reflect.TypeFor[int](),
+			wantOK: true,
+		}, {
+			name: "param out of range",
+			fn:   "fixed",
+			i:    2,
+		}, {
+			name:   "variadic param",
+			fn:     "variadic",
+			i:      3,
+			want:   reflect.TypeFor[int](),
+			wantOK: true,
+		}, {
+			name: "raw function",
+			fn:   "raw",
+			i:    0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			entry, ok := sut.Lookup(tc.fn)
+			if !ok {
+				t.Fatalf("failed to lookup entry")
+			}
+
+			got, ok := entry.ParamType(tc.i)
+
+			if got != tc.want || ok != tc.wantOK {
+				t.Errorf("ParamType(%d) = %v, %v, want %v, %v", tc.i, got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestEntry_ResultType(t *testing.T) {
+	t.Parallel()
+	sut := invocation.NewTable()
+	sut.AddFunc("typed", func() (int, error) { return 0, nil })
+	sut.Add("raw", func(...reflect.Value) (reflect.Value, error) {
+		return reflect.Value{}, nil
+	})
+	sut.Add("declared", func(...reflect.Value) (reflect.Value, error) {
+		return reflect.Value{}, nil
+	}).SetResultType(reflect.TypeFor[string]())
+
+	testCases := []struct {
+		fn   string
+		want reflect.Type
+	}{
+		{fn: "typed", want: reflect.TypeFor[int]()},
+		{fn: "raw", want: nil},
+		{fn: "declared", want: reflect.TypeFor[string]()},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.fn, func(t *testing.T) {
+			t.Parallel()
+			entry, ok := sut.Lookup(tc.fn)
+			if !ok {
+				t.Fatalf("failed to lookup entry")
+			}
+
+			if got, want := entry.ResultType(), tc.want; got != want {
+				t.Errorf("ResultType() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestEntry_Pure(t *testing.T) {
+	t.Parallel()
+	sut := invocation.NewTable()
+	impure := sut.Add("impure", func(...reflect.Value) (reflect.Value, error) {
+		return reflect.Value{}, nil
+	})
+	pure := sut.Add("pure", func(...reflect.Value) (reflect.Value, error) {
+		return reflect.Value{}, nil
+	}).SetPure(true)
+
+	if got, want := impure.Pure(), false; got != want {
+		t.Errorf("Pure() = %v, want %v", got, want)
+	}
+	if got, want := pure.Pure(), true; got != want {
+		t.Errorf("Pure() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,104 @@
+package jsondoc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Decode decodes the JSON document read from r, skipping the members that are
+// not touched by paths. The keys of skipped members are kept with null values,
+// so that a misspelled member is reported with the same suggestions as it is
+// for the equivalent struct. Objects decode into map[string]any, arrays into []any,
+// and numbers into int64 if they are integers that fit, uint64 if they are
+// larger integers that fit, and float64 otherwise, so that large integers such
+// as IDs stay exact.
+func Decode(r io.Reader, paths *Paths) (any, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	value, err := decodeValue(dec, paths)
+	if err != nil {
+		return nil, err
+	}
+	switch _, err := dec.Token(); err {
+	case io.EOF:
+		return value, nil
+	case nil:
+		return nil, errors.New("json: invalid data after top-level value")
+	default:
+		return nil, err
+	}
+}
+
+func decodeValue(dec *json.Decoder, paths *Paths) (any, error) {
+	if paths == nil {
+		var raw json.RawMessage
+		return nil, dec.Decode(&raw)
+	}
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok := tok.(type) {
+	case json.Delim:
+		if tok == '{' {
+			return decodeObject(dec, paths)
+		}
+		return decodeArray(dec, paths)
+	case json.Number:
+		return number(tok)
+	}
+	return tok, nil
+}
+
+func decodeObject(dec *json.Decoder, paths *Paths) (any, error) {
+	result := make(map[string]any)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key := tok.(string)
+		member := paths.Member(key)
+		value, err := decodeValue(dec, member)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = value
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func decodeArray(dec *json.Decoder, paths *Paths) (any, error) {
+	result := make([]any, 0)
+	for dec.More() {
+		value, err := decodeValue(dec, paths)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func number(n json.Number) (any, error) {
+	if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+		return i, nil
+	}
+	if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+		return u, nil
+	}
+	f, err := strconv.ParseFloat(string(n), 64)
+	if err != nil {
+		return nil, fmt.Errorf("json: invalid number %s: %w", n, err)
+	}
+	return f, nil
+}
@@ -0,0 +1,201 @@
+package jsondoc_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"rodusek.dev/pkg/dcell/internal/compile"
+	"rodusek.dev/pkg/dcell/internal/invocation"
+	"rodusek.dev/pkg/dcell/internal/jsondoc"
+	"rodusek.dev/pkg/dcell/internal/stdlib"
+)
+
+const document = `{
+	"action": "opened",
+	"number": 9007199254740993,
+	"pull_request": {
+		"title": "Add JSON",
+		"labels": [{"name": "bug", "color": "red"}, {"name": "ui", "color": "blue"}],
+		"user": {"login": "octocat", "id": 1}
+	},
+	"sender": {"login": "octocat", "id": 1}
+}`
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		expression string
+		want       any
+	}{
+		{
+			name:       "Constant",
+			expression: `1 + 2`,
+			want:       map[string]any{"action": nil, "number": nil, "pull_request": nil, "sender": nil},
+		}, {
+			name:       "Member",
+			expression: `action == "opened"`,
+			want:       map[string]any{"action": "opened", "number": nil, "pull_request": nil, "sender": nil},
+		}, {
+			name:       "Nested member",
+			expression: `pull_request.user.login`,
+			want: map[string]any{
+				"action": nil,
+				"number": nil,
+				"pull_request": map[string]any{
+					"title":  nil,
+					"labels": nil,
+					"user":   map[string]any{"login": "octocat", "id": nil},
+				},
+				"sender": nil,
+			},
+		}, {
+			name:       "Member projected over array",
+			expression: `pull_request.labels[0].name`,
+			want: map[string]any{
+				"action": nil,
+				"number": nil,
+				"pull_request": map[string]any{
+					"title": nil,
+					"labels": []any{
+						map[string]any{"name": "bug", "color": nil},
+						map[string]any{"name": "ui", "color": nil},
+					},
+					"user": nil,
+				},
+				"sender": nil,
+			},
+		}, {
+			name:       "Value used entirely",
+			expression: `sender`,
+			want: map[string]any{
+				"action":       nil,
+				"number":       nil,
+				"pull_request": nil,
+				"sender":       map[string]any{"login": "octocat", "id": int64(1)},
+			},
+		}, {
+			name:       "Receiver of member function",
+			expression: `pull_request.labels.count() > 1`,
+			want: map[string]any{
+				"action": nil,
+				"number": nil,
+				"pull_request": map[string]any{
+					"title": nil,
+					"labels": []any{
+						map[string]any{"name": "bug", "color": "red"},
+						map[string]any{"name": "ui", "color": "blue"},
+					},
+					"user": nil,
+				},
+				"sender": nil,
+			},
+		}, {
+			name:       "Lambda refers to root",
+			expression: `count(pull_request.labels, l => l.name == action)`,
+			want: map[string]any{
+				"action": "opened",
+				"number": nil,
+				"pull_request": map[string]any{
+					"title": nil,
+					"labels": []any{
+						map[string]any{"name": "bug", "color": "red"},
+						map[string]any{"name": "ui", "color": "blue"},
+					},
+					"user": nil,
+				},
+				"sender": nil,
+			},
+		}, {
+			name:       "Wildcard",
+			expression: `sender.*`,
+			want: map[string]any{
+				"action":       nil,
+				"number":       nil,
+				"pull_request": nil,
+				"sender":       map[string]any{"login": "octocat", "id": int64(1)},
+			},
+		}, {
+			name:       "Large integer",
+			expression: `number`,
+			want:       map[string]any{"action": nil, "number": int64(9007199254740993), "pull_request": nil, "sender": nil},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			table := invocation.NewTable()
+			stdlib.AddCollections(table)
+			tree, err := compile.NewTree(tc.expression, &compile.Config{FuncTable: table})
+			if err != nil {
+				t.Fatalf("NewTree(%q) error = %v", tc.expression, err)
+			}
+
+			got, err := jsondoc.Decode(strings.NewReader(document), jsondoc.Analyze(tree))
+
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if want := tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Decode() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestDecode_Numbers(t *testing.T) {
+	t.Parallel()
+	tree, err := compile.NewTree(`numbers`, &compile.Config{FuncTable: invocation.NewTable()})
+	if err != nil {
+		t.Fatalf("NewTree() error = %v", err)
+	}
+	input := `{"numbers": [1, -9223372036854775808, 18446744073709551615, 1.5, 1e3, 1.0, 99999999999999999999]}`
+
+	got, err := jsondoc.Decode(strings.NewReader(input), jsondoc.Analyze(tree))
+
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	want := map[string]any{"numbers": []any{
+		int64(1), int64(math.MinInt64), uint64(math.MaxUint64), 1.5, 1000.0, 1.0, 1e20,
+	}}
+	if !cmp.Equal(got, want) {
+		t.Errorf("Decode() = %v, want %v", got, want)
+	}
+}
+
+func TestDecode_Error(t *testing.T) {
+	t.Parallel()
+	tree, err := compile.NewTree(`a`, &compile.Config{FuncTable: invocation.NewTable()})
+	if err != nil {
+		t.Fatalf("NewTree() error = %v", err)
+	}
+
+	testCases := []struct {
+		name  string
+		input string
+	}{
+		{name: "Empty", input: ``},
+		{name: "Truncated", input: `{"a": [1, 2`},
+		{name: "Invalid skipped member", input: `{"b": [1,, 2], "a": 1}`},
+		{name: "Trailing data", input: `{"a": 1} {}`},
+		{name: "Trailing garbage", input: `{"a": 1} ]`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := jsondoc.Decode(strings.NewReader(tc.input), jsondoc.Analyze(tree))
+
+			if got, want := err, cmpopts.AnyError; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Errorf("Decode() error = %v, want %v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Package jsondoc decodes JSON documents for evaluation, decoding only the parts
+of the document that an expression can observe.
+*/
+package jsondoc
+
+import (
+	"reflect"
+
+	"rodusek.dev/pkg/dcell/internal/expr"
+)
+
+// Paths is a tree of the members of a document that an expression touches.
+// Each node is either touched entirely, in which case the whole value at its
+// path is needed, or only through the members that are its children.
+//
+// The elements of arrays share the node of the array, since members of arrays
+// are projected over their elements. Members indexed by a constant key, as in
+// `headers["Content-Type"]`, are nodes like any other member.
+type Paths struct {
+	all     bool
+	members map[string]*Paths
+}
+
+// Analyze returns the paths of the document that evaluating e can touch. The
+// analysis is conservative: values that are passed to functions, operators, or
+// that are the result of e are touched entirely, as is the current value of
+// any expression that the analysis does not know.
+func Analyze(e expr.Expr) *Paths {
+	root := &Paths{}
+	root.member(e).use()
+	return root
+}
+
+// Member returns the paths touched under the member with the given name, or
+// nil if the member is not touched.
+func (p *Paths) Member(name string) *Paths {
+	if p == nil || p.all {
+		return p
+	}
+	return p.members[name]
+}
+
+// child returns the node of the member with the given name, adding it if it
+// does not exist. It returns nil if p is nil, which is the node of values that
+// are not part of the document.
+func (p *Paths) child(name string) *Paths {
+	if p == nil || p.all {
+		return p
+	}
+	if p.members == nil {
+		p.members = make(map[string]*Paths)
+	}
+	node, ok := p.members[name]
+	if !ok {
+		node = &Paths{}
+		p.members[name] = node
+	}
+	return node
+}
+
+// use marks the entire value at the path as touched.
+func (p *Paths) use() {
+	if p != nil {
+		p.all = true
+		p.members = nil
+	}
+}
+
+// member records the paths touched by evaluating e with the value at p as
+// the current value, and returns the node of the value that e evaluates to,
+// or nil if that value is not part of the document.
+func (p *Paths) member(e expr.Expr) *Paths {
+	switch e := e.(type) {
+	case expr.LiteralExpr, expr.VariableExpr, expr.ParamExpr:
+		return nil
+	case expr.MemberExpr:
+		return p.child(string(e))
+	case expr.WildcardExpr:
+		p.use()
+		return nil
+	case expr.SequenceExpr:
+		current := p
+		for _, step := range e {
+			current = current.member(step)
+		}
+		return current
+	case *expr.NonNullExpr:
+		return p.member(e.Expr)
+	case expr.IndexExpr:
+		if literal, ok := e.Index.(expr.LiteralExpr); ok {
+			key := reflect.Value(literal)
+			if key.Kind() == reflect.String {
+				return p.child(key.String())
+			}
+			return p
+		}
+		// A dynamic index may be the key of any member of an object.
+		p.operands(e.Index)
+		p.use()
+		return nil
+	case *expr.IndexSliceExpr:
+		p.operands(e.Begin)
+		if e.End != nil {
+			p.operands(e.End)
+		}
+		return p
+	case *expr.FreeFuncExpr:
+		p.operands(e.Args...)
+	case *expr.MemberFuncExpr:
+		p.use()
+		p.operands(e.Args...)
+	case *expr.MethodExpr:
+		p.use()
+		p.operands(e.Args...)
+	case *expr.LambdaExpr:
+		p.operands(e.Body)
+	case *expr.HasExpr:
+		p.operands(e.Expr)
+	case *expr.ListExpr:
+		p.operands(e.Elems...)
+	case *expr.ObjectExpr:
+		p.operands(e.Values...)
+	case expr.LogicalNotExpr:
+		p.operands(e.Expr)
+	case expr.BitwiseNotExpr:
+		p.operands(e.Expr)
+	case expr.PolarityPlusExpr:
+		p.operands(e.Expr)
+	case expr.PolarityMinusExpr:
+		p.operands(e.Expr)
+	case *expr.IsExpr:
+		p.operands(e.Expr)
+	case *expr.AsExpr:
+		p.operands(e.Expr)
+	case *expr.FormatExpr:
+		p.operands(e.Expr)
+	case *expr.PatternExpr:
+		p.operands(e.Expr)
+	case *expr.AddExpr:
+		p.operands(e.Left, e.Right)
+	case *expr.SubtractExpr:
+		p.operands(e.Left, e.Right)
+	case *expr.MultiplyExpr:
+		p.operands(e.Left, e.Right)
+	case *expr.DivideExpr:
+		p.operands(e.Left, e.Right)
+	case *expr.FloorDivideExpr:
+		p.operands(e.Left, e.Right)
+	case *expr.ModulusExpr:
+		p.operands(e.Left, e.Right)
+	case *expr.PowerExpr:
+		p.operands(e.Left, e.Right)
+	case *expr.BitwiseAndExpr:
+		p.operands(e.Left, e.Right)
+	case *expr.BitwiseOrExpr:
+		p.operands(e.Left, e.Right)
+	case *expr.BitwiseXorExpr:
+		p.operands(e.Left, e.Right)
+	case *expr.BitwiseShiftLeftExpr:
+		p.operands(e.Left, e.Right)
+	case *expr.BitwiseShiftRightExpr:
+		p.operands(e.Left, e.Right)
+	case *expr.EqualityExpr:
+		p.operands(e.Left, e.Right)
+	case *expr.InequalityExpr:
+		p.operands(e.Left, e.Right)
+	case *expr.ImpliesExpr:
+		p.operands(e.Left, e.Right)
+	case *expr.InExpr:
+		p.operands(e.Left, e.Right)
+	case *expr.MatchExpr:
+		p.operands(e.Left, e.Pattern)
+	case *expr.LogicalAndExpr:
+		p.operands(e.Left, e.Right)
+	case *expr.LogicalOrExpr:
+		p.operands(e.Left, e.Right)
+	case *expr.CoalesceExpr:
+		p.operands(e.Left, e.Right)
+	case *expr.TernaryExpr:
+		p.operands(e.Condition, e.TrueExpr, e.FalseExpr)
+	default:
+		p.use()
+	}
+	return nil
+}
+
+// operands records the paths touched by expressions whose values are used
+// entirely, such as the operands of operators and the arguments of functions.
+func (p *Paths) operands(exprs ...expr.Expr) {
+	for _, e := range exprs {
+		p.member(e).use()
+	}
+}
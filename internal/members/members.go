@@ -0,0 +1,480 @@
+/*
+Package members resolves the names by which expressions access the fields and
+methods of Go values.
+*/
+package members
+
+import (
+	"iter"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"rodusek.dev/pkg/dcell/internal/errs"
+)
+
+// Policy is the policy that names the fields of structs. A nil Policy names
+// fields by their `dcell` tag or, if they have none, their Go name, and
+// matches names exactly.
+//
+// The fields of each struct type are cached by the Policy once they are first
+// requested, so a Policy must not be modified after it is used.
+type Policy struct {
+	// Tags are the keys of the struct tags that name a field, in order of
+	// precedence. The first tag that a field has names it; the text of the tag
+	// up to the first comma is the name, as in `json:"name,omitempty"`. A tag
+	// of "-" hides the field. If nil, only the `dcell` tag is consulted.
+	Tags []string
+
+	// IgnoreCase matches names case-insensitively.
+	IgnoreCase bool
+
+	// FoldSeparators matches names treating '-' and '_' as equivalent.
+	FoldSeparators bool
+
+	// Methods are the types whose exported methods may be called by member
+	// function calls that match no function of the function table. If nil,
+	// no methods may be called.
+	Methods *TypeSet
+
+	// Getters are the types whose exported methods named GetX, taking no
+	// arguments, are readable as members named X. If nil, no getters are
+	// read.
+	Getters *TypeSet
+
+	// MissingAsNull evaluates members that values do not have to null, rather
+	// than failing with an [errs.NameError].
+	MissingAsNull bool
+
+	// tables caches the *table of each struct type.
+	tables sync.Map
+}
+
+// TypeSet is a set of Go types. A nil TypeSet contains no types.
+type TypeSet struct {
+	// All is whether the set contains every type.
+	All bool
+
+	// Types are the types in the set. Pointers to the types, and the types
+	// that pointers in the set point to, are in the set as well.
+	Types []reflect.Type
+}
+
+// Contains reports whether rt is in the set.
+func (s *TypeSet) Contains(rt reflect.Type) bool {
+	if s == nil || rt == nil {
+		return false
+	}
+	if s.All {
+		return true
+	}
+	rt = deref(rt)
+	for _, t := range s.Types {
+		if deref(t) == rt {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultTag is the key of the struct tag that names fields.
+const DefaultTag = "dcell"
+
+var defaultTags = []string{DefaultTag}
+
+// Field is a field of a struct type that is visible to expressions.
+type Field struct {
+	// Name is the name of the field in expressions.
+	Name string
+
+	// Index is the index sequence of the field in the struct type, as for
+	// [reflect.Value.FieldByIndex]. Fields promoted from embedded structs
+	// have an index for each struct that they are promoted through.
+	Index []int
+
+	// Embedded is whether the field is an embedded struct, or pointer to a
+	// struct, whose fields are promoted.
+	Embedded bool
+
+	// path is the name of the field preceded by the names of the embedded
+	// structs that it is promoted through, such as `User.login`.
+	path string
+}
+
+// Fields returns the fields of a struct type that are visible to expressions,
+// in declaration order. Unexported fields, and fields hidden by a tag of "-",
+// are not visible. The returned slice is shared, and must not be modified.
+//
+// The fields of embedded structs, and of embedded pointers to structs, are
+// promoted following the rules of Go: a field at a shallower depth shadows
+// fields of the same name at deeper depths, and fields of the same name at
+// the same depth are ambiguous and not visible. Embedded structs that are
+// named by a tag are not promoted. Unexported embedded structs are not visible
+// themselves, but their exported fields are promoted.
+func (p *Policy) Fields(rt reflect.Type) []Field {
+	return p.table(rt).fields
+}
+
+// Names returns the names of the fields of a struct type that are visible to
+// expressions, in declaration order. Embedded structs whose fields are
+// promoted are not named.
+func (p *Policy) Names(rt reflect.Type) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for _, field := range p.Fields(rt) {
+			if field.Embedded {
+				continue
+			}
+			if !yield(field.Name) {
+				return
+			}
+		}
+	}
+}
+
+// Field returns the field of a struct type that the given name refers to. A
+// field whose name is exactly the given name takes precedence over fields
+// that only match it case-insensitively or with separators folded.
+func (p *Policy) Field(rt reflect.Type, name string) (Field, bool) {
+	field, ok, _ := p.Lookup(rt, name)
+	return field, ok
+}
+
+// Lookup returns the field of a struct type that the given name refers to, as
+// [Policy.Field] does, or else the error of [Policy.Ambiguous] for the name.
+func (p *Policy) Lookup(rt reflect.Type, name string) (Field, bool, error) {
+	t := p.table(rt)
+	if field, ok := p.match(t, name); ok {
+		return field, true, nil
+	}
+	return Field{}, false, t.ambiguity(name)
+}
+
+// match returns the field of the table that the given name refers to.
+func (p *Policy) match(t *table, name string) (Field, bool) {
+	fields := t.fields
+	for _, field := range fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	if _, ok := t.ambiguous[name]; ok {
+		return Field{}, false
+	}
+	if p == nil || (!p.IgnoreCase && !p.FoldSeparators) {
+		return Field{}, false
+	}
+	name = p.normalize(name)
+	for _, field := range fields {
+		if p.normalize(field.Name) == name {
+			return field, true
+		}
+	}
+	return Field{}, false
+}
+
+// Ambiguous returns an [errs.AmbiguousNameError] if the given name refers to
+// more than one field promoted from the embedded structs of a struct type at
+// the same depth, or nil otherwise.
+func (p *Policy) Ambiguous(rt reflect.Type, name string) error {
+	return p.table(rt).ambiguity(name)
+}
+
+// table is the table of the visible fields of a struct type, and of the fields
+// of each name that is ambiguous.
+type table struct {
+	fields    []Field
+	ambiguous map[string][]Field
+}
+
+// ambiguity returns the [errs.AmbiguousNameError] of the given name, or nil if
+// the name is not ambiguous.
+func (t *table) ambiguity(name string) error {
+	candidates, ok := t.ambiguous[name]
+	if !ok {
+		return nil
+	}
+	err := &errs.AmbiguousNameError{Input: name}
+	for _, field := range candidates {
+		err.Candidates = append(err.Candidates, field.path)
+	}
+	return err
+}
+
+// defaultTables caches the tables of the nil Policy.
+var defaultTables sync.Map
+
+// table returns the field table of a struct type, computing it on first use.
+func (p *Policy) table(rt reflect.Type) *table {
+	cache := &defaultTables
+	if p != nil {
+		cache = &p.tables
+	}
+	if t, ok := cache.Load(rt); ok {
+		return t.(*table)
+	}
+	fields, ambiguous := p.fields(rt)
+	t, _ := cache.LoadOrStore(rt, &table{fields: fields, ambiguous: ambiguous})
+	return t.(*table)
+}
+
+// embedded is an embedded struct whose fields are promoted.
+type embedded struct {
+	rt    reflect.Type
+	index []int
+	path  string
+}
+
+// fields returns the visible fields of a struct type, and the fields of each
+// name that is ambiguous.
+func (p *Policy) fields(rt reflect.Type) ([]Field, map[string][]Field) {
+	var fields []Field
+	var ambiguous map[string][]Field
+
+	// Names that are decided by a shallower depth, either as a visible field
+	// or as an ambiguous name, and the struct types that were expanded at a
+	// shallower depth, whose fields would be shadowed.
+	decided := make(map[string]bool)
+	expanded := map[reflect.Type]bool{rt: true}
+
+	for level := []embedded{{rt: rt}}; len(level) > 0; {
+		var next []embedded
+		var names []string
+		candidates := make(map[string][]Field)
+		for _, parent := range level {
+			for i := range parent.rt.NumField() {
+				sf := parent.rt.Field(i)
+				name, tagged, ok := p.name(sf)
+				if !ok {
+					continue
+				}
+				promotes := sf.Anonymous && !tagged && deref(sf.Type).Kind() == reflect.Struct
+				if !sf.IsExported() && !promotes {
+					continue
+				}
+				field := Field{
+					Name:     name,
+					Index:    append(slices.Clip(parent.index), i),
+					Embedded: promotes,
+					path:     parent.path + name,
+				}
+				if field.Embedded {
+					next = append(next, embedded{rt: deref(sf.Type), index: field.Index, path: field.path + "."})
+				}
+				// The fields of unexported embedded structs are promoted, but
+				// the structs themselves are not visible.
+				if !sf.IsExported() || decided[name] {
+					continue
+				}
+				if _, ok := candidates[name]; !ok {
+					names = append(names, name)
+				}
+				candidates[name] = append(candidates[name], field)
+			}
+		}
+		for _, name := range names {
+			decided[name] = true
+			if len(candidates[name]) == 1 {
+				fields = append(fields, candidates[name][0])
+				continue
+			}
+			if ambiguous == nil {
+				ambiguous = make(map[string][]Field)
+			}
+			ambiguous[name] = candidates[name]
+		}
+
+		level = next[:0]
+		for _, e := range next {
+			if !expanded[e.rt] {
+				level = append(level, e)
+			}
+		}
+		for _, e := range level {
+			expanded[e.rt] = true
+		}
+	}
+	slices.SortFunc(fields, func(a, b Field) int {
+		return slices.Compare(a.Index, b.Index)
+	})
+	return fields, ambiguous
+}
+
+// AllowsMissing reports whether members that values do not have evaluate to
+// null.
+func (p *Policy) AllowsMissing() bool {
+	return p != nil && p.MissingAsNull
+}
+
+// AllowsMethods reports whether the exported methods of values of type rt may
+// be called by expressions.
+func (p *Policy) AllowsMethods(rt reflect.Type) bool {
+	return p != nil && p.Methods.Contains(rt)
+}
+
+// HasMethods reports whether the methods of any type may be called by
+// expressions.
+func (p *Policy) HasMethods() bool {
+	return p != nil && p.Methods != nil
+}
+
+// Method returns the exported method of values of type rt, or of pointers to
+// them, with the given Go name. It returns false if the method does not
+// exist, or if the methods of rt may not be called.
+func (p *Policy) Method(rt reflect.Type, name string) (reflect.Method, bool) {
+	if !p.AllowsMethods(rt) {
+		return reflect.Method{}, false
+	}
+	return reflect.PointerTo(deref(rt)).MethodByName(name)
+}
+
+// MethodNames returns the names of the methods of values of type rt that may
+// be called by expressions.
+func (p *Policy) MethodNames(rt reflect.Type) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		if !p.AllowsMethods(rt) {
+			return
+		}
+		ptr := reflect.PointerTo(deref(rt))
+		for i := range ptr.NumMethod() {
+			if !yield(ptr.Method(i).Name) {
+				return
+			}
+		}
+	}
+}
+
+// Getter returns the getter of values of type rt, or of pointers to them,
+// that reads the member with the given name. A getter named GetX reads the
+// member X, which may also be written with its first letter in lower case,
+// as in `name` for GetName. A getter whose member name is exactly the given
+// name takes precedence over getters that only match it case-insensitively
+// or with separators folded.
+func (p *Policy) Getter(rt reflect.Type, name string) (reflect.Method, bool) {
+	getters := p.getters(rt)
+	for _, getter := range getters {
+		if member := getterName(getter); member == name || lowerFirst(member) == name {
+			return getter, true
+		}
+	}
+	if p == nil || (!p.IgnoreCase && !p.FoldSeparators) {
+		return reflect.Method{}, false
+	}
+	name = p.normalize(name)
+	for _, getter := range getters {
+		if p.normalize(getterName(getter)) == name {
+			return getter, true
+		}
+	}
+	return reflect.Method{}, false
+}
+
+// GetterNames returns the names of the members of values of type rt that are
+// read by getters.
+func (p *Policy) GetterNames(rt reflect.Type) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for _, getter := range p.getters(rt) {
+			if !yield(getterName(getter)) {
+				return
+			}
+		}
+	}
+}
+
+// MemberNames returns the names of the members of values of type rt, which
+// are the names of its fields if it is a struct type, followed by the names of
+// the members read by getters.
+func (p *Policy) MemberNames(rt reflect.Type) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		if deref(rt).Kind() == reflect.Struct {
+			for name := range p.Names(deref(rt)) {
+				if !yield(name) {
+					return
+				}
+			}
+		}
+		for name := range p.GetterNames(rt) {
+			if !yield(name) {
+				return
+			}
+		}
+	}
+}
+
+// getters returns the getters of values of type rt, or of pointers to them.
+// Getters are methods named GetX that take no arguments, and return a value
+// or a value and an error.
+func (p *Policy) getters(rt reflect.Type) []reflect.Method {
+	if p == nil || !p.Getters.Contains(rt) {
+		return nil
+	}
+	ptr := reflect.PointerTo(deref(rt))
+	var getters []reflect.Method
+	for i := range ptr.NumMethod() {
+		method := ptr.Method(i)
+		if len(method.Name) <= len("Get") || !strings.HasPrefix(method.Name, "Get") {
+			continue
+		}
+		mt := method.Type
+		if mt.NumIn() != 1 || mt.NumOut() == 0 || mt.NumOut() > 2 {
+			continue
+		}
+		if mt.NumOut() == 2 && mt.Out(1) != errorType {
+			continue
+		}
+		getters = append(getters, method)
+	}
+	return getters
+}
+
+var errorType = reflect.TypeFor[error]()
+
+func getterName(getter reflect.Method) string {
+	return strings.TrimPrefix(getter.Name, "Get")
+}
+
+func lowerFirst(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToLower(r)) + name[size:]
+}
+
+func deref(rt reflect.Type) reflect.Type {
+	for rt.Kind() == reflect.Pointer {
+		rt = rt.Elem()
+	}
+	return rt
+}
+
+// name returns the name of a field, whether the name is given by a tag, and
+// false if the field is hidden.
+func (p *Policy) name(field reflect.StructField) (string, bool, bool) {
+	tags := defaultTags
+	if p != nil && p.Tags != nil {
+		tags = p.Tags
+	}
+	for _, key := range tags {
+		tag, ok := field.Tag.Lookup(key)
+		if !ok {
+			continue
+		}
+		if tag == "-" {
+			return "", false, false
+		}
+		if name, _, _ := strings.Cut(tag, ","); name != "" {
+			return name, true, true
+		}
+	}
+	return field.Name, false, true
+}
+
+func (p *Policy) normalize(name string) string {
+	if p.FoldSeparators {
+		name = strings.ReplaceAll(name, "-", "_")
+	}
+	if p.IgnoreCase {
+		name = strings.ToLower(name)
+	}
+	return name
+}
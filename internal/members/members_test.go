@@ -0,0 +1,556 @@
+package members_test
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"rodusek.dev/pkg/dcell/internal/errs"
+	"rodusek.dev/pkg/dcell/internal/members"
+)
+
+type repository struct {
+	FullName string `dcell:"full_name" json:"fullName"`
+	HTMLURL  string `json:"html_url,omitempty"`
+	Private  bool   `json:",omitempty"`
+	Secret   string `dcell:"-" json:"secret"`
+	Token    string `json:"-"`
+	Owner    string `yaml:"owner"`
+	Name     string
+	NAME     string `dcell:"name"`
+
+	unexported string
+}
+
+func TestPolicy_Fields(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		policy *members.Policy
+		want   []string
+	}{
+		{
+			name:   "Default policy",
+			policy: nil,
+			want:   []string{"full_name", "HTMLURL", "Private", "Token", "Owner", "Name", "name"},
+		}, {
+			name:   "Fallback tag",
+			policy: &members.Policy{Tags: []string{"dcell", "json"}},
+			want:   []string{"full_name", "html_url", "Private", "Owner", "Name", "name"},
+		}, {
+			name:   "Fallback tags in order",
+			policy: &members.Policy{Tags: []string{"dcell", "yaml", "json"}},
+			want:   []string{"full_name", "html_url", "Private", "owner", "Name", "name"},
+		}, {
+			name:   "Other tag takes precedence",
+			policy: &members.Policy{Tags: []string{"json", "dcell"}},
+			want:   []string{"fullName", "html_url", "Private", "secret", "Owner", "Name", "name"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := slices.Collect(tc.policy.Names(reflect.TypeFor[repository]()))
+
+			if want := tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Policy.Names() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestPolicy_Field(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		policy *members.Policy
+		input  string
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "Exact match",
+			input:  "full_name",
+			want:   "FullName",
+			wantOK: true,
+		}, {
+			name:  "Hidden field",
+			input: "Secret",
+		}, {
+			name:  "Different case",
+			input: "FULL_NAME",
+		}, {
+			name:   "Different case, ignoring case",
+			policy: &members.Policy{IgnoreCase: true},
+			input:  "FULL_NAME",
+			want:   "FullName",
+			wantOK: true,
+		}, {
+			name:   "Exact match takes precedence over case",
+			policy: &members.Policy{IgnoreCase: true},
+			input:  "Name",
+			want:   "Name",
+			wantOK: true,
+		}, {
+			name:   "First match without exact match",
+			policy: &members.Policy{IgnoreCase: true},
+			input:  "nAmE",
+			want:   "Name",
+			wantOK: true,
+		}, {
+			name:  "Different separator",
+			input: "full-name",
+		}, {
+			name:   "Different separator, folding separators",
+			policy: &members.Policy{FoldSeparators: true},
+			input:  "full-name",
+			want:   "FullName",
+			wantOK: true,
+		}, {
+			name:   "Different separator and case",
+			policy: &members.Policy{FoldSeparators: true},
+			input:  "Full-Name",
+		}, {
+			name:   "Different separator and case, folding both",
+			policy: &members.Policy{IgnoreCase: true, FoldSeparators: true},
+			input:  "Full-Name",
+			want:   "FullName",
+			wantOK: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rt := reflect.TypeFor[repository]()
+
+			field, ok := tc.policy.Field(rt, tc.input)
+
+			if got, want := ok, tc.wantOK; got != want {
+				t.Fatalf("Policy.Field(%q) ok = %v, want %v", tc.input, got, want)
+			}
+			if !ok {
+				return
+			}
+			if got, want := rt.FieldByIndex(field.Index).Name, tc.want; got != want {
+				t.Errorf("Policy.Field(%q) = %v, want %v", tc.input, got, want)
+			}
+		})
+	}
+}
+
+type message struct {
+	name string
+}
+
+func (m *message) GetName() string            { return m.name }
+func (m message) GetURL() (string, error)     { return "", nil }
+func (m message) GetChild(i int) string       { return "" }
+func (m message) GetNothing()                 {}
+func (m message) GetPair() (string, string)   { return "", "" }
+func (m message) Get() string                 { return "" }
+func (m message) Reset()                      {}
+func (m message) Format(layout string) string { return layout }
+func (m *message) GetDisplayName() string     { return m.name }
+
+func TestTypeSet_Contains(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		set   *members.TypeSet
+		input reflect.Type
+		want  bool
+	}{
+		{
+			name:  "Nil set",
+			input: reflect.TypeFor[message](),
+		}, {
+			name:  "All types",
+			set:   &members.TypeSet{All: true},
+			input: reflect.TypeFor[message](),
+			want:  true,
+		}, {
+			name:  "Type in set",
+			set:   &members.TypeSet{Types: []reflect.Type{reflect.TypeFor[message]()}},
+			input: reflect.TypeFor[message](),
+			want:  true,
+		}, {
+			name:  "Pointer to type in set",
+			set:   &members.TypeSet{Types: []reflect.Type{reflect.TypeFor[message]()}},
+			input: reflect.TypeFor[*message](),
+			want:  true,
+		}, {
+			name:  "Type that pointer in set points to",
+			set:   &members.TypeSet{Types: []reflect.Type{reflect.TypeFor[*message]()}},
+			input: reflect.TypeFor[message](),
+			want:  true,
+		}, {
+			name:  "Type not in set",
+			set:   &members.TypeSet{Types: []reflect.Type{reflect.TypeFor[message]()}},
+			input: reflect.TypeFor[repository](),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tc.set.Contains(tc.input)
+
+			if want := tc.want; got != want {
+				t.Errorf("TypeSet.Contains(%v) = %v, want %v", tc.input, got, want)
+			}
+		})
+	}
+}
+
+func TestPolicy_Method(t *testing.T) {
+	t.Parallel()
+	allowed := &members.TypeSet{Types: []reflect.Type{reflect.TypeFor[message]()}}
+
+	testCases := []struct {
+		name   string
+		policy *members.Policy
+		input  string
+		wantOK bool
+	}{
+		{
+			name:  "Default policy",
+			input: "Format",
+		}, {
+			name:   "Value receiver",
+			policy: &members.Policy{Methods: allowed},
+			input:  "Format",
+			wantOK: true,
+		}, {
+			name:   "Pointer receiver",
+			policy: &members.Policy{Methods: allowed},
+			input:  "GetName",
+			wantOK: true,
+		}, {
+			name:   "Unknown method",
+			policy: &members.Policy{Methods: allowed},
+			input:  "format",
+		}, {
+			name:   "Getters do not allow methods",
+			policy: &members.Policy{Getters: allowed},
+			input:  "Format",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			method, ok := tc.policy.Method(reflect.TypeFor[message](), tc.input)
+
+			if got, want := ok, tc.wantOK; got != want {
+				t.Fatalf("Policy.Method(%q) ok = %v, want %v", tc.input, got, want)
+			}
+			if got, want := method.Name, tc.input; ok && got != want {
+				t.Errorf("Policy.Method(%q) = %v, want %v", tc.input, got, want)
+			}
+		})
+	}
+}
+
+func TestPolicy_Getter(t *testing.T) {
+	t.Parallel()
+	allowed := &members.TypeSet{Types: []reflect.Type{reflect.TypeFor[message]()}}
+
+	testCases := []struct {
+		name   string
+		policy *members.Policy
+		input  string
+		want   string
+		wantOK bool
+	}{
+		{
+			name:  "Default policy",
+			input: "Name",
+		}, {
+			name:   "Exact match",
+			policy: &members.Policy{Getters: allowed},
+			input:  "Name",
+			want:   "GetName",
+			wantOK: true,
+		}, {
+			name:   "Lower case first letter",
+			policy: &members.Policy{Getters: allowed},
+			input:  "displayName",
+			want:   "GetDisplayName",
+			wantOK: true,
+		}, {
+			name:   "Error result",
+			policy: &members.Policy{Getters: allowed},
+			input:  "URL",
+			want:   "GetURL",
+			wantOK: true,
+		}, {
+			name:   "Different case",
+			policy: &members.Policy{Getters: allowed},
+			input:  "url",
+		}, {
+			name:   "Different case, ignoring case",
+			policy: &members.Policy{Getters: allowed, IgnoreCase: true},
+			input:  "url",
+			want:   "GetURL",
+			wantOK: true,
+		}, {
+			name:   "Getter with arguments",
+			policy: &members.Policy{Getters: allowed},
+			input:  "Child",
+		}, {
+			name:   "Getter without results",
+			policy: &members.Policy{Getters: allowed},
+			input:  "Nothing",
+		}, {
+			name:   "Getter with second result that is not an error",
+			policy: &members.Policy{Getters: allowed},
+			input:  "Pair",
+		}, {
+			name:   "Methods do not allow getters",
+			policy: &members.Policy{Methods: allowed},
+			input:  "Name",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			getter, ok := tc.policy.Getter(reflect.TypeFor[message](), tc.input)
+
+			if got, want := ok, tc.wantOK; got != want {
+				t.Fatalf("Policy.Getter(%q) ok = %v, want %v", tc.input, got, want)
+			}
+			if got, want := getter.Name, tc.want; ok && got != want {
+				t.Errorf("Policy.Getter(%q) = %v, want %v", tc.input, got, want)
+			}
+		})
+	}
+}
+
+func TestPolicy_MemberNames(t *testing.T) {
+	t.Parallel()
+	policy := &members.Policy{Getters: &members.TypeSet{All: true}}
+
+	got := slices.Collect(policy.MemberNames(reflect.TypeFor[*message]()))
+
+	if want := []string{"DisplayName", "Name", "URL"}; !cmp.Equal(got, want) {
+		t.Errorf("Policy.MemberNames() = %v, want %v", got, want)
+	}
+}
+
+type User struct {
+	Login string `dcell:"login"`
+	ID    int    `dcell:"id"`
+}
+
+type Team struct {
+	ID   int    `dcell:"id"`
+	Name string `dcell:"name"`
+}
+
+type Admin struct {
+	User
+	*Team
+	Level int    `dcell:"level"`
+	Name  string `dcell:"name"`
+}
+
+type Owner struct {
+	Admin
+	User `dcell:"user"`
+}
+
+type Node struct {
+	*Node
+	Value int
+}
+
+type profile struct {
+	Bio string `dcell:"bio"`
+}
+
+type settings struct {
+	Theme string `dcell:"theme"`
+}
+
+type Member struct {
+	profile
+	*settings
+	Level int `dcell:"level"`
+}
+
+func TestPolicy_Fields_Embedded(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		input reflect.Type
+		want  []members.Field
+	}{
+		{
+			name:  "Promoted fields",
+			input: reflect.TypeFor[Admin](),
+			want: []members.Field{
+				{Name: "User", Index: []int{0}, Embedded: true},
+				{Name: "login", Index: []int{0, 0}},
+				{Name: "Team", Index: []int{1}, Embedded: true},
+				{Name: "level", Index: []int{2}},
+				{Name: "name", Index: []int{3}},
+			},
+		}, {
+			name:  "Tagged embedded struct is not promoted",
+			input: reflect.TypeFor[Owner](),
+			want: []members.Field{
+				{Name: "Admin", Index: []int{0}, Embedded: true},
+				{Name: "User", Index: []int{0, 0}, Embedded: true},
+				{Name: "login", Index: []int{0, 0, 0}},
+				{Name: "Team", Index: []int{0, 1}, Embedded: true},
+				{Name: "level", Index: []int{0, 2}},
+				{Name: "name", Index: []int{0, 3}},
+				{Name: "user", Index: []int{1}},
+			},
+		}, {
+			name:  "Recursive embedded pointer",
+			input: reflect.TypeFor[Node](),
+			want: []members.Field{
+				{Name: "Node", Index: []int{0}, Embedded: true},
+				{Name: "Value", Index: []int{1}},
+			},
+		}, {
+			name:  "Unexported embedded structs are promoted",
+			input: reflect.TypeFor[Member](),
+			want: []members.Field{
+				{Name: "bio", Index: []int{0, 0}},
+				{Name: "theme", Index: []int{1, 0}},
+				{Name: "level", Index: []int{2}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := (*members.Policy)(nil).Fields(tc.input)
+
+			if want := tc.want; !cmp.Equal(got, want, cmpopts.IgnoreUnexported(members.Field{})) {
+				t.Errorf("Policy.Fields() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestPolicy_Ambiguous(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name  string
+		input reflect.Type
+		field string
+		want  error
+	}{
+		{
+			name:  "Ambiguous at same depth",
+			input: reflect.TypeFor[Admin](),
+			field: "id",
+			want:  &errs.AmbiguousNameError{Input: "id", Candidates: []string{"User.id", "Team.id"}},
+		}, {
+			name:  "Shadowed at shallower depth",
+			input: reflect.TypeFor[Admin](),
+			field: "name",
+		}, {
+			name:  "Ambiguous through another embedded struct",
+			input: reflect.TypeFor[Owner](),
+			field: "id",
+			want:  &errs.AmbiguousNameError{Input: "id", Candidates: []string{"Admin.User.id", "Admin.Team.id"}},
+		}, {
+			name:  "Unknown field",
+			input: reflect.TypeFor[Admin](),
+			field: "unknown",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			policy := (*members.Policy)(nil)
+
+			got := policy.Ambiguous(tc.input, tc.field)
+
+			if want := tc.want; !cmp.Equal(got, want) {
+				t.Errorf("Policy.Ambiguous(%q) = %v, want %v", tc.field, got, want)
+			}
+			if _, ok := policy.Field(tc.input, tc.field); ok && got != nil {
+				t.Errorf("Policy.Field(%q) ok = true for ambiguous name", tc.field)
+			}
+		})
+	}
+}
+
+func TestPolicy_Lookup(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		field   string
+		want    members.Field
+		wantOK  bool
+		wantErr error
+	}{
+		{
+			name:   "Visible field",
+			field:  "level",
+			want:   members.Field{Name: "level", Index: []int{2}},
+			wantOK: true,
+		}, {
+			name:    "Ambiguous field",
+			field:   "id",
+			wantErr: errs.ErrAmbiguousName,
+		}, {
+			name:  "Unknown field",
+			field: "unknown",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			policy := &members.Policy{}
+
+			got, ok, err := policy.Lookup(reflect.TypeFor[Admin](), tc.field)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Policy.Lookup(%q) error = %v, want %v", tc.field, got, want)
+			}
+			if got, want := ok, tc.wantOK; got != want {
+				t.Fatalf("Policy.Lookup(%q) ok = %v, want %v", tc.field, got, want)
+			}
+			if want := tc.want; !cmp.Equal(got, want, cmpopts.IgnoreUnexported(members.Field{})) {
+				t.Errorf("Policy.Lookup(%q) = %v, want %v", tc.field, got, want)
+			}
+		})
+	}
+}
+
+func TestPolicy_Fields_Cached(t *testing.T) {
+	t.Parallel()
+	policy := &members.Policy{}
+
+	first := policy.Fields(reflect.TypeFor[Admin]())
+	second := policy.Fields(reflect.TypeFor[Admin]())
+
+	if &first[0] != &second[0] {
+		t.Errorf("Policy.Fields() computed the fields again, want cached fields")
+	}
+}
@@ -43,20 +43,22 @@ func dcelllexerLexerInit() {
 		"DEFAULT_MODE",
 	}
 	staticData.LiteralNames = []string{
-		"", "'.'", "'['", "']'", "'is'", "'not'", "'in'", "'('", "')'", "'!'",
-		"'~'", "'+'", "'-'", "'**'", "'*'", "'/'", "'//'", "'%'", "'&&'", "'and'",
-		"'||'", "'or'", "'<->'", "'implies'", "'<<'", "'>>'", "'&'", "'^'",
-		"'|'", "'<='", "'<'", "'>'", "'>='", "'=='", "'!='", "'?'", "':'", "'?:'",
-		"'??'", "'as'", "','", "'true'", "'false'", "'null'", "'int'", "'uint'",
-		"'float'", "'string'", "'bool'",
+		"", "'.'", "'?.'", "'['", "']'", "'is'", "'not'", "'in'", "'('", "')'",
+		"'!'", "'~'", "'+'", "'-'", "'**'", "'*'", "'/'", "'//'", "'%'", "'=~'",
+		"'!~'", "'&&'", "'and'", "'||'", "'or'", "'<->'", "'implies'", "'<<'",
+		"'>>'", "'&'", "'^'", "'|'", "'<='", "'<'", "'>'", "'>='", "'=='", "'!='",
+		"'?'", "':'", "'?:'", "'??'", "'as'", "','", "'=>'", "'true'", "'false'",
+		"'null'", "'int'", "'uint'", "'float'", "'string'", "'bool'", "'{'",
+		"'}'",
 	}
 	staticData.SymbolicNames = []string{
 		"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "",
 		"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "",
-		"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "IDENTIFIER",
-		"DECIMAL_INTEGER", "HEX_INTEGER", "OCTAL_INTEGER", "BINARY_INTEGER",
-		"DECIMAL_FLOAT", "SCIENTIFIC_FLOAT", "SINGLE_QUOTE_STRING", "DOUBLE_QUOTE_STRING",
-		"TRIPLE_QUOTE_STRING", "WS", "COMMENT",
+		"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "",
+		"", "", "", "", "IDENTIFIER", "VARIABLE", "DECIMAL_INTEGER", "HEX_INTEGER",
+		"OCTAL_INTEGER", "BINARY_INTEGER", "DECIMAL_FLOAT", "SCIENTIFIC_FLOAT",
+		"SINGLE_QUOTE_STRING", "DOUBLE_QUOTE_STRING", "TRIPLE_QUOTE_STRING",
+		"FORMAT_STRING", "WS", "COMMENT",
 	}
 	staticData.RuleNames = []string{
 		"T__0", "T__1", "T__2", "T__3", "T__4", "T__5", "T__6", "T__7", "T__8",
@@ -64,14 +66,16 @@ func dcelllexerLexerInit() {
 		"T__17", "T__18", "T__19", "T__20", "T__21", "T__22", "T__23", "T__24",
 		"T__25", "T__26", "T__27", "T__28", "T__29", "T__30", "T__31", "T__32",
 		"T__33", "T__34", "T__35", "T__36", "T__37", "T__38", "T__39", "T__40",
-		"T__41", "T__42", "T__43", "T__44", "T__45", "T__46", "T__47", "IDENTIFIER",
+		"T__41", "T__42", "T__43", "T__44", "T__45", "T__46", "T__47", "T__48",
+		"T__49", "T__50", "T__51", "T__52", "T__53", "IDENTIFIER", "VARIABLE",
 		"DECIMAL_INTEGER", "HEX_INTEGER", "OCTAL_INTEGER", "BINARY_INTEGER",
 		"DECIMAL_FLOAT", "SCIENTIFIC_FLOAT", "SINGLE_QUOTE_STRING", "DOUBLE_QUOTE_STRING",
-		"TRIPLE_QUOTE_STRING", "WS", "COMMENT", "ESC", "UNICODE", "HEX",
+		"TRIPLE_QUOTE_STRING", "FORMAT_STRING", "WS", "COMMENT", "ESC", "INTERPOLATION",
+		"NESTED_STRING", "UNICODE", "HEX",
 	}
 	staticData.PredictionContextCache = antlr.NewPredictionContextCache()
 	staticData.serializedATN = []int32{
-		4, 0, 60, 436, 6, -1, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2,
+		4, 0, 68, 540, 6, -1, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2,
 		4, 7, 4, 2, 5, 7, 5, 2, 6, 7, 6, 2, 7, 7, 7, 2, 8, 7, 8, 2, 9, 7, 9, 2,
 		10, 7, 10, 2, 11, 7, 11, 2, 12, 7, 12, 2, 13, 7, 13, 2, 14, 7, 14, 2, 15,
 		7, 15, 2, 16, 7, 16, 2, 17, 7, 17, 2, 18, 7, 18, 2, 19, 7, 19, 2, 20, 7,
@@ -83,195 +87,248 @@ func dcelllexerLexerInit() {
 		2, 47, 7, 47, 2, 48, 7, 48, 2, 49, 7, 49, 2, 50, 7, 50, 2, 51, 7, 51, 2,
 		52, 7, 52, 2, 53, 7, 53, 2, 54, 7, 54, 2, 55, 7, 55, 2, 56, 7, 56, 2, 57,
 		7, 57, 2, 58, 7, 58, 2, 59, 7, 59, 2, 60, 7, 60, 2, 61, 7, 61, 2, 62, 7,
-		62, 1, 0, 1, 0, 1, 1, 1, 1, 1, 2, 1, 2, 1, 3, 1, 3, 1, 3, 1, 4, 1, 4, 1,
-		4, 1, 4, 1, 5, 1, 5, 1, 5, 1, 6, 1, 6, 1, 7, 1, 7, 1, 8, 1, 8, 1, 9, 1,
-		9, 1, 10, 1, 10, 1, 11, 1, 11, 1, 12, 1, 12, 1, 12, 1, 13, 1, 13, 1, 14,
-		1, 14, 1, 15, 1, 15, 1, 15, 1, 16, 1, 16, 1, 17, 1, 17, 1, 17, 1, 18, 1,
-		18, 1, 18, 1, 18, 1, 19, 1, 19, 1, 19, 1, 20, 1, 20, 1, 20, 1, 21, 1, 21,
-		1, 21, 1, 21, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1, 22, 1,
-		23, 1, 23, 1, 23, 1, 24, 1, 24, 1, 24, 1, 25, 1, 25, 1, 26, 1, 26, 1, 27,
-		1, 27, 1, 28, 1, 28, 1, 28, 1, 29, 1, 29, 1, 30, 1, 30, 1, 31, 1, 31, 1,
-		31, 1, 32, 1, 32, 1, 32, 1, 33, 1, 33, 1, 33, 1, 34, 1, 34, 1, 35, 1, 35,
-		1, 36, 1, 36, 1, 36, 1, 37, 1, 37, 1, 37, 1, 38, 1, 38, 1, 38, 1, 39, 1,
-		39, 1, 40, 1, 40, 1, 40, 1, 40, 1, 40, 1, 41, 1, 41, 1, 41, 1, 41, 1, 41,
-		1, 41, 1, 42, 1, 42, 1, 42, 1, 42, 1, 42, 1, 43, 1, 43, 1, 43, 1, 43, 1,
-		44, 1, 44, 1, 44, 1, 44, 1, 44, 1, 45, 1, 45, 1, 45, 1, 45, 1, 45, 1, 45,
-		1, 46, 1, 46, 1, 46, 1, 46, 1, 46, 1, 46, 1, 46, 1, 47, 1, 47, 1, 47, 1,
-		47, 1, 47, 1, 48, 1, 48, 5, 48, 281, 8, 48, 10, 48, 12, 48, 284, 9, 48,
-		1, 48, 3, 48, 287, 8, 48, 1, 49, 3, 49, 290, 8, 49, 1, 49, 1, 49, 5, 49,
-		294, 8, 49, 10, 49, 12, 49, 297, 9, 49, 1, 49, 3, 49, 300, 8, 49, 1, 50,
-		1, 50, 1, 50, 4, 50, 305, 8, 50, 11, 50, 12, 50, 306, 1, 51, 1, 51, 4,
-		51, 311, 8, 51, 11, 51, 12, 51, 312, 1, 52, 1, 52, 1, 52, 4, 52, 318, 8,
-		52, 11, 52, 12, 52, 319, 1, 53, 3, 53, 323, 8, 53, 1, 53, 1, 53, 1, 53,
-		5, 53, 328, 8, 53, 10, 53, 12, 53, 331, 9, 53, 3, 53, 333, 8, 53, 1, 53,
-		1, 53, 4, 53, 337, 8, 53, 11, 53, 12, 53, 338, 1, 54, 3, 54, 342, 8, 54,
-		1, 54, 1, 54, 1, 54, 5, 54, 347, 8, 54, 10, 54, 12, 54, 350, 9, 54, 3,
-		54, 352, 8, 54, 1, 54, 1, 54, 4, 54, 356, 8, 54, 11, 54, 12, 54, 357, 3,
-		54, 360, 8, 54, 1, 54, 1, 54, 3, 54, 364, 8, 54, 1, 54, 1, 54, 5, 54, 368,
-		8, 54, 10, 54, 12, 54, 371, 9, 54, 1, 55, 1, 55, 1, 55, 5, 55, 376, 8,
-		55, 10, 55, 12, 55, 379, 9, 55, 1, 55, 1, 55, 1, 56, 1, 56, 1, 56, 5, 56,
-		386, 8, 56, 10, 56, 12, 56, 389, 9, 56, 1, 56, 1, 56, 1, 57, 1, 57, 1,
-		57, 1, 57, 1, 57, 1, 57, 5, 57, 399, 8, 57, 10, 57, 12, 57, 402, 9, 57,
-		1, 57, 1, 57, 1, 57, 1, 57, 1, 58, 4, 58, 409, 8, 58, 11, 58, 12, 58, 410,
-		1, 58, 1, 58, 1, 59, 1, 59, 5, 59, 417, 8, 59, 10, 59, 12, 59, 420, 9,
-		59, 1, 59, 1, 59, 1, 60, 1, 60, 1, 60, 3, 60, 427, 8, 60, 1, 61, 1, 61,
-		1, 61, 1, 61, 1, 61, 1, 61, 1, 62, 1, 62, 1, 400, 0, 63, 1, 1, 3, 2, 5,
-		3, 7, 4, 9, 5, 11, 6, 13, 7, 15, 8, 17, 9, 19, 10, 21, 11, 23, 12, 25,
-		13, 27, 14, 29, 15, 31, 16, 33, 17, 35, 18, 37, 19, 39, 20, 41, 21, 43,
-		22, 45, 23, 47, 24, 49, 25, 51, 26, 53, 27, 55, 28, 57, 29, 59, 30, 61,
-		31, 63, 32, 65, 33, 67, 34, 69, 35, 71, 36, 73, 37, 75, 38, 77, 39, 79,
-		40, 81, 41, 83, 42, 85, 43, 87, 44, 89, 45, 91, 46, 93, 47, 95, 48, 97,
-		49, 99, 50, 101, 51, 103, 52, 105, 53, 107, 54, 109, 55, 111, 56, 113,
-		57, 115, 58, 117, 59, 119, 60, 121, 0, 123, 0, 125, 0, 1, 0, 17, 3, 0,
-		65, 90, 95, 95, 97, 122, 5, 0, 45, 45, 48, 57, 65, 90, 95, 95, 97, 122,
-		4, 0, 48, 57, 65, 90, 95, 95, 97, 122, 1, 0, 49, 57, 1, 0, 48, 57, 2, 0,
-		88, 88, 120, 120, 3, 0, 48, 57, 65, 70, 97, 102, 1, 0, 48, 55, 2, 0, 66,
-		66, 98, 98, 1, 0, 48, 49, 2, 0, 69, 69, 101, 101, 2, 0, 43, 43, 45, 45,
-		4, 0, 10, 10, 13, 13, 39, 39, 92, 92, 4, 0, 10, 10, 13, 13, 34, 34, 92,
-		92, 3, 0, 9, 10, 13, 13, 32, 32, 2, 0, 10, 10, 13, 13, 8, 0, 39, 39, 47,
-		47, 92, 92, 96, 96, 102, 102, 110, 110, 114, 114, 116, 116, 460, 0, 1,
-		1, 0, 0, 0, 0, 3, 1, 0, 0, 0, 0, 5, 1, 0, 0, 0, 0, 7, 1, 0, 0, 0, 0, 9,
-		1, 0, 0, 0, 0, 11, 1, 0, 0, 0, 0, 13, 1, 0, 0, 0, 0, 15, 1, 0, 0, 0, 0,
-		17, 1, 0, 0, 0, 0, 19, 1, 0, 0, 0, 0, 21, 1, 0, 0, 0, 0, 23, 1, 0, 0, 0,
-		0, 25, 1, 0, 0, 0, 0, 27, 1, 0, 0, 0, 0, 29, 1, 0, 0, 0, 0, 31, 1, 0, 0,
-		0, 0, 33, 1, 0, 0, 0, 0, 35, 1, 0, 0, 0, 0, 37, 1, 0, 0, 0, 0, 39, 1, 0,
-		0, 0, 0, 41, 1, 0, 0, 0, 0, 43, 1, 0, 0, 0, 0, 45, 1, 0, 0, 0, 0, 47, 1,
-		0, 0, 0, 0, 49, 1, 0, 0, 0, 0, 51, 1, 0, 0, 0, 0, 53, 1, 0, 0, 0, 0, 55,
-		1, 0, 0, 0, 0, 57, 1, 0, 0, 0, 0, 59, 1, 0, 0, 0, 0, 61, 1, 0, 0, 0, 0,
-		63, 1, 0, 0, 0, 0, 65, 1, 0, 0, 0, 0, 67, 1, 0, 0, 0, 0, 69, 1, 0, 0, 0,
-		0, 71, 1, 0, 0, 0, 0, 73, 1, 0, 0, 0, 0, 75, 1, 0, 0, 0, 0, 77, 1, 0, 0,
-		0, 0, 79, 1, 0, 0, 0, 0, 81, 1, 0, 0, 0, 0, 83, 1, 0, 0, 0, 0, 85, 1, 0,
-		0, 0, 0, 87, 1, 0, 0, 0, 0, 89, 1, 0, 0, 0, 0, 91, 1, 0, 0, 0, 0, 93, 1,
-		0, 0, 0, 0, 95, 1, 0, 0, 0, 0, 97, 1, 0, 0, 0, 0, 99, 1, 0, 0, 0, 0, 101,
-		1, 0, 0, 0, 0, 103, 1, 0, 0, 0, 0, 105, 1, 0, 0, 0, 0, 107, 1, 0, 0, 0,
-		0, 109, 1, 0, 0, 0, 0, 111, 1, 0, 0, 0, 0, 113, 1, 0, 0, 0, 0, 115, 1,
-		0, 0, 0, 0, 117, 1, 0, 0, 0, 0, 119, 1, 0, 0, 0, 1, 127, 1, 0, 0, 0, 3,
-		129, 1, 0, 0, 0, 5, 131, 1, 0, 0, 0, 7, 133, 1, 0, 0, 0, 9, 136, 1, 0,
-		0, 0, 11, 140, 1, 0, 0, 0, 13, 143, 1, 0, 0, 0, 15, 145, 1, 0, 0, 0, 17,
-		147, 1, 0, 0, 0, 19, 149, 1, 0, 0, 0, 21, 151, 1, 0, 0, 0, 23, 153, 1,
-		0, 0, 0, 25, 155, 1, 0, 0, 0, 27, 158, 1, 0, 0, 0, 29, 160, 1, 0, 0, 0,
-		31, 162, 1, 0, 0, 0, 33, 165, 1, 0, 0, 0, 35, 167, 1, 0, 0, 0, 37, 170,
-		1, 0, 0, 0, 39, 174, 1, 0, 0, 0, 41, 177, 1, 0, 0, 0, 43, 180, 1, 0, 0,
-		0, 45, 184, 1, 0, 0, 0, 47, 192, 1, 0, 0, 0, 49, 195, 1, 0, 0, 0, 51, 198,
-		1, 0, 0, 0, 53, 200, 1, 0, 0, 0, 55, 202, 1, 0, 0, 0, 57, 204, 1, 0, 0,
-		0, 59, 207, 1, 0, 0, 0, 61, 209, 1, 0, 0, 0, 63, 211, 1, 0, 0, 0, 65, 214,
-		1, 0, 0, 0, 67, 217, 1, 0, 0, 0, 69, 220, 1, 0, 0, 0, 71, 222, 1, 0, 0,
-		0, 73, 224, 1, 0, 0, 0, 75, 227, 1, 0, 0, 0, 77, 230, 1, 0, 0, 0, 79, 233,
-		1, 0, 0, 0, 81, 235, 1, 0, 0, 0, 83, 240, 1, 0, 0, 0, 85, 246, 1, 0, 0,
-		0, 87, 251, 1, 0, 0, 0, 89, 255, 1, 0, 0, 0, 91, 260, 1, 0, 0, 0, 93, 266,
-		1, 0, 0, 0, 95, 273, 1, 0, 0, 0, 97, 278, 1, 0, 0, 0, 99, 299, 1, 0, 0,
-		0, 101, 301, 1, 0, 0, 0, 103, 308, 1, 0, 0, 0, 105, 314, 1, 0, 0, 0, 107,
-		322, 1, 0, 0, 0, 109, 341, 1, 0, 0, 0, 111, 372, 1, 0, 0, 0, 113, 382,
-		1, 0, 0, 0, 115, 392, 1, 0, 0, 0, 117, 408, 1, 0, 0, 0, 119, 414, 1, 0,
-		0, 0, 121, 423, 1, 0, 0, 0, 123, 428, 1, 0, 0, 0, 125, 434, 1, 0, 0, 0,
-		127, 128, 5, 46, 0, 0, 128, 2, 1, 0, 0, 0, 129, 130, 5, 91, 0, 0, 130,
-		4, 1, 0, 0, 0, 131, 132, 5, 93, 0, 0, 132, 6, 1, 0, 0, 0, 133, 134, 5,
-		105, 0, 0, 134, 135, 5, 115, 0, 0, 135, 8, 1, 0, 0, 0, 136, 137, 5, 110,
-		0, 0, 137, 138, 5, 111, 0, 0, 138, 139, 5, 116, 0, 0, 139, 10, 1, 0, 0,
-		0, 140, 141, 5, 105, 0, 0, 141, 142, 5, 110, 0, 0, 142, 12, 1, 0, 0, 0,
-		143, 144, 5, 40, 0, 0, 144, 14, 1, 0, 0, 0, 145, 146, 5, 41, 0, 0, 146,
-		16, 1, 0, 0, 0, 147, 148, 5, 33, 0, 0, 148, 18, 1, 0, 0, 0, 149, 150, 5,
-		126, 0, 0, 150, 20, 1, 0, 0, 0, 151, 152, 5, 43, 0, 0, 152, 22, 1, 0, 0,
-		0, 153, 154, 5, 45, 0, 0, 154, 24, 1, 0, 0, 0, 155, 156, 5, 42, 0, 0, 156,
-		157, 5, 42, 0, 0, 157, 26, 1, 0, 0, 0, 158, 159, 5, 42, 0, 0, 159, 28,
-		1, 0, 0, 0, 160, 161, 5, 47, 0, 0, 161, 30, 1, 0, 0, 0, 162, 163, 5, 47,
-		0, 0, 163, 164, 5, 47, 0, 0, 164, 32, 1, 0, 0, 0, 165, 166, 5, 37, 0, 0,
-		166, 34, 1, 0, 0, 0, 167, 168, 5, 38, 0, 0, 168, 169, 5, 38, 0, 0, 169,
-		36, 1, 0, 0, 0, 170, 171, 5, 97, 0, 0, 171, 172, 5, 110, 0, 0, 172, 173,
-		5, 100, 0, 0, 173, 38, 1, 0, 0, 0, 174, 175, 5, 124, 0, 0, 175, 176, 5,
-		124, 0, 0, 176, 40, 1, 0, 0, 0, 177, 178, 5, 111, 0, 0, 178, 179, 5, 114,
-		0, 0, 179, 42, 1, 0, 0, 0, 180, 181, 5, 60, 0, 0, 181, 182, 5, 45, 0, 0,
-		182, 183, 5, 62, 0, 0, 183, 44, 1, 0, 0, 0, 184, 185, 5, 105, 0, 0, 185,
-		186, 5, 109, 0, 0, 186, 187, 5, 112, 0, 0, 187, 188, 5, 108, 0, 0, 188,
-		189, 5, 105, 0, 0, 189, 190, 5, 101, 0, 0, 190, 191, 5, 115, 0, 0, 191,
-		46, 1, 0, 0, 0, 192, 193, 5, 60, 0, 0, 193, 194, 5, 60, 0, 0, 194, 48,
-		1, 0, 0, 0, 195, 196, 5, 62, 0, 0, 196, 197, 5, 62, 0, 0, 197, 50, 1, 0,
-		0, 0, 198, 199, 5, 38, 0, 0, 199, 52, 1, 0, 0, 0, 200, 201, 5, 94, 0, 0,
-		201, 54, 1, 0, 0, 0, 202, 203, 5, 124, 0, 0, 203, 56, 1, 0, 0, 0, 204,
-		205, 5, 60, 0, 0, 205, 206, 5, 61, 0, 0, 206, 58, 1, 0, 0, 0, 207, 208,
-		5, 60, 0, 0, 208, 60, 1, 0, 0, 0, 209, 210, 5, 62, 0, 0, 210, 62, 1, 0,
-		0, 0, 211, 212, 5, 62, 0, 0, 212, 213, 5, 61, 0, 0, 213, 64, 1, 0, 0, 0,
-		214, 215, 5, 61, 0, 0, 215, 216, 5, 61, 0, 0, 216, 66, 1, 0, 0, 0, 217,
-		218, 5, 33, 0, 0, 218, 219, 5, 61, 0, 0, 219, 68, 1, 0, 0, 0, 220, 221,
-		5, 63, 0, 0, 221, 70, 1, 0, 0, 0, 222, 223, 5, 58, 0, 0, 223, 72, 1, 0,
-		0, 0, 224, 225, 5, 63, 0, 0, 225, 226, 5, 58, 0, 0, 226, 74, 1, 0, 0, 0,
-		227, 228, 5, 63, 0, 0, 228, 229, 5, 63, 0, 0, 229, 76, 1, 0, 0, 0, 230,
-		231, 5, 97, 0, 0, 231, 232, 5, 115, 0, 0, 232, 78, 1, 0, 0, 0, 233, 234,
-		5, 44, 0, 0, 234, 80, 1, 0, 0, 0, 235, 236, 5, 116, 0, 0, 236, 237, 5,
-		114, 0, 0, 237, 238, 5, 117, 0, 0, 238, 239, 5, 101, 0, 0, 239, 82, 1,
-		0, 0, 0, 240, 241, 5, 102, 0, 0, 241, 242, 5, 97, 0, 0, 242, 243, 5, 108,
-		0, 0, 243, 244, 5, 115, 0, 0, 244, 245, 5, 101, 0, 0, 245, 84, 1, 0, 0,
-		0, 246, 247, 5, 110, 0, 0, 247, 248, 5, 117, 0, 0, 248, 249, 5, 108, 0,
-		0, 249, 250, 5, 108, 0, 0, 250, 86, 1, 0, 0, 0, 251, 252, 5, 105, 0, 0,
-		252, 253, 5, 110, 0, 0, 253, 254, 5, 116, 0, 0, 254, 88, 1, 0, 0, 0, 255,
-		256, 5, 117, 0, 0, 256, 257, 5, 105, 0, 0, 257, 258, 5, 110, 0, 0, 258,
-		259, 5, 116, 0, 0, 259, 90, 1, 0, 0, 0, 260, 261, 5, 102, 0, 0, 261, 262,
-		5, 108, 0, 0, 262, 263, 5, 111, 0, 0, 263, 264, 5, 97, 0, 0, 264, 265,
-		5, 116, 0, 0, 265, 92, 1, 0, 0, 0, 266, 267, 5, 115, 0, 0, 267, 268, 5,
-		116, 0, 0, 268, 269, 5, 114, 0, 0, 269, 270, 5, 105, 0, 0, 270, 271, 5,
-		110, 0, 0, 271, 272, 5, 103, 0, 0, 272, 94, 1, 0, 0, 0, 273, 274, 5, 98,
-		0, 0, 274, 275, 5, 111, 0, 0, 275, 276, 5, 111, 0, 0, 276, 277, 5, 108,
-		0, 0, 277, 96, 1, 0, 0, 0, 278, 282, 7, 0, 0, 0, 279, 281, 7, 1, 0, 0,
-		280, 279, 1, 0, 0, 0, 281, 284, 1, 0, 0, 0, 282, 280, 1, 0, 0, 0, 282,
-		283, 1, 0, 0, 0, 283, 286, 1, 0, 0, 0, 284, 282, 1, 0, 0, 0, 285, 287,
-		7, 2, 0, 0, 286, 285, 1, 0, 0, 0, 286, 287, 1, 0, 0, 0, 287, 98, 1, 0,
-		0, 0, 288, 290, 5, 45, 0, 0, 289, 288, 1, 0, 0, 0, 289, 290, 1, 0, 0, 0,
-		290, 291, 1, 0, 0, 0, 291, 295, 7, 3, 0, 0, 292, 294, 7, 4, 0, 0, 293,
-		292, 1, 0, 0, 0, 294, 297, 1, 0, 0, 0, 295, 293, 1, 0, 0, 0, 295, 296,
-		1, 0, 0, 0, 296, 300, 1, 0, 0, 0, 297, 295, 1, 0, 0, 0, 298, 300, 5, 48,
-		0, 0, 299, 289, 1, 0, 0, 0, 299, 298, 1, 0, 0, 0, 300, 100, 1, 0, 0, 0,
-		301, 302, 5, 48, 0, 0, 302, 304, 7, 5, 0, 0, 303, 305, 7, 6, 0, 0, 304,
-		303, 1, 0, 0, 0, 305, 306, 1, 0, 0, 0, 306, 304, 1, 0, 0, 0, 306, 307,
-		1, 0, 0, 0, 307, 102, 1, 0, 0, 0, 308, 310, 5, 48, 0, 0, 309, 311, 7, 7,
-		0, 0, 310, 309, 1, 0, 0, 0, 311, 312, 1, 0, 0, 0, 312, 310, 1, 0, 0, 0,
-		312, 313, 1, 0, 0, 0, 313, 104, 1, 0, 0, 0, 314, 315, 5, 48, 0, 0, 315,
-		317, 7, 8, 0, 0, 316, 318, 7, 9, 0, 0, 317, 316, 1, 0, 0, 0, 318, 319,
-		1, 0, 0, 0, 319, 317, 1, 0, 0, 0, 319, 320, 1, 0, 0, 0, 320, 106, 1, 0,
-		0, 0, 321, 323, 5, 45, 0, 0, 322, 321, 1, 0, 0, 0, 322, 323, 1, 0, 0, 0,
-		323, 332, 1, 0, 0, 0, 324, 333, 5, 48, 0, 0, 325, 329, 7, 3, 0, 0, 326,
-		328, 7, 4, 0, 0, 327, 326, 1, 0, 0, 0, 328, 331, 1, 0, 0, 0, 329, 327,
-		1, 0, 0, 0, 329, 330, 1, 0, 0, 0, 330, 333, 1, 0, 0, 0, 331, 329, 1, 0,
-		0, 0, 332, 324, 1, 0, 0, 0, 332, 325, 1, 0, 0, 0, 333, 334, 1, 0, 0, 0,
-		334, 336, 5, 46, 0, 0, 335, 337, 7, 4, 0, 0, 336, 335, 1, 0, 0, 0, 337,
-		338, 1, 0, 0, 0, 338, 336, 1, 0, 0, 0, 338, 339, 1, 0, 0, 0, 339, 108,
-		1, 0, 0, 0, 340, 342, 5, 45, 0, 0, 341, 340, 1, 0, 0, 0, 341, 342, 1, 0,
-		0, 0, 342, 351, 1, 0, 0, 0, 343, 352, 5, 48, 0, 0, 344, 348, 7, 3, 0, 0,
-		345, 347, 7, 4, 0, 0, 346, 345, 1, 0, 0, 0, 347, 350, 1, 0, 0, 0, 348,
-		346, 1, 0, 0, 0, 348, 349, 1, 0, 0, 0, 349, 352, 1, 0, 0, 0, 350, 348,
-		1, 0, 0, 0, 351, 343, 1, 0, 0, 0, 351, 344, 1, 0, 0, 0, 352, 359, 1, 0,
-		0, 0, 353, 355, 5, 46, 0, 0, 354, 356, 7, 4, 0, 0, 355, 354, 1, 0, 0, 0,
-		356, 357, 1, 0, 0, 0, 357, 355, 1, 0, 0, 0, 357, 358, 1, 0, 0, 0, 358,
-		360, 1, 0, 0, 0, 359, 353, 1, 0, 0, 0, 359, 360, 1, 0, 0, 0, 360, 361,
-		1, 0, 0, 0, 361, 363, 7, 10, 0, 0, 362, 364, 7, 11, 0, 0, 363, 362, 1,
-		0, 0, 0, 363, 364, 1, 0, 0, 0, 364, 365, 1, 0, 0, 0, 365, 369, 7, 3, 0,
-		0, 366, 368, 7, 4, 0, 0, 367, 366, 1, 0, 0, 0, 368, 371, 1, 0, 0, 0, 369,
-		367, 1, 0, 0, 0, 369, 370, 1, 0, 0, 0, 370, 110, 1, 0, 0, 0, 371, 369,
-		1, 0, 0, 0, 372, 377, 5, 39, 0, 0, 373, 376, 3, 121, 60, 0, 374, 376, 8,
-		12, 0, 0, 375, 373, 1, 0, 0, 0, 375, 374, 1, 0, 0, 0, 376, 379, 1, 0, 0,
-		0, 377, 375, 1, 0, 0, 0, 377, 378, 1, 0, 0, 0, 378, 380, 1, 0, 0, 0, 379,
-		377, 1, 0, 0, 0, 380, 381, 5, 39, 0, 0, 381, 112, 1, 0, 0, 0, 382, 387,
-		5, 34, 0, 0, 383, 386, 3, 121, 60, 0, 384, 386, 8, 13, 0, 0, 385, 383,
-		1, 0, 0, 0, 385, 384, 1, 0, 0, 0, 386, 389, 1, 0, 0, 0, 387, 385, 1, 0,
-		0, 0, 387, 388, 1, 0, 0, 0, 388, 390, 1, 0, 0, 0, 389, 387, 1, 0, 0, 0,
-		390, 391, 5, 34, 0, 0, 391, 114, 1, 0, 0, 0, 392, 393, 5, 34, 0, 0, 393,
-		394, 5, 34, 0, 0, 394, 395, 5, 34, 0, 0, 395, 400, 1, 0, 0, 0, 396, 399,
-		3, 121, 60, 0, 397, 399, 9, 0, 0, 0, 398, 396, 1, 0, 0, 0, 398, 397, 1,
-		0, 0, 0, 399, 402, 1, 0, 0, 0, 400, 401, 1, 0, 0, 0, 400, 398, 1, 0, 0,
-		0, 401, 403, 1, 0, 0, 0, 402, 400, 1, 0, 0, 0, 403, 404, 5, 34, 0, 0, 404,
-		405, 5, 34, 0, 0, 405, 406, 5, 34, 0, 0, 406, 116, 1, 0, 0, 0, 407, 409,
-		7, 14, 0, 0, 408, 407, 1, 0, 0, 0, 409, 410, 1, 0, 0, 0, 410, 408, 1, 0,
-		0, 0, 410, 411, 1, 0, 0, 0, 411, 412, 1, 0, 0, 0, 412, 413, 6, 58, 0, 0,
-		413, 118, 1, 0, 0, 0, 414, 418, 5, 35, 0, 0, 415, 417, 8, 15, 0, 0, 416,
-		415, 1, 0, 0, 0, 417, 420, 1, 0, 0, 0, 418, 416, 1, 0, 0, 0, 418, 419,
-		1, 0, 0, 0, 419, 421, 1, 0, 0, 0, 420, 418, 1, 0, 0, 0, 421, 422, 6, 59,
-		0, 0, 422, 120, 1, 0, 0, 0, 423, 426, 5, 92, 0, 0, 424, 427, 7, 16, 0,
-		0, 425, 427, 3, 123, 61, 0, 426, 424, 1, 0, 0, 0, 426, 425, 1, 0, 0, 0,
-		427, 122, 1, 0, 0, 0, 428, 429, 5, 117, 0, 0, 429, 430, 3, 125, 62, 0,
-		430, 431, 3, 125, 62, 0, 431, 432, 3, 125, 62, 0, 432, 433, 3, 125, 62,
-		0, 433, 124, 1, 0, 0, 0, 434, 435, 7, 6, 0, 0, 435, 126, 1, 0, 0, 0, 29,
-		0, 282, 286, 289, 295, 299, 306, 312, 319, 322, 329, 332, 338, 341, 348,
-		351, 357, 359, 363, 369, 375, 377, 385, 387, 398, 400, 410, 418, 426, 1,
-		0, 1, 0,
+		62, 2, 63, 7, 63, 2, 64, 7, 64, 2, 65, 7, 65, 2, 66, 7, 66, 2, 67, 7, 67,
+		2, 68, 7, 68, 2, 69, 7, 69, 2, 70, 7, 70, 2, 71, 7, 71, 2, 72, 7, 72, 1,
+		0, 1, 0, 1, 1, 1, 1, 1, 1, 1, 2, 1, 2, 1, 3, 1, 3, 1, 4, 1, 4, 1, 4, 1,
+		5, 1, 5, 1, 5, 1, 5, 1, 6, 1, 6, 1, 6, 1, 7, 1, 7, 1, 8, 1, 8, 1, 9, 1,
+		9, 1, 10, 1, 10, 1, 11, 1, 11, 1, 12, 1, 12, 1, 13, 1, 13, 1, 13, 1, 14,
+		1, 14, 1, 15, 1, 15, 1, 16, 1, 16, 1, 16, 1, 17, 1, 17, 1, 18, 1, 18, 1,
+		18, 1, 19, 1, 19, 1, 19, 1, 20, 1, 20, 1, 20, 1, 21, 1, 21, 1, 21, 1, 21,
+		1, 22, 1, 22, 1, 22, 1, 23, 1, 23, 1, 23, 1, 24, 1, 24, 1, 24, 1, 24, 1,
+		25, 1, 25, 1, 25, 1, 25, 1, 25, 1, 25, 1, 25, 1, 25, 1, 26, 1, 26, 1, 26,
+		1, 27, 1, 27, 1, 27, 1, 28, 1, 28, 1, 29, 1, 29, 1, 30, 1, 30, 1, 31, 1,
+		31, 1, 31, 1, 32, 1, 32, 1, 33, 1, 33, 1, 34, 1, 34, 1, 34, 1, 35, 1, 35,
+		1, 35, 1, 36, 1, 36, 1, 36, 1, 37, 1, 37, 1, 38, 1, 38, 1, 39, 1, 39, 1,
+		39, 1, 40, 1, 40, 1, 40, 1, 41, 1, 41, 1, 41, 1, 42, 1, 42, 1, 43, 1, 43,
+		1, 43, 1, 44, 1, 44, 1, 44, 1, 44, 1, 44, 1, 45, 1, 45, 1, 45, 1, 45, 1,
+		45, 1, 45, 1, 46, 1, 46, 1, 46, 1, 46, 1, 46, 1, 47, 1, 47, 1, 47, 1, 47,
+		1, 48, 1, 48, 1, 48, 1, 48, 1, 48, 1, 49, 1, 49, 1, 49, 1, 49, 1, 49, 1,
+		49, 1, 50, 1, 50, 1, 50, 1, 50, 1, 50, 1, 50, 1, 50, 1, 51, 1, 51, 1, 51,
+		1, 51, 1, 51, 1, 52, 1, 52, 1, 53, 1, 53, 1, 54, 1, 54, 5, 54, 317, 8,
+		54, 10, 54, 12, 54, 320, 9, 54, 1, 54, 3, 54, 323, 8, 54, 1, 55, 1, 55,
+		1, 55, 1, 56, 3, 56, 329, 8, 56, 1, 56, 1, 56, 5, 56, 333, 8, 56, 10, 56,
+		12, 56, 336, 9, 56, 1, 56, 3, 56, 339, 8, 56, 1, 57, 1, 57, 1, 57, 4, 57,
+		344, 8, 57, 11, 57, 12, 57, 345, 1, 58, 1, 58, 4, 58, 350, 8, 58, 11, 58,
+		12, 58, 351, 1, 59, 1, 59, 1, 59, 4, 59, 357, 8, 59, 11, 59, 12, 59, 358,
+		1, 60, 3, 60, 362, 8, 60, 1, 60, 1, 60, 1, 60, 5, 60, 367, 8, 60, 10, 60,
+		12, 60, 370, 9, 60, 3, 60, 372, 8, 60, 1, 60, 1, 60, 4, 60, 376, 8, 60,
+		11, 60, 12, 60, 377, 1, 61, 3, 61, 381, 8, 61, 1, 61, 1, 61, 1, 61, 5,
+		61, 386, 8, 61, 10, 61, 12, 61, 389, 9, 61, 3, 61, 391, 8, 61, 1, 61, 1,
+		61, 4, 61, 395, 8, 61, 11, 61, 12, 61, 396, 3, 61, 399, 8, 61, 1, 61, 1,
+		61, 3, 61, 403, 8, 61, 1, 61, 1, 61, 5, 61, 407, 8, 61, 10, 61, 12, 61,
+		410, 9, 61, 1, 62, 1, 62, 1, 62, 5, 62, 415, 8, 62, 10, 62, 12, 62, 418,
+		9, 62, 1, 62, 1, 62, 1, 63, 1, 63, 1, 63, 5, 63, 425, 8, 63, 10, 63, 12,
+		63, 428, 9, 63, 1, 63, 1, 63, 1, 64, 1, 64, 1, 64, 1, 64, 1, 64, 1, 64,
+		5, 64, 438, 8, 64, 10, 64, 12, 64, 441, 9, 64, 1, 64, 1, 64, 1, 64, 1,
+		64, 1, 65, 1, 65, 1, 65, 1, 65, 1, 65, 1, 65, 1, 65, 1, 65, 1, 65, 1, 65,
+		5, 65, 457, 8, 65, 10, 65, 12, 65, 460, 9, 65, 1, 65, 1, 65, 1, 65, 1,
+		65, 1, 65, 1, 65, 1, 65, 1, 65, 1, 65, 1, 65, 1, 65, 5, 65, 473, 8, 65,
+		10, 65, 12, 65, 476, 9, 65, 1, 65, 3, 65, 479, 8, 65, 1, 66, 4, 66, 482,
+		8, 66, 11, 66, 12, 66, 483, 1, 66, 1, 66, 1, 67, 1, 67, 5, 67, 490, 8,
+		67, 10, 67, 12, 67, 493, 9, 67, 1, 67, 1, 67, 1, 68, 1, 68, 1, 68, 3, 68,
+		500, 8, 68, 1, 69, 1, 69, 1, 69, 1, 69, 5, 69, 506, 8, 69, 10, 69, 12,
+		69, 509, 9, 69, 1, 69, 1, 69, 1, 70, 1, 70, 1, 70, 5, 70, 516, 8, 70, 10,
+		70, 12, 70, 519, 9, 70, 1, 70, 1, 70, 1, 70, 1, 70, 5, 70, 525, 8, 70,
+		10, 70, 12, 70, 528, 9, 70, 1, 70, 3, 70, 531, 8, 70, 1, 71, 1, 71, 1,
+		71, 1, 71, 1, 71, 1, 71, 1, 72, 1, 72, 1, 439, 0, 73, 1, 1, 3, 2, 5, 3,
+		7, 4, 9, 5, 11, 6, 13, 7, 15, 8, 17, 9, 19, 10, 21, 11, 23, 12, 25, 13,
+		27, 14, 29, 15, 31, 16, 33, 17, 35, 18, 37, 19, 39, 20, 41, 21, 43, 22,
+		45, 23, 47, 24, 49, 25, 51, 26, 53, 27, 55, 28, 57, 29, 59, 30, 61, 31,
+		63, 32, 65, 33, 67, 34, 69, 35, 71, 36, 73, 37, 75, 38, 77, 39, 79, 40,
+		81, 41, 83, 42, 85, 43, 87, 44, 89, 45, 91, 46, 93, 47, 95, 48, 97, 49,
+		99, 50, 101, 51, 103, 52, 105, 53, 107, 54, 109, 55, 111, 56, 113, 57,
+		115, 58, 117, 59, 119, 60, 121, 61, 123, 62, 125, 63, 127, 64, 129, 65,
+		131, 66, 133, 67, 135, 68, 137, 0, 139, 0, 141, 0, 143, 0, 145, 0, 1, 0,
+		22, 3, 0, 65, 90, 95, 95, 97, 122, 5, 0, 45, 45, 48, 57, 65, 90, 95, 95,
+		97, 122, 4, 0, 48, 57, 65, 90, 95, 95, 97, 122, 1, 0, 49, 57, 1, 0, 48,
+		57, 2, 0, 88, 88, 120, 120, 3, 0, 48, 57, 65, 70, 97, 102, 1, 0, 48, 55,
+		2, 0, 66, 66, 98, 98, 1, 0, 48, 49, 2, 0, 69, 69, 101, 101, 2, 0, 43, 43,
+		45, 45, 4, 0, 10, 10, 13, 13, 39, 39, 92, 92, 4, 0, 10, 10, 13, 13, 34,
+		34, 92, 92, 6, 0, 10, 10, 13, 13, 34, 34, 92, 92, 123, 123, 125, 125, 6,
+		0, 10, 10, 13, 13, 39, 39, 92, 92, 123, 123, 125, 125, 3, 0, 9, 10, 13,
+		13, 32, 32, 2, 0, 10, 10, 13, 13, 8, 0, 39, 39, 47, 47, 92, 92, 96, 96,
+		102, 102, 110, 110, 114, 114, 116, 116, 4, 0, 34, 34, 39, 39, 123, 123,
+		125, 125, 2, 0, 34, 34, 92, 92, 2, 0, 39, 39, 92, 92, 581, 0, 1, 1, 0,
+		0, 0, 0, 3, 1, 0, 0, 0, 0, 5, 1, 0, 0, 0, 0, 7, 1, 0, 0, 0, 0, 9, 1, 0,
+		0, 0, 0, 11, 1, 0, 0, 0, 0, 13, 1, 0, 0, 0, 0, 15, 1, 0, 0, 0, 0, 17, 1,
+		0, 0, 0, 0, 19, 1, 0, 0, 0, 0, 21, 1, 0, 0, 0, 0, 23, 1, 0, 0, 0, 0, 25,
+		1, 0, 0, 0, 0, 27, 1, 0, 0, 0, 0, 29, 1, 0, 0, 0, 0, 31, 1, 0, 0, 0, 0,
+		33, 1, 0, 0, 0, 0, 35, 1, 0, 0, 0, 0, 37, 1, 0, 0, 0, 0, 39, 1, 0, 0, 0,
+		0, 41, 1, 0, 0, 0, 0, 43, 1, 0, 0, 0, 0, 45, 1, 0, 0, 0, 0, 47, 1, 0, 0,
+		0, 0, 49, 1, 0, 0, 0, 0, 51, 1, 0, 0, 0, 0, 53, 1, 0, 0, 0, 0, 55, 1, 0,
+		0, 0, 0, 57, 1, 0, 0, 0, 0, 59, 1, 0, 0, 0, 0, 61, 1, 0, 0, 0, 0, 63, 1,
+		0, 0, 0, 0, 65, 1, 0, 0, 0, 0, 67, 1, 0, 0, 0, 0, 69, 1, 0, 0, 0, 0, 71,
+		1, 0, 0, 0, 0, 73, 1, 0, 0, 0, 0, 75, 1, 0, 0, 0, 0, 77, 1, 0, 0, 0, 0,
+		79, 1, 0, 0, 0, 0, 81, 1, 0, 0, 0, 0, 83, 1, 0, 0, 0, 0, 85, 1, 0, 0, 0,
+		0, 87, 1, 0, 0, 0, 0, 89, 1, 0, 0, 0, 0, 91, 1, 0, 0, 0, 0, 93, 1, 0, 0,
+		0, 0, 95, 1, 0, 0, 0, 0, 97, 1, 0, 0, 0, 0, 99, 1, 0, 0, 0, 0, 101, 1,
+		0, 0, 0, 0, 103, 1, 0, 0, 0, 0, 105, 1, 0, 0, 0, 0, 107, 1, 0, 0, 0, 0,
+		109, 1, 0, 0, 0, 0, 111, 1, 0, 0, 0, 0, 113, 1, 0, 0, 0, 0, 115, 1, 0,
+		0, 0, 0, 117, 1, 0, 0, 0, 0, 119, 1, 0, 0, 0, 0, 121, 1, 0, 0, 0, 0, 123,
+		1, 0, 0, 0, 0, 125, 1, 0, 0, 0, 0, 127, 1, 0, 0, 0, 0, 129, 1, 0, 0, 0,
+		0, 131, 1, 0, 0, 0, 0, 133, 1, 0, 0, 0, 0, 135, 1, 0, 0, 0, 1, 147, 1,
+		0, 0, 0, 3, 149, 1, 0, 0, 0, 5, 152, 1, 0, 0, 0, 7, 154, 1, 0, 0, 0, 9,
+		156, 1, 0, 0, 0, 11, 159, 1, 0, 0, 0, 13, 163, 1, 0, 0, 0, 15, 166, 1,
+		0, 0, 0, 17, 168, 1, 0, 0, 0, 19, 170, 1, 0, 0, 0, 21, 172, 1, 0, 0, 0,
+		23, 174, 1, 0, 0, 0, 25, 176, 1, 0, 0, 0, 27, 178, 1, 0, 0, 0, 29, 181,
+		1, 0, 0, 0, 31, 183, 1, 0, 0, 0, 33, 185, 1, 0, 0, 0, 35, 188, 1, 0, 0,
+		0, 37, 190, 1, 0, 0, 0, 39, 193, 1, 0, 0, 0, 41, 196, 1, 0, 0, 0, 43, 199,
+		1, 0, 0, 0, 45, 203, 1, 0, 0, 0, 47, 206, 1, 0, 0, 0, 49, 209, 1, 0, 0,
+		0, 51, 213, 1, 0, 0, 0, 53, 221, 1, 0, 0, 0, 55, 224, 1, 0, 0, 0, 57, 227,
+		1, 0, 0, 0, 59, 229, 1, 0, 0, 0, 61, 231, 1, 0, 0, 0, 63, 233, 1, 0, 0,
+		0, 65, 236, 1, 0, 0, 0, 67, 238, 1, 0, 0, 0, 69, 240, 1, 0, 0, 0, 71, 243,
+		1, 0, 0, 0, 73, 246, 1, 0, 0, 0, 75, 249, 1, 0, 0, 0, 77, 251, 1, 0, 0,
+		0, 79, 253, 1, 0, 0, 0, 81, 256, 1, 0, 0, 0, 83, 259, 1, 0, 0, 0, 85, 262,
+		1, 0, 0, 0, 87, 264, 1, 0, 0, 0, 89, 267, 1, 0, 0, 0, 91, 272, 1, 0, 0,
+		0, 93, 278, 1, 0, 0, 0, 95, 283, 1, 0, 0, 0, 97, 287, 1, 0, 0, 0, 99, 292,
+		1, 0, 0, 0, 101, 298, 1, 0, 0, 0, 103, 305, 1, 0, 0, 0, 105, 310, 1, 0,
+		0, 0, 107, 312, 1, 0, 0, 0, 109, 314, 1, 0, 0, 0, 111, 324, 1, 0, 0, 0,
+		113, 338, 1, 0, 0, 0, 115, 340, 1, 0, 0, 0, 117, 347, 1, 0, 0, 0, 119,
+		353, 1, 0, 0, 0, 121, 361, 1, 0, 0, 0, 123, 380, 1, 0, 0, 0, 125, 411,
+		1, 0, 0, 0, 127, 421, 1, 0, 0, 0, 129, 431, 1, 0, 0, 0, 131, 478, 1, 0,
+		0, 0, 133, 481, 1, 0, 0, 0, 135, 487, 1, 0, 0, 0, 137, 496, 1, 0, 0, 0,
+		139, 501, 1, 0, 0, 0, 141, 530, 1, 0, 0, 0, 143, 532, 1, 0, 0, 0, 145,
+		538, 1, 0, 0, 0, 147, 148, 5, 46, 0, 0, 148, 2, 1, 0, 0, 0, 149, 150, 5,
+		63, 0, 0, 150, 151, 5, 46, 0, 0, 151, 4, 1, 0, 0, 0, 152, 153, 5, 91, 0,
+		0, 153, 6, 1, 0, 0, 0, 154, 155, 5, 93, 0, 0, 155, 8, 1, 0, 0, 0, 156,
+		157, 5, 105, 0, 0, 157, 158, 5, 115, 0, 0, 158, 10, 1, 0, 0, 0, 159, 160,
+		5, 110, 0, 0, 160, 161, 5, 111, 0, 0, 161, 162, 5, 116, 0, 0, 162, 12,
+		1, 0, 0, 0, 163, 164, 5, 105, 0, 0, 164, 165, 5, 110, 0, 0, 165, 14, 1,
+		0, 0, 0, 166, 167, 5, 40, 0, 0, 167, 16, 1, 0, 0, 0, 168, 169, 5, 41, 0,
+		0, 169, 18, 1, 0, 0, 0, 170, 171, 5, 33, 0, 0, 171, 20, 1, 0, 0, 0, 172,
+		173, 5, 126, 0, 0, 173, 22, 1, 0, 0, 0, 174, 175, 5, 43, 0, 0, 175, 24,
+		1, 0, 0, 0, 176, 177, 5, 45, 0, 0, 177, 26, 1, 0, 0, 0, 178, 179, 5, 42,
+		0, 0, 179, 180, 5, 42, 0, 0, 180, 28, 1, 0, 0, 0, 181, 182, 5, 42, 0, 0,
+		182, 30, 1, 0, 0, 0, 183, 184, 5, 47, 0, 0, 184, 32, 1, 0, 0, 0, 185, 186,
+		5, 47, 0, 0, 186, 187, 5, 47, 0, 0, 187, 34, 1, 0, 0, 0, 188, 189, 5, 37,
+		0, 0, 189, 36, 1, 0, 0, 0, 190, 191, 5, 61, 0, 0, 191, 192, 5, 126, 0,
+		0, 192, 38, 1, 0, 0, 0, 193, 194, 5, 33, 0, 0, 194, 195, 5, 126, 0, 0,
+		195, 40, 1, 0, 0, 0, 196, 197, 5, 38, 0, 0, 197, 198, 5, 38, 0, 0, 198,
+		42, 1, 0, 0, 0, 199, 200, 5, 97, 0, 0, 200, 201, 5, 110, 0, 0, 201, 202,
+		5, 100, 0, 0, 202, 44, 1, 0, 0, 0, 203, 204, 5, 124, 0, 0, 204, 205, 5,
+		124, 0, 0, 205, 46, 1, 0, 0, 0, 206, 207, 5, 111, 0, 0, 207, 208, 5, 114,
+		0, 0, 208, 48, 1, 0, 0, 0, 209, 210, 5, 60, 0, 0, 210, 211, 5, 45, 0, 0,
+		211, 212, 5, 62, 0, 0, 212, 50, 1, 0, 0, 0, 213, 214, 5, 105, 0, 0, 214,
+		215, 5, 109, 0, 0, 215, 216, 5, 112, 0, 0, 216, 217, 5, 108, 0, 0, 217,
+		218, 5, 105, 0, 0, 218, 219, 5, 101, 0, 0, 219, 220, 5, 115, 0, 0, 220,
+		52, 1, 0, 0, 0, 221, 222, 5, 60, 0, 0, 222, 223, 5, 60, 0, 0, 223, 54,
+		1, 0, 0, 0, 224, 225, 5, 62, 0, 0, 225, 226, 5, 62, 0, 0, 226, 56, 1, 0,
+		0, 0, 227, 228, 5, 38, 0, 0, 228, 58, 1, 0, 0, 0, 229, 230, 5, 94, 0, 0,
+		230, 60, 1, 0, 0, 0, 231, 232, 5, 124, 0, 0, 232, 62, 1, 0, 0, 0, 233,
+		234, 5, 60, 0, 0, 234, 235, 5, 61, 0, 0, 235, 64, 1, 0, 0, 0, 236, 237,
+		5, 60, 0, 0, 237, 66, 1, 0, 0, 0, 238, 239, 5, 62, 0, 0, 239, 68, 1, 0,
+		0, 0, 240, 241, 5, 62, 0, 0, 241, 242, 5, 61, 0, 0, 242, 70, 1, 0, 0, 0,
+		243, 244, 5, 61, 0, 0, 244, 245, 5, 61, 0, 0, 245, 72, 1, 0, 0, 0, 246,
+		247, 5, 33, 0, 0, 247, 248, 5, 61, 0, 0, 248, 74, 1, 0, 0, 0, 249, 250,
+		5, 63, 0, 0, 250, 76, 1, 0, 0, 0, 251, 252, 5, 58, 0, 0, 252, 78, 1, 0,
+		0, 0, 253, 254, 5, 63, 0, 0, 254, 255, 5, 58, 0, 0, 255, 80, 1, 0, 0, 0,
+		256, 257, 5, 63, 0, 0, 257, 258, 5, 63, 0, 0, 258, 82, 1, 0, 0, 0, 259,
+		260, 5, 97, 0, 0, 260, 261, 5, 115, 0, 0, 261, 84, 1, 0, 0, 0, 262, 263,
+		5, 44, 0, 0, 263, 86, 1, 0, 0, 0, 264, 265, 5, 61, 0, 0, 265, 266, 5, 62,
+		0, 0, 266, 88, 1, 0, 0, 0, 267, 268, 5, 116, 0, 0, 268, 269, 5, 114, 0,
+		0, 269, 270, 5, 117, 0, 0, 270, 271, 5, 101, 0, 0, 271, 90, 1, 0, 0, 0,
+		272, 273, 5, 102, 0, 0, 273, 274, 5, 97, 0, 0, 274, 275, 5, 108, 0, 0,
+		275, 276, 5, 115, 0, 0, 276, 277, 5, 101, 0, 0, 277, 92, 1, 0, 0, 0, 278,
+		279, 5, 110, 0, 0, 279, 280, 5, 117, 0, 0, 280, 281, 5, 108, 0, 0, 281,
+		282, 5, 108, 0, 0, 282, 94, 1, 0, 0, 0, 283, 284, 5, 105, 0, 0, 284, 285,
+		5, 110, 0, 0, 285, 286, 5, 116, 0, 0, 286, 96, 1, 0, 0, 0, 287, 288, 5,
+		117, 0, 0, 288, 289, 5, 105, 0, 0, 289, 290, 5, 110, 0, 0, 290, 291, 5,
+		116, 0, 0, 291, 98, 1, 0, 0, 0, 292, 293, 5, 102, 0, 0, 293, 294, 5, 108,
+		0, 0, 294, 295, 5, 111, 0, 0, 295, 296, 5, 97, 0, 0, 296, 297, 5, 116,
+		0, 0, 297, 100, 1, 0, 0, 0, 298, 299, 5, 115, 0, 0, 299, 300, 5, 116, 0,
+		0, 300, 301, 5, 114, 0, 0, 301, 302, 5, 105, 0, 0, 302, 303, 5, 110, 0,
+		0, 303, 304, 5, 103, 0, 0, 304, 102, 1, 0, 0, 0, 305, 306, 5, 98, 0, 0,
+		306, 307, 5, 111, 0, 0, 307, 308, 5, 111, 0, 0, 308, 309, 5, 108, 0, 0,
+		309, 104, 1, 0, 0, 0, 310, 311, 5, 123, 0, 0, 311, 106, 1, 0, 0, 0, 312,
+		313, 5, 125, 0, 0, 313, 108, 1, 0, 0, 0, 314, 318, 7, 0, 0, 0, 315, 317,
+		7, 1, 0, 0, 316, 315, 1, 0, 0, 0, 317, 320, 1, 0, 0, 0, 318, 316, 1, 0,
+		0, 0, 318, 319, 1, 0, 0, 0, 319, 322, 1, 0, 0, 0, 320, 318, 1, 0, 0, 0,
+		321, 323, 7, 2, 0, 0, 322, 321, 1, 0, 0, 0, 322, 323, 1, 0, 0, 0, 323,
+		110, 1, 0, 0, 0, 324, 325, 5, 36, 0, 0, 325, 326, 3, 109, 54, 0, 326, 112,
+		1, 0, 0, 0, 327, 329, 5, 45, 0, 0, 328, 327, 1, 0, 0, 0, 328, 329, 1, 0,
+		0, 0, 329, 330, 1, 0, 0, 0, 330, 334, 7, 3, 0, 0, 331, 333, 7, 4, 0, 0,
+		332, 331, 1, 0, 0, 0, 333, 336, 1, 0, 0, 0, 334, 332, 1, 0, 0, 0, 334,
+		335, 1, 0, 0, 0, 335, 339, 1, 0, 0, 0, 336, 334, 1, 0, 0, 0, 337, 339,
+		5, 48, 0, 0, 338, 328, 1, 0, 0, 0, 338, 337, 1, 0, 0, 0, 339, 114, 1, 0,
+		0, 0, 340, 341, 5, 48, 0, 0, 341, 343, 7, 5, 0, 0, 342, 344, 7, 6, 0, 0,
+		343, 342, 1, 0, 0, 0, 344, 345, 1, 0, 0, 0, 345, 343, 1, 0, 0, 0, 345,
+		346, 1, 0, 0, 0, 346, 116, 1, 0, 0, 0, 347, 349, 5, 48, 0, 0, 348, 350,
+		7, 7, 0, 0, 349, 348, 1, 0, 0, 0, 350, 351, 1, 0, 0, 0, 351, 349, 1, 0,
+		0, 0, 351, 352, 1, 0, 0, 0, 352, 118, 1, 0, 0, 0, 353, 354, 5, 48, 0, 0,
+		354, 356, 7, 8, 0, 0, 355, 357, 7, 9, 0, 0, 356, 355, 1, 0, 0, 0, 357,
+		358, 1, 0, 0, 0, 358, 356, 1, 0, 0, 0, 358, 359, 1, 0, 0, 0, 359, 120,
+		1, 0, 0, 0, 360, 362, 5, 45, 0, 0, 361, 360, 1, 0, 0, 0, 361, 362, 1, 0,
+		0, 0, 362, 371, 1, 0, 0, 0, 363, 372, 5, 48, 0, 0, 364, 368, 7, 3, 0, 0,
+		365, 367, 7, 4, 0, 0, 366, 365, 1, 0, 0, 0, 367, 370, 1, 0, 0, 0, 368,
+		366, 1, 0, 0, 0, 368, 369, 1, 0, 0, 0, 369, 372, 1, 0, 0, 0, 370, 368,
+		1, 0, 0, 0, 371, 363, 1, 0, 0, 0, 371, 364, 1, 0, 0, 0, 372, 373, 1, 0,
+		0, 0, 373, 375, 5, 46, 0, 0, 374, 376, 7, 4, 0, 0, 375, 374, 1, 0, 0, 0,
+		376, 377, 1, 0, 0, 0, 377, 375, 1, 0, 0, 0, 377, 378, 1, 0, 0, 0, 378,
+		122, 1, 0, 0, 0, 379, 381, 5, 45, 0, 0, 380, 379, 1, 0, 0, 0, 380, 381,
+		1, 0, 0, 0, 381, 390, 1, 0, 0, 0, 382, 391, 5, 48, 0, 0, 383, 387, 7, 3,
+		0, 0, 384, 386, 7, 4, 0, 0, 385, 384, 1, 0, 0, 0, 386, 389, 1, 0, 0, 0,
+		387, 385, 1, 0, 0, 0, 387, 388, 1, 0, 0, 0, 388, 391, 1, 0, 0, 0, 389,
+		387, 1, 0, 0, 0, 390, 382, 1, 0, 0, 0, 390, 383, 1, 0, 0, 0, 391, 398,
+		1, 0, 0, 0, 392, 394, 5, 46, 0, 0, 393, 395, 7, 4, 0, 0, 394, 393, 1, 0,
+		0, 0, 395, 396, 1, 0, 0, 0, 396, 394, 1, 0, 0, 0, 396, 397, 1, 0, 0, 0,
+		397, 399, 1, 0, 0, 0, 398, 392, 1, 0, 0, 0, 398, 399, 1, 0, 0, 0, 399,
+		400, 1, 0, 0, 0, 400, 402, 7, 10, 0, 0, 401, 403, 7, 11, 0, 0, 402, 401,
+		1, 0, 0, 0, 402, 403, 1, 0, 0, 0, 403, 404, 1, 0, 0, 0, 404, 408, 7, 3,
+		0, 0, 405, 407, 7, 4, 0, 0, 406, 405, 1, 0, 0, 0, 407, 410, 1, 0, 0, 0,
+		408, 406, 1, 0, 0, 0, 408, 409, 1, 0, 0, 0, 409, 124, 1, 0, 0, 0, 410,
+		408, 1, 0, 0, 0, 411, 416, 5, 39, 0, 0, 412, 415, 3, 137, 68, 0, 413, 415,
+		8, 12, 0, 0, 414, 412, 1, 0, 0, 0, 414, 413, 1, 0, 0, 0, 415, 418, 1, 0,
+		0, 0, 416, 414, 1, 0, 0, 0, 416, 417, 1, 0, 0, 0, 417, 419, 1, 0, 0, 0,
+		418, 416, 1, 0, 0, 0, 419, 420, 5, 39, 0, 0, 420, 126, 1, 0, 0, 0, 421,
+		426, 5, 34, 0, 0, 422, 425, 3, 137, 68, 0, 423, 425, 8, 13, 0, 0, 424,
+		422, 1, 0, 0, 0, 424, 423, 1, 0, 0, 0, 425, 428, 1, 0, 0, 0, 426, 424,
+		1, 0, 0, 0, 426, 427, 1, 0, 0, 0, 427, 429, 1, 0, 0, 0, 428, 426, 1, 0,
+		0, 0, 429, 430, 5, 34, 0, 0, 430, 128, 1, 0, 0, 0, 431, 432, 5, 34, 0,
+		0, 432, 433, 5, 34, 0, 0, 433, 434, 5, 34, 0, 0, 434, 439, 1, 0, 0, 0,
+		435, 438, 3, 137, 68, 0, 436, 438, 9, 0, 0, 0, 437, 435, 1, 0, 0, 0, 437,
+		436, 1, 0, 0, 0, 438, 441, 1, 0, 0, 0, 439, 440, 1, 0, 0, 0, 439, 437,
+		1, 0, 0, 0, 440, 442, 1, 0, 0, 0, 441, 439, 1, 0, 0, 0, 442, 443, 5, 34,
+		0, 0, 443, 444, 5, 34, 0, 0, 444, 445, 5, 34, 0, 0, 445, 130, 1, 0, 0,
+		0, 446, 447, 5, 102, 0, 0, 447, 448, 5, 34, 0, 0, 448, 458, 1, 0, 0, 0,
+		449, 457, 3, 137, 68, 0, 450, 451, 5, 123, 0, 0, 451, 457, 5, 123, 0, 0,
+		452, 453, 5, 125, 0, 0, 453, 457, 5, 125, 0, 0, 454, 457, 3, 139, 69, 0,
+		455, 457, 8, 14, 0, 0, 456, 449, 1, 0, 0, 0, 456, 450, 1, 0, 0, 0, 456,
+		452, 1, 0, 0, 0, 456, 454, 1, 0, 0, 0, 456, 455, 1, 0, 0, 0, 457, 460,
+		1, 0, 0, 0, 458, 456, 1, 0, 0, 0, 458, 459, 1, 0, 0, 0, 459, 461, 1, 0,
+		0, 0, 460, 458, 1, 0, 0, 0, 461, 479, 5, 34, 0, 0, 462, 463, 5, 102, 0,
+		0, 463, 464, 5, 39, 0, 0, 464, 474, 1, 0, 0, 0, 465, 473, 3, 137, 68, 0,
+		466, 467, 5, 123, 0, 0, 467, 473, 5, 123, 0, 0, 468, 469, 5, 125, 0, 0,
+		469, 473, 5, 125, 0, 0, 470, 473, 3, 139, 69, 0, 471, 473, 8, 15, 0, 0,
+		472, 465, 1, 0, 0, 0, 472, 466, 1, 0, 0, 0, 472, 468, 1, 0, 0, 0, 472,
+		470, 1, 0, 0, 0, 472, 471, 1, 0, 0, 0, 473, 476, 1, 0, 0, 0, 474, 472,
+		1, 0, 0, 0, 474, 475, 1, 0, 0, 0, 475, 477, 1, 0, 0, 0, 476, 474, 1, 0,
+		0, 0, 477, 479, 5, 39, 0, 0, 478, 446, 1, 0, 0, 0, 478, 462, 1, 0, 0, 0,
+		479, 132, 1, 0, 0, 0, 480, 482, 7, 16, 0, 0, 481, 480, 1, 0, 0, 0, 482,
+		483, 1, 0, 0, 0, 483, 481, 1, 0, 0, 0, 483, 484, 1, 0, 0, 0, 484, 485,
+		1, 0, 0, 0, 485, 486, 6, 66, 0, 0, 486, 134, 1, 0, 0, 0, 487, 491, 5, 35,
+		0, 0, 488, 490, 8, 17, 0, 0, 489, 488, 1, 0, 0, 0, 490, 493, 1, 0, 0, 0,
+		491, 489, 1, 0, 0, 0, 491, 492, 1, 0, 0, 0, 492, 494, 1, 0, 0, 0, 493,
+		491, 1, 0, 0, 0, 494, 495, 6, 67, 0, 0, 495, 136, 1, 0, 0, 0, 496, 499,
+		5, 92, 0, 0, 497, 500, 7, 18, 0, 0, 498, 500, 3, 143, 71, 0, 499, 497,
+		1, 0, 0, 0, 499, 498, 1, 0, 0, 0, 500, 138, 1, 0, 0, 0, 501, 507, 5, 123,
+		0, 0, 502, 506, 3, 139, 69, 0, 503, 506, 3, 141, 70, 0, 504, 506, 8, 19,
+		0, 0, 505, 502, 1, 0, 0, 0, 505, 503, 1, 0, 0, 0, 505, 504, 1, 0, 0, 0,
+		506, 509, 1, 0, 0, 0, 507, 505, 1, 0, 0, 0, 507, 508, 1, 0, 0, 0, 508,
+		510, 1, 0, 0, 0, 509, 507, 1, 0, 0, 0, 510, 511, 5, 125, 0, 0, 511, 140,
+		1, 0, 0, 0, 512, 517, 5, 34, 0, 0, 513, 516, 3, 137, 68, 0, 514, 516, 8,
+		20, 0, 0, 515, 513, 1, 0, 0, 0, 515, 514, 1, 0, 0, 0, 516, 519, 1, 0, 0,
+		0, 517, 515, 1, 0, 0, 0, 517, 518, 1, 0, 0, 0, 518, 520, 1, 0, 0, 0, 519,
+		517, 1, 0, 0, 0, 520, 531, 5, 34, 0, 0, 521, 526, 5, 39, 0, 0, 522, 525,
+		3, 137, 68, 0, 523, 525, 8, 21, 0, 0, 524, 522, 1, 0, 0, 0, 524, 523, 1,
+		0, 0, 0, 525, 528, 1, 0, 0, 0, 526, 524, 1, 0, 0, 0, 526, 527, 1, 0, 0,
+		0, 527, 529, 1, 0, 0, 0, 528, 526, 1, 0, 0, 0, 529, 531, 5, 39, 0, 0, 530,
+		512, 1, 0, 0, 0, 530, 521, 1, 0, 0, 0, 531, 142, 1, 0, 0, 0, 532, 533,
+		5, 117, 0, 0, 533, 534, 3, 145, 72, 0, 534, 535, 3, 145, 72, 0, 535, 536,
+		3, 145, 72, 0, 536, 537, 3, 145, 72, 0, 537, 144, 1, 0, 0, 0, 538, 539,
+		7, 6, 0, 0, 539, 146, 1, 0, 0, 0, 41, 0, 318, 322, 328, 334, 338, 345,
+		351, 358, 361, 368, 371, 377, 380, 387, 390, 396, 398, 402, 408, 414, 416,
+		424, 426, 437, 439, 456, 458, 472, 474, 478, 483, 491, 499, 505, 507, 515,
+		517, 524, 526, 530, 1, 0, 1, 0,
 	}
 	deserializer := antlr.NewATNDeserializer(nil)
 	staticData.atn = deserializer.Deserialize(staticData.serializedATN)
@@ -360,16 +417,24 @@ const (
 	DCellLexerT__45               = 46
 	DCellLexerT__46               = 47
 	DCellLexerT__47               = 48
-	DCellLexerIDENTIFIER          = 49
-	DCellLexerDECIMAL_INTEGER     = 50
-	DCellLexerHEX_INTEGER         = 51
-	DCellLexerOCTAL_INTEGER       = 52
-	DCellLexerBINARY_INTEGER      = 53
-	DCellLexerDECIMAL_FLOAT       = 54
-	DCellLexerSCIENTIFIC_FLOAT    = 55
-	DCellLexerSINGLE_QUOTE_STRING = 56
-	DCellLexerDOUBLE_QUOTE_STRING = 57
-	DCellLexerTRIPLE_QUOTE_STRING = 58
-	DCellLexerWS                  = 59
-	DCellLexerCOMMENT             = 60
+	DCellLexerT__48               = 49
+	DCellLexerT__49               = 50
+	DCellLexerT__50               = 51
+	DCellLexerT__51               = 52
+	DCellLexerT__52               = 53
+	DCellLexerT__53               = 54
+	DCellLexerIDENTIFIER          = 55
+	DCellLexerVARIABLE            = 56
+	DCellLexerDECIMAL_INTEGER     = 57
+	DCellLexerHEX_INTEGER         = 58
+	DCellLexerOCTAL_INTEGER       = 59
+	DCellLexerBINARY_INTEGER      = 60
+	DCellLexerDECIMAL_FLOAT       = 61
+	DCellLexerSCIENTIFIC_FLOAT    = 62
+	DCellLexerSINGLE_QUOTE_STRING = 63
+	DCellLexerDOUBLE_QUOTE_STRING = 64
+	DCellLexerTRIPLE_QUOTE_STRING = 65
+	DCellLexerFORMAT_STRING       = 66
+	DCellLexerWS                  = 67
+	DCellLexerCOMMENT             = 68
 )
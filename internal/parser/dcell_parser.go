@@ -33,116 +33,141 @@ var DCellParserStaticData struct {
 func dcellParserInit() {
 	staticData := &DCellParserStaticData
 	staticData.LiteralNames = []string{
-		"", "'.'", "'['", "']'", "'is'", "'not'", "'in'", "'('", "')'", "'!'",
-		"'~'", "'+'", "'-'", "'**'", "'*'", "'/'", "'//'", "'%'", "'&&'", "'and'",
-		"'||'", "'or'", "'<->'", "'implies'", "'<<'", "'>>'", "'&'", "'^'",
-		"'|'", "'<='", "'<'", "'>'", "'>='", "'=='", "'!='", "'?'", "':'", "'?:'",
-		"'??'", "'as'", "','", "'true'", "'false'", "'null'", "'int'", "'uint'",
-		"'float'", "'string'", "'bool'",
+		"", "'.'", "'?.'", "'['", "']'", "'is'", "'not'", "'in'", "'('", "')'",
+		"'!'", "'~'", "'+'", "'-'", "'**'", "'*'", "'/'", "'//'", "'%'", "'=~'",
+		"'!~'", "'&&'", "'and'", "'||'", "'or'", "'<->'", "'implies'", "'<<'",
+		"'>>'", "'&'", "'^'", "'|'", "'<='", "'<'", "'>'", "'>='", "'=='", "'!='",
+		"'?'", "':'", "'?:'", "'??'", "'as'", "','", "'=>'", "'true'", "'false'",
+		"'null'", "'int'", "'uint'", "'float'", "'string'", "'bool'", "'{'",
+		"'}'",
 	}
 	staticData.SymbolicNames = []string{
 		"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "",
 		"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "",
-		"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "IDENTIFIER",
-		"DECIMAL_INTEGER", "HEX_INTEGER", "OCTAL_INTEGER", "BINARY_INTEGER",
-		"DECIMAL_FLOAT", "SCIENTIFIC_FLOAT", "SINGLE_QUOTE_STRING", "DOUBLE_QUOTE_STRING",
-		"TRIPLE_QUOTE_STRING", "WS", "COMMENT",
+		"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "",
+		"", "", "", "", "IDENTIFIER", "VARIABLE", "DECIMAL_INTEGER", "HEX_INTEGER",
+		"OCTAL_INTEGER", "BINARY_INTEGER", "DECIMAL_FLOAT", "SCIENTIFIC_FLOAT",
+		"SINGLE_QUOTE_STRING", "DOUBLE_QUOTE_STRING", "TRIPLE_QUOTE_STRING",
+		"FORMAT_STRING", "WS", "COMMENT",
 	}
 	staticData.RuleNames = []string{
-		"program", "expression", "term", "invocation", "parameterList", "identifier",
-		"index", "literal", "type", "list", "string", "integer", "float",
+		"program", "expression", "term", "invocation", "parameterList", "parameter",
+		"lambda", "identifier", "index", "literal", "type", "list", "object",
+		"property", "string", "integer", "float",
 	}
 	staticData.PredictionContextCache = antlr.NewPredictionContextCache()
 	staticData.serializedATN = []int32{
-		4, 1, 60, 192, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
+		4, 1, 68, 237, 2, 0, 7, 0, 2, 1, 7, 1, 2, 2, 7, 2, 2, 3, 7, 3, 2, 4, 7,
 		4, 2, 5, 7, 5, 2, 6, 7, 6, 2, 7, 7, 7, 2, 8, 7, 8, 2, 9, 7, 9, 2, 10, 7,
-		10, 2, 11, 7, 11, 2, 12, 7, 12, 1, 0, 1, 0, 1, 0, 1, 1, 1, 1, 1, 1, 1,
-		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 3, 1, 42, 8, 1, 1, 1,
-		1, 1, 1, 1, 1, 1, 3, 1, 48, 8, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		10, 2, 11, 7, 11, 2, 12, 7, 12, 2, 13, 7, 13, 2, 14, 7, 14, 2, 15, 7, 15,
+		2, 16, 7, 16, 1, 0, 1, 0, 1, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 3, 1, 50, 8, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		3, 1, 56, 8, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
 		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
 		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
 		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
 		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-		1, 1, 1, 1, 1, 1, 1, 3, 1, 108, 8, 1, 1, 1, 1, 1, 1, 1, 1, 1, 5, 1, 114,
-		8, 1, 10, 1, 12, 1, 117, 9, 1, 1, 2, 1, 2, 3, 2, 121, 8, 2, 1, 3, 1, 3,
-		1, 3, 1, 3, 1, 3, 3, 3, 128, 8, 3, 1, 3, 1, 3, 3, 3, 132, 8, 3, 1, 4, 1,
-		4, 1, 4, 5, 4, 137, 8, 4, 10, 4, 12, 4, 140, 9, 4, 1, 5, 1, 5, 1, 6, 3,
-		6, 145, 8, 6, 1, 6, 1, 6, 3, 6, 149, 8, 6, 1, 6, 3, 6, 152, 8, 6, 1, 7,
-		1, 7, 1, 7, 1, 7, 1, 7, 1, 7, 3, 7, 160, 8, 7, 1, 8, 1, 8, 1, 9, 1, 9,
-		1, 9, 1, 9, 5, 9, 168, 8, 9, 10, 9, 12, 9, 171, 9, 9, 3, 9, 173, 8, 9,
-		1, 9, 1, 9, 1, 10, 1, 10, 1, 10, 3, 10, 180, 8, 10, 1, 11, 1, 11, 1, 11,
-		1, 11, 3, 11, 186, 8, 11, 1, 12, 1, 12, 3, 12, 190, 8, 12, 1, 12, 0, 1,
-		2, 13, 0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 0, 12, 2, 0, 5, 5,
-		9, 9, 1, 0, 11, 12, 1, 0, 14, 17, 1, 0, 18, 19, 1, 0, 20, 21, 1, 0, 22,
-		23, 1, 0, 24, 25, 1, 0, 27, 28, 1, 0, 29, 32, 1, 0, 33, 34, 1, 0, 41, 42,
-		1, 0, 44, 48, 224, 0, 26, 1, 0, 0, 0, 2, 41, 1, 0, 0, 0, 4, 120, 1, 0,
-		0, 0, 6, 131, 1, 0, 0, 0, 8, 133, 1, 0, 0, 0, 10, 141, 1, 0, 0, 0, 12,
-		151, 1, 0, 0, 0, 14, 159, 1, 0, 0, 0, 16, 161, 1, 0, 0, 0, 18, 163, 1,
-		0, 0, 0, 20, 179, 1, 0, 0, 0, 22, 185, 1, 0, 0, 0, 24, 189, 1, 0, 0, 0,
-		26, 27, 3, 2, 1, 0, 27, 28, 5, 0, 0, 1, 28, 1, 1, 0, 0, 0, 29, 30, 6, 1,
-		-1, 0, 30, 42, 3, 4, 2, 0, 31, 32, 5, 7, 0, 0, 32, 33, 3, 2, 1, 0, 33,
-		34, 5, 8, 0, 0, 34, 42, 1, 0, 0, 0, 35, 36, 7, 0, 0, 0, 36, 42, 3, 2, 1,
-		18, 37, 38, 5, 10, 0, 0, 38, 42, 3, 2, 1, 17, 39, 40, 7, 1, 0, 0, 40, 42,
-		3, 2, 1, 16, 41, 29, 1, 0, 0, 0, 41, 31, 1, 0, 0, 0, 41, 35, 1, 0, 0, 0,
-		41, 37, 1, 0, 0, 0, 41, 39, 1, 0, 0, 0, 42, 115, 1, 0, 0, 0, 43, 47, 10,
-		20, 0, 0, 44, 48, 5, 6, 0, 0, 45, 46, 5, 5, 0, 0, 46, 48, 5, 6, 0, 0, 47,
-		44, 1, 0, 0, 0, 47, 45, 1, 0, 0, 0, 48, 49, 1, 0, 0, 0, 49, 114, 3, 2,
-		1, 21, 50, 51, 10, 15, 0, 0, 51, 52, 5, 13, 0, 0, 52, 114, 3, 2, 1, 16,
-		53, 54, 10, 14, 0, 0, 54, 55, 7, 2, 0, 0, 55, 114, 3, 2, 1, 15, 56, 57,
-		10, 13, 0, 0, 57, 58, 7, 1, 0, 0, 58, 114, 3, 2, 1, 14, 59, 60, 10, 12,
-		0, 0, 60, 61, 7, 3, 0, 0, 61, 114, 3, 2, 1, 13, 62, 63, 10, 11, 0, 0, 63,
-		64, 7, 4, 0, 0, 64, 114, 3, 2, 1, 12, 65, 66, 10, 10, 0, 0, 66, 67, 7,
-		5, 0, 0, 67, 114, 3, 2, 1, 11, 68, 69, 10, 9, 0, 0, 69, 70, 7, 6, 0, 0,
-		70, 114, 3, 2, 1, 10, 71, 72, 10, 8, 0, 0, 72, 73, 5, 26, 0, 0, 73, 114,
-		3, 2, 1, 9, 74, 75, 10, 7, 0, 0, 75, 76, 7, 7, 0, 0, 76, 114, 3, 2, 1,
-		8, 77, 78, 10, 6, 0, 0, 78, 79, 7, 8, 0, 0, 79, 114, 3, 2, 1, 7, 80, 81,
-		10, 5, 0, 0, 81, 82, 7, 9, 0, 0, 82, 114, 3, 2, 1, 6, 83, 84, 10, 4, 0,
-		0, 84, 85, 5, 35, 0, 0, 85, 86, 3, 2, 1, 0, 86, 87, 5, 36, 0, 0, 87, 88,
-		3, 2, 1, 5, 88, 114, 1, 0, 0, 0, 89, 90, 10, 3, 0, 0, 90, 91, 5, 37, 0,
-		0, 91, 114, 3, 2, 1, 4, 92, 93, 10, 2, 0, 0, 93, 94, 5, 38, 0, 0, 94, 114,
-		3, 2, 1, 3, 95, 96, 10, 23, 0, 0, 96, 97, 5, 1, 0, 0, 97, 114, 3, 6, 3,
-		0, 98, 99, 10, 22, 0, 0, 99, 100, 5, 2, 0, 0, 100, 101, 3, 12, 6, 0, 101,
-		102, 5, 3, 0, 0, 102, 114, 1, 0, 0, 0, 103, 107, 10, 21, 0, 0, 104, 105,
-		5, 4, 0, 0, 105, 108, 5, 5, 0, 0, 106, 108, 5, 4, 0, 0, 107, 104, 1, 0,
-		0, 0, 107, 106, 1, 0, 0, 0, 108, 109, 1, 0, 0, 0, 109, 114, 3, 16, 8, 0,
-		110, 111, 10, 1, 0, 0, 111, 112, 5, 39, 0, 0, 112, 114, 3, 16, 8, 0, 113,
-		43, 1, 0, 0, 0, 113, 50, 1, 0, 0, 0, 113, 53, 1, 0, 0, 0, 113, 56, 1, 0,
-		0, 0, 113, 59, 1, 0, 0, 0, 113, 62, 1, 0, 0, 0, 113, 65, 1, 0, 0, 0, 113,
-		68, 1, 0, 0, 0, 113, 71, 1, 0, 0, 0, 113, 74, 1, 0, 0, 0, 113, 77, 1, 0,
-		0, 0, 113, 80, 1, 0, 0, 0, 113, 83, 1, 0, 0, 0, 113, 89, 1, 0, 0, 0, 113,
-		92, 1, 0, 0, 0, 113, 95, 1, 0, 0, 0, 113, 98, 1, 0, 0, 0, 113, 103, 1,
-		0, 0, 0, 113, 110, 1, 0, 0, 0, 114, 117, 1, 0, 0, 0, 115, 113, 1, 0, 0,
-		0, 115, 116, 1, 0, 0, 0, 116, 3, 1, 0, 0, 0, 117, 115, 1, 0, 0, 0, 118,
-		121, 3, 14, 7, 0, 119, 121, 3, 6, 3, 0, 120, 118, 1, 0, 0, 0, 120, 119,
-		1, 0, 0, 0, 121, 5, 1, 0, 0, 0, 122, 132, 3, 10, 5, 0, 123, 132, 5, 14,
-		0, 0, 124, 125, 3, 10, 5, 0, 125, 127, 5, 7, 0, 0, 126, 128, 3, 8, 4, 0,
-		127, 126, 1, 0, 0, 0, 127, 128, 1, 0, 0, 0, 128, 129, 1, 0, 0, 0, 129,
-		130, 5, 8, 0, 0, 130, 132, 1, 0, 0, 0, 131, 122, 1, 0, 0, 0, 131, 123,
-		1, 0, 0, 0, 131, 124, 1, 0, 0, 0, 132, 7, 1, 0, 0, 0, 133, 138, 3, 2, 1,
-		0, 134, 135, 5, 40, 0, 0, 135, 137, 3, 2, 1, 0, 136, 134, 1, 0, 0, 0, 137,
-		140, 1, 0, 0, 0, 138, 136, 1, 0, 0, 0, 138, 139, 1, 0, 0, 0, 139, 9, 1,
-		0, 0, 0, 140, 138, 1, 0, 0, 0, 141, 142, 5, 49, 0, 0, 142, 11, 1, 0, 0,
-		0, 143, 145, 3, 2, 1, 0, 144, 143, 1, 0, 0, 0, 144, 145, 1, 0, 0, 0, 145,
-		146, 1, 0, 0, 0, 146, 148, 5, 36, 0, 0, 147, 149, 3, 2, 1, 0, 148, 147,
-		1, 0, 0, 0, 148, 149, 1, 0, 0, 0, 149, 152, 1, 0, 0, 0, 150, 152, 3, 2,
-		1, 0, 151, 144, 1, 0, 0, 0, 151, 150, 1, 0, 0, 0, 152, 13, 1, 0, 0, 0,
-		153, 160, 3, 20, 10, 0, 154, 160, 3, 22, 11, 0, 155, 160, 3, 24, 12, 0,
-		156, 160, 7, 10, 0, 0, 157, 160, 5, 43, 0, 0, 158, 160, 3, 18, 9, 0, 159,
-		153, 1, 0, 0, 0, 159, 154, 1, 0, 0, 0, 159, 155, 1, 0, 0, 0, 159, 156,
-		1, 0, 0, 0, 159, 157, 1, 0, 0, 0, 159, 158, 1, 0, 0, 0, 160, 15, 1, 0,
-		0, 0, 161, 162, 7, 11, 0, 0, 162, 17, 1, 0, 0, 0, 163, 172, 5, 2, 0, 0,
-		164, 169, 3, 14, 7, 0, 165, 166, 5, 40, 0, 0, 166, 168, 3, 14, 7, 0, 167,
-		165, 1, 0, 0, 0, 168, 171, 1, 0, 0, 0, 169, 167, 1, 0, 0, 0, 169, 170,
-		1, 0, 0, 0, 170, 173, 1, 0, 0, 0, 171, 169, 1, 0, 0, 0, 172, 164, 1, 0,
-		0, 0, 172, 173, 1, 0, 0, 0, 173, 174, 1, 0, 0, 0, 174, 175, 5, 3, 0, 0,
-		175, 19, 1, 0, 0, 0, 176, 180, 5, 56, 0, 0, 177, 180, 5, 57, 0, 0, 178,
-		180, 5, 58, 0, 0, 179, 176, 1, 0, 0, 0, 179, 177, 1, 0, 0, 0, 179, 178,
-		1, 0, 0, 0, 180, 21, 1, 0, 0, 0, 181, 186, 5, 50, 0, 0, 182, 186, 5, 51,
-		0, 0, 183, 186, 5, 52, 0, 0, 184, 186, 5, 53, 0, 0, 185, 181, 1, 0, 0,
-		0, 185, 182, 1, 0, 0, 0, 185, 183, 1, 0, 0, 0, 185, 184, 1, 0, 0, 0, 186,
-		23, 1, 0, 0, 0, 187, 190, 5, 55, 0, 0, 188, 190, 5, 54, 0, 0, 189, 187,
-		1, 0, 0, 0, 189, 188, 1, 0, 0, 0, 190, 25, 1, 0, 0, 0, 18, 41, 47, 107,
-		113, 115, 120, 127, 131, 138, 144, 148, 151, 159, 169, 172, 179, 185, 189,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 3, 1, 122, 8, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 5, 1, 128, 8, 1, 10, 1, 12, 1, 131, 9, 1, 1, 2, 1, 2, 1, 2, 3,
+		2, 136, 8, 2, 1, 3, 1, 3, 1, 3, 1, 3, 1, 3, 3, 3, 143, 8, 3, 1, 3, 1, 3,
+		3, 3, 147, 8, 3, 1, 4, 1, 4, 1, 4, 5, 4, 152, 8, 4, 10, 4, 12, 4, 155,
+		9, 4, 1, 5, 1, 5, 3, 5, 159, 8, 5, 1, 6, 1, 6, 1, 6, 1, 6, 1, 7, 1, 7,
+		1, 8, 3, 8, 168, 8, 8, 1, 8, 1, 8, 3, 8, 172, 8, 8, 1, 8, 3, 8, 175, 8,
+		8, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 1, 9, 3, 9, 185, 8, 9, 1,
+		10, 1, 10, 1, 11, 1, 11, 1, 11, 1, 11, 5, 11, 193, 8, 11, 10, 11, 12, 11,
+		196, 9, 11, 3, 11, 198, 8, 11, 1, 11, 1, 11, 1, 12, 1, 12, 1, 12, 1, 12,
+		5, 12, 206, 8, 12, 10, 12, 12, 12, 209, 9, 12, 3, 12, 211, 8, 12, 1, 12,
+		1, 12, 1, 13, 1, 13, 3, 13, 217, 8, 13, 1, 13, 1, 13, 1, 13, 1, 14, 1,
+		14, 1, 14, 3, 14, 225, 8, 14, 1, 15, 1, 15, 1, 15, 1, 15, 3, 15, 231, 8,
+		15, 1, 16, 1, 16, 3, 16, 235, 8, 16, 1, 16, 0, 1, 2, 17, 0, 2, 4, 6, 8,
+		10, 12, 14, 16, 18, 20, 22, 24, 26, 28, 30, 32, 0, 13, 2, 0, 6, 6, 10,
+		10, 1, 0, 12, 13, 1, 0, 15, 18, 1, 0, 19, 20, 1, 0, 21, 22, 1, 0, 23, 24,
+		1, 0, 25, 26, 1, 0, 27, 28, 1, 0, 30, 31, 1, 0, 32, 35, 1, 0, 36, 37, 1,
+		0, 45, 46, 1, 0, 48, 52, 274, 0, 34, 1, 0, 0, 0, 2, 49, 1, 0, 0, 0, 4,
+		135, 1, 0, 0, 0, 6, 146, 1, 0, 0, 0, 8, 148, 1, 0, 0, 0, 10, 158, 1, 0,
+		0, 0, 12, 160, 1, 0, 0, 0, 14, 164, 1, 0, 0, 0, 16, 174, 1, 0, 0, 0, 18,
+		184, 1, 0, 0, 0, 20, 186, 1, 0, 0, 0, 22, 188, 1, 0, 0, 0, 24, 201, 1,
+		0, 0, 0, 26, 216, 1, 0, 0, 0, 28, 224, 1, 0, 0, 0, 30, 230, 1, 0, 0, 0,
+		32, 234, 1, 0, 0, 0, 34, 35, 3, 2, 1, 0, 35, 36, 5, 0, 0, 1, 36, 1, 1,
+		0, 0, 0, 37, 38, 6, 1, -1, 0, 38, 50, 3, 4, 2, 0, 39, 40, 5, 8, 0, 0, 40,
+		41, 3, 2, 1, 0, 41, 42, 5, 9, 0, 0, 42, 50, 1, 0, 0, 0, 43, 44, 7, 0, 0,
+		0, 44, 50, 3, 2, 1, 19, 45, 46, 5, 11, 0, 0, 46, 50, 3, 2, 1, 18, 47, 48,
+		7, 1, 0, 0, 48, 50, 3, 2, 1, 17, 49, 37, 1, 0, 0, 0, 49, 39, 1, 0, 0, 0,
+		49, 43, 1, 0, 0, 0, 49, 45, 1, 0, 0, 0, 49, 47, 1, 0, 0, 0, 50, 129, 1,
+		0, 0, 0, 51, 55, 10, 21, 0, 0, 52, 56, 5, 7, 0, 0, 53, 54, 5, 6, 0, 0,
+		54, 56, 5, 7, 0, 0, 55, 52, 1, 0, 0, 0, 55, 53, 1, 0, 0, 0, 56, 57, 1,
+		0, 0, 0, 57, 128, 3, 2, 1, 22, 58, 59, 10, 16, 0, 0, 59, 60, 5, 14, 0,
+		0, 60, 128, 3, 2, 1, 17, 61, 62, 10, 15, 0, 0, 62, 63, 7, 2, 0, 0, 63,
+		128, 3, 2, 1, 16, 64, 65, 10, 14, 0, 0, 65, 66, 7, 1, 0, 0, 66, 128, 3,
+		2, 1, 15, 67, 68, 10, 13, 0, 0, 68, 69, 7, 3, 0, 0, 69, 128, 3, 2, 1, 14,
+		70, 71, 10, 12, 0, 0, 71, 72, 7, 4, 0, 0, 72, 128, 3, 2, 1, 13, 73, 74,
+		10, 11, 0, 0, 74, 75, 7, 5, 0, 0, 75, 128, 3, 2, 1, 12, 76, 77, 10, 10,
+		0, 0, 77, 78, 7, 6, 0, 0, 78, 128, 3, 2, 1, 11, 79, 80, 10, 9, 0, 0, 80,
+		81, 7, 7, 0, 0, 81, 128, 3, 2, 1, 10, 82, 83, 10, 8, 0, 0, 83, 84, 5, 29,
+		0, 0, 84, 128, 3, 2, 1, 9, 85, 86, 10, 7, 0, 0, 86, 87, 7, 8, 0, 0, 87,
+		128, 3, 2, 1, 8, 88, 89, 10, 6, 0, 0, 89, 90, 7, 9, 0, 0, 90, 128, 3, 2,
+		1, 7, 91, 92, 10, 5, 0, 0, 92, 93, 7, 10, 0, 0, 93, 128, 3, 2, 1, 6, 94,
+		95, 10, 4, 0, 0, 95, 96, 5, 38, 0, 0, 96, 97, 3, 2, 1, 0, 97, 98, 5, 39,
+		0, 0, 98, 99, 3, 2, 1, 5, 99, 128, 1, 0, 0, 0, 100, 101, 10, 3, 0, 0, 101,
+		102, 5, 40, 0, 0, 102, 128, 3, 2, 1, 4, 103, 104, 10, 2, 0, 0, 104, 105,
+		5, 41, 0, 0, 105, 128, 3, 2, 1, 3, 106, 107, 10, 25, 0, 0, 107, 108, 5,
+		1, 0, 0, 108, 128, 3, 6, 3, 0, 109, 110, 10, 24, 0, 0, 110, 111, 5, 2,
+		0, 0, 111, 128, 3, 6, 3, 0, 112, 113, 10, 23, 0, 0, 113, 114, 5, 3, 0,
+		0, 114, 115, 3, 16, 8, 0, 115, 116, 5, 4, 0, 0, 116, 128, 1, 0, 0, 0, 117,
+		121, 10, 22, 0, 0, 118, 119, 5, 5, 0, 0, 119, 122, 5, 6, 0, 0, 120, 122,
+		5, 5, 0, 0, 121, 118, 1, 0, 0, 0, 121, 120, 1, 0, 0, 0, 122, 123, 1, 0,
+		0, 0, 123, 128, 3, 20, 10, 0, 124, 125, 10, 1, 0, 0, 125, 126, 5, 42, 0,
+		0, 126, 128, 3, 20, 10, 0, 127, 51, 1, 0, 0, 0, 127, 58, 1, 0, 0, 0, 127,
+		61, 1, 0, 0, 0, 127, 64, 1, 0, 0, 0, 127, 67, 1, 0, 0, 0, 127, 70, 1, 0,
+		0, 0, 127, 73, 1, 0, 0, 0, 127, 76, 1, 0, 0, 0, 127, 79, 1, 0, 0, 0, 127,
+		82, 1, 0, 0, 0, 127, 85, 1, 0, 0, 0, 127, 88, 1, 0, 0, 0, 127, 91, 1, 0,
+		0, 0, 127, 94, 1, 0, 0, 0, 127, 100, 1, 0, 0, 0, 127, 103, 1, 0, 0, 0,
+		127, 106, 1, 0, 0, 0, 127, 109, 1, 0, 0, 0, 127, 112, 1, 0, 0, 0, 127,
+		117, 1, 0, 0, 0, 127, 124, 1, 0, 0, 0, 128, 131, 1, 0, 0, 0, 129, 127,
+		1, 0, 0, 0, 129, 130, 1, 0, 0, 0, 130, 3, 1, 0, 0, 0, 131, 129, 1, 0, 0,
+		0, 132, 136, 3, 18, 9, 0, 133, 136, 3, 6, 3, 0, 134, 136, 5, 56, 0, 0,
+		135, 132, 1, 0, 0, 0, 135, 133, 1, 0, 0, 0, 135, 134, 1, 0, 0, 0, 136,
+		5, 1, 0, 0, 0, 137, 147, 3, 14, 7, 0, 138, 147, 5, 15, 0, 0, 139, 140,
+		3, 14, 7, 0, 140, 142, 5, 8, 0, 0, 141, 143, 3, 8, 4, 0, 142, 141, 1, 0,
+		0, 0, 142, 143, 1, 0, 0, 0, 143, 144, 1, 0, 0, 0, 144, 145, 5, 9, 0, 0,
+		145, 147, 1, 0, 0, 0, 146, 137, 1, 0, 0, 0, 146, 138, 1, 0, 0, 0, 146,
+		139, 1, 0, 0, 0, 147, 7, 1, 0, 0, 0, 148, 153, 3, 10, 5, 0, 149, 150, 5,
+		43, 0, 0, 150, 152, 3, 10, 5, 0, 151, 149, 1, 0, 0, 0, 152, 155, 1, 0,
+		0, 0, 153, 151, 1, 0, 0, 0, 153, 154, 1, 0, 0, 0, 154, 9, 1, 0, 0, 0, 155,
+		153, 1, 0, 0, 0, 156, 159, 3, 12, 6, 0, 157, 159, 3, 2, 1, 0, 158, 156,
+		1, 0, 0, 0, 158, 157, 1, 0, 0, 0, 159, 11, 1, 0, 0, 0, 160, 161, 3, 14,
+		7, 0, 161, 162, 5, 44, 0, 0, 162, 163, 3, 2, 1, 0, 163, 13, 1, 0, 0, 0,
+		164, 165, 5, 55, 0, 0, 165, 15, 1, 0, 0, 0, 166, 168, 3, 2, 1, 0, 167,
+		166, 1, 0, 0, 0, 167, 168, 1, 0, 0, 0, 168, 169, 1, 0, 0, 0, 169, 171,
+		5, 39, 0, 0, 170, 172, 3, 2, 1, 0, 171, 170, 1, 0, 0, 0, 171, 172, 1, 0,
+		0, 0, 172, 175, 1, 0, 0, 0, 173, 175, 3, 2, 1, 0, 174, 167, 1, 0, 0, 0,
+		174, 173, 1, 0, 0, 0, 175, 17, 1, 0, 0, 0, 176, 185, 3, 28, 14, 0, 177,
+		185, 3, 30, 15, 0, 178, 185, 3, 32, 16, 0, 179, 185, 7, 11, 0, 0, 180,
+		185, 5, 47, 0, 0, 181, 185, 3, 22, 11, 0, 182, 185, 3, 24, 12, 0, 183,
+		185, 5, 66, 0, 0, 184, 176, 1, 0, 0, 0, 184, 177, 1, 0, 0, 0, 184, 178,
+		1, 0, 0, 0, 184, 179, 1, 0, 0, 0, 184, 180, 1, 0, 0, 0, 184, 181, 1, 0,
+		0, 0, 184, 182, 1, 0, 0, 0, 184, 183, 1, 0, 0, 0, 185, 19, 1, 0, 0, 0,
+		186, 187, 7, 12, 0, 0, 187, 21, 1, 0, 0, 0, 188, 197, 5, 3, 0, 0, 189,
+		194, 3, 2, 1, 0, 190, 191, 5, 43, 0, 0, 191, 193, 3, 2, 1, 0, 192, 190,
+		1, 0, 0, 0, 193, 196, 1, 0, 0, 0, 194, 192, 1, 0, 0, 0, 194, 195, 1, 0,
+		0, 0, 195, 198, 1, 0, 0, 0, 196, 194, 1, 0, 0, 0, 197, 189, 1, 0, 0, 0,
+		197, 198, 1, 0, 0, 0, 198, 199, 1, 0, 0, 0, 199, 200, 5, 4, 0, 0, 200,
+		23, 1, 0, 0, 0, 201, 210, 5, 53, 0, 0, 202, 207, 3, 26, 13, 0, 203, 204,
+		5, 43, 0, 0, 204, 206, 3, 26, 13, 0, 205, 203, 1, 0, 0, 0, 206, 209, 1,
+		0, 0, 0, 207, 205, 1, 0, 0, 0, 207, 208, 1, 0, 0, 0, 208, 211, 1, 0, 0,
+		0, 209, 207, 1, 0, 0, 0, 210, 202, 1, 0, 0, 0, 210, 211, 1, 0, 0, 0, 211,
+		212, 1, 0, 0, 0, 212, 213, 5, 54, 0, 0, 213, 25, 1, 0, 0, 0, 214, 217,
+		3, 14, 7, 0, 215, 217, 3, 28, 14, 0, 216, 214, 1, 0, 0, 0, 216, 215, 1,
+		0, 0, 0, 217, 218, 1, 0, 0, 0, 218, 219, 5, 39, 0, 0, 219, 220, 3, 2, 1,
+		0, 220, 27, 1, 0, 0, 0, 221, 225, 5, 63, 0, 0, 222, 225, 5, 64, 0, 0, 223,
+		225, 5, 65, 0, 0, 224, 221, 1, 0, 0, 0, 224, 222, 1, 0, 0, 0, 224, 223,
+		1, 0, 0, 0, 225, 29, 1, 0, 0, 0, 226, 231, 5, 57, 0, 0, 227, 231, 5, 58,
+		0, 0, 228, 231, 5, 59, 0, 0, 229, 231, 5, 60, 0, 0, 230, 226, 1, 0, 0,
+		0, 230, 227, 1, 0, 0, 0, 230, 228, 1, 0, 0, 0, 230, 229, 1, 0, 0, 0, 231,
+		31, 1, 0, 0, 0, 232, 235, 5, 62, 0, 0, 233, 235, 5, 61, 0, 0, 234, 232,
+		1, 0, 0, 0, 234, 233, 1, 0, 0, 0, 235, 33, 1, 0, 0, 0, 22, 49, 55, 121,
+		127, 129, 135, 142, 146, 153, 158, 167, 171, 174, 184, 194, 197, 207, 210,
+		216, 224, 230, 234,
 	}
 	deserializer := antlr.NewATNDeserializer(nil)
 	staticData.atn = deserializer.Deserialize(staticData.serializedATN)
@@ -229,18 +254,26 @@ const (
 	DCellParserT__45               = 46
 	DCellParserT__46               = 47
 	DCellParserT__47               = 48
-	DCellParserIDENTIFIER          = 49
-	DCellParserDECIMAL_INTEGER     = 50
-	DCellParserHEX_INTEGER         = 51
-	DCellParserOCTAL_INTEGER       = 52
-	DCellParserBINARY_INTEGER      = 53
-	DCellParserDECIMAL_FLOAT       = 54
-	DCellParserSCIENTIFIC_FLOAT    = 55
-	DCellParserSINGLE_QUOTE_STRING = 56
-	DCellParserDOUBLE_QUOTE_STRING = 57
-	DCellParserTRIPLE_QUOTE_STRING = 58
-	DCellParserWS                  = 59
-	DCellParserCOMMENT             = 60
+	DCellParserT__48               = 49
+	DCellParserT__49               = 50
+	DCellParserT__50               = 51
+	DCellParserT__51               = 52
+	DCellParserT__52               = 53
+	DCellParserT__53               = 54
+	DCellParserIDENTIFIER          = 55
+	DCellParserVARIABLE            = 56
+	DCellParserDECIMAL_INTEGER     = 57
+	DCellParserHEX_INTEGER         = 58
+	DCellParserOCTAL_INTEGER       = 59
+	DCellParserBINARY_INTEGER      = 60
+	DCellParserDECIMAL_FLOAT       = 61
+	DCellParserSCIENTIFIC_FLOAT    = 62
+	DCellParserSINGLE_QUOTE_STRING = 63
+	DCellParserDOUBLE_QUOTE_STRING = 64
+	DCellParserTRIPLE_QUOTE_STRING = 65
+	DCellParserFORMAT_STRING       = 66
+	DCellParserWS                  = 67
+	DCellParserCOMMENT             = 68
 )
 
 // DCellParser rules.
@@ -250,14 +283,18 @@ const (
 	DCellParserRULE_term          = 2
 	DCellParserRULE_invocation    = 3
 	DCellParserRULE_parameterList = 4
-	DCellParserRULE_identifier    = 5
-	DCellParserRULE_index         = 6
-	DCellParserRULE_literal       = 7
-	DCellParserRULE_type          = 8
-	DCellParserRULE_list          = 9
-	DCellParserRULE_string        = 10
-	DCellParserRULE_integer       = 11
-	DCellParserRULE_float         = 12
+	DCellParserRULE_parameter     = 5
+	DCellParserRULE_lambda        = 6
+	DCellParserRULE_identifier    = 7
+	DCellParserRULE_index         = 8
+	DCellParserRULE_literal       = 9
+	DCellParserRULE_type          = 10
+	DCellParserRULE_list          = 11
+	DCellParserRULE_object        = 12
+	DCellParserRULE_property      = 13
+	DCellParserRULE_string        = 14
+	DCellParserRULE_integer       = 15
+	DCellParserRULE_float         = 16
 )
 
 // IProgramContext is an interface to support dynamic dispatch.
@@ -340,11 +377,11 @@ func (p *DCellParser) Program() (localctx IProgramContext) {
 	p.EnterRule(localctx, 0, DCellParserRULE_program)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(26)
+		p.SetState(34)
 		p.expression(0)
 	}
 	{
-		p.SetState(27)
+		p.SetState(35)
 		p.Match(DCellParserEOF)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -419,46 +456,12 @@ func (s *ExpressionContext) ToStringTree(ruleNames []string, recog antlr.Recogni
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-type LogicalNotExpressionContext struct {
-	ExpressionContext
-}
-
-func NewLogicalNotExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *LogicalNotExpressionContext {
-	var p = new(LogicalNotExpressionContext)
-
-	InitEmptyExpressionContext(&p.ExpressionContext)
-	p.parser = parser
-	p.CopyAll(ctx.(*ExpressionContext))
-
-	return p
-}
-
-func (s *LogicalNotExpressionContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
-
-func (s *LogicalNotExpressionContext) Expression() IExpressionContext {
-	var t antlr.RuleContext
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IExpressionContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
-		}
-	}
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(IExpressionContext)
-}
-
-type CoalesceExpressionContext struct {
+type MatchExpressionContext struct {
 	ExpressionContext
 }
 
-func NewCoalesceExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *CoalesceExpressionContext {
-	var p = new(CoalesceExpressionContext)
+func NewMatchExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *MatchExpressionContext {
+	var p = new(MatchExpressionContext)
 
 	InitEmptyExpressionContext(&p.ExpressionContext)
 	p.parser = parser
@@ -467,11 +470,11 @@ func NewCoalesceExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleConte
 	return p
 }
 
-func (s *CoalesceExpressionContext) GetRuleContext() antlr.RuleContext {
+func (s *MatchExpressionContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *CoalesceExpressionContext) AllExpression() []IExpressionContext {
+func (s *MatchExpressionContext) AllExpression() []IExpressionContext {
 	children := s.GetChildren()
 	len := 0
 	for _, ctx := range children {
@@ -492,7 +495,7 @@ func (s *CoalesceExpressionContext) AllExpression() []IExpressionContext {
 	return tst
 }
 
-func (s *CoalesceExpressionContext) Expression(i int) IExpressionContext {
+func (s *MatchExpressionContext) Expression(i int) IExpressionContext {
 	var t antlr.RuleContext
 	j := 0
 	for _, ctx := range s.GetChildren() {
@@ -512,12 +515,12 @@ func (s *CoalesceExpressionContext) Expression(i int) IExpressionContext {
 	return t.(IExpressionContext)
 }
 
-type ShiftExpressionContext struct {
+type LogicalNotExpressionContext struct {
 	ExpressionContext
 }
 
-func NewShiftExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ShiftExpressionContext {
-	var p = new(ShiftExpressionContext)
+func NewLogicalNotExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *LogicalNotExpressionContext {
+	var p = new(LogicalNotExpressionContext)
 
 	InitEmptyExpressionContext(&p.ExpressionContext)
 	p.parser = parser
@@ -526,41 +529,16 @@ func NewShiftExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext)
 	return p
 }
 
-func (s *ShiftExpressionContext) GetRuleContext() antlr.RuleContext {
+func (s *LogicalNotExpressionContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *ShiftExpressionContext) AllExpression() []IExpressionContext {
-	children := s.GetChildren()
-	len := 0
-	for _, ctx := range children {
-		if _, ok := ctx.(IExpressionContext); ok {
-			len++
-		}
-	}
-
-	tst := make([]IExpressionContext, len)
-	i := 0
-	for _, ctx := range children {
-		if t, ok := ctx.(IExpressionContext); ok {
-			tst[i] = t.(IExpressionContext)
-			i++
-		}
-	}
-
-	return tst
-}
-
-func (s *ShiftExpressionContext) Expression(i int) IExpressionContext {
+func (s *LogicalNotExpressionContext) Expression() IExpressionContext {
 	var t antlr.RuleContext
-	j := 0
 	for _, ctx := range s.GetChildren() {
 		if _, ok := ctx.(IExpressionContext); ok {
-			if j == i {
-				t = ctx.(antlr.RuleContext)
-				break
-			}
-			j++
+			t = ctx.(antlr.RuleContext)
+			break
 		}
 	}
 
@@ -621,40 +599,6 @@ func (s *IsExpressionContext) Type_() ITypeContext {
 	return t.(ITypeContext)
 }
 
-type PolarityExpressionContext struct {
-	ExpressionContext
-}
-
-func NewPolarityExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *PolarityExpressionContext {
-	var p = new(PolarityExpressionContext)
-
-	InitEmptyExpressionContext(&p.ExpressionContext)
-	p.parser = parser
-	p.CopyAll(ctx.(*ExpressionContext))
-
-	return p
-}
-
-func (s *PolarityExpressionContext) GetRuleContext() antlr.RuleContext {
-	return s
-}
-
-func (s *PolarityExpressionContext) Expression() IExpressionContext {
-	var t antlr.RuleContext
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IExpressionContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
-		}
-	}
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(IExpressionContext)
-}
-
 type AdditiveExpressionContext struct {
 	ExpressionContext
 }
@@ -773,12 +717,12 @@ func (s *ContainsExpressionContext) Expression(i int) IExpressionContext {
 	return t.(IExpressionContext)
 }
 
-type ParenthesisExpressionContext struct {
+type CastExpressionContext struct {
 	ExpressionContext
 }
 
-func NewParenthesisExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ParenthesisExpressionContext {
-	var p = new(ParenthesisExpressionContext)
+func NewCastExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *CastExpressionContext {
+	var p = new(CastExpressionContext)
 
 	InitEmptyExpressionContext(&p.ExpressionContext)
 	p.parser = parser
@@ -787,11 +731,11 @@ func NewParenthesisExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleCo
 	return p
 }
 
-func (s *ParenthesisExpressionContext) GetRuleContext() antlr.RuleContext {
+func (s *CastExpressionContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *ParenthesisExpressionContext) Expression() IExpressionContext {
+func (s *CastExpressionContext) Expression() IExpressionContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
 		if _, ok := ctx.(IExpressionContext); ok {
@@ -807,12 +751,28 @@ func (s *ParenthesisExpressionContext) Expression() IExpressionContext {
 	return t.(IExpressionContext)
 }
 
-type MultiplicativeExpressionContext struct {
+func (s *CastExpressionContext) Type_() ITypeContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ITypeContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ITypeContext)
+}
+
+type InvocationExpressionContext struct {
 	ExpressionContext
 }
 
-func NewMultiplicativeExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *MultiplicativeExpressionContext {
-	var p = new(MultiplicativeExpressionContext)
+func NewInvocationExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *InvocationExpressionContext {
+	var p = new(InvocationExpressionContext)
 
 	InitEmptyExpressionContext(&p.ExpressionContext)
 	p.parser = parser
@@ -821,11 +781,61 @@ func NewMultiplicativeExpressionContext(parser antlr.Parser, ctx antlr.ParserRul
 	return p
 }
 
-func (s *MultiplicativeExpressionContext) GetRuleContext() antlr.RuleContext {
+func (s *InvocationExpressionContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *MultiplicativeExpressionContext) AllExpression() []IExpressionContext {
+func (s *InvocationExpressionContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *InvocationExpressionContext) Invocation() IInvocationContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IInvocationContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IInvocationContext)
+}
+
+type BitwiseAndExpressionContext struct {
+	ExpressionContext
+}
+
+func NewBitwiseAndExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *BitwiseAndExpressionContext {
+	var p = new(BitwiseAndExpressionContext)
+
+	InitEmptyExpressionContext(&p.ExpressionContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ExpressionContext))
+
+	return p
+}
+
+func (s *BitwiseAndExpressionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *BitwiseAndExpressionContext) AllExpression() []IExpressionContext {
 	children := s.GetChildren()
 	len := 0
 	for _, ctx := range children {
@@ -846,7 +856,7 @@ func (s *MultiplicativeExpressionContext) AllExpression() []IExpressionContext {
 	return tst
 }
 
-func (s *MultiplicativeExpressionContext) Expression(i int) IExpressionContext {
+func (s *BitwiseAndExpressionContext) Expression(i int) IExpressionContext {
 	var t antlr.RuleContext
 	j := 0
 	for _, ctx := range s.GetChildren() {
@@ -866,12 +876,12 @@ func (s *MultiplicativeExpressionContext) Expression(i int) IExpressionContext {
 	return t.(IExpressionContext)
 }
 
-type LogicalOrExpressionContext struct {
+type LogicalAndExpressionContext struct {
 	ExpressionContext
 }
 
-func NewLogicalOrExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *LogicalOrExpressionContext {
-	var p = new(LogicalOrExpressionContext)
+func NewLogicalAndExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *LogicalAndExpressionContext {
+	var p = new(LogicalAndExpressionContext)
 
 	InitEmptyExpressionContext(&p.ExpressionContext)
 	p.parser = parser
@@ -880,11 +890,11 @@ func NewLogicalOrExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleCont
 	return p
 }
 
-func (s *LogicalOrExpressionContext) GetRuleContext() antlr.RuleContext {
+func (s *LogicalAndExpressionContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *LogicalOrExpressionContext) AllExpression() []IExpressionContext {
+func (s *LogicalAndExpressionContext) AllExpression() []IExpressionContext {
 	children := s.GetChildren()
 	len := 0
 	for _, ctx := range children {
@@ -905,7 +915,7 @@ func (s *LogicalOrExpressionContext) AllExpression() []IExpressionContext {
 	return tst
 }
 
-func (s *LogicalOrExpressionContext) Expression(i int) IExpressionContext {
+func (s *LogicalAndExpressionContext) Expression(i int) IExpressionContext {
 	var t antlr.RuleContext
 	j := 0
 	for _, ctx := range s.GetChildren() {
@@ -925,12 +935,12 @@ func (s *LogicalOrExpressionContext) Expression(i int) IExpressionContext {
 	return t.(IExpressionContext)
 }
 
-type CastExpressionContext struct {
+type IndexExpressionContext struct {
 	ExpressionContext
 }
 
-func NewCastExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *CastExpressionContext {
-	var p = new(CastExpressionContext)
+func NewIndexExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *IndexExpressionContext {
+	var p = new(IndexExpressionContext)
 
 	InitEmptyExpressionContext(&p.ExpressionContext)
 	p.parser = parser
@@ -939,11 +949,11 @@ func NewCastExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext)
 	return p
 }
 
-func (s *CastExpressionContext) GetRuleContext() antlr.RuleContext {
+func (s *IndexExpressionContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *CastExpressionContext) Expression() IExpressionContext {
+func (s *IndexExpressionContext) Expression() IExpressionContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
 		if _, ok := ctx.(IExpressionContext); ok {
@@ -959,10 +969,10 @@ func (s *CastExpressionContext) Expression() IExpressionContext {
 	return t.(IExpressionContext)
 }
 
-func (s *CastExpressionContext) Type_() ITypeContext {
+func (s *IndexExpressionContext) Index() IIndexContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(ITypeContext); ok {
+		if _, ok := ctx.(IIndexContext); ok {
 			t = ctx.(antlr.RuleContext)
 			break
 		}
@@ -972,15 +982,15 @@ func (s *CastExpressionContext) Type_() ITypeContext {
 		return nil
 	}
 
-	return t.(ITypeContext)
+	return t.(IIndexContext)
 }
 
-type BitwiseOrExpressionContext struct {
+type TermExpressionContext struct {
 	ExpressionContext
 }
 
-func NewBitwiseOrExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *BitwiseOrExpressionContext {
-	var p = new(BitwiseOrExpressionContext)
+func NewTermExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *TermExpressionContext {
+	var p = new(TermExpressionContext)
 
 	InitEmptyExpressionContext(&p.ExpressionContext)
 	p.parser = parser
@@ -989,32 +999,66 @@ func NewBitwiseOrExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleCont
 	return p
 }
 
-func (s *BitwiseOrExpressionContext) GetRuleContext() antlr.RuleContext {
+func (s *TermExpressionContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *BitwiseOrExpressionContext) AllExpression() []IExpressionContext {
-	children := s.GetChildren()
-	len := 0
-	for _, ctx := range children {
-		if _, ok := ctx.(IExpressionContext); ok {
-			len++
+func (s *TermExpressionContext) Term() ITermContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ITermContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
 		}
 	}
 
-	tst := make([]IExpressionContext, len)
-	i := 0
-	for _, ctx := range children {
-		if t, ok := ctx.(IExpressionContext); ok {
-			tst[i] = t.(IExpressionContext)
-			i++
+	if t == nil {
+		return nil
+	}
+
+	return t.(ITermContext)
+}
+
+type CoalesceExpressionContext struct {
+	ExpressionContext
+}
+
+func NewCoalesceExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *CoalesceExpressionContext {
+	var p = new(CoalesceExpressionContext)
+
+	InitEmptyExpressionContext(&p.ExpressionContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ExpressionContext))
+
+	return p
+}
+
+func (s *CoalesceExpressionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *CoalesceExpressionContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IExpressionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
 		}
 	}
 
 	return tst
 }
 
-func (s *BitwiseOrExpressionContext) Expression(i int) IExpressionContext {
+func (s *CoalesceExpressionContext) Expression(i int) IExpressionContext {
 	var t antlr.RuleContext
 	j := 0
 	for _, ctx := range s.GetChildren() {
@@ -1034,12 +1078,12 @@ func (s *BitwiseOrExpressionContext) Expression(i int) IExpressionContext {
 	return t.(IExpressionContext)
 }
 
-type InequalityExpressionContext struct {
+type ShiftExpressionContext struct {
 	ExpressionContext
 }
 
-func NewInequalityExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *InequalityExpressionContext {
-	var p = new(InequalityExpressionContext)
+func NewShiftExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ShiftExpressionContext {
+	var p = new(ShiftExpressionContext)
 
 	InitEmptyExpressionContext(&p.ExpressionContext)
 	p.parser = parser
@@ -1048,11 +1092,11 @@ func NewInequalityExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleCon
 	return p
 }
 
-func (s *InequalityExpressionContext) GetRuleContext() antlr.RuleContext {
+func (s *ShiftExpressionContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *InequalityExpressionContext) AllExpression() []IExpressionContext {
+func (s *ShiftExpressionContext) AllExpression() []IExpressionContext {
 	children := s.GetChildren()
 	len := 0
 	for _, ctx := range children {
@@ -1073,7 +1117,7 @@ func (s *InequalityExpressionContext) AllExpression() []IExpressionContext {
 	return tst
 }
 
-func (s *InequalityExpressionContext) Expression(i int) IExpressionContext {
+func (s *ShiftExpressionContext) Expression(i int) IExpressionContext {
 	var t antlr.RuleContext
 	j := 0
 	for _, ctx := range s.GetChildren() {
@@ -1093,12 +1137,12 @@ func (s *InequalityExpressionContext) Expression(i int) IExpressionContext {
 	return t.(IExpressionContext)
 }
 
-type InvocationExpressionContext struct {
+type PolarityExpressionContext struct {
 	ExpressionContext
 }
 
-func NewInvocationExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *InvocationExpressionContext {
-	var p = new(InvocationExpressionContext)
+func NewPolarityExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *PolarityExpressionContext {
+	var p = new(PolarityExpressionContext)
 
 	InitEmptyExpressionContext(&p.ExpressionContext)
 	p.parser = parser
@@ -1107,11 +1151,11 @@ func NewInvocationExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleCon
 	return p
 }
 
-func (s *InvocationExpressionContext) GetRuleContext() antlr.RuleContext {
+func (s *PolarityExpressionContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *InvocationExpressionContext) Expression() IExpressionContext {
+func (s *PolarityExpressionContext) Expression() IExpressionContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
 		if _, ok := ctx.(IExpressionContext); ok {
@@ -1127,28 +1171,12 @@ func (s *InvocationExpressionContext) Expression() IExpressionContext {
 	return t.(IExpressionContext)
 }
 
-func (s *InvocationExpressionContext) Invocation() IInvocationContext {
-	var t antlr.RuleContext
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IInvocationContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
-		}
-	}
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(IInvocationContext)
-}
-
-type BitwiseNotExpressionContext struct {
+type ParenthesisExpressionContext struct {
 	ExpressionContext
 }
 
-func NewBitwiseNotExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *BitwiseNotExpressionContext {
-	var p = new(BitwiseNotExpressionContext)
+func NewParenthesisExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ParenthesisExpressionContext {
+	var p = new(ParenthesisExpressionContext)
 
 	InitEmptyExpressionContext(&p.ExpressionContext)
 	p.parser = parser
@@ -1157,11 +1185,11 @@ func NewBitwiseNotExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleCon
 	return p
 }
 
-func (s *BitwiseNotExpressionContext) GetRuleContext() antlr.RuleContext {
+func (s *ParenthesisExpressionContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *BitwiseNotExpressionContext) Expression() IExpressionContext {
+func (s *ParenthesisExpressionContext) Expression() IExpressionContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
 		if _, ok := ctx.(IExpressionContext); ok {
@@ -1177,12 +1205,12 @@ func (s *BitwiseNotExpressionContext) Expression() IExpressionContext {
 	return t.(IExpressionContext)
 }
 
-type BitwiseAndExpressionContext struct {
+type MultiplicativeExpressionContext struct {
 	ExpressionContext
 }
 
-func NewBitwiseAndExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *BitwiseAndExpressionContext {
-	var p = new(BitwiseAndExpressionContext)
+func NewMultiplicativeExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *MultiplicativeExpressionContext {
+	var p = new(MultiplicativeExpressionContext)
 
 	InitEmptyExpressionContext(&p.ExpressionContext)
 	p.parser = parser
@@ -1191,11 +1219,11 @@ func NewBitwiseAndExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleCon
 	return p
 }
 
-func (s *BitwiseAndExpressionContext) GetRuleContext() antlr.RuleContext {
+func (s *MultiplicativeExpressionContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *BitwiseAndExpressionContext) AllExpression() []IExpressionContext {
+func (s *MultiplicativeExpressionContext) AllExpression() []IExpressionContext {
 	children := s.GetChildren()
 	len := 0
 	for _, ctx := range children {
@@ -1216,7 +1244,7 @@ func (s *BitwiseAndExpressionContext) AllExpression() []IExpressionContext {
 	return tst
 }
 
-func (s *BitwiseAndExpressionContext) Expression(i int) IExpressionContext {
+func (s *MultiplicativeExpressionContext) Expression(i int) IExpressionContext {
 	var t antlr.RuleContext
 	j := 0
 	for _, ctx := range s.GetChildren() {
@@ -1236,12 +1264,12 @@ func (s *BitwiseAndExpressionContext) Expression(i int) IExpressionContext {
 	return t.(IExpressionContext)
 }
 
-type LogicalAndExpressionContext struct {
+type LogicalOrExpressionContext struct {
 	ExpressionContext
 }
 
-func NewLogicalAndExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *LogicalAndExpressionContext {
-	var p = new(LogicalAndExpressionContext)
+func NewLogicalOrExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *LogicalOrExpressionContext {
+	var p = new(LogicalOrExpressionContext)
 
 	InitEmptyExpressionContext(&p.ExpressionContext)
 	p.parser = parser
@@ -1250,11 +1278,11 @@ func NewLogicalAndExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleCon
 	return p
 }
 
-func (s *LogicalAndExpressionContext) GetRuleContext() antlr.RuleContext {
+func (s *LogicalOrExpressionContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *LogicalAndExpressionContext) AllExpression() []IExpressionContext {
+func (s *LogicalOrExpressionContext) AllExpression() []IExpressionContext {
 	children := s.GetChildren()
 	len := 0
 	for _, ctx := range children {
@@ -1275,7 +1303,7 @@ func (s *LogicalAndExpressionContext) AllExpression() []IExpressionContext {
 	return tst
 }
 
-func (s *LogicalAndExpressionContext) Expression(i int) IExpressionContext {
+func (s *LogicalOrExpressionContext) Expression(i int) IExpressionContext {
 	var t antlr.RuleContext
 	j := 0
 	for _, ctx := range s.GetChildren() {
@@ -1295,12 +1323,12 @@ func (s *LogicalAndExpressionContext) Expression(i int) IExpressionContext {
 	return t.(IExpressionContext)
 }
 
-type EqualityExpressionContext struct {
+type BitwiseOrExpressionContext struct {
 	ExpressionContext
 }
 
-func NewEqualityExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *EqualityExpressionContext {
-	var p = new(EqualityExpressionContext)
+func NewBitwiseOrExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *BitwiseOrExpressionContext {
+	var p = new(BitwiseOrExpressionContext)
 
 	InitEmptyExpressionContext(&p.ExpressionContext)
 	p.parser = parser
@@ -1309,11 +1337,11 @@ func NewEqualityExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleConte
 	return p
 }
 
-func (s *EqualityExpressionContext) GetRuleContext() antlr.RuleContext {
+func (s *BitwiseOrExpressionContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *EqualityExpressionContext) AllExpression() []IExpressionContext {
+func (s *BitwiseOrExpressionContext) AllExpression() []IExpressionContext {
 	children := s.GetChildren()
 	len := 0
 	for _, ctx := range children {
@@ -1334,7 +1362,7 @@ func (s *EqualityExpressionContext) AllExpression() []IExpressionContext {
 	return tst
 }
 
-func (s *EqualityExpressionContext) Expression(i int) IExpressionContext {
+func (s *BitwiseOrExpressionContext) Expression(i int) IExpressionContext {
 	var t antlr.RuleContext
 	j := 0
 	for _, ctx := range s.GetChildren() {
@@ -1354,12 +1382,12 @@ func (s *EqualityExpressionContext) Expression(i int) IExpressionContext {
 	return t.(IExpressionContext)
 }
 
-type ImplicationExpressionContext struct {
+type InequalityExpressionContext struct {
 	ExpressionContext
 }
 
-func NewImplicationExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ImplicationExpressionContext {
-	var p = new(ImplicationExpressionContext)
+func NewInequalityExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *InequalityExpressionContext {
+	var p = new(InequalityExpressionContext)
 
 	InitEmptyExpressionContext(&p.ExpressionContext)
 	p.parser = parser
@@ -1368,11 +1396,11 @@ func NewImplicationExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleCo
 	return p
 }
 
-func (s *ImplicationExpressionContext) GetRuleContext() antlr.RuleContext {
+func (s *InequalityExpressionContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *ImplicationExpressionContext) AllExpression() []IExpressionContext {
+func (s *InequalityExpressionContext) AllExpression() []IExpressionContext {
 	children := s.GetChildren()
 	len := 0
 	for _, ctx := range children {
@@ -1393,7 +1421,7 @@ func (s *ImplicationExpressionContext) AllExpression() []IExpressionContext {
 	return tst
 }
 
-func (s *ImplicationExpressionContext) Expression(i int) IExpressionContext {
+func (s *InequalityExpressionContext) Expression(i int) IExpressionContext {
 	var t antlr.RuleContext
 	j := 0
 	for _, ctx := range s.GetChildren() {
@@ -1413,12 +1441,12 @@ func (s *ImplicationExpressionContext) Expression(i int) IExpressionContext {
 	return t.(IExpressionContext)
 }
 
-type ExponentiationExpressionContext struct {
+type SafeInvocationExpressionContext struct {
 	ExpressionContext
 }
 
-func NewExponentiationExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ExponentiationExpressionContext {
-	var p = new(ExponentiationExpressionContext)
+func NewSafeInvocationExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *SafeInvocationExpressionContext {
+	var p = new(SafeInvocationExpressionContext)
 
 	InitEmptyExpressionContext(&p.ExpressionContext)
 	p.parser = parser
@@ -1427,41 +1455,32 @@ func NewExponentiationExpressionContext(parser antlr.Parser, ctx antlr.ParserRul
 	return p
 }
 
-func (s *ExponentiationExpressionContext) GetRuleContext() antlr.RuleContext {
+func (s *SafeInvocationExpressionContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *ExponentiationExpressionContext) AllExpression() []IExpressionContext {
-	children := s.GetChildren()
-	len := 0
-	for _, ctx := range children {
+func (s *SafeInvocationExpressionContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
 		if _, ok := ctx.(IExpressionContext); ok {
-			len++
+			t = ctx.(antlr.RuleContext)
+			break
 		}
 	}
 
-	tst := make([]IExpressionContext, len)
-	i := 0
-	for _, ctx := range children {
-		if t, ok := ctx.(IExpressionContext); ok {
-			tst[i] = t.(IExpressionContext)
-			i++
-		}
+	if t == nil {
+		return nil
 	}
 
-	return tst
+	return t.(IExpressionContext)
 }
 
-func (s *ExponentiationExpressionContext) Expression(i int) IExpressionContext {
+func (s *SafeInvocationExpressionContext) Invocation() IInvocationContext {
 	var t antlr.RuleContext
-	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IExpressionContext); ok {
-			if j == i {
-				t = ctx.(antlr.RuleContext)
-				break
-			}
-			j++
+		if _, ok := ctx.(IInvocationContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
 		}
 	}
 
@@ -1469,15 +1488,15 @@ func (s *ExponentiationExpressionContext) Expression(i int) IExpressionContext {
 		return nil
 	}
 
-	return t.(IExpressionContext)
+	return t.(IInvocationContext)
 }
 
-type IndexExpressionContext struct {
+type BitwiseNotExpressionContext struct {
 	ExpressionContext
 }
 
-func NewIndexExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *IndexExpressionContext {
-	var p = new(IndexExpressionContext)
+func NewBitwiseNotExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *BitwiseNotExpressionContext {
+	var p = new(BitwiseNotExpressionContext)
 
 	InitEmptyExpressionContext(&p.ExpressionContext)
 	p.parser = parser
@@ -1486,11 +1505,11 @@ func NewIndexExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext)
 	return p
 }
 
-func (s *IndexExpressionContext) GetRuleContext() antlr.RuleContext {
+func (s *BitwiseNotExpressionContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *IndexExpressionContext) Expression() IExpressionContext {
+func (s *BitwiseNotExpressionContext) Expression() IExpressionContext {
 	var t antlr.RuleContext
 	for _, ctx := range s.GetChildren() {
 		if _, ok := ctx.(IExpressionContext); ok {
@@ -1506,28 +1525,12 @@ func (s *IndexExpressionContext) Expression() IExpressionContext {
 	return t.(IExpressionContext)
 }
 
-func (s *IndexExpressionContext) Index() IIndexContext {
-	var t antlr.RuleContext
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(IIndexContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
-		}
-	}
-
-	if t == nil {
-		return nil
-	}
-
-	return t.(IIndexContext)
-}
-
-type ElvisExpressionContext struct {
+type EqualityExpressionContext struct {
 	ExpressionContext
 }
 
-func NewElvisExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ElvisExpressionContext {
-	var p = new(ElvisExpressionContext)
+func NewEqualityExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *EqualityExpressionContext {
+	var p = new(EqualityExpressionContext)
 
 	InitEmptyExpressionContext(&p.ExpressionContext)
 	p.parser = parser
@@ -1536,11 +1539,11 @@ func NewElvisExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext)
 	return p
 }
 
-func (s *ElvisExpressionContext) GetRuleContext() antlr.RuleContext {
+func (s *EqualityExpressionContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *ElvisExpressionContext) AllExpression() []IExpressionContext {
+func (s *EqualityExpressionContext) AllExpression() []IExpressionContext {
 	children := s.GetChildren()
 	len := 0
 	for _, ctx := range children {
@@ -1561,7 +1564,7 @@ func (s *ElvisExpressionContext) AllExpression() []IExpressionContext {
 	return tst
 }
 
-func (s *ElvisExpressionContext) Expression(i int) IExpressionContext {
+func (s *EqualityExpressionContext) Expression(i int) IExpressionContext {
 	var t antlr.RuleContext
 	j := 0
 	for _, ctx := range s.GetChildren() {
@@ -1581,12 +1584,12 @@ func (s *ElvisExpressionContext) Expression(i int) IExpressionContext {
 	return t.(IExpressionContext)
 }
 
-type TernaryExpressionContext struct {
+type ImplicationExpressionContext struct {
 	ExpressionContext
 }
 
-func NewTernaryExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *TernaryExpressionContext {
-	var p = new(TernaryExpressionContext)
+func NewImplicationExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ImplicationExpressionContext {
+	var p = new(ImplicationExpressionContext)
 
 	InitEmptyExpressionContext(&p.ExpressionContext)
 	p.parser = parser
@@ -1595,11 +1598,11 @@ func NewTernaryExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContex
 	return p
 }
 
-func (s *TernaryExpressionContext) GetRuleContext() antlr.RuleContext {
+func (s *ImplicationExpressionContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *TernaryExpressionContext) AllExpression() []IExpressionContext {
+func (s *ImplicationExpressionContext) AllExpression() []IExpressionContext {
 	children := s.GetChildren()
 	len := 0
 	for _, ctx := range children {
@@ -1620,7 +1623,7 @@ func (s *TernaryExpressionContext) AllExpression() []IExpressionContext {
 	return tst
 }
 
-func (s *TernaryExpressionContext) Expression(i int) IExpressionContext {
+func (s *ImplicationExpressionContext) Expression(i int) IExpressionContext {
 	var t antlr.RuleContext
 	j := 0
 	for _, ctx := range s.GetChildren() {
@@ -1640,12 +1643,12 @@ func (s *TernaryExpressionContext) Expression(i int) IExpressionContext {
 	return t.(IExpressionContext)
 }
 
-type TermExpressionContext struct {
+type ExponentiationExpressionContext struct {
 	ExpressionContext
 }
 
-func NewTermExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *TermExpressionContext {
-	var p = new(TermExpressionContext)
+func NewExponentiationExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ExponentiationExpressionContext {
+	var p = new(ExponentiationExpressionContext)
 
 	InitEmptyExpressionContext(&p.ExpressionContext)
 	p.parser = parser
@@ -1654,24 +1657,167 @@ func NewTermExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext)
 	return p
 }
 
-func (s *TermExpressionContext) GetRuleContext() antlr.RuleContext {
+func (s *ExponentiationExpressionContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *TermExpressionContext) Term() ITermContext {
-	var t antlr.RuleContext
-	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(ITermContext); ok {
-			t = ctx.(antlr.RuleContext)
-			break
+func (s *ExponentiationExpressionContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IExpressionContext); ok {
+			len++
 		}
 	}
 
-	if t == nil {
-		return nil
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
+		}
 	}
 
-	return t.(ITermContext)
+	return tst
+}
+
+func (s *ExponentiationExpressionContext) Expression(i int) IExpressionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+type ElvisExpressionContext struct {
+	ExpressionContext
+}
+
+func NewElvisExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ElvisExpressionContext {
+	var p = new(ElvisExpressionContext)
+
+	InitEmptyExpressionContext(&p.ExpressionContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ExpressionContext))
+
+	return p
+}
+
+func (s *ElvisExpressionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ElvisExpressionContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IExpressionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *ElvisExpressionContext) Expression(i int) IExpressionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+type TernaryExpressionContext struct {
+	ExpressionContext
+}
+
+func NewTernaryExpressionContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *TernaryExpressionContext {
+	var p = new(TernaryExpressionContext)
+
+	InitEmptyExpressionContext(&p.ExpressionContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ExpressionContext))
+
+	return p
+}
+
+func (s *TernaryExpressionContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *TernaryExpressionContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IExpressionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *TernaryExpressionContext) Expression(i int) IExpressionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
 }
 
 func (p *DCellParser) Expression() (localctx IExpressionContext) {
@@ -1692,57 +1838,57 @@ func (p *DCellParser) expression(_p int) (localctx IExpressionContext) {
 	var _alt int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(41)
+	p.SetState(49)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 
 	switch p.GetTokenStream().LA(1) {
-	case DCellParserT__1, DCellParserT__13, DCellParserT__40, DCellParserT__41, DCellParserT__42, DCellParserIDENTIFIER, DCellParserDECIMAL_INTEGER, DCellParserHEX_INTEGER, DCellParserOCTAL_INTEGER, DCellParserBINARY_INTEGER, DCellParserDECIMAL_FLOAT, DCellParserSCIENTIFIC_FLOAT, DCellParserSINGLE_QUOTE_STRING, DCellParserDOUBLE_QUOTE_STRING, DCellParserTRIPLE_QUOTE_STRING:
+	case DCellParserT__2, DCellParserT__14, DCellParserT__44, DCellParserT__45, DCellParserT__46, DCellParserT__52, DCellParserIDENTIFIER, DCellParserVARIABLE, DCellParserDECIMAL_INTEGER, DCellParserHEX_INTEGER, DCellParserOCTAL_INTEGER, DCellParserBINARY_INTEGER, DCellParserDECIMAL_FLOAT, DCellParserSCIENTIFIC_FLOAT, DCellParserSINGLE_QUOTE_STRING, DCellParserDOUBLE_QUOTE_STRING, DCellParserTRIPLE_QUOTE_STRING, DCellParserFORMAT_STRING:
 		localctx = NewTermExpressionContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 
 		{
-			p.SetState(30)
+			p.SetState(38)
 			p.Term()
 		}
 
-	case DCellParserT__6:
+	case DCellParserT__7:
 		localctx = NewParenthesisExpressionContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(31)
-			p.Match(DCellParserT__6)
+			p.SetState(39)
+			p.Match(DCellParserT__7)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
 		{
-			p.SetState(32)
+			p.SetState(40)
 			p.expression(0)
 		}
 		{
-			p.SetState(33)
-			p.Match(DCellParserT__7)
+			p.SetState(41)
+			p.Match(DCellParserT__8)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
 
-	case DCellParserT__4, DCellParserT__8:
+	case DCellParserT__5, DCellParserT__9:
 		localctx = NewLogicalNotExpressionContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(35)
+			p.SetState(43)
 			_la = p.GetTokenStream().LA(1)
 
-			if !(_la == DCellParserT__4 || _la == DCellParserT__8) {
+			if !(_la == DCellParserT__5 || _la == DCellParserT__9) {
 				p.GetErrorHandler().RecoverInline(p)
 			} else {
 				p.GetErrorHandler().ReportMatch(p)
@@ -1750,36 +1896,36 @@ func (p *DCellParser) expression(_p int) (localctx IExpressionContext) {
 			}
 		}
 		{
-			p.SetState(36)
-			p.expression(18)
+			p.SetState(44)
+			p.expression(19)
 		}
 
-	case DCellParserT__9:
+	case DCellParserT__10:
 		localctx = NewBitwiseNotExpressionContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(37)
-			p.Match(DCellParserT__9)
+			p.SetState(45)
+			p.Match(DCellParserT__10)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
 		{
-			p.SetState(38)
-			p.expression(17)
+			p.SetState(46)
+			p.expression(18)
 		}
 
-	case DCellParserT__10, DCellParserT__11:
+	case DCellParserT__11, DCellParserT__12:
 		localctx = NewPolarityExpressionContext(p, localctx)
 		p.SetParserRuleContext(localctx)
 		_prevctx = localctx
 		{
-			p.SetState(39)
+			p.SetState(47)
 			_la = p.GetTokenStream().LA(1)
 
-			if !(_la == DCellParserT__10 || _la == DCellParserT__11) {
+			if !(_la == DCellParserT__11 || _la == DCellParserT__12) {
 				p.GetErrorHandler().RecoverInline(p)
 			} else {
 				p.GetErrorHandler().ReportMatch(p)
@@ -1787,8 +1933,8 @@ func (p *DCellParser) expression(_p int) (localctx IExpressionContext) {
 			}
 		}
 		{
-			p.SetState(40)
-			p.expression(16)
+			p.SetState(48)
+			p.expression(17)
 		}
 
 	default:
@@ -1796,7 +1942,7 @@ func (p *DCellParser) expression(_p int) (localctx IExpressionContext) {
 		goto errorExit
 	}
 	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
-	p.SetState(115)
+	p.SetState(129)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -1811,7 +1957,7 @@ func (p *DCellParser) expression(_p int) (localctx IExpressionContext) {
 				p.TriggerExitRuleEvent()
 			}
 			_prevctx = localctx
-			p.SetState(113)
+			p.SetState(127)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
@@ -1821,41 +1967,41 @@ func (p *DCellParser) expression(_p int) (localctx IExpressionContext) {
 			case 1:
 				localctx = NewContainsExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, DCellParserRULE_expression)
-				p.SetState(43)
+				p.SetState(51)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 20)) {
-					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 20)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 21)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 21)", ""))
 					goto errorExit
 				}
-				p.SetState(47)
+				p.SetState(55)
 				p.GetErrorHandler().Sync(p)
 				if p.HasError() {
 					goto errorExit
 				}
 
 				switch p.GetTokenStream().LA(1) {
-				case DCellParserT__5:
+				case DCellParserT__6:
 					{
-						p.SetState(44)
-						p.Match(DCellParserT__5)
+						p.SetState(52)
+						p.Match(DCellParserT__6)
 						if p.HasError() {
 							// Recognition error - abort rule
 							goto errorExit
 						}
 					}
 
-				case DCellParserT__4:
+				case DCellParserT__5:
 					{
-						p.SetState(45)
-						p.Match(DCellParserT__4)
+						p.SetState(53)
+						p.Match(DCellParserT__5)
 						if p.HasError() {
 							// Recognition error - abort rule
 							goto errorExit
 						}
 					}
 					{
-						p.SetState(46)
-						p.Match(DCellParserT__5)
+						p.SetState(54)
+						p.Match(DCellParserT__6)
 						if p.HasError() {
 							// Recognition error - abort rule
 							goto errorExit
@@ -1867,46 +2013,71 @@ func (p *DCellParser) expression(_p int) (localctx IExpressionContext) {
 					goto errorExit
 				}
 				{
-					p.SetState(49)
-					p.expression(21)
+					p.SetState(57)
+					p.expression(22)
 				}
 
 			case 2:
 				localctx = NewExponentiationExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, DCellParserRULE_expression)
-				p.SetState(50)
+				p.SetState(58)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 15)) {
-					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 15)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 16)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 16)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(51)
-					p.Match(DCellParserT__12)
+					p.SetState(59)
+					p.Match(DCellParserT__13)
 					if p.HasError() {
 						// Recognition error - abort rule
 						goto errorExit
 					}
 				}
 				{
-					p.SetState(52)
-					p.expression(16)
+					p.SetState(60)
+					p.expression(17)
 				}
 
 			case 3:
 				localctx = NewMultiplicativeExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, DCellParserRULE_expression)
-				p.SetState(53)
+				p.SetState(61)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 15)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 15)", ""))
+					goto errorExit
+				}
+				{
+					p.SetState(62)
+					_la = p.GetTokenStream().LA(1)
+
+					if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&491520) != 0) {
+						p.GetErrorHandler().RecoverInline(p)
+					} else {
+						p.GetErrorHandler().ReportMatch(p)
+						p.Consume()
+					}
+				}
+				{
+					p.SetState(63)
+					p.expression(16)
+				}
+
+			case 4:
+				localctx = NewAdditiveExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
+				p.PushNewRecursionContext(localctx, _startState, DCellParserRULE_expression)
+				p.SetState(64)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 14)) {
 					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 14)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(54)
+					p.SetState(65)
 					_la = p.GetTokenStream().LA(1)
 
-					if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&245760) != 0) {
+					if !(_la == DCellParserT__11 || _la == DCellParserT__12) {
 						p.GetErrorHandler().RecoverInline(p)
 					} else {
 						p.GetErrorHandler().ReportMatch(p)
@@ -1914,24 +2085,24 @@ func (p *DCellParser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(55)
+					p.SetState(66)
 					p.expression(15)
 				}
 
-			case 4:
-				localctx = NewAdditiveExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
+			case 5:
+				localctx = NewMatchExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, DCellParserRULE_expression)
-				p.SetState(56)
+				p.SetState(67)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 13)) {
 					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 13)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(57)
+					p.SetState(68)
 					_la = p.GetTokenStream().LA(1)
 
-					if !(_la == DCellParserT__10 || _la == DCellParserT__11) {
+					if !(_la == DCellParserT__18 || _la == DCellParserT__19) {
 						p.GetErrorHandler().RecoverInline(p)
 					} else {
 						p.GetErrorHandler().ReportMatch(p)
@@ -1939,24 +2110,24 @@ func (p *DCellParser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(58)
+					p.SetState(69)
 					p.expression(14)
 				}
 
-			case 5:
+			case 6:
 				localctx = NewLogicalAndExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, DCellParserRULE_expression)
-				p.SetState(59)
+				p.SetState(70)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 12)) {
 					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 12)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(60)
+					p.SetState(71)
 					_la = p.GetTokenStream().LA(1)
 
-					if !(_la == DCellParserT__17 || _la == DCellParserT__18) {
+					if !(_la == DCellParserT__20 || _la == DCellParserT__21) {
 						p.GetErrorHandler().RecoverInline(p)
 					} else {
 						p.GetErrorHandler().ReportMatch(p)
@@ -1964,24 +2135,24 @@ func (p *DCellParser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(61)
+					p.SetState(72)
 					p.expression(13)
 				}
 
-			case 6:
+			case 7:
 				localctx = NewLogicalOrExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, DCellParserRULE_expression)
-				p.SetState(62)
+				p.SetState(73)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 11)) {
 					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 11)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(63)
+					p.SetState(74)
 					_la = p.GetTokenStream().LA(1)
 
-					if !(_la == DCellParserT__19 || _la == DCellParserT__20) {
+					if !(_la == DCellParserT__22 || _la == DCellParserT__23) {
 						p.GetErrorHandler().RecoverInline(p)
 					} else {
 						p.GetErrorHandler().ReportMatch(p)
@@ -1989,24 +2160,24 @@ func (p *DCellParser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(64)
+					p.SetState(75)
 					p.expression(12)
 				}
 
-			case 7:
+			case 8:
 				localctx = NewImplicationExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, DCellParserRULE_expression)
-				p.SetState(65)
+				p.SetState(76)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 10)) {
 					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 10)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(66)
+					p.SetState(77)
 					_la = p.GetTokenStream().LA(1)
 
-					if !(_la == DCellParserT__21 || _la == DCellParserT__22) {
+					if !(_la == DCellParserT__24 || _la == DCellParserT__25) {
 						p.GetErrorHandler().RecoverInline(p)
 					} else {
 						p.GetErrorHandler().ReportMatch(p)
@@ -2014,24 +2185,24 @@ func (p *DCellParser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(67)
+					p.SetState(78)
 					p.expression(11)
 				}
 
-			case 8:
+			case 9:
 				localctx = NewShiftExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, DCellParserRULE_expression)
-				p.SetState(68)
+				p.SetState(79)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 9)) {
 					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 9)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(69)
+					p.SetState(80)
 					_la = p.GetTokenStream().LA(1)
 
-					if !(_la == DCellParserT__23 || _la == DCellParserT__24) {
+					if !(_la == DCellParserT__26 || _la == DCellParserT__27) {
 						p.GetErrorHandler().RecoverInline(p)
 					} else {
 						p.GetErrorHandler().ReportMatch(p)
@@ -2039,46 +2210,46 @@ func (p *DCellParser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(70)
+					p.SetState(81)
 					p.expression(10)
 				}
 
-			case 9:
+			case 10:
 				localctx = NewBitwiseAndExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, DCellParserRULE_expression)
-				p.SetState(71)
+				p.SetState(82)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 8)) {
 					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 8)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(72)
-					p.Match(DCellParserT__25)
+					p.SetState(83)
+					p.Match(DCellParserT__28)
 					if p.HasError() {
 						// Recognition error - abort rule
 						goto errorExit
 					}
 				}
 				{
-					p.SetState(73)
+					p.SetState(84)
 					p.expression(9)
 				}
 
-			case 10:
+			case 11:
 				localctx = NewBitwiseOrExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, DCellParserRULE_expression)
-				p.SetState(74)
+				p.SetState(85)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 7)) {
 					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 7)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(75)
+					p.SetState(86)
 					_la = p.GetTokenStream().LA(1)
 
-					if !(_la == DCellParserT__26 || _la == DCellParserT__27) {
+					if !(_la == DCellParserT__29 || _la == DCellParserT__30) {
 						p.GetErrorHandler().RecoverInline(p)
 					} else {
 						p.GetErrorHandler().ReportMatch(p)
@@ -2086,24 +2257,24 @@ func (p *DCellParser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(76)
+					p.SetState(87)
 					p.expression(8)
 				}
 
-			case 11:
+			case 12:
 				localctx = NewInequalityExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, DCellParserRULE_expression)
-				p.SetState(77)
+				p.SetState(88)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 6)) {
 					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 6)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(78)
+					p.SetState(89)
 					_la = p.GetTokenStream().LA(1)
 
-					if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&8053063680) != 0) {
+					if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&64424509440) != 0) {
 						p.GetErrorHandler().RecoverInline(p)
 					} else {
 						p.GetErrorHandler().ReportMatch(p)
@@ -2111,24 +2282,24 @@ func (p *DCellParser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(79)
+					p.SetState(90)
 					p.expression(7)
 				}
 
-			case 12:
+			case 13:
 				localctx = NewEqualityExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, DCellParserRULE_expression)
-				p.SetState(80)
+				p.SetState(91)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 5)) {
 					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 5)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(81)
+					p.SetState(92)
 					_la = p.GetTokenStream().LA(1)
 
-					if !(_la == DCellParserT__32 || _la == DCellParserT__33) {
+					if !(_la == DCellParserT__35 || _la == DCellParserT__36) {
 						p.GetErrorHandler().RecoverInline(p)
 					} else {
 						p.GetErrorHandler().ReportMatch(p)
@@ -2136,99 +2307,99 @@ func (p *DCellParser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(82)
+					p.SetState(93)
 					p.expression(6)
 				}
 
-			case 13:
+			case 14:
 				localctx = NewTernaryExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, DCellParserRULE_expression)
-				p.SetState(83)
+				p.SetState(94)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 4)) {
 					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 4)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(84)
-					p.Match(DCellParserT__34)
+					p.SetState(95)
+					p.Match(DCellParserT__37)
 					if p.HasError() {
 						// Recognition error - abort rule
 						goto errorExit
 					}
 				}
 				{
-					p.SetState(85)
+					p.SetState(96)
 					p.expression(0)
 				}
 				{
-					p.SetState(86)
-					p.Match(DCellParserT__35)
+					p.SetState(97)
+					p.Match(DCellParserT__38)
 					if p.HasError() {
 						// Recognition error - abort rule
 						goto errorExit
 					}
 				}
 				{
-					p.SetState(87)
+					p.SetState(98)
 					p.expression(5)
 				}
 
-			case 14:
+			case 15:
 				localctx = NewElvisExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, DCellParserRULE_expression)
-				p.SetState(89)
+				p.SetState(100)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 3)) {
 					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 3)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(90)
-					p.Match(DCellParserT__36)
+					p.SetState(101)
+					p.Match(DCellParserT__39)
 					if p.HasError() {
 						// Recognition error - abort rule
 						goto errorExit
 					}
 				}
 				{
-					p.SetState(91)
+					p.SetState(102)
 					p.expression(4)
 				}
 
-			case 15:
+			case 16:
 				localctx = NewCoalesceExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, DCellParserRULE_expression)
-				p.SetState(92)
+				p.SetState(103)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 2)) {
 					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 2)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(93)
-					p.Match(DCellParserT__37)
+					p.SetState(104)
+					p.Match(DCellParserT__40)
 					if p.HasError() {
 						// Recognition error - abort rule
 						goto errorExit
 					}
 				}
 				{
-					p.SetState(94)
+					p.SetState(105)
 					p.expression(3)
 				}
 
-			case 16:
+			case 17:
 				localctx = NewInvocationExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, DCellParserRULE_expression)
-				p.SetState(95)
+				p.SetState(106)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 23)) {
-					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 23)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 25)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 25)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(96)
+					p.SetState(107)
 					p.Match(DCellParserT__0)
 					if p.HasError() {
 						// Recognition error - abort rule
@@ -2236,21 +2407,21 @@ func (p *DCellParser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(97)
+					p.SetState(108)
 					p.Invocation()
 				}
 
-			case 17:
-				localctx = NewIndexExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
+			case 18:
+				localctx = NewSafeInvocationExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, DCellParserRULE_expression)
-				p.SetState(98)
+				p.SetState(109)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 22)) {
-					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 22)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 24)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 24)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(99)
+					p.SetState(110)
 					p.Match(DCellParserT__1)
 					if p.HasError() {
 						// Recognition error - abort rule
@@ -2258,28 +2429,50 @@ func (p *DCellParser) expression(_p int) (localctx IExpressionContext) {
 					}
 				}
 				{
-					p.SetState(100)
-					p.Index()
+					p.SetState(111)
+					p.Invocation()
+				}
+
+			case 19:
+				localctx = NewIndexExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
+				p.PushNewRecursionContext(localctx, _startState, DCellParserRULE_expression)
+				p.SetState(112)
+
+				if !(p.Precpred(p.GetParserRuleContext(), 23)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 23)", ""))
+					goto errorExit
 				}
 				{
-					p.SetState(101)
+					p.SetState(113)
 					p.Match(DCellParserT__2)
 					if p.HasError() {
 						// Recognition error - abort rule
 						goto errorExit
 					}
 				}
+				{
+					p.SetState(114)
+					p.Index()
+				}
+				{
+					p.SetState(115)
+					p.Match(DCellParserT__3)
+					if p.HasError() {
+						// Recognition error - abort rule
+						goto errorExit
+					}
+				}
 
-			case 18:
+			case 20:
 				localctx = NewIsExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, DCellParserRULE_expression)
-				p.SetState(103)
+				p.SetState(117)
 
-				if !(p.Precpred(p.GetParserRuleContext(), 21)) {
-					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 21)", ""))
+				if !(p.Precpred(p.GetParserRuleContext(), 22)) {
+					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 22)", ""))
 					goto errorExit
 				}
-				p.SetState(107)
+				p.SetState(121)
 				p.GetErrorHandler().Sync(p)
 				if p.HasError() {
 					goto errorExit
@@ -2288,16 +2481,16 @@ func (p *DCellParser) expression(_p int) (localctx IExpressionContext) {
 				switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 2, p.GetParserRuleContext()) {
 				case 1:
 					{
-						p.SetState(104)
-						p.Match(DCellParserT__3)
+						p.SetState(118)
+						p.Match(DCellParserT__4)
 						if p.HasError() {
 							// Recognition error - abort rule
 							goto errorExit
 						}
 					}
 					{
-						p.SetState(105)
-						p.Match(DCellParserT__4)
+						p.SetState(119)
+						p.Match(DCellParserT__5)
 						if p.HasError() {
 							// Recognition error - abort rule
 							goto errorExit
@@ -2306,8 +2499,8 @@ func (p *DCellParser) expression(_p int) (localctx IExpressionContext) {
 
 				case 2:
 					{
-						p.SetState(106)
-						p.Match(DCellParserT__3)
+						p.SetState(120)
+						p.Match(DCellParserT__4)
 						if p.HasError() {
 							// Recognition error - abort rule
 							goto errorExit
@@ -2318,29 +2511,29 @@ func (p *DCellParser) expression(_p int) (localctx IExpressionContext) {
 					goto errorExit
 				}
 				{
-					p.SetState(109)
+					p.SetState(123)
 					p.Type_()
 				}
 
-			case 19:
+			case 21:
 				localctx = NewCastExpressionContext(p, NewExpressionContext(p, _parentctx, _parentState))
 				p.PushNewRecursionContext(localctx, _startState, DCellParserRULE_expression)
-				p.SetState(110)
+				p.SetState(124)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 1)) {
 					p.SetError(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 1)", ""))
 					goto errorExit
 				}
 				{
-					p.SetState(111)
-					p.Match(DCellParserT__38)
+					p.SetState(125)
+					p.Match(DCellParserT__41)
 					if p.HasError() {
 						// Recognition error - abort rule
 						goto errorExit
 					}
 				}
 				{
-					p.SetState(112)
+					p.SetState(126)
 					p.Type_()
 				}
 
@@ -2349,7 +2542,7 @@ func (p *DCellParser) expression(_p int) (localctx IExpressionContext) {
 			}
 
 		}
-		p.SetState(117)
+		p.SetState(131)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
@@ -2495,32 +2688,66 @@ func (s *InvocationTermContext) Invocation() IInvocationContext {
 	return t.(IInvocationContext)
 }
 
+type VariableTermContext struct {
+	TermContext
+}
+
+func NewVariableTermContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *VariableTermContext {
+	var p = new(VariableTermContext)
+
+	InitEmptyTermContext(&p.TermContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*TermContext))
+
+	return p
+}
+
+func (s *VariableTermContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *VariableTermContext) VARIABLE() antlr.TerminalNode {
+	return s.GetToken(DCellParserVARIABLE, 0)
+}
+
 func (p *DCellParser) Term() (localctx ITermContext) {
 	localctx = NewTermContext(p, p.GetParserRuleContext(), p.GetState())
 	p.EnterRule(localctx, 4, DCellParserRULE_term)
-	p.SetState(120)
+	p.SetState(135)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 
 	switch p.GetTokenStream().LA(1) {
-	case DCellParserT__1, DCellParserT__40, DCellParserT__41, DCellParserT__42, DCellParserDECIMAL_INTEGER, DCellParserHEX_INTEGER, DCellParserOCTAL_INTEGER, DCellParserBINARY_INTEGER, DCellParserDECIMAL_FLOAT, DCellParserSCIENTIFIC_FLOAT, DCellParserSINGLE_QUOTE_STRING, DCellParserDOUBLE_QUOTE_STRING, DCellParserTRIPLE_QUOTE_STRING:
+	case DCellParserT__2, DCellParserT__44, DCellParserT__45, DCellParserT__46, DCellParserT__52, DCellParserDECIMAL_INTEGER, DCellParserHEX_INTEGER, DCellParserOCTAL_INTEGER, DCellParserBINARY_INTEGER, DCellParserDECIMAL_FLOAT, DCellParserSCIENTIFIC_FLOAT, DCellParserSINGLE_QUOTE_STRING, DCellParserDOUBLE_QUOTE_STRING, DCellParserTRIPLE_QUOTE_STRING, DCellParserFORMAT_STRING:
 		localctx = NewLiteralTermContext(p, localctx)
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(118)
+			p.SetState(132)
 			p.Literal()
 		}
 
-	case DCellParserT__13, DCellParserIDENTIFIER:
+	case DCellParserT__14, DCellParserIDENTIFIER:
 		localctx = NewInvocationTermContext(p, localctx)
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(119)
+			p.SetState(133)
 			p.Invocation()
 		}
 
+	case DCellParserVARIABLE:
+		localctx = NewVariableTermContext(p, localctx)
+		p.EnterOuterAlt(localctx, 3)
+		{
+			p.SetState(134)
+			p.Match(DCellParserVARIABLE)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
 	default:
 		p.SetError(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 		goto errorExit
@@ -2700,7 +2927,7 @@ func (p *DCellParser) Invocation() (localctx IInvocationContext) {
 	p.EnterRule(localctx, 6, DCellParserRULE_invocation)
 	var _la int
 
-	p.SetState(131)
+	p.SetState(146)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -2711,7 +2938,7 @@ func (p *DCellParser) Invocation() (localctx IInvocationContext) {
 		localctx = NewMemberInvocationContext(p, localctx)
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(122)
+			p.SetState(137)
 			p.Identifier()
 		}
 
@@ -2719,8 +2946,8 @@ func (p *DCellParser) Invocation() (localctx IInvocationContext) {
 		localctx = NewWildcardInvocationContext(p, localctx)
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(123)
-			p.Match(DCellParserT__13)
+			p.SetState(138)
+			p.Match(DCellParserT__14)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
@@ -2731,34 +2958,34 @@ func (p *DCellParser) Invocation() (localctx IInvocationContext) {
 		localctx = NewFunctionInvocationContext(p, localctx)
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(124)
+			p.SetState(139)
 			p.Identifier()
 		}
 		{
-			p.SetState(125)
-			p.Match(DCellParserT__6)
+			p.SetState(140)
+			p.Match(DCellParserT__7)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
-		p.SetState(127)
+		p.SetState(142)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
 		}
 		_la = p.GetTokenStream().LA(1)
 
-		if (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&575913195512815268) != 0 {
+		if (int64((_la-3)) & ^0x3f) == 0 && ((int64(1)<<(_la-3))&-3346913394944087) != 0 {
 			{
-				p.SetState(126)
+				p.SetState(141)
 				p.ParameterList()
 			}
 
 		}
 		{
-			p.SetState(129)
-			p.Match(DCellParserT__7)
+			p.SetState(144)
+			p.Match(DCellParserT__8)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
@@ -2790,8 +3017,8 @@ type IParameterListContext interface {
 	GetParser() antlr.Parser
 
 	// Getter signatures
-	AllExpression() []IExpressionContext
-	Expression(i int) IExpressionContext
+	AllParameter() []IParameterContext
+	Parameter(i int) IParameterContext
 
 	// IsParameterListContext differentiates from other interfaces.
 	IsParameterListContext()
@@ -2805,61 +3032,365 @@ type ParameterListContext struct {
 func NewEmptyParameterListContext() *ParameterListContext {
 	var p = new(ParameterListContext)
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = DCellParserRULE_parameterList
+	p.RuleIndex = DCellParserRULE_parameterList
+	return p
+}
+
+func InitEmptyParameterListContext(p *ParameterListContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = DCellParserRULE_parameterList
+}
+
+func (*ParameterListContext) IsParameterListContext() {}
+
+func NewParameterListContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ParameterListContext {
+	var p = new(ParameterListContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = DCellParserRULE_parameterList
+
+	return p
+}
+
+func (s *ParameterListContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ParameterListContext) AllParameter() []IParameterContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IParameterContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IParameterContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IParameterContext); ok {
+			tst[i] = t.(IParameterContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *ParameterListContext) Parameter(i int) IParameterContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IParameterContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IParameterContext)
+}
+
+func (s *ParameterListContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ParameterListContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (p *DCellParser) ParameterList() (localctx IParameterListContext) {
+	localctx = NewParameterListContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 8, DCellParserRULE_parameterList)
+	var _la int
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(148)
+		p.Parameter()
+	}
+	p.SetState(153)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	for _la == DCellParserT__42 {
+		{
+			p.SetState(149)
+			p.Match(DCellParserT__42)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+		{
+			p.SetState(150)
+			p.Parameter()
+		}
+
+		p.SetState(155)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
+		_la = p.GetTokenStream().LA(1)
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IParameterContext is an interface to support dynamic dispatch.
+type IParameterContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+	// IsParameterContext differentiates from other interfaces.
+	IsParameterContext()
+}
+
+type ParameterContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyParameterContext() *ParameterContext {
+	var p = new(ParameterContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = DCellParserRULE_parameter
+	return p
+}
+
+func InitEmptyParameterContext(p *ParameterContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = DCellParserRULE_parameter
+}
+
+func (*ParameterContext) IsParameterContext() {}
+
+func NewParameterContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ParameterContext {
+	var p = new(ParameterContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = DCellParserRULE_parameter
+
+	return p
+}
+
+func (s *ParameterContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ParameterContext) CopyAll(ctx *ParameterContext) {
+	s.CopyFrom(&ctx.BaseParserRuleContext)
+}
+
+func (s *ParameterContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ParameterContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+type ExpressionParameterContext struct {
+	ParameterContext
+}
+
+func NewExpressionParameterContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ExpressionParameterContext {
+	var p = new(ExpressionParameterContext)
+
+	InitEmptyParameterContext(&p.ParameterContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ParameterContext))
+
+	return p
+}
+
+func (s *ExpressionParameterContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ExpressionParameterContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+type LambdaParameterContext struct {
+	ParameterContext
+}
+
+func NewLambdaParameterContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *LambdaParameterContext {
+	var p = new(LambdaParameterContext)
+
+	InitEmptyParameterContext(&p.ParameterContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*ParameterContext))
+
+	return p
+}
+
+func (s *LambdaParameterContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *LambdaParameterContext) Lambda() ILambdaContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(ILambdaContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(ILambdaContext)
+}
+
+func (p *DCellParser) Parameter() (localctx IParameterContext) {
+	localctx = NewParameterContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 10, DCellParserRULE_parameter)
+	p.SetState(158)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 9, p.GetParserRuleContext()) {
+	case 1:
+		localctx = NewLambdaParameterContext(p, localctx)
+		p.EnterOuterAlt(localctx, 1)
+		{
+			p.SetState(156)
+			p.Lambda()
+		}
+
+	case 2:
+		localctx = NewExpressionParameterContext(p, localctx)
+		p.EnterOuterAlt(localctx, 2)
+		{
+			p.SetState(157)
+			p.expression(0)
+		}
+
+	case antlr.ATNInvalidAltNumber:
+		goto errorExit
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// ILambdaContext is an interface to support dynamic dispatch.
+type ILambdaContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	Identifier() IIdentifierContext
+	Expression() IExpressionContext
+
+	// IsLambdaContext differentiates from other interfaces.
+	IsLambdaContext()
+}
+
+type LambdaContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyLambdaContext() *LambdaContext {
+	var p = new(LambdaContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = DCellParserRULE_lambda
 	return p
 }
 
-func InitEmptyParameterListContext(p *ParameterListContext) {
+func InitEmptyLambdaContext(p *LambdaContext) {
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = DCellParserRULE_parameterList
+	p.RuleIndex = DCellParserRULE_lambda
 }
 
-func (*ParameterListContext) IsParameterListContext() {}
+func (*LambdaContext) IsLambdaContext() {}
 
-func NewParameterListContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ParameterListContext {
-	var p = new(ParameterListContext)
+func NewLambdaContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *LambdaContext {
+	var p = new(LambdaContext)
 
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = DCellParserRULE_parameterList
+	p.RuleIndex = DCellParserRULE_lambda
 
 	return p
 }
 
-func (s *ParameterListContext) GetParser() antlr.Parser { return s.parser }
+func (s *LambdaContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *ParameterListContext) AllExpression() []IExpressionContext {
-	children := s.GetChildren()
-	len := 0
-	for _, ctx := range children {
-		if _, ok := ctx.(IExpressionContext); ok {
-			len++
+func (s *LambdaContext) Identifier() IIdentifierContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IIdentifierContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
 		}
 	}
 
-	tst := make([]IExpressionContext, len)
-	i := 0
-	for _, ctx := range children {
-		if t, ok := ctx.(IExpressionContext); ok {
-			tst[i] = t.(IExpressionContext)
-			i++
-		}
+	if t == nil {
+		return nil
 	}
 
-	return tst
+	return t.(IIdentifierContext)
 }
 
-func (s *ParameterListContext) Expression(i int) IExpressionContext {
+func (s *LambdaContext) Expression() IExpressionContext {
 	var t antlr.RuleContext
-	j := 0
 	for _, ctx := range s.GetChildren() {
 		if _, ok := ctx.(IExpressionContext); ok {
-			if j == i {
-				t = ctx.(antlr.RuleContext)
-				break
-			}
-			j++
+			t = ctx.(antlr.RuleContext)
+			break
 		}
 	}
 
@@ -2870,51 +3401,33 @@ func (s *ParameterListContext) Expression(i int) IExpressionContext {
 	return t.(IExpressionContext)
 }
 
-func (s *ParameterListContext) GetRuleContext() antlr.RuleContext {
+func (s *LambdaContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *ParameterListContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *LambdaContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (p *DCellParser) ParameterList() (localctx IParameterListContext) {
-	localctx = NewParameterListContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 8, DCellParserRULE_parameterList)
-	var _la int
-
+func (p *DCellParser) Lambda() (localctx ILambdaContext) {
+	localctx = NewLambdaContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 12, DCellParserRULE_lambda)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(133)
-		p.expression(0)
-	}
-	p.SetState(138)
-	p.GetErrorHandler().Sync(p)
-	if p.HasError() {
-		goto errorExit
+		p.SetState(160)
+		p.Identifier()
 	}
-	_la = p.GetTokenStream().LA(1)
-
-	for _la == DCellParserT__39 {
-		{
-			p.SetState(134)
-			p.Match(DCellParserT__39)
-			if p.HasError() {
-				// Recognition error - abort rule
-				goto errorExit
-			}
-		}
-		{
-			p.SetState(135)
-			p.expression(0)
-		}
-
-		p.SetState(140)
-		p.GetErrorHandler().Sync(p)
+	{
+		p.SetState(161)
+		p.Match(DCellParserT__43)
 		if p.HasError() {
+			// Recognition error - abort rule
 			goto errorExit
 		}
-		_la = p.GetTokenStream().LA(1)
+	}
+	{
+		p.SetState(162)
+		p.expression(0)
 	}
 
 errorExit:
@@ -2990,10 +3503,10 @@ func (s *IdentifierContext) ToStringTree(ruleNames []string, recog antlr.Recogni
 
 func (p *DCellParser) Identifier() (localctx IIdentifierContext) {
 	localctx = NewIdentifierContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 10, DCellParserRULE_identifier)
+	p.EnterRule(localctx, 14, DCellParserRULE_identifier)
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(141)
+		p.SetState(164)
 		p.Match(DCellParserIDENTIFIER)
 		if p.HasError() {
 			// Recognition error - abort rule
@@ -3163,51 +3676,51 @@ func (s *ExpressionIndexContext) Expression() IExpressionContext {
 
 func (p *DCellParser) Index() (localctx IIndexContext) {
 	localctx = NewIndexContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 12, DCellParserRULE_index)
+	p.EnterRule(localctx, 16, DCellParserRULE_index)
 	var _la int
 
-	p.SetState(151)
+	p.SetState(174)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 
-	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 11, p.GetParserRuleContext()) {
+	switch p.GetInterpreter().AdaptivePredict(p.BaseParser, p.GetTokenStream(), 12, p.GetParserRuleContext()) {
 	case 1:
 		localctx = NewSliceIndexContext(p, localctx)
 		p.EnterOuterAlt(localctx, 1)
-		p.SetState(144)
+		p.SetState(167)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
 		}
 		_la = p.GetTokenStream().LA(1)
 
-		if (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&575913195512815268) != 0 {
+		if (int64((_la-3)) & ^0x3f) == 0 && ((int64(1)<<(_la-3))&-3346913394944087) != 0 {
 			{
-				p.SetState(143)
+				p.SetState(166)
 				p.expression(0)
 			}
 
 		}
 		{
-			p.SetState(146)
-			p.Match(DCellParserT__35)
+			p.SetState(169)
+			p.Match(DCellParserT__38)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
-		p.SetState(148)
+		p.SetState(171)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
 		}
 		_la = p.GetTokenStream().LA(1)
 
-		if (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&575913195512815268) != 0 {
+		if (int64((_la-3)) & ^0x3f) == 0 && ((int64(1)<<(_la-3))&-3346913394944087) != 0 {
 			{
-				p.SetState(147)
+				p.SetState(170)
 				p.expression(0)
 			}
 
@@ -3217,7 +3730,7 @@ func (p *DCellParser) Index() (localctx IIndexContext) {
 		localctx = NewExpressionIndexContext(p, localctx)
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(150)
+			p.SetState(173)
 			p.expression(0)
 		}
 
@@ -3310,6 +3823,40 @@ func (s *NullLiteralContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
+type ObjectLiteralContext struct {
+	LiteralContext
+}
+
+func NewObjectLiteralContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *ObjectLiteralContext {
+	var p = new(ObjectLiteralContext)
+
+	InitEmptyLiteralContext(&p.LiteralContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*LiteralContext))
+
+	return p
+}
+
+func (s *ObjectLiteralContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ObjectLiteralContext) Object() IObjectContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IObjectContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IObjectContext)
+}
+
 type StringLiteralContext struct {
 	LiteralContext
 }
@@ -3344,6 +3891,28 @@ func (s *StringLiteralContext) String_() IStringContext {
 	return t.(IStringContext)
 }
 
+type FormatStringLiteralContext struct {
+	LiteralContext
+}
+
+func NewFormatStringLiteralContext(parser antlr.Parser, ctx antlr.ParserRuleContext) *FormatStringLiteralContext {
+	var p = new(FormatStringLiteralContext)
+
+	InitEmptyLiteralContext(&p.LiteralContext)
+	p.parser = parser
+	p.CopyAll(ctx.(*LiteralContext))
+
+	return p
+}
+
+func (s *FormatStringLiteralContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *FormatStringLiteralContext) FORMAT_STRING() antlr.TerminalNode {
+	return s.GetToken(DCellParserFORMAT_STRING, 0)
+}
+
 type ListLiteralContext struct {
 	LiteralContext
 }
@@ -3466,10 +4035,10 @@ func (s *BooleanLiteralContext) GetRuleContext() antlr.RuleContext {
 
 func (p *DCellParser) Literal() (localctx ILiteralContext) {
 	localctx = NewLiteralContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 14, DCellParserRULE_literal)
+	p.EnterRule(localctx, 18, DCellParserRULE_literal)
 	var _la int
 
-	p.SetState(159)
+	p.SetState(184)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -3480,7 +4049,7 @@ func (p *DCellParser) Literal() (localctx ILiteralContext) {
 		localctx = NewStringLiteralContext(p, localctx)
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(153)
+			p.SetState(176)
 			p.String_()
 		}
 
@@ -3488,7 +4057,7 @@ func (p *DCellParser) Literal() (localctx ILiteralContext) {
 		localctx = NewIntegerLiteralContext(p, localctx)
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(154)
+			p.SetState(177)
 			p.Integer()
 		}
 
@@ -3496,18 +4065,18 @@ func (p *DCellParser) Literal() (localctx ILiteralContext) {
 		localctx = NewFloatLiteralContext(p, localctx)
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(155)
+			p.SetState(178)
 			p.Float()
 		}
 
-	case DCellParserT__40, DCellParserT__41:
+	case DCellParserT__44, DCellParserT__45:
 		localctx = NewBooleanLiteralContext(p, localctx)
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(156)
+			p.SetState(179)
 			_la = p.GetTokenStream().LA(1)
 
-			if !(_la == DCellParserT__40 || _la == DCellParserT__41) {
+			if !(_la == DCellParserT__44 || _la == DCellParserT__45) {
 				p.GetErrorHandler().RecoverInline(p)
 			} else {
 				p.GetErrorHandler().ReportMatch(p)
@@ -3515,26 +4084,46 @@ func (p *DCellParser) Literal() (localctx ILiteralContext) {
 			}
 		}
 
-	case DCellParserT__42:
+	case DCellParserT__46:
 		localctx = NewNullLiteralContext(p, localctx)
 		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(157)
-			p.Match(DCellParserT__42)
+			p.SetState(180)
+			p.Match(DCellParserT__46)
 			if p.HasError() {
 				// Recognition error - abort rule
 				goto errorExit
 			}
 		}
 
-	case DCellParserT__1:
+	case DCellParserT__2:
 		localctx = NewListLiteralContext(p, localctx)
 		p.EnterOuterAlt(localctx, 6)
 		{
-			p.SetState(158)
+			p.SetState(181)
 			p.List()
 		}
 
+	case DCellParserT__52:
+		localctx = NewObjectLiteralContext(p, localctx)
+		p.EnterOuterAlt(localctx, 7)
+		{
+			p.SetState(182)
+			p.Object()
+		}
+
+	case DCellParserFORMAT_STRING:
+		localctx = NewFormatStringLiteralContext(p, localctx)
+		p.EnterOuterAlt(localctx, 8)
+		{
+			p.SetState(183)
+			p.Match(DCellParserFORMAT_STRING)
+			if p.HasError() {
+				// Recognition error - abort rule
+				goto errorExit
+			}
+		}
+
 	default:
 		p.SetError(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 		goto errorExit
@@ -3587,36 +4176,210 @@ func NewTypeContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokin
 
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
 
-	p.parser = parser
-	p.RuleIndex = DCellParserRULE_type
+	p.parser = parser
+	p.RuleIndex = DCellParserRULE_type
+
+	return p
+}
+
+func (s *TypeContext) GetParser() antlr.Parser { return s.parser }
+func (s *TypeContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *TypeContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (p *DCellParser) Type_() (localctx ITypeContext) {
+	localctx = NewTypeContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 20, DCellParserRULE_type)
+	var _la int
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(186)
+		_la = p.GetTokenStream().LA(1)
+
+		if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&8725724278030336) != 0) {
+			p.GetErrorHandler().RecoverInline(p)
+		} else {
+			p.GetErrorHandler().ReportMatch(p)
+			p.Consume()
+		}
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IListContext is an interface to support dynamic dispatch.
+type IListContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	AllExpression() []IExpressionContext
+	Expression(i int) IExpressionContext
+
+	// IsListContext differentiates from other interfaces.
+	IsListContext()
+}
+
+type ListContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyListContext() *ListContext {
+	var p = new(ListContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = DCellParserRULE_list
+	return p
+}
+
+func InitEmptyListContext(p *ListContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = DCellParserRULE_list
+}
+
+func (*ListContext) IsListContext() {}
+
+func NewListContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ListContext {
+	var p = new(ListContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = DCellParserRULE_list
+
+	return p
+}
+
+func (s *ListContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ListContext) AllExpression() []IExpressionContext {
+	children := s.GetChildren()
+	len := 0
+	for _, ctx := range children {
+		if _, ok := ctx.(IExpressionContext); ok {
+			len++
+		}
+	}
+
+	tst := make([]IExpressionContext, len)
+	i := 0
+	for _, ctx := range children {
+		if t, ok := ctx.(IExpressionContext); ok {
+			tst[i] = t.(IExpressionContext)
+			i++
+		}
+	}
+
+	return tst
+}
+
+func (s *ListContext) Expression(i int) IExpressionContext {
+	var t antlr.RuleContext
+	j := 0
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			if j == i {
+				t = ctx.(antlr.RuleContext)
+				break
+			}
+			j++
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
 
-	return p
+	return t.(IExpressionContext)
 }
 
-func (s *TypeContext) GetParser() antlr.Parser { return s.parser }
-func (s *TypeContext) GetRuleContext() antlr.RuleContext {
+func (s *ListContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *TypeContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *ListContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (p *DCellParser) Type_() (localctx ITypeContext) {
-	localctx = NewTypeContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 16, DCellParserRULE_type)
+func (p *DCellParser) List() (localctx IListContext) {
+	localctx = NewListContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 22, DCellParserRULE_list)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(161)
+		p.SetState(188)
+		p.Match(DCellParserT__2)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+	p.SetState(197)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+	_la = p.GetTokenStream().LA(1)
+
+	if (int64((_la-3)) & ^0x3f) == 0 && ((int64(1)<<(_la-3))&-3346913394944087) != 0 {
+		{
+			p.SetState(189)
+			p.expression(0)
+		}
+		p.SetState(194)
+		p.GetErrorHandler().Sync(p)
+		if p.HasError() {
+			goto errorExit
+		}
 		_la = p.GetTokenStream().LA(1)
 
-		if !((int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&545357767376896) != 0) {
-			p.GetErrorHandler().RecoverInline(p)
-		} else {
-			p.GetErrorHandler().ReportMatch(p)
-			p.Consume()
+		for _la == DCellParserT__42 {
+			{
+				p.SetState(190)
+				p.Match(DCellParserT__42)
+				if p.HasError() {
+					// Recognition error - abort rule
+					goto errorExit
+				}
+			}
+			{
+				p.SetState(191)
+				p.expression(0)
+			}
+
+			p.SetState(196)
+			p.GetErrorHandler().Sync(p)
+			if p.HasError() {
+				goto errorExit
+			}
+			_la = p.GetTokenStream().LA(1)
+		}
+
+	}
+	{
+		p.SetState(199)
+		p.Match(DCellParserT__3)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
 		}
 	}
 
@@ -3633,67 +4396,67 @@ errorExit:
 	goto errorExit // Trick to prevent compiler error if the label is not used
 }
 
-// IListContext is an interface to support dynamic dispatch.
-type IListContext interface {
+// IObjectContext is an interface to support dynamic dispatch.
+type IObjectContext interface {
 	antlr.ParserRuleContext
 
 	// GetParser returns the parser.
 	GetParser() antlr.Parser
 
 	// Getter signatures
-	AllLiteral() []ILiteralContext
-	Literal(i int) ILiteralContext
+	AllProperty() []IPropertyContext
+	Property(i int) IPropertyContext
 
-	// IsListContext differentiates from other interfaces.
-	IsListContext()
+	// IsObjectContext differentiates from other interfaces.
+	IsObjectContext()
 }
 
-type ListContext struct {
+type ObjectContext struct {
 	antlr.BaseParserRuleContext
 	parser antlr.Parser
 }
 
-func NewEmptyListContext() *ListContext {
-	var p = new(ListContext)
+func NewEmptyObjectContext() *ObjectContext {
+	var p = new(ObjectContext)
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = DCellParserRULE_list
+	p.RuleIndex = DCellParserRULE_object
 	return p
 }
 
-func InitEmptyListContext(p *ListContext) {
+func InitEmptyObjectContext(p *ObjectContext) {
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
-	p.RuleIndex = DCellParserRULE_list
+	p.RuleIndex = DCellParserRULE_object
 }
 
-func (*ListContext) IsListContext() {}
+func (*ObjectContext) IsObjectContext() {}
 
-func NewListContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ListContext {
-	var p = new(ListContext)
+func NewObjectContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ObjectContext {
+	var p = new(ObjectContext)
 
 	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
 
 	p.parser = parser
-	p.RuleIndex = DCellParserRULE_list
+	p.RuleIndex = DCellParserRULE_object
 
 	return p
 }
 
-func (s *ListContext) GetParser() antlr.Parser { return s.parser }
+func (s *ObjectContext) GetParser() antlr.Parser { return s.parser }
 
-func (s *ListContext) AllLiteral() []ILiteralContext {
+func (s *ObjectContext) AllProperty() []IPropertyContext {
 	children := s.GetChildren()
 	len := 0
 	for _, ctx := range children {
-		if _, ok := ctx.(ILiteralContext); ok {
+		if _, ok := ctx.(IPropertyContext); ok {
 			len++
 		}
 	}
 
-	tst := make([]ILiteralContext, len)
+	tst := make([]IPropertyContext, len)
 	i := 0
 	for _, ctx := range children {
-		if t, ok := ctx.(ILiteralContext); ok {
-			tst[i] = t.(ILiteralContext)
+		if t, ok := ctx.(IPropertyContext); ok {
+			tst[i] = t.(IPropertyContext)
 			i++
 		}
 	}
@@ -3701,11 +4464,11 @@ func (s *ListContext) AllLiteral() []ILiteralContext {
 	return tst
 }
 
-func (s *ListContext) Literal(i int) ILiteralContext {
+func (s *ObjectContext) Property(i int) IPropertyContext {
 	var t antlr.RuleContext
 	j := 0
 	for _, ctx := range s.GetChildren() {
-		if _, ok := ctx.(ILiteralContext); ok {
+		if _, ok := ctx.(IPropertyContext); ok {
 			if j == i {
 				t = ctx.(antlr.RuleContext)
 				break
@@ -3718,65 +4481,65 @@ func (s *ListContext) Literal(i int) ILiteralContext {
 		return nil
 	}
 
-	return t.(ILiteralContext)
+	return t.(IPropertyContext)
 }
 
-func (s *ListContext) GetRuleContext() antlr.RuleContext {
+func (s *ObjectContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
 
-func (s *ListContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+func (s *ObjectContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
 	return antlr.TreesStringTree(s, ruleNames, recog)
 }
 
-func (p *DCellParser) List() (localctx IListContext) {
-	localctx = NewListContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 18, DCellParserRULE_list)
+func (p *DCellParser) Object() (localctx IObjectContext) {
+	localctx = NewObjectContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 24, DCellParserRULE_object)
 	var _la int
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(163)
-		p.Match(DCellParserT__1)
+		p.SetState(201)
+		p.Match(DCellParserT__52)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
-	p.SetState(172)
+	p.SetState(210)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
 	}
 	_la = p.GetTokenStream().LA(1)
 
-	if (int64(_la) & ^0x3f) == 0 && ((int64(1)<<_la)&575350245559369732) != 0 {
+	if (int64((_la-55)) & ^0x3f) == 0 && ((int64(1)<<(_la-55))&1793) != 0 {
 		{
-			p.SetState(164)
-			p.Literal()
+			p.SetState(202)
+			p.Property()
 		}
-		p.SetState(169)
+		p.SetState(207)
 		p.GetErrorHandler().Sync(p)
 		if p.HasError() {
 			goto errorExit
 		}
 		_la = p.GetTokenStream().LA(1)
 
-		for _la == DCellParserT__39 {
+		for _la == DCellParserT__42 {
 			{
-				p.SetState(165)
-				p.Match(DCellParserT__39)
+				p.SetState(203)
+				p.Match(DCellParserT__42)
 				if p.HasError() {
 					// Recognition error - abort rule
 					goto errorExit
 				}
 			}
 			{
-				p.SetState(166)
-				p.Literal()
+				p.SetState(204)
+				p.Property()
 			}
 
-			p.SetState(171)
+			p.SetState(209)
 			p.GetErrorHandler().Sync(p)
 			if p.HasError() {
 				goto errorExit
@@ -3786,13 +4549,170 @@ func (p *DCellParser) List() (localctx IListContext) {
 
 	}
 	{
-		p.SetState(174)
-		p.Match(DCellParserT__2)
+		p.SetState(212)
+		p.Match(DCellParserT__53)
+		if p.HasError() {
+			// Recognition error - abort rule
+			goto errorExit
+		}
+	}
+
+errorExit:
+	if p.HasError() {
+		v := p.GetError()
+		localctx.SetException(v)
+		p.GetErrorHandler().ReportError(p, v)
+		p.GetErrorHandler().Recover(p, v)
+		p.SetError(nil)
+	}
+	p.ExitRule()
+	return localctx
+	goto errorExit // Trick to prevent compiler error if the label is not used
+}
+
+// IPropertyContext is an interface to support dynamic dispatch.
+type IPropertyContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// Getter signatures
+	Expression() IExpressionContext
+	Identifier() IIdentifierContext
+	String_() IStringContext
+
+	// IsPropertyContext differentiates from other interfaces.
+	IsPropertyContext()
+}
+
+type PropertyContext struct {
+	antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyPropertyContext() *PropertyContext {
+	var p = new(PropertyContext)
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = DCellParserRULE_property
+	return p
+}
+
+func InitEmptyPropertyContext(p *PropertyContext) {
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, nil, -1)
+	p.RuleIndex = DCellParserRULE_property
+}
+
+func (*PropertyContext) IsPropertyContext() {}
+
+func NewPropertyContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *PropertyContext {
+	var p = new(PropertyContext)
+
+	antlr.InitBaseParserRuleContext(&p.BaseParserRuleContext, parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = DCellParserRULE_property
+
+	return p
+}
+
+func (s *PropertyContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *PropertyContext) Expression() IExpressionContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IExpressionContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IExpressionContext)
+}
+
+func (s *PropertyContext) Identifier() IIdentifierContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IIdentifierContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IIdentifierContext)
+}
+
+func (s *PropertyContext) String_() IStringContext {
+	var t antlr.RuleContext
+	for _, ctx := range s.GetChildren() {
+		if _, ok := ctx.(IStringContext); ok {
+			t = ctx.(antlr.RuleContext)
+			break
+		}
+	}
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IStringContext)
+}
+
+func (s *PropertyContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *PropertyContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+func (p *DCellParser) Property() (localctx IPropertyContext) {
+	localctx = NewPropertyContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 26, DCellParserRULE_property)
+	p.EnterOuterAlt(localctx, 1)
+	p.SetState(216)
+	p.GetErrorHandler().Sync(p)
+	if p.HasError() {
+		goto errorExit
+	}
+
+	switch p.GetTokenStream().LA(1) {
+	case DCellParserIDENTIFIER:
+		{
+			p.SetState(214)
+			p.Identifier()
+		}
+
+	case DCellParserSINGLE_QUOTE_STRING, DCellParserDOUBLE_QUOTE_STRING, DCellParserTRIPLE_QUOTE_STRING:
+		{
+			p.SetState(215)
+			p.String_()
+		}
+
+	default:
+		p.SetError(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
+		goto errorExit
+	}
+	{
+		p.SetState(218)
+		p.Match(DCellParserT__38)
 		if p.HasError() {
 			// Recognition error - abort rule
 			goto errorExit
 		}
 	}
+	{
+		p.SetState(219)
+		p.expression(0)
+	}
 
 errorExit:
 	if p.HasError() {
@@ -3929,8 +4849,8 @@ func (s *TripleQuoteStringContext) TRIPLE_QUOTE_STRING() antlr.TerminalNode {
 
 func (p *DCellParser) String_() (localctx IStringContext) {
 	localctx = NewStringContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 20, DCellParserRULE_string)
-	p.SetState(179)
+	p.EnterRule(localctx, 28, DCellParserRULE_string)
+	p.SetState(224)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -3941,7 +4861,7 @@ func (p *DCellParser) String_() (localctx IStringContext) {
 		localctx = NewSingleQuoteStringContext(p, localctx)
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(176)
+			p.SetState(221)
 			p.Match(DCellParserSINGLE_QUOTE_STRING)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -3953,7 +4873,7 @@ func (p *DCellParser) String_() (localctx IStringContext) {
 		localctx = NewDoubleQuoteStringContext(p, localctx)
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(177)
+			p.SetState(222)
 			p.Match(DCellParserDOUBLE_QUOTE_STRING)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -3965,7 +4885,7 @@ func (p *DCellParser) String_() (localctx IStringContext) {
 		localctx = NewTripleQuoteStringContext(p, localctx)
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(178)
+			p.SetState(223)
 			p.Match(DCellParserTRIPLE_QUOTE_STRING)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -4135,8 +5055,8 @@ func (s *BinaryIntegerContext) BINARY_INTEGER() antlr.TerminalNode {
 
 func (p *DCellParser) Integer() (localctx IIntegerContext) {
 	localctx = NewIntegerContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 22, DCellParserRULE_integer)
-	p.SetState(185)
+	p.EnterRule(localctx, 30, DCellParserRULE_integer)
+	p.SetState(230)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -4147,7 +5067,7 @@ func (p *DCellParser) Integer() (localctx IIntegerContext) {
 		localctx = NewDecimalIntegerContext(p, localctx)
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(181)
+			p.SetState(226)
 			p.Match(DCellParserDECIMAL_INTEGER)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -4159,7 +5079,7 @@ func (p *DCellParser) Integer() (localctx IIntegerContext) {
 		localctx = NewHexIntegerContext(p, localctx)
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(182)
+			p.SetState(227)
 			p.Match(DCellParserHEX_INTEGER)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -4171,7 +5091,7 @@ func (p *DCellParser) Integer() (localctx IIntegerContext) {
 		localctx = NewOctalIntegerContext(p, localctx)
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(183)
+			p.SetState(228)
 			p.Match(DCellParserOCTAL_INTEGER)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -4183,7 +5103,7 @@ func (p *DCellParser) Integer() (localctx IIntegerContext) {
 		localctx = NewBinaryIntegerContext(p, localctx)
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(184)
+			p.SetState(229)
 			p.Match(DCellParserBINARY_INTEGER)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -4309,8 +5229,8 @@ func (s *ScientificFloatContext) SCIENTIFIC_FLOAT() antlr.TerminalNode {
 
 func (p *DCellParser) Float() (localctx IFloatContext) {
 	localctx = NewFloatContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 24, DCellParserRULE_float)
-	p.SetState(189)
+	p.EnterRule(localctx, 32, DCellParserRULE_float)
+	p.SetState(234)
 	p.GetErrorHandler().Sync(p)
 	if p.HasError() {
 		goto errorExit
@@ -4321,7 +5241,7 @@ func (p *DCellParser) Float() (localctx IFloatContext) {
 		localctx = NewScientificFloatContext(p, localctx)
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(187)
+			p.SetState(232)
 			p.Match(DCellParserSCIENTIFIC_FLOAT)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -4333,7 +5253,7 @@ func (p *DCellParser) Float() (localctx IFloatContext) {
 		localctx = NewDecimalFloatContext(p, localctx)
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(188)
+			p.SetState(233)
 			p.Match(DCellParserDECIMAL_FLOAT)
 			if p.HasError() {
 				// Recognition error - abort rule
@@ -4376,60 +5296,66 @@ func (p *DCellParser) Sempred(localctx antlr.RuleContext, ruleIndex, predIndex i
 func (p *DCellParser) Expression_Sempred(localctx antlr.RuleContext, predIndex int) bool {
 	switch predIndex {
 	case 0:
-		return p.Precpred(p.GetParserRuleContext(), 20)
+		return p.Precpred(p.GetParserRuleContext(), 21)
 
 	case 1:
-		return p.Precpred(p.GetParserRuleContext(), 15)
+		return p.Precpred(p.GetParserRuleContext(), 16)
 
 	case 2:
-		return p.Precpred(p.GetParserRuleContext(), 14)
+		return p.Precpred(p.GetParserRuleContext(), 15)
 
 	case 3:
-		return p.Precpred(p.GetParserRuleContext(), 13)
+		return p.Precpred(p.GetParserRuleContext(), 14)
 
 	case 4:
-		return p.Precpred(p.GetParserRuleContext(), 12)
+		return p.Precpred(p.GetParserRuleContext(), 13)
 
 	case 5:
-		return p.Precpred(p.GetParserRuleContext(), 11)
+		return p.Precpred(p.GetParserRuleContext(), 12)
 
 	case 6:
-		return p.Precpred(p.GetParserRuleContext(), 10)
+		return p.Precpred(p.GetParserRuleContext(), 11)
 
 	case 7:
-		return p.Precpred(p.GetParserRuleContext(), 9)
+		return p.Precpred(p.GetParserRuleContext(), 10)
 
 	case 8:
-		return p.Precpred(p.GetParserRuleContext(), 8)
+		return p.Precpred(p.GetParserRuleContext(), 9)
 
 	case 9:
-		return p.Precpred(p.GetParserRuleContext(), 7)
+		return p.Precpred(p.GetParserRuleContext(), 8)
 
 	case 10:
-		return p.Precpred(p.GetParserRuleContext(), 6)
+		return p.Precpred(p.GetParserRuleContext(), 7)
 
 	case 11:
-		return p.Precpred(p.GetParserRuleContext(), 5)
+		return p.Precpred(p.GetParserRuleContext(), 6)
 
 	case 12:
-		return p.Precpred(p.GetParserRuleContext(), 4)
+		return p.Precpred(p.GetParserRuleContext(), 5)
 
 	case 13:
-		return p.Precpred(p.GetParserRuleContext(), 3)
+		return p.Precpred(p.GetParserRuleContext(), 4)
 
 	case 14:
-		return p.Precpred(p.GetParserRuleContext(), 2)
+		return p.Precpred(p.GetParserRuleContext(), 3)
 
 	case 15:
-		return p.Precpred(p.GetParserRuleContext(), 23)
+		return p.Precpred(p.GetParserRuleContext(), 2)
 
 	case 16:
-		return p.Precpred(p.GetParserRuleContext(), 22)
+		return p.Precpred(p.GetParserRuleContext(), 25)
 
 	case 17:
-		return p.Precpred(p.GetParserRuleContext(), 21)
+		return p.Precpred(p.GetParserRuleContext(), 24)
 
 	case 18:
+		return p.Precpred(p.GetParserRuleContext(), 23)
+
+	case 19:
+		return p.Precpred(p.GetParserRuleContext(), 22)
+
+	case 20:
 		return p.Precpred(p.GetParserRuleContext(), 1)
 
 	default:
@@ -0,0 +1,236 @@
+package reflectconv
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"rodusek.dev/pkg/dcell/internal/errs"
+	"rodusek.dev/pkg/dcell/internal/members"
+)
+
+// Decode converts src into the type of dst, and stores it in dst, which must
+// be settable.
+//
+// Scalars are converted with the same lossless rules as [Int64], [Float64],
+// [String], and [Bool]. Slices and arrays are converted element by element,
+// maps entry by entry, and maps with string keys and structs are converted
+// into structs field by field, matching fields by their names under policy.
+// Nil values convert to the zero value.
+//
+// Errors are of type [*errs.ConvertError], and report the path of the element
+// that failed to convert.
+func Decode(dst, src reflect.Value, policy *members.Policy) error {
+	d := decoder{policy: policy}
+	err := d.decode(dst, src)
+	var convertErr *errs.ConvertError
+	if errors.As(err, &convertErr) {
+		convertErr.Path = strings.TrimPrefix(convertErr.Path, ".")
+	}
+	return err
+}
+
+// decoder decodes values, naming the fields of structs by its policy.
+type decoder struct {
+	policy *members.Policy
+}
+
+func (d decoder) decode(dst, src reflect.Value) error {
+	src = Deref(src)
+	if IsNil(src) {
+		dst.SetZero()
+		return nil
+	}
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Pointer:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return d.decode(dst.Elem(), src)
+	case reflect.Bool:
+		b, err := Bool(src)
+		if err != nil {
+			return convertError(err)
+		}
+		dst.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := Int64(src)
+		if err != nil {
+			return convertError(err)
+		}
+		if dst.OverflowInt(i) {
+			return convertError(fmt.Errorf("value %d overflows %v", i, dst.Type()))
+		}
+		dst.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := Uint64(src)
+		if err != nil {
+			return convertError(err)
+		}
+		if dst.OverflowUint(u) {
+			return convertError(fmt.Errorf("value %d overflows %v", u, dst.Type()))
+		}
+		dst.SetUint(u)
+	case reflect.Float32:
+		f, err := Float32(src)
+		if err != nil {
+			return convertError(err)
+		}
+		dst.SetFloat(float64(f))
+	case reflect.Float64:
+		f, err := Float64(src)
+		if err != nil {
+			return convertError(err)
+		}
+		dst.SetFloat(f)
+	case reflect.String:
+		s, err := String(src)
+		if err != nil {
+			return convertError(err)
+		}
+		dst.SetString(s)
+	case reflect.Slice:
+		return d.decodeSlice(dst, src)
+	case reflect.Array:
+		return d.decodeArray(dst, src)
+	case reflect.Map:
+		return d.decodeMap(dst, src)
+	case reflect.Struct:
+		return d.decodeStruct(dst, src)
+	default:
+		return mismatch(dst, src)
+	}
+	return nil
+}
+
+func (d decoder) decodeSlice(dst, src reflect.Value) error {
+	if src.Kind() != reflect.Slice && src.Kind() != reflect.Array {
+		return mismatch(dst, src)
+	}
+	result := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+	for i := range src.Len() {
+		if err := d.decode(result.Index(i), src.Index(i)); err != nil {
+			return withPath(err, fmt.Sprintf("[%d]", i))
+		}
+	}
+	dst.Set(result)
+	return nil
+}
+
+func (d decoder) decodeArray(dst, src reflect.Value) error {
+	if src.Kind() != reflect.Slice && src.Kind() != reflect.Array {
+		return mismatch(dst, src)
+	}
+	if src.Len() != dst.Len() {
+		return convertError(fmt.Errorf("cannot convert %d elements to %v", src.Len(), dst.Type()))
+	}
+	for i := range src.Len() {
+		if err := d.decode(dst.Index(i), src.Index(i)); err != nil {
+			return withPath(err, fmt.Sprintf("[%d]", i))
+		}
+	}
+	return nil
+}
+
+func (d decoder) decodeMap(dst, src reflect.Value) error {
+	if src.Kind() != reflect.Map {
+		return mismatch(dst, src)
+	}
+	rt := dst.Type()
+	result := reflect.MakeMapWithSize(rt, src.Len())
+	iter := src.MapRange()
+	for iter.Next() {
+		key := reflect.New(rt.Key()).Elem()
+		if err := d.decode(key, iter.Key()); err != nil {
+			return withPath(err, fmt.Sprintf("[%v]", iter.Key()))
+		}
+		value := reflect.New(rt.Elem()).Elem()
+		if err := d.decode(value, iter.Value()); err != nil {
+			return withPath(err, fmt.Sprintf("[%v]", iter.Key()))
+		}
+		result.SetMapIndex(key, value)
+	}
+	dst.Set(result)
+	return nil
+}
+
+func (d decoder) decodeStruct(dst, src reflect.Value) error {
+	var lookup func(name string) (reflect.Value, bool)
+	switch {
+	case src.Kind() == reflect.Map && src.Type().Key().Kind() == reflect.String:
+		keyType := src.Type().Key()
+		lookup = func(name string) (reflect.Value, bool) {
+			value := src.MapIndex(reflect.ValueOf(name).Convert(keyType))
+			return value, value.IsValid()
+		}
+	case src.Kind() == reflect.Struct:
+		rt := src.Type()
+		lookup = func(name string) (reflect.Value, bool) {
+			field, ok := d.policy.Field(rt, name)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			value, err := src.FieldByIndexErr(field.Index)
+			return value, err == nil
+		}
+	default:
+		return mismatch(dst, src)
+	}
+
+	for _, field := range d.policy.Fields(dst.Type()) {
+		value, ok := lookup(field.Name)
+		if !ok {
+			continue
+		}
+		target, err := fieldByIndex(dst, field.Index)
+		if err == nil {
+			err = d.decode(target, value)
+		}
+		if err != nil {
+			return withPath(err, "."+field.Name)
+		}
+	}
+	return nil
+}
+
+// fieldByIndex returns the field of dst with the given index sequence,
+// allocating the embedded pointers that it is promoted through if they are
+// nil. Nil embedded pointers to unexported structs cannot be allocated.
+func fieldByIndex(dst reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && dst.Kind() == reflect.Pointer {
+			if dst.IsNil() {
+				if !dst.CanSet() {
+					return reflect.Value{}, convertError(fmt.Errorf("cannot set embedded pointer to unexported struct %v", dst.Type().Elem()))
+				}
+				dst.Set(reflect.New(dst.Type().Elem()))
+			}
+			dst = dst.Elem()
+		}
+		dst = dst.Field(x)
+	}
+	return dst, nil
+}
+
+func mismatch(dst, src reflect.Value) error {
+	return convertError(fmt.Errorf("cannot convert %v to %v", src.Type(), dst.Type()))
+}
+
+func convertError(err error) error {
+	return &errs.ConvertError{Err: err}
+}
+
+// withPath prepends a path segment to the path of a conversion error.
+func withPath(err error, segment string) error {
+	var convertErr *errs.ConvertError
+	if errors.As(err, &convertErr) {
+		convertErr.Path = segment + convertErr.Path
+	}
+	return err
+}
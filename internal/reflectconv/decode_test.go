@@ -0,0 +1,235 @@
+package reflectconv_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"rodusek.dev/pkg/dcell/internal/errs"
+	"rodusek.dev/pkg/dcell/internal/reflectconv"
+)
+
+type Base struct {
+	Name string `dcell:"name"`
+}
+
+type Extended struct {
+	*Base
+	Count int
+}
+
+type base struct {
+	Name string `dcell:"name"`
+}
+
+type Hidden struct {
+	base
+	Count int
+}
+
+type HiddenPointer struct {
+	*base
+	Count int
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+	type label struct {
+		Name  string `dcell:"name"`
+		Count int8
+	}
+	type source struct {
+		Name  string `dcell:"name"`
+		Count int64
+		Extra bool
+	}
+
+	testCases := []struct {
+		name  string
+		input any
+		into  reflect.Type
+		want  any
+	}{
+		{
+			name:  "Same type",
+			input: []string{"a"},
+			into:  reflect.TypeFor[[]string](),
+			want:  []string{"a"},
+		}, {
+			name:  "Integer into smaller integer",
+			input: int64(42),
+			into:  reflect.TypeFor[int8](),
+			want:  int8(42),
+		}, {
+			name:  "Integer into float",
+			input: 42,
+			into:  reflect.TypeFor[float32](),
+			want:  float32(42),
+		}, {
+			name:  "Untyped slice into typed slice",
+			input: []any{"a", "b"},
+			into:  reflect.TypeFor[[]string](),
+			want:  []string{"a", "b"},
+		}, {
+			name:  "Slice into array",
+			input: []any{1, uint8(2)},
+			into:  reflect.TypeFor[[2]int](),
+			want:  [2]int{1, 2},
+		}, {
+			name:  "Map into typed map",
+			input: map[string]any{"a": 1},
+			into:  reflect.TypeFor[map[string]int64](),
+			want:  map[string]int64{"a": 1},
+		}, {
+			name:  "Map into struct by tag and name",
+			input: map[string]any{"name": "bug", "Count": 3, "other": true},
+			into:  reflect.TypeFor[label](),
+			want:  label{Name: "bug", Count: 3},
+		}, {
+			name:  "Struct into struct",
+			input: source{Name: "bug", Count: 3, Extra: true},
+			into:  reflect.TypeFor[label](),
+			want:  label{Name: "bug", Count: 3},
+		}, {
+			name:  "Map into promoted fields",
+			input: map[string]any{"name": "bug", "Count": 3},
+			into:  reflect.TypeFor[Extended](),
+			want:  Extended{Base: &Base{Name: "bug"}, Count: 3},
+		}, {
+			name:  "Map into fields promoted from unexported struct",
+			input: map[string]any{"name": "bug", "Count": 3},
+			into:  reflect.TypeFor[Hidden](),
+			want:  Hidden{base: base{Name: "bug"}, Count: 3},
+		}, {
+			name:  "Promoted fields into struct",
+			input: Extended{Base: &Base{Name: "bug"}, Count: 3},
+			into:  reflect.TypeFor[label](),
+			want:  label{Name: "bug", Count: 3},
+		}, {
+			name:  "Nil embedded pointer into struct",
+			input: Extended{Count: 3},
+			into:  reflect.TypeFor[label](),
+			want:  label{Count: 3},
+		}, {
+			name:  "Nested values",
+			input: []any{map[string]any{"name": "a"}, nil},
+			into:  reflect.TypeFor[[]*label](),
+			want:  []*label{{Name: "a"}, nil},
+		}, {
+			name:  "Nil into value",
+			input: nil,
+			into:  reflect.TypeFor[int](),
+			want:  0,
+		}, {
+			name:  "Value into interface",
+			input: []int{1},
+			into:  reflect.TypeFor[any](),
+			want:  []int{1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			dst := reflect.New(tc.into).Elem()
+
+			err := reflectconv.Decode(dst, reflect.ValueOf(tc.input), nil)
+
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if got, want := dst.Interface(), tc.want; !cmp.Equal(got, want, cmp.AllowUnexported(Hidden{})) {
+				t.Errorf("Decode() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestDecode_Error(t *testing.T) {
+	t.Parallel()
+	type label struct {
+		Name   string `dcell:"name"`
+		Counts []uint8
+	}
+
+	testCases := []struct {
+		name     string
+		input    any
+		into     reflect.Type
+		wantPath string
+	}{
+		{
+			name:  "Float into integer",
+			input: 1.5,
+			into:  reflect.TypeFor[int](),
+		}, {
+			name:  "Integer overflows",
+			input: 300,
+			into:  reflect.TypeFor[int8](),
+		}, {
+			name:  "Negative into unsigned",
+			input: -1,
+			into:  reflect.TypeFor[uint](),
+		}, {
+			name:  "Float overflows float32",
+			input: math.MaxFloat64,
+			into:  reflect.TypeFor[float32](),
+		}, {
+			name:  "Scalar into slice",
+			input: "a",
+			into:  reflect.TypeFor[[]string](),
+		}, {
+			name:     "Element of slice",
+			input:    []any{"a", 2},
+			into:     reflect.TypeFor[[]string](),
+			wantPath: "[1]",
+		}, {
+			name:     "Field of struct",
+			input:    []any{map[string]any{"name": 1}},
+			into:     reflect.TypeFor[[]label](),
+			wantPath: "[0].name",
+		}, {
+			name:     "Element of field",
+			input:    map[string]any{"Counts": []int{1, 256}},
+			into:     reflect.TypeFor[label](),
+			wantPath: "Counts[1]",
+		}, {
+			name:     "Value of map",
+			input:    map[string]any{"a": "b"},
+			into:     reflect.TypeFor[map[string]bool](),
+			wantPath: "[a]",
+		}, {
+			name:  "Slice into array of different length",
+			input: []int{1, 2, 3},
+			into:  reflect.TypeFor[[2]int](),
+		}, {
+			name:     "Nil embedded pointer to unexported struct",
+			input:    map[string]any{"name": "bug"},
+			into:     reflect.TypeFor[HiddenPointer](),
+			wantPath: "name",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			dst := reflect.New(tc.into).Elem()
+
+			err := reflectconv.Decode(dst, reflect.ValueOf(tc.input), nil)
+
+			if got, want := err, errs.ErrConvert; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Decode() error = %v, want %v", got, want)
+			}
+			var convertErr *errs.ConvertError
+			if !errors.As(err, &convertErr) {
+				t.Fatalf("Decode() error = %v, want ConvertError", err)
+			}
+			if got, want := convertErr.Path, tc.wantPath; got != want {
+				t.Errorf("Decode() error path = %q, want %q", got, want)
+			}
+		})
+	}
+}
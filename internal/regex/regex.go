@@ -0,0 +1,105 @@
+/*
+Package regex is an internal package that compiles the regular expressions
+that expressions match strings against, such as the pattern of
+`ref =~ "^refs/heads/"`.
+
+Patterns use the RE2 syntax of the [regexp] package, which matches in time
+linear in the length of the input. Compiled patterns are kept in a bounded
+cache that is shared by all expressions, so that patterns which are only known
+during evaluation are not compiled on every evaluation.
+*/
+package regex
+
+import (
+	"container/list"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"rodusek.dev/pkg/dcell/internal/errs"
+)
+
+// Policy limits the patterns that can be compiled. A nil Policy allows any
+// valid pattern.
+type Policy struct {
+	// MaxLength is the maximum length of a pattern in bytes, or 0 if the
+	// length is not limited.
+	MaxLength int
+}
+
+// Compile compiles a pattern, returning the cached result if the pattern was
+// compiled before. Errors wrap [errs.ErrInvalidPattern].
+func (p *Policy) Compile(pattern string) (*regexp.Regexp, error) {
+	if p != nil && p.MaxLength > 0 && len(pattern) > p.MaxLength {
+		return nil, fmt.Errorf("%w: length %d exceeds the maximum of %d", errs.ErrInvalidPattern, len(pattern), p.MaxLength)
+	}
+	return cache.compile(pattern)
+}
+
+// CacheCapacity is the maximum number of compiled patterns in the cache.
+const CacheCapacity = 256
+
+var cache = newLRU(CacheCapacity)
+
+// lru is a cache of compiled patterns that evicts the least recently used
+// pattern when it is full.
+type lru struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+}
+
+type lruEntry struct {
+	pattern string
+	re      *regexp.Regexp
+}
+
+func newLRU(capacity int) *lru {
+	return &lru{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (c *lru) compile(pattern string) (*regexp.Regexp, error) {
+	if re, ok := c.get(pattern); ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errs.ErrInvalidPattern, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[pattern]; ok {
+		// Another goroutine compiled the same pattern concurrently.
+		c.order.MoveToFront(elem)
+		return elem.Value.(*lruEntry).re, nil
+	}
+	c.entries[pattern] = c.order.PushFront(&lruEntry{pattern: pattern, re: re})
+	if c.order.Len() > c.capacity {
+		entry := c.order.Remove(c.order.Back()).(*lruEntry)
+		delete(c.entries, entry.pattern)
+	}
+	return re, nil
+}
+
+func (c *lru) get(pattern string) (*regexp.Regexp, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[pattern]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*lruEntry).re, true
+}
+
+func (c *lru) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
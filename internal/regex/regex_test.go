@@ -0,0 +1,85 @@
+package regex
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"rodusek.dev/pkg/dcell/internal/errs"
+)
+
+func TestPolicy_Compile(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name    string
+		policy  *Policy
+		pattern string
+		wantErr error
+	}{
+		{name: "Nil policy", pattern: "^refs/heads/.*"},
+		{name: "Unlimited length", policy: &Policy{}, pattern: "^a+$"},
+		{name: "Within max length", policy: &Policy{MaxLength: 4}, pattern: "^a+$"},
+		{name: "Exceeds max length", policy: &Policy{MaxLength: 3}, pattern: "^a+$", wantErr: errs.ErrInvalidPattern},
+		{name: "Invalid pattern", pattern: "(a", wantErr: errs.ErrInvalidPattern},
+		{name: "Unsupported syntax", pattern: `(?=a)`, wantErr: errs.ErrInvalidPattern},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			re, err := tc.policy.Compile(tc.pattern)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("Policy.Compile(%q) error = %v, want %v", tc.pattern, got, want)
+			}
+			if err == nil && re.String() != tc.pattern {
+				t.Errorf("Policy.Compile(%q) = %v, want %v", tc.pattern, re, tc.pattern)
+			}
+		})
+	}
+}
+
+func TestPolicy_Compile_ReturnsCachedPattern(t *testing.T) {
+	t.Parallel()
+	var policy *Policy
+
+	first, err := policy.Compile("^cached$")
+	if err != nil {
+		t.Fatalf("Policy.Compile() error = %v", err)
+	}
+	second, err := policy.Compile("^cached$")
+	if err != nil {
+		t.Fatalf("Policy.Compile() error = %v", err)
+	}
+
+	if first != second {
+		t.Errorf("Policy.Compile() = %p, want cached %p", second, first)
+	}
+}
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+	sut := newLRU(2)
+
+	a, _ := sut.compile("a")
+	for i := range 2 {
+		if _, err := sut.compile(strconv.Itoa(i)); err != nil {
+			t.Fatalf("lru.compile() error = %v", err)
+		}
+		if _, err := sut.compile("a"); err != nil {
+			t.Fatalf("lru.compile() error = %v", err)
+		}
+	}
+	_, evicted := sut.get("0")
+
+	if got, want := sut.len(), 2; got != want {
+		t.Errorf("lru.len() = %d, want %d", got, want)
+	}
+	if got, _ := sut.get("a"); got != a {
+		t.Errorf("lru.get(%q) = %p, want %p", "a", got, a)
+	}
+	if evicted {
+		t.Errorf("lru.get(%q) found evicted pattern", "0")
+	}
+}
@@ -0,0 +1,298 @@
+package stdlib
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"rodusek.dev/pkg/dcell/internal/invocation"
+	"rodusek.dev/pkg/dcell/internal/invocation/arity"
+	"rodusek.dev/pkg/dcell/internal/reflectcmp"
+	"rodusek.dev/pkg/dcell/internal/reflectconv"
+)
+
+// AddCollections adds the collection functions to the function table. Most of
+// these functions accept a lambda as their last argument, which is invoked
+// once per element of the list.
+func AddCollections(table *invocation.Table) {
+	table.Add("where", propagateNil(where)).SetArity(arity.Exactly(2)).SetPure(true)
+	table.Add("select", propagateNil(selectFn)).SetArity(arity.Exactly(2)).SetPure(true)
+	table.Add("any", propagateNil(anyFn)).SetArity(arity.ClosedRange(1, 2)).SetResultType(boolType).SetPure(true)
+	table.Add("all", propagateNil(all)).SetArity(arity.ClosedRange(1, 2)).SetResultType(boolType).SetPure(true)
+	table.Add("none", propagateNil(none)).SetArity(arity.ClosedRange(1, 2)).SetResultType(boolType).SetPure(true)
+	table.Add("first", propagateNil(first)).SetArity(arity.ClosedRange(1, 2)).SetPure(true)
+	table.Add("count", propagateNil(count)).SetArity(arity.ClosedRange(1, 2)).SetResultType(intType).SetPure(true)
+	table.Add("sortBy", propagateNil(sortBy)).SetArity(arity.Exactly(2)).SetPure(true)
+	table.Add("groupBy", propagateNil(groupBy)).SetArity(arity.Exactly(2)).SetPure(true)
+}
+
+func where(params ...reflect.Value) (reflect.Value, error) {
+	list, pred, err := listAndCallable(params)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	result := reflect.MakeSlice(reflect.SliceOf(list.Type().Elem()), 0, list.Len())
+	for i := range list.Len() {
+		elem := list.Index(i)
+		ok, err := test(pred, elem)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if ok {
+			result = reflect.Append(result, elem)
+		}
+	}
+	return result, nil
+}
+
+func selectFn(params ...reflect.Value) (reflect.Value, error) {
+	list, fn, err := listAndCallable(params)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	entries := make([]reflect.Value, 0, list.Len())
+	for i := range list.Len() {
+		value, err := fn(list.Index(i))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		entries = append(entries, value)
+	}
+	return makeSlice(entries), nil
+}
+
+func anyFn(params ...reflect.Value) (reflect.Value, error) {
+	n, err := countMatches(params, 1)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(n > 0), nil
+}
+
+func all(params ...reflect.Value) (reflect.Value, error) {
+	list, err := listArg(params, 0)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	n, err := countMatches(params, -1)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(n == list.Len()), nil
+}
+
+func none(params ...reflect.Value) (reflect.Value, error) {
+	n, err := countMatches(params, 1)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(n == 0), nil
+}
+
+func count(params ...reflect.Value) (reflect.Value, error) {
+	n, err := countMatches(params, -1)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(n), nil
+}
+
+func first(params ...reflect.Value) (reflect.Value, error) {
+	list, err := listArg(params, 0)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	pred, err := optionalCallableArg(params, 1)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	for i := range list.Len() {
+		elem := list.Index(i)
+		ok, err := test(pred, elem)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if ok {
+			return elem, nil
+		}
+	}
+	return reflect.Value{}, nil
+}
+
+func sortBy(params ...reflect.Value) (reflect.Value, error) {
+	list, fn, err := listAndCallable(params)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	type keyed struct {
+		key, elem reflect.Value
+	}
+	entries := make([]keyed, 0, list.Len())
+	for i := range list.Len() {
+		elem := list.Index(i)
+		key, err := fn(elem)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		entries = append(entries, keyed{key: key, elem: elem})
+	}
+	slices.SortStableFunc(entries, func(lhs, rhs keyed) int {
+		return reflectcmp.Compare(lhs.key, rhs.key)
+	})
+	result := reflect.MakeSlice(reflect.SliceOf(list.Type().Elem()), 0, len(entries))
+	for _, entry := range entries {
+		result = reflect.Append(result, entry.elem)
+	}
+	return result, nil
+}
+
+// groupBy groups the elements of the list by the key computed for each
+// element. The result is a map[string][]T if every key is a string, and a
+// map[any][]T otherwise.
+func groupBy(params ...reflect.Value) (reflect.Value, error) {
+	list, fn, err := listAndCallable(params)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	keys := make([]reflect.Value, 0, list.Len())
+	allStrings := true
+	for i := range list.Len() {
+		key, err := fn(list.Index(i))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		key = reflectconv.Deref(key)
+		if key.IsValid() && !key.Comparable() {
+			return reflect.Value{}, fmt.Errorf("%w: groupBy key must be comparable, got %s", invocation.ErrBadArgument, key.Type())
+		}
+		allStrings = allStrings && key.IsValid() && key.Kind() == reflect.String
+		keys = append(keys, key)
+	}
+
+	groupType := reflect.SliceOf(list.Type().Elem())
+	keyType := reflect.TypeFor[any]()
+	if allStrings {
+		keyType = reflect.TypeFor[string]()
+	}
+	result := reflect.MakeMap(reflect.MapOf(keyType, groupType))
+	for i, key := range keys {
+		if allStrings {
+			key = reflect.ValueOf(key.String())
+		} else if !key.IsValid() {
+			key = reflect.Zero(keyType)
+		} else {
+			key = key.Convert(keyType)
+		}
+		group := result.MapIndex(key)
+		if !group.IsValid() {
+			group = reflect.MakeSlice(groupType, 0, 1)
+		}
+		result.SetMapIndex(key, reflect.Append(group, list.Index(i)))
+	}
+	return result, nil
+}
+
+// countMatches counts the elements of the list in the first parameter that
+// satisfy the optional predicate in the second parameter, or that are truthy
+// if no predicate is given. Counting stops once limit matches have been found,
+// unless limit is negative.
+func countMatches(params []reflect.Value, limit int) (int, error) {
+	list, err := listArg(params, 0)
+	if err != nil {
+		return 0, err
+	}
+	pred, err := optionalCallableArg(params, 1)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for i := range list.Len() {
+		if n == limit {
+			break
+		}
+		ok, err := test(pred, list.Index(i))
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// test reports whether the element satisfies the predicate. A nil predicate
+// tests the truthiness of the element itself.
+func test(pred invocation.Callable, elem reflect.Value) (bool, error) {
+	if pred == nil {
+		return reflectconv.IsTruthy(reflectconv.Deref(elem)), nil
+	}
+	result, err := pred(elem)
+	if err != nil {
+		return false, err
+	}
+	return reflectconv.IsTruthy(reflectconv.Deref(result)), nil
+}
+
+// makeSlice creates a slice from the entries, typed as []T if every entry has
+// the same type T, and []any otherwise.
+func makeSlice(entries []reflect.Value) reflect.Value {
+	sliceType := reflect.TypeFor[[]any]()
+	if len(entries) > 0 && entries[0].IsValid() {
+		sliceType = reflect.SliceOf(entries[0].Type())
+		for _, entry := range entries[1:] {
+			if !entry.IsValid() || entry.Type() != entries[0].Type() {
+				sliceType = reflect.TypeFor[[]any]()
+				break
+			}
+		}
+	}
+	result := reflect.MakeSlice(sliceType, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsValid() {
+			entry = reflect.Zero(sliceType.Elem())
+		}
+		result = reflect.Append(result, entry)
+	}
+	return result
+}
+
+func listAndCallable(params []reflect.Value) (reflect.Value, invocation.Callable, error) {
+	list, err := listArg(params, 0)
+	if err != nil {
+		return reflect.Value{}, nil, err
+	}
+	fn, err := callableArg(params, 1)
+	if err != nil {
+		return reflect.Value{}, nil, err
+	}
+	return list, fn, nil
+}
+
+// listArg returns the i-th parameter as a slice or array.
+func listArg(params []reflect.Value, i int) (reflect.Value, error) {
+	rv := reflectconv.Deref(params[i])
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return reflect.Value{}, argumentError(i, "list", rv)
+	}
+	return rv, nil
+}
+
+// callableArg returns the i-th parameter as a callable, such as a lambda.
+func callableArg(params []reflect.Value, i int) (invocation.Callable, error) {
+	rv := reflectconv.Deref(params[i])
+	fn, ok := rv.Interface().(invocation.Callable)
+	if !ok {
+		return nil, argumentError(i, "lambda", rv)
+	}
+	return fn, nil
+}
+
+// optionalCallableArg returns the i-th parameter as a callable, or nil if
+// there is no i-th parameter.
+func optionalCallableArg(params []reflect.Value, i int) (invocation.Callable, error) {
+	if i >= len(params) {
+		return nil, nil
+	}
+	return callableArg(params, i)
+}
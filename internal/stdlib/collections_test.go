@@ -0,0 +1,219 @@
+package stdlib_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"rodusek.dev/pkg/dcell/internal/invocation"
+	"rodusek.dev/pkg/dcell/internal/invocation/arity"
+	"rodusek.dev/pkg/dcell/internal/stdlib"
+)
+
+func lambda[T, R any](fn func(T) R) invocation.Callable {
+	return func(args ...reflect.Value) (reflect.Value, error) {
+		return reflect.ValueOf(fn(args[0].Interface().(T))), nil
+	}
+}
+
+func TestAddCollections(t *testing.T) {
+	t.Parallel()
+	testErr := errors.New("test error")
+	failing := invocation.Callable(func(...reflect.Value) (reflect.Value, error) {
+		return reflect.Value{}, testErr
+	})
+	even := lambda(func(v int) bool { return v%2 == 0 })
+	type label struct {
+		Name  string
+		Color string
+	}
+	labels := []label{
+		{Name: "bug", Color: "red"},
+		{Name: "docs", Color: "blue"},
+		{Name: "urgent", Color: "red"},
+	}
+	byColor := lambda(func(l label) string { return l.Color })
+	var nilSlice *[]int
+
+	table := invocation.NewTable()
+	stdlib.AddCollections(table)
+
+	testCases := []struct {
+		name    string
+		fn      string
+		args    []reflect.Value
+		want    any
+		wantErr error
+	}{
+		{
+			name: "where",
+			fn:   "where",
+			args: values([]int{1, 2, 3, 4}, even),
+			want: []int{2, 4},
+		}, {
+			name: "where no matches",
+			fn:   "where",
+			args: values([]int{1, 3}, even),
+			want: []int{},
+		}, {
+			name: "where nil input",
+			fn:   "where",
+			args: values(nilSlice, even),
+			want: nil,
+		}, {
+			name:    "where non-list input",
+			fn:      "where",
+			args:    values(42, even),
+			wantErr: invocation.ErrBadArgument,
+		}, {
+			name:    "where non-lambda argument",
+			fn:      "where",
+			args:    values([]int{1}, 42),
+			wantErr: invocation.ErrBadArgument,
+		}, {
+			name:    "where lambda error",
+			fn:      "where",
+			args:    values([]int{1}, failing),
+			wantErr: testErr,
+		}, {
+			name: "select uniform type",
+			fn:   "select",
+			args: values(labels, lambda(func(l label) string { return l.Name })),
+			want: []string{"bug", "docs", "urgent"},
+		}, {
+			name: "select mixed types",
+			fn:   "select",
+			args: values([]int{1, 2}, lambda(func(v int) any {
+				if v == 1 {
+					return "one"
+				}
+				return v
+			})),
+			want: []any{"one", 2},
+		}, {
+			name: "any with predicate",
+			fn:   "any",
+			args: values([]int{1, 2}, even),
+			want: true,
+		}, {
+			name: "any without predicate",
+			fn:   "any",
+			args: values([]bool{false, false}),
+			want: false,
+		}, {
+			name: "all with predicate",
+			fn:   "all",
+			args: values([]int{2, 4}, even),
+			want: true,
+		}, {
+			name: "all without predicate",
+			fn:   "all",
+			args: values([]string{"a", ""}),
+			want: false,
+		}, {
+			name: "all empty list",
+			fn:   "all",
+			args: values([]int{}, even),
+			want: true,
+		}, {
+			name: "none with predicate",
+			fn:   "none",
+			args: values([]int{1, 3}, even),
+			want: true,
+		}, {
+			name: "none without predicate",
+			fn:   "none",
+			args: values([]int{0, 1}),
+			want: false,
+		}, {
+			name: "first with predicate",
+			fn:   "first",
+			args: values([]int{1, 2, 4}, even),
+			want: 2,
+		}, {
+			name: "first without predicate",
+			fn:   "first",
+			args: values([]string{"a", "b"}),
+			want: "a",
+		}, {
+			name: "first no match",
+			fn:   "first",
+			args: values([]int{1, 3}, even),
+			want: nil,
+		}, {
+			name: "count with predicate",
+			fn:   "count",
+			args: values([]int{1, 2, 4}, even),
+			want: 2,
+		}, {
+			name: "count without predicate",
+			fn:   "count",
+			args: values([]int{0, 1, 2}),
+			want: 2,
+		}, {
+			name: "sortBy",
+			fn:   "sortBy",
+			args: values(labels, byColor),
+			want: []label{labels[1], labels[0], labels[2]},
+		}, {
+			name:    "sortBy lambda error",
+			fn:      "sortBy",
+			args:    values(labels, failing),
+			wantErr: testErr,
+		}, {
+			name: "groupBy string keys",
+			fn:   "groupBy",
+			args: values(labels, byColor),
+			want: map[string][]label{
+				"red":  {labels[0], labels[2]},
+				"blue": {labels[1]},
+			},
+		}, {
+			name: "groupBy non-string keys",
+			fn:   "groupBy",
+			args: values([]int{1, 2, 3}, lambda(func(v int) int { return v % 2 })),
+			want: map[any][]int{
+				1: {1, 3},
+				0: {2},
+			},
+		}, {
+			name:    "groupBy non-comparable key",
+			fn:      "groupBy",
+			args:    values([]int{1}, lambda(func(v int) []int { return []int{v} })),
+			wantErr: invocation.ErrBadArgument,
+		}, {
+			name:    "bad arity",
+			fn:      "where",
+			args:    values([]int{1}),
+			wantErr: arity.ErrBadArity,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			entry, ok := table.Lookup(tc.fn)
+			if !ok {
+				t.Fatalf("Lookup(%q) failed", tc.fn)
+			}
+
+			got, err := entry.Invoke(tc.args...)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("%s() error = %v, want %v", tc.fn, got, want)
+			}
+			if err != nil {
+				return
+			}
+			var result any
+			if got.IsValid() {
+				result = got.Interface()
+			}
+			if got, want := result, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("%s() = %v, want %v", tc.fn, got, want)
+			}
+		})
+	}
+}
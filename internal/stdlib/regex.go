@@ -0,0 +1,112 @@
+package stdlib
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+
+	"rodusek.dev/pkg/dcell/internal/invocation"
+	"rodusek.dev/pkg/dcell/internal/invocation/arity"
+	"rodusek.dev/pkg/dcell/internal/regex"
+)
+
+var capturesType = reflect.TypeFor[map[string]string]()
+
+// AddRegexps adds the regular expression functions to the function table.
+// The second argument of each function is a pattern, which is compiled once
+// at compile time if it is a literal.
+func AddRegexps(table *invocation.Table) {
+	table.Add("matches", propagateNil(matches)).SetArity(arity.Exactly(2)).SetResultType(boolType).SetPure(true).SetPatternArgs(1)
+	table.Add("find", propagateNil(find)).SetArity(arity.Exactly(2)).SetResultType(stringType).SetPure(true).SetPatternArgs(1)
+	table.Add("findAll", propagateNil(findAll)).SetArity(arity.Exactly(2)).SetResultType(stringsType).SetPure(true).SetPatternArgs(1)
+	table.Add("replaceRegex", propagateNil(replaceRegex)).SetArity(arity.Exactly(3)).SetResultType(stringType).SetPure(true).SetPatternArgs(1)
+	table.Add("captures", propagateNil(captures)).SetArity(arity.Exactly(2)).SetResultType(capturesType).SetPure(true).SetPatternArgs(1)
+}
+
+func matches(params ...reflect.Value) (reflect.Value, error) {
+	return mapPattern(params, func(s string, re *regexp.Regexp) reflect.Value {
+		return reflect.ValueOf(re.MatchString(s))
+	})
+}
+
+// find returns the leftmost match of the pattern, or null if there is none.
+func find(params ...reflect.Value) (reflect.Value, error) {
+	return mapPattern(params, func(s string, re *regexp.Regexp) reflect.Value {
+		loc := re.FindStringIndex(s)
+		if loc == nil {
+			return reflect.Value{}
+		}
+		return reflect.ValueOf(s[loc[0]:loc[1]])
+	})
+}
+
+func findAll(params ...reflect.Value) (reflect.Value, error) {
+	return mapPattern(params, func(s string, re *regexp.Regexp) reflect.Value {
+		all := re.FindAllString(s, -1)
+		if all == nil {
+			all = []string{}
+		}
+		return reflect.ValueOf(all)
+	})
+}
+
+// replaceRegex replaces every match of the pattern, expanding references to
+// groups such as `$1` or `${name}` in the replacement.
+func replaceRegex(params ...reflect.Value) (reflect.Value, error) {
+	replacement, err := stringArg(params, 2)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return mapPattern(params, func(s string, re *regexp.Regexp) reflect.Value {
+		return reflect.ValueOf(re.ReplaceAllString(s, replacement))
+	})
+}
+
+// captures returns the groups of the leftmost match of the pattern, or null if
+// there is none. Every group is keyed by its index, where "0" is the whole
+// match, and named groups are also keyed by their name. Groups that did not
+// participate in the match are empty.
+func captures(params ...reflect.Value) (reflect.Value, error) {
+	return mapPattern(params, func(s string, re *regexp.Regexp) reflect.Value {
+		groups := re.FindStringSubmatch(s)
+		if groups == nil {
+			return reflect.Value{}
+		}
+		result := make(map[string]string, len(groups))
+		for i, name := range re.SubexpNames() {
+			result[strconv.Itoa(i)] = groups[i]
+			if name != "" {
+				result[name] = groups[i]
+			}
+		}
+		return reflect.ValueOf(result)
+	})
+}
+
+// patternArg returns the i-th parameter as a compiled pattern. Patterns that
+// are strings are compiled without a policy.
+func patternArg(params []reflect.Value, i int) (*regexp.Regexp, error) {
+	if params[i].CanInterface() {
+		if re, ok := params[i].Interface().(*regexp.Regexp); ok && re != nil {
+			return re, nil
+		}
+	}
+	pattern, err := stringArg(params, i)
+	if err != nil {
+		return nil, err
+	}
+	var policy *regex.Policy
+	return policy.Compile(pattern)
+}
+
+func mapPattern(params []reflect.Value, fn func(string, *regexp.Regexp) reflect.Value) (reflect.Value, error) {
+	s, err := stringArg(params, 0)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	re, err := patternArg(params, 1)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return fn(s, re), nil
+}
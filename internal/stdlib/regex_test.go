@@ -0,0 +1,145 @@
+package stdlib_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"rodusek.dev/pkg/dcell/internal/errs"
+	"rodusek.dev/pkg/dcell/internal/invocation"
+	"rodusek.dev/pkg/dcell/internal/stdlib"
+)
+
+func TestAddRegexps(t *testing.T) {
+	t.Parallel()
+	var nilString *string
+	version := regexp.MustCompile(`v(?P<major>\d+)\.(\d+)`)
+
+	table := invocation.NewTable()
+	stdlib.AddRegexps(table)
+
+	testCases := []struct {
+		name    string
+		fn      string
+		args    []any
+		want    any
+		wantErr error
+	}{
+		{
+			name: "matches",
+			fn:   "matches",
+			args: []any{"refs/heads/release-1", version},
+			want: false,
+		}, {
+			name: "matches compiled pattern",
+			fn:   "matches",
+			args: []any{"release v1.2", version},
+			want: true,
+		}, {
+			name: "matches string pattern",
+			fn:   "matches",
+			args: []any{"refs/heads/release-1", "^refs/heads/release-"},
+			want: true,
+		}, {
+			name: "matches nil input",
+			fn:   "matches",
+			args: []any{nilString, version},
+			want: nil,
+		}, {
+			name:    "matches invalid pattern",
+			fn:      "matches",
+			args:    []any{"abc", "(abc"},
+			wantErr: errs.ErrInvalidPattern,
+		}, {
+			name:    "matches non-string input",
+			fn:      "matches",
+			args:    []any{42, version},
+			wantErr: invocation.ErrBadArgument,
+		}, {
+			name:    "matches non-pattern argument",
+			fn:      "matches",
+			args:    []any{"abc", 42},
+			wantErr: invocation.ErrBadArgument,
+		}, {
+			name: "find",
+			fn:   "find",
+			args: []any{"from v1.2 to v3.4", version},
+			want: "v1.2",
+		}, {
+			name: "find no match",
+			fn:   "find",
+			args: []any{"none", version},
+			want: nil,
+		}, {
+			name: "findAll",
+			fn:   "findAll",
+			args: []any{"from v1.2 to v3.4", version},
+			want: []string{"v1.2", "v3.4"},
+		}, {
+			name: "findAll no match",
+			fn:   "findAll",
+			args: []any{"none", version},
+			want: []string{},
+		}, {
+			name: "replaceRegex",
+			fn:   "replaceRegex",
+			args: []any{"from v1.2 to v3.4", version, "$major"},
+			want: "from 1 to 3",
+		}, {
+			name: "replaceRegex numbered group",
+			fn:   "replaceRegex",
+			args: []any{"v1.2", version, "${2}.${1}"},
+			want: "2.1",
+		}, {
+			name: "replaceRegex nil replacement",
+			fn:   "replaceRegex",
+			args: []any{"v1.2", version, nilString},
+			want: nil,
+		}, {
+			name: "captures",
+			fn:   "captures",
+			args: []any{"from v1.2 to v3.4", version},
+			want: map[string]string{"0": "v1.2", "1": "1", "major": "1", "2": "2"},
+		}, {
+			name: "captures optional group",
+			fn:   "captures",
+			args: []any{"ab", `a(x)?b`},
+			want: map[string]string{"0": "ab", "1": ""},
+		}, {
+			name: "captures no match",
+			fn:   "captures",
+			args: []any{"none", version},
+			want: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			entry, ok := table.Lookup(tc.fn)
+			if !ok {
+				t.Fatalf("Lookup(%q) failed", tc.fn)
+			}
+
+			got, err := entry.Invoke(values(tc.args...)...)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("%s() error = %v, want %v", tc.fn, got, want)
+			}
+			if err != nil {
+				return
+			}
+			var result any
+			if got.IsValid() {
+				result = got.Interface()
+			}
+			if got, want := result, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("%s() = %v, want %v", tc.fn, got, want)
+			}
+			if !entry.PatternArg(1) {
+				t.Errorf("%s() PatternArg(1) = false, want true", tc.fn)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Package stdlib provides the built-in functions that are made available to
+every dcell expression through the default function table.
+
+Every function in this package is written against the raw [reflect.Value]
+calling convention used by the [invocation.Table], which allows the same
+function to be invoked either as a free function (e.g. `lower(name)`) or as a
+member function (e.g. `name.lower()`), where the receiver is provided as the
+first argument.
+*/
+package stdlib
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"rodusek.dev/pkg/dcell/internal/invocation"
+	"rodusek.dev/pkg/dcell/internal/reflectconv"
+)
+
+type funcEntry = func(params ...reflect.Value) (reflect.Value, error)
+
+type contextFuncEntry = func(ctx context.Context, params ...reflect.Value) (reflect.Value, error)
+
+// Static result types of the functions in this package.
+var (
+	boolType    = reflect.TypeFor[bool]()
+	intType     = reflect.TypeFor[int]()
+	int64Type   = reflect.TypeFor[int64]()
+	stringType  = reflect.TypeFor[string]()
+	stringsType = reflect.TypeFor[[]string]()
+)
+
+// propagateNil wraps the function so that a nil value in any of the
+// parameters produces a nil result, mirroring the way that member access on a
+// nil value produces a nil value.
+func propagateNil(fn funcEntry) funcEntry {
+	return func(params ...reflect.Value) (reflect.Value, error) {
+		for _, param := range params {
+			if reflectconv.IsNil(reflectconv.Deref(param)) {
+				return reflect.Value{}, nil
+			}
+		}
+		return fn(params...)
+	}
+}
+
+// propagateNilContext is [propagateNil] for functions that receive the context
+// of the evaluation.
+func propagateNilContext(fn contextFuncEntry) contextFuncEntry {
+	return func(ctx context.Context, params ...reflect.Value) (reflect.Value, error) {
+		for _, param := range params {
+			if reflectconv.IsNil(reflectconv.Deref(param)) {
+				return reflect.Value{}, nil
+			}
+		}
+		return fn(ctx, params...)
+	}
+}
+
+// stringArg returns the i-th parameter as a string.
+func stringArg(params []reflect.Value, i int) (string, error) {
+	rv := reflectconv.Deref(params[i])
+	if rv.Kind() != reflect.String {
+		return "", argumentError(i, "string", rv)
+	}
+	return rv.String(), nil
+}
+
+// intArg returns the i-th parameter as an int.
+func intArg(params []reflect.Value, i int) (int, error) {
+	rv := reflectconv.Deref(params[i])
+	if !reflectconv.IsInt(rv.Type()) {
+		return 0, argumentError(i, "int", rv)
+	}
+	return reflectconv.Int(rv)
+}
+
+func argumentError(i int, want string, got reflect.Value) error {
+	return fmt.Errorf("%w: argument %d must be of type %s, got %s", invocation.ErrBadArgument, i, want, got.Type())
+}
@@ -0,0 +1,304 @@
+package stdlib
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"rodusek.dev/pkg/dcell/internal/invocation"
+	"rodusek.dev/pkg/dcell/internal/invocation/arity"
+	"rodusek.dev/pkg/dcell/internal/reflectconv"
+)
+
+// AddStrings adds the string functions to the function table.
+func AddStrings(table *invocation.Table) {
+	table.Add("lower", propagateNil(lower)).SetArity(arity.Exactly(1)).SetResultType(stringType).SetPure(true)
+	table.Add("upper", propagateNil(upper)).SetArity(arity.Exactly(1)).SetResultType(stringType).SetPure(true)
+	table.Add("trim", propagateNil(trim)).SetArity(arity.ClosedRange(1, 2)).SetResultType(stringType).SetPure(true)
+	table.Add("trimLeft", propagateNil(trimLeft)).SetArity(arity.ClosedRange(1, 2)).SetResultType(stringType).SetPure(true)
+	table.Add("trimRight", propagateNil(trimRight)).SetArity(arity.ClosedRange(1, 2)).SetResultType(stringType).SetPure(true)
+	table.Add("trimPrefix", propagateNil(trimPrefix)).SetArity(arity.Exactly(2)).SetResultType(stringType).SetPure(true)
+	table.Add("trimSuffix", propagateNil(trimSuffix)).SetArity(arity.Exactly(2)).SetResultType(stringType).SetPure(true)
+	table.Add("startsWith", propagateNil(startsWith)).SetArity(arity.Exactly(2)).SetResultType(boolType).SetPure(true)
+	table.Add("endsWith", propagateNil(endsWith)).SetArity(arity.Exactly(2)).SetResultType(boolType).SetPure(true)
+	table.Add("contains", propagateNil(contains)).SetArity(arity.Exactly(2)).SetResultType(boolType).SetPure(true)
+	table.Add("indexOf", propagateNil(indexOf)).SetArity(arity.Exactly(2)).SetResultType(int64Type).SetPure(true)
+	table.Add("replace", propagateNil(replace)).SetArity(arity.ClosedRange(3, 4)).SetResultType(stringType).SetPure(true)
+	table.Add("split", propagateNil(split)).SetArity(arity.Exactly(2)).SetResultType(stringsType).SetPure(true)
+	table.Add("join", propagateNil(join)).SetArity(arity.Exactly(2)).SetResultType(stringType).SetPure(true)
+	table.AddContext("repeat", propagateNilContext(repeat)).SetArity(arity.Exactly(2)).SetResultType(stringType).SetPure(true)
+	table.AddContext("padLeft", propagateNilContext(padLeft)).SetArity(arity.ClosedRange(2, 3)).SetResultType(stringType).SetPure(true)
+	table.AddContext("padRight", propagateNilContext(padRight)).SetArity(arity.ClosedRange(2, 3)).SetResultType(stringType).SetPure(true)
+	table.Add("substring", propagateNil(substring)).SetArity(arity.ClosedRange(2, 3)).SetResultType(stringType).SetPure(true)
+	table.Add("format", format).SetArity(arity.AtLeast(1)).SetResultType(stringType).SetPure(true)
+}
+
+func lower(params ...reflect.Value) (reflect.Value, error) {
+	return mapString(params, strings.ToLower)
+}
+
+func upper(params ...reflect.Value) (reflect.Value, error) {
+	return mapString(params, strings.ToUpper)
+}
+
+func trim(params ...reflect.Value) (reflect.Value, error) {
+	return trimImpl(params, strings.TrimSpace, strings.Trim)
+}
+
+func trimLeft(params ...reflect.Value) (reflect.Value, error) {
+	trimSpace := func(s string) string {
+		return strings.TrimLeftFunc(s, unicode.IsSpace)
+	}
+	return trimImpl(params, trimSpace, strings.TrimLeft)
+}
+
+func trimRight(params ...reflect.Value) (reflect.Value, error) {
+	trimSpace := func(s string) string {
+		return strings.TrimRightFunc(s, unicode.IsSpace)
+	}
+	return trimImpl(params, trimSpace, strings.TrimRight)
+}
+
+// trimImpl trims whitespace from the string if no cutset is provided, or
+// trims the characters in the cutset otherwise.
+func trimImpl(params []reflect.Value, space func(string) string, cutset func(string, string) string) (reflect.Value, error) {
+	if len(params) == 1 {
+		return mapString(params, space)
+	}
+	return mapStrings(params, func(s, chars string) reflect.Value {
+		return reflect.ValueOf(cutset(s, chars))
+	})
+}
+
+func trimPrefix(params ...reflect.Value) (reflect.Value, error) {
+	return mapStrings(params, func(s, prefix string) reflect.Value {
+		return reflect.ValueOf(strings.TrimPrefix(s, prefix))
+	})
+}
+
+func trimSuffix(params ...reflect.Value) (reflect.Value, error) {
+	return mapStrings(params, func(s, suffix string) reflect.Value {
+		return reflect.ValueOf(strings.TrimSuffix(s, suffix))
+	})
+}
+
+func startsWith(params ...reflect.Value) (reflect.Value, error) {
+	return mapStrings(params, func(s, prefix string) reflect.Value {
+		return reflect.ValueOf(strings.HasPrefix(s, prefix))
+	})
+}
+
+func endsWith(params ...reflect.Value) (reflect.Value, error) {
+	return mapStrings(params, func(s, suffix string) reflect.Value {
+		return reflect.ValueOf(strings.HasSuffix(s, suffix))
+	})
+}
+
+func contains(params ...reflect.Value) (reflect.Value, error) {
+	return mapStrings(params, func(s, substr string) reflect.Value {
+		return reflect.ValueOf(strings.Contains(s, substr))
+	})
+}
+
+// indexOf returns the index of the first occurrence of the substring, counted
+// in characters rather than bytes, or -1 if the substring is not present.
+func indexOf(params ...reflect.Value) (reflect.Value, error) {
+	return mapStrings(params, func(s, substr string) reflect.Value {
+		i := strings.Index(s, substr)
+		if i < 0 {
+			return reflect.ValueOf(int64(-1))
+		}
+		return reflect.ValueOf(int64(utf8.RuneCountInString(s[:i])))
+	})
+}
+
+func replace(params ...reflect.Value) (reflect.Value, error) {
+	s, err := stringArg(params, 0)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	old, err := stringArg(params, 1)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	replacement, err := stringArg(params, 2)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	n := -1
+	if len(params) == 4 {
+		if n, err = intArg(params, 3); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+	return reflect.ValueOf(strings.Replace(s, old, replacement, n)), nil
+}
+
+func split(params ...reflect.Value) (reflect.Value, error) {
+	return mapStrings(params, func(s, sep string) reflect.Value {
+		return reflect.ValueOf(strings.Split(s, sep))
+	})
+}
+
+func join(params ...reflect.Value) (reflect.Value, error) {
+	list := reflectconv.Deref(params[0])
+	if list.Kind() != reflect.Slice && list.Kind() != reflect.Array {
+		return reflect.Value{}, argumentError(0, "list", list)
+	}
+	sep, err := stringArg(params, 1)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	elems := make([]string, 0, list.Len())
+	for i := range list.Len() {
+		elem, err := reflectconv.String(list.Index(i))
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("%w: element %d: %w", invocation.ErrBadArgument, i, err)
+		}
+		elems = append(elems, elem)
+	}
+	return reflect.ValueOf(strings.Join(elems, sep)), nil
+}
+
+// repeat repeats the string count times. The length of the result is checked
+// against the string length limit of the context before it is built.
+func repeat(ctx context.Context, params ...reflect.Value) (reflect.Value, error) {
+	s, err := stringArg(params, 0)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	count, err := intArg(params, 1)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if count < 0 {
+		return reflect.Value{}, fmt.Errorf("%w: repeat count must not be negative, got %d", invocation.ErrBadArgument, count)
+	}
+	if len(s) > 0 && count > math.MaxInt/len(s) {
+		return reflect.Value{}, fmt.Errorf("%w: repeat count %d is too large", invocation.ErrBadArgument, count)
+	}
+	if err := invocation.CheckStringLength(ctx, len(s)*count); err != nil {
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(strings.Repeat(s, count)), nil
+}
+
+func padLeft(ctx context.Context, params ...reflect.Value) (reflect.Value, error) {
+	return padImpl(ctx, params, func(s, padding string) string {
+		return padding + s
+	})
+}
+
+func padRight(ctx context.Context, params ...reflect.Value) (reflect.Value, error) {
+	return padImpl(ctx, params, func(s, padding string) string {
+		return s + padding
+	})
+}
+
+// padImpl pads the string to the requested width, measured in characters,
+// by cycling through the characters of the pad string. The pad string
+// defaults to a single space. The length of the result is checked against the
+// string length limit of the context before the padding is built.
+func padImpl(ctx context.Context, params []reflect.Value, combine func(s, padding string) string) (reflect.Value, error) {
+	s, err := stringArg(params, 0)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	width, err := intArg(params, 1)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	pad := " "
+	if len(params) == 3 {
+		if pad, err = stringArg(params, 2); err != nil {
+			return reflect.Value{}, err
+		}
+		if pad == "" {
+			return reflect.Value{}, fmt.Errorf("%w: pad string must not be empty", invocation.ErrBadArgument)
+		}
+	}
+
+	missing := width - utf8.RuneCountInString(s)
+	if missing <= 0 {
+		return reflect.ValueOf(s), nil
+	}
+	padRunes := []rune(pad)
+	cycles, rest := missing/len(padRunes), string(padRunes[:missing%len(padRunes)])
+	if cycles > (math.MaxInt-len(s)-len(rest))/len(pad) {
+		return reflect.Value{}, fmt.Errorf("%w: pad width %d is too large", invocation.ErrBadArgument, width)
+	}
+	if err := invocation.CheckStringLength(ctx, len(s)+cycles*len(pad)+len(rest)); err != nil {
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(combine(s, strings.Repeat(pad, cycles)+rest)), nil
+}
+
+// substring returns the characters of the string in the half-open range
+// [start, end). If end is not provided, it defaults to the end of the string.
+func substring(params ...reflect.Value) (reflect.Value, error) {
+	s, err := stringArg(params, 0)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	runes := []rune(s)
+	start, err := intArg(params, 1)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	end := len(runes)
+	if len(params) == 3 {
+		if end, err = intArg(params, 2); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+	if start < 0 || end > len(runes) || start > end {
+		return reflect.Value{}, fmt.Errorf("%w: substring range [%d:%d] out of bounds for length %d", invocation.ErrBadArgument, start, end, len(runes))
+	}
+	return reflect.ValueOf(string(runes[start:end])), nil
+}
+
+// format formats the arguments according to the printf-style format string.
+// Only a nil format string produces a nil result; nil arguments are formatted
+// as nil values.
+func format(params ...reflect.Value) (reflect.Value, error) {
+	if reflectconv.IsNil(reflectconv.Deref(params[0])) {
+		return reflect.Value{}, nil
+	}
+	f, err := stringArg(params, 0)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	args := make([]any, 0, len(params)-1)
+	for _, param := range params[1:] {
+		if !param.IsValid() {
+			args = append(args, nil)
+			continue
+		}
+		args = append(args, param.Interface())
+	}
+	return reflect.ValueOf(fmt.Sprintf(f, args...)), nil
+}
+
+func mapString(params []reflect.Value, fn func(string) string) (reflect.Value, error) {
+	s, err := stringArg(params, 0)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(fn(s)), nil
+}
+
+func mapStrings(params []reflect.Value, fn func(string, string) reflect.Value) (reflect.Value, error) {
+	lhs, err := stringArg(params, 0)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	rhs, err := stringArg(params, 1)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return fn(lhs, rhs), nil
+}
@@ -0,0 +1,357 @@
+package stdlib_test
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"rodusek.dev/pkg/dcell/internal/errs"
+	"rodusek.dev/pkg/dcell/internal/invocation"
+	"rodusek.dev/pkg/dcell/internal/invocation/arity"
+	"rodusek.dev/pkg/dcell/internal/stdlib"
+)
+
+func values(args ...any) []reflect.Value {
+	result := make([]reflect.Value, 0, len(args))
+	for _, arg := range args {
+		result = append(result, reflect.ValueOf(arg))
+	}
+	return result
+}
+
+func TestAddStrings(t *testing.T) {
+	t.Parallel()
+	type named string
+	var nilString *string
+
+	table := invocation.NewTable()
+	stdlib.AddStrings(table)
+
+	testCases := []struct {
+		name    string
+		fn      string
+		args    []reflect.Value
+		want    any
+		wantErr error
+	}{
+		{
+			name: "lower",
+			fn:   "lower",
+			args: values("Hello World"),
+			want: "hello world",
+		}, {
+			name: "lower named string type",
+			fn:   "lower",
+			args: values(named("ABC")),
+			want: "abc",
+		}, {
+			name: "lower nil input",
+			fn:   "lower",
+			args: values(nilString),
+			want: nil,
+		}, {
+			name: "lower invalid input",
+			fn:   "lower",
+			args: []reflect.Value{{}},
+			want: nil,
+		}, {
+			name:    "lower non-string input",
+			fn:      "lower",
+			args:    values(42),
+			wantErr: invocation.ErrBadArgument,
+		}, {
+			name: "upper",
+			fn:   "upper",
+			args: values("Hello World"),
+			want: "HELLO WORLD",
+		}, {
+			name: "trim whitespace",
+			fn:   "trim",
+			args: values("  hello \t\n"),
+			want: "hello",
+		}, {
+			name: "trim cutset",
+			fn:   "trim",
+			args: values("--hello--", "-"),
+			want: "hello",
+		}, {
+			name: "trimLeft whitespace",
+			fn:   "trimLeft",
+			args: values("  hello  "),
+			want: "hello  ",
+		}, {
+			name: "trimLeft cutset",
+			fn:   "trimLeft",
+			args: values("xxhelloxx", "x"),
+			want: "helloxx",
+		}, {
+			name: "trimRight whitespace",
+			fn:   "trimRight",
+			args: values("  hello  "),
+			want: "  hello",
+		}, {
+			name: "trimRight cutset",
+			fn:   "trimRight",
+			args: values("xxhelloxx", "x"),
+			want: "xxhello",
+		}, {
+			name: "trimPrefix",
+			fn:   "trimPrefix",
+			args: values("refs/heads/main", "refs/heads/"),
+			want: "main",
+		}, {
+			name: "trimSuffix",
+			fn:   "trimSuffix",
+			args: values("file.go", ".go"),
+			want: "file",
+		}, {
+			name: "startsWith true",
+			fn:   "startsWith",
+			args: values("WIP: change", "WIP"),
+			want: true,
+		}, {
+			name: "startsWith false",
+			fn:   "startsWith",
+			args: values("change", "WIP"),
+			want: false,
+		}, {
+			name: "startsWith nil prefix",
+			fn:   "startsWith",
+			args: values("change", nilString),
+			want: nil,
+		}, {
+			name: "endsWith",
+			fn:   "endsWith",
+			args: values("file.go", ".go"),
+			want: true,
+		}, {
+			name: "contains",
+			fn:   "contains",
+			args: values("hello world", "lo w"),
+			want: true,
+		}, {
+			name: "indexOf found",
+			fn:   "indexOf",
+			args: values("héllo", "llo"),
+			want: int64(2),
+		}, {
+			name: "indexOf not found",
+			fn:   "indexOf",
+			args: values("hello", "z"),
+			want: int64(-1),
+		}, {
+			name: "replace all",
+			fn:   "replace",
+			args: values("a-b-c", "-", "+"),
+			want: "a+b+c",
+		}, {
+			name: "replace count",
+			fn:   "replace",
+			args: values("a-b-c", "-", "+", int64(1)),
+			want: "a+b-c",
+		}, {
+			name:    "replace bad count",
+			fn:      "replace",
+			args:    values("a-b-c", "-", "+", "1"),
+			wantErr: invocation.ErrBadArgument,
+		}, {
+			name: "split",
+			fn:   "split",
+			args: values("a,b,c", ","),
+			want: []string{"a", "b", "c"},
+		}, {
+			name: "join string slice",
+			fn:   "join",
+			args: values([]string{"a", "b", "c"}, ", "),
+			want: "a, b, c",
+		}, {
+			name: "join any slice",
+			fn:   "join",
+			args: values([]any{"a", "b"}, "-"),
+			want: "a-b",
+		}, {
+			name:    "join non-string element",
+			fn:      "join",
+			args:    values([]any{"a", 1}, "-"),
+			wantErr: invocation.ErrBadArgument,
+		}, {
+			name:    "join non-list",
+			fn:      "join",
+			args:    values("abc", "-"),
+			wantErr: invocation.ErrBadArgument,
+		}, {
+			name: "repeat",
+			fn:   "repeat",
+			args: values("ab", int64(3)),
+			want: "ababab",
+		}, {
+			name:    "repeat negative",
+			fn:      "repeat",
+			args:    values("ab", int64(-1)),
+			wantErr: invocation.ErrBadArgument,
+		}, {
+			name:    "repeat count overflows",
+			fn:      "repeat",
+			args:    values("ab", int64(math.MaxInt/2+1)),
+			wantErr: invocation.ErrBadArgument,
+		}, {
+			name: "padLeft default",
+			fn:   "padLeft",
+			args: values("7", int64(3)),
+			want: "  7",
+		}, {
+			name: "padLeft custom",
+			fn:   "padLeft",
+			args: values("7", int64(3), "0"),
+			want: "007",
+		}, {
+			name: "padLeft already wide",
+			fn:   "padLeft",
+			args: values("1234", int64(3), "0"),
+			want: "1234",
+		}, {
+			name:    "padLeft empty pad",
+			fn:      "padLeft",
+			args:    values("7", int64(3), ""),
+			wantErr: invocation.ErrBadArgument,
+		}, {
+			name: "padRight multi-character pad",
+			fn:   "padRight",
+			args: values("ab", int64(7), "xy"),
+			want: "abxyxyx",
+		}, {
+			name:    "padRight width overflows",
+			fn:      "padRight",
+			args:    values("a", int64(math.MaxInt), "é"),
+			wantErr: invocation.ErrBadArgument,
+		}, {
+			name: "substring start",
+			fn:   "substring",
+			args: values("héllo", int64(1)),
+			want: "éllo",
+		}, {
+			name: "substring range",
+			fn:   "substring",
+			args: values("héllo", int64(1), int64(3)),
+			want: "él",
+		}, {
+			name:    "substring out of bounds",
+			fn:      "substring",
+			args:    values("hello", int64(2), int64(10)),
+			wantErr: invocation.ErrBadArgument,
+		}, {
+			name: "format",
+			fn:   "format",
+			args: values("%s-%03d", "build", int64(7)),
+			want: "build-007",
+		}, {
+			name: "format nil argument",
+			fn:   "format",
+			args: []reflect.Value{reflect.ValueOf("%v"), {}},
+			want: "<nil>",
+		}, {
+			name: "format nil format",
+			fn:   "format",
+			args: values(nilString, "x"),
+			want: nil,
+		}, {
+			name:    "bad arity",
+			fn:      "lower",
+			args:    values("a", "b"),
+			wantErr: arity.ErrBadArity,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			entry, ok := table.Lookup(tc.fn)
+			if !ok {
+				t.Fatalf("Lookup(%q) failed", tc.fn)
+			}
+
+			got, err := entry.Invoke(tc.args...)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("%s() error = %v, want %v", tc.fn, got, want)
+			}
+			if err != nil {
+				return
+			}
+			var result any
+			if got.IsValid() {
+				result = got.Interface()
+			}
+			if got, want := result, tc.want; !cmp.Equal(got, want) {
+				t.Errorf("%s() = %v, want %v", tc.fn, got, want)
+			}
+		})
+	}
+}
+
+func TestAddStrings_StringLengthLimit(t *testing.T) {
+	t.Parallel()
+	table := invocation.NewTable()
+	stdlib.AddStrings(table)
+	ctx := invocation.WithMaxStringLength(context.Background(), 8)
+
+	testCases := []struct {
+		name    string
+		fn      string
+		args    []reflect.Value
+		want    any
+		wantErr error
+	}{
+		{
+			name: "repeat within limit",
+			fn:   "repeat",
+			args: values("ab", int64(4)),
+			want: "abababab",
+		}, {
+			name:    "repeat over limit",
+			fn:      "repeat",
+			args:    values("x", int64(1)<<40),
+			wantErr: errs.ErrBudgetExceeded,
+		}, {
+			name: "padLeft within limit",
+			fn:   "padLeft",
+			args: values("7", int64(8), "0"),
+			want: "00000007",
+		}, {
+			name:    "padLeft over limit",
+			fn:      "padLeft",
+			args:    values("7", int64(1)<<40),
+			wantErr: errs.ErrBudgetExceeded,
+		}, {
+			name:    "padRight over limit in bytes",
+			fn:      "padRight",
+			args:    values("a", int64(5), "é"),
+			wantErr: errs.ErrBudgetExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			entry, ok := table.Lookup(tc.fn)
+			if !ok {
+				t.Fatalf("Lookup(%q) failed", tc.fn)
+			}
+
+			got, err := entry.InvokeContext(ctx, tc.args...)
+
+			if got, want := err, tc.wantErr; !cmp.Equal(got, want, cmpopts.EquateErrors()) {
+				t.Fatalf("%s() error = %v, want %v", tc.fn, got, want)
+			}
+			if err != nil {
+				return
+			}
+			if got, want := got.Interface(), tc.want; !cmp.Equal(got, want) {
+				t.Errorf("%s() = %v, want %v", tc.fn, got, want)
+			}
+		})
+	}
+}
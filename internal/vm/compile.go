@@ -0,0 +1,474 @@
+package vm
+
+import (
+	"errors"
+	"reflect"
+
+	"rodusek.dev/pkg/dcell/internal/expr"
+)
+
+// opcode is the operation of an instruction.
+type opcode uint8
+
+const (
+	// opEnter charges the evaluation of a node at depth a.
+	opEnter opcode = iota
+	// opConst pushes constant a.
+	opConst
+	// opLookup pushes the value of variable or parameter lookup a.
+	opLookup
+	// opCurrent pushes the result of applying node a to the current value.
+	opCurrent
+	// opIndexGuard pushes null and jumps to a if the current value is nil.
+	opIndexGuard
+	// opIndex pops an index, and pushes the result of indexing the current
+	// value with binary node a.
+	opIndex
+	// opSliceGuard pushes null and jumps to b if the current value is nil,
+	// and fails if slice node a cannot slice it.
+	opSliceGuard
+	// opSlice pops the bounds of slice node a, and pushes the current value
+	// sliced by them.
+	opSlice
+	// opCheckErr fails if the Go context of the evaluation is done.
+	opCheckErr
+	// opMethodGuard pushes null and jumps to a if the current value is
+	// invalid.
+	opMethodGuard
+	// opCall pops the arguments of call a, and pushes its result.
+	opCall
+	// opLambda pushes a callable for lambda a.
+	opLambda
+	// opNot replaces the top of the stack with its negated truthiness.
+	opNot
+	// opTruthy replaces the top of the stack with its truthiness.
+	opTruthy
+	// opUnary replaces the top of the stack with the result of unary node a.
+	opUnary
+	// opBinary pops two operands, and pushes the result of binary node a.
+	opBinary
+	// opAdd, opSub, opMul, opEqual, and opCompare pop two operands, and push
+	// the result of the operation, falling back to binary node a for operands
+	// that are not unboxed values of the same kind.
+	opAdd
+	opSub
+	opMul
+	opEqual
+	// opCompare tests the comparison of the operands with comparison b.
+	opCompare
+	// opAnd and opOr pop the left operand, and push it as a boolean and jump
+	// to a if it decides the result.
+	opAnd
+	opOr
+	// opJumpIfFalsy pops a condition, and jumps to a if it is falsy.
+	opJumpIfFalsy
+	// opJump jumps to a.
+	opJump
+	// opCoalesce jumps to a if the top of the stack is not nil, and pops it
+	// otherwise.
+	opCoalesce
+	// opPushCurrent pushes the current value.
+	opPushCurrent
+	// opSetCurrent pops a value and makes it the current value, unless it is
+	// nil, in which case it pushes null and jumps to a.
+	opSetCurrent
+	// opRestoreCurrent pops a result and the value pushed by opPushCurrent,
+	// restores the latter as the current value, and pushes the result, or
+	// null if it is nil.
+	opRestoreCurrent
+	// opEval pushes the result of evaluating tree a.
+	opEval
+)
+
+// comparison is the comparison tested by opCompare.
+type comparison int
+
+const (
+	compareLess comparison = iota
+	compareLessOrEqual
+	compareGreater
+	compareGreaterOrEqual
+)
+
+var comparisons = map[expr.InequalityOperator]comparison{
+	expr.OpLessThan:           compareLess,
+	expr.OpLessThanOrEqual:    compareLessOrEqual,
+	expr.OpGreaterThan:        compareGreater,
+	expr.OpGreaterThanOrEqual: compareGreaterOrEqual,
+}
+
+func (c comparison) test(cmp int) bool {
+	switch c {
+	case compareLess:
+		return cmp < 0
+	case compareLessOrEqual:
+		return cmp <= 0
+	case compareGreater:
+		return cmp > 0
+	}
+	return cmp >= 0
+}
+
+// instr is a single instruction of a chunk.
+type instr struct {
+	op   opcode
+	a, b int
+}
+
+var errNilExpr = errors.New("vm: cannot compile nil expression")
+
+// Compile compiles an expression tree into a [Program].
+//
+// Nodes that the VM has no instructions for, such as expressions implemented
+// outside of the expr package, are evaluated as trees by the program.
+func Compile(e expr.Expr) (*Program, error) {
+	p := &Program{}
+	root, err := p.compileChunk(e)
+	if err != nil {
+		return nil, err
+	}
+	p.root = root
+	return p, nil
+}
+
+// MustCompile compiles an expression tree into a [Program], and panics if it
+// fails.
+func MustCompile(e expr.Expr) *Program {
+	p, err := Compile(e)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func (p *Program) compileChunk(e expr.Expr) (*chunk, error) {
+	c := &compiler{program: p, chunk: &chunk{}}
+	if err := c.compile(e); err != nil {
+		return nil, err
+	}
+	return c.chunk, nil
+}
+
+// compiler compiles an expression tree into a single chunk.
+type compiler struct {
+	program *Program
+	chunk   *chunk
+
+	// depth is the depth of the node being compiled, relative to the root
+	// of the chunk.
+	depth int
+
+	// sp is the size of the stack after the instructions compiled so far.
+	sp int
+}
+
+func (c *compiler) emit(op opcode, a, b int) int {
+	c.chunk.code = append(c.chunk.code, instr{op: op, a: a, b: b})
+	return len(c.chunk.code) - 1
+}
+
+// patch sets the jump target of the instruction at i to the next
+// instruction.
+func (c *compiler) patch(i int) {
+	in := &c.chunk.code[i]
+	if in.op == opSliceGuard {
+		in.b = len(c.chunk.code)
+		return
+	}
+	in.a = len(c.chunk.code)
+}
+
+func (c *compiler) push(n int) {
+	c.sp += n
+	c.chunk.stack = max(c.chunk.stack, c.sp)
+}
+
+func (c *compiler) pop(n int) {
+	c.sp -= n
+}
+
+func (c *compiler) compile(e expr.Expr) error {
+	if e == nil {
+		return errNilExpr
+	}
+	mark := len(c.chunk.code)
+	c.emit(opEnter, c.depth, 0)
+	c.depth++
+	ok, err := c.compileNode(e)
+	c.depth--
+	if err != nil || ok {
+		return err
+	}
+
+	c.chunk.code = c.chunk.code[:mark]
+	p := c.program
+	c.emit(opEval, len(p.evals), 0)
+	p.evals = append(p.evals, eval{node: e, depth: c.depth})
+	c.push(1)
+	return nil
+}
+
+// compileNode compiles the instructions of e, after the instruction that
+// charges its evaluation. It reports false if the VM has no instructions for
+// e.
+func (c *compiler) compileNode(e expr.Expr) (bool, error) {
+	p := c.program
+	switch e := e.(type) {
+	case expr.LiteralExpr:
+		c.emit(opConst, len(p.consts), 0)
+		p.consts = append(p.consts, valueOf(reflect.Value(e)))
+		c.push(1)
+	case expr.SequenceExpr:
+		return true, c.compileSequence(e)
+	case expr.MemberExpr:
+		c.current(e.Apply)
+	case expr.WildcardExpr:
+		c.current(e.Apply)
+	case expr.VariableExpr:
+		c.lookup(e.Lookup)
+	case expr.ParamExpr:
+		c.lookup(e.Lookup)
+	case expr.IndexExpr:
+		guard := c.emit(opIndexGuard, 0, 0)
+		if err := c.compile(e.Index); err != nil {
+			return true, err
+		}
+		c.emit(opIndex, len(p.binaries), 0)
+		p.binaries = append(p.binaries, e.Apply)
+		c.patch(guard)
+	case *expr.IndexSliceExpr:
+		return true, c.compileSlice(e)
+	case *expr.FreeFuncExpr:
+		c.emit(opCheckErr, 0, 0)
+		return true, c.compileCall(call{fn: e.Fn, args: len(e.Args)}, e.Args)
+	case *expr.MemberFuncExpr:
+		guard := c.emit(opMethodGuard, 0, 0)
+		if err := c.compileCall(call{fn: e.Fn, args: len(e.Args), member: true}, e.Args); err != nil {
+			return true, err
+		}
+		c.patch(guard)
+	case *expr.ListExpr:
+		return true, c.compileCall(call{fn: e.Build, args: len(e.Elems)}, e.Elems)
+	case *expr.ObjectExpr:
+		return true, c.compileCall(call{fn: e.Build, args: len(e.Values)}, e.Values)
+	case *expr.LambdaExpr:
+		body, err := p.compileChunk(e.Body)
+		if err != nil {
+			return true, err
+		}
+		c.emit(opLambda, len(p.lambdas), 0)
+		p.lambdas = append(p.lambdas, lambda{node: e, body: body})
+		c.push(1)
+	case expr.LogicalNotExpr:
+		if err := c.compile(e.Expr); err != nil {
+			return true, err
+		}
+		c.emit(opNot, 0, 0)
+	case expr.BitwiseNotExpr:
+		return true, c.unary(e.Expr, e.Apply)
+	case expr.PolarityPlusExpr:
+		return true, c.unary(e.Expr, e.Apply)
+	case expr.PolarityMinusExpr:
+		return true, c.unary(e.Expr, e.Apply)
+	case *expr.IsExpr:
+		return true, c.unary(e.Expr, e.Apply)
+	case *expr.AsExpr:
+		return true, c.unary(e.Expr, e.Apply)
+	case *expr.FormatExpr:
+		return true, c.unary(e.Expr, e.Apply)
+	case *expr.PatternExpr:
+		return true, c.unary(e.Expr, e.Apply)
+	case *expr.NonNullExpr:
+		return true, c.unary(e.Expr, e.Apply)
+	case *expr.AddExpr:
+		return true, c.binary(opAdd, 0, e.Left, e.Right, e.Apply)
+	case *expr.SubtractExpr:
+		return true, c.binary(opSub, 0, e.Left, e.Right, e.Apply)
+	case *expr.MultiplyExpr:
+		return true, c.binary(opMul, 0, e.Left, e.Right, e.Apply)
+	case *expr.EqualityExpr:
+		return true, c.binary(opEqual, 0, e.Left, e.Right, e.Apply)
+	case *expr.InequalityExpr:
+		if cmp, ok := comparisons[e.Operator]; ok {
+			return true, c.binary(opCompare, int(cmp), e.Left, e.Right, e.Apply)
+		}
+		return true, c.binary(opBinary, 0, e.Left, e.Right, e.Apply)
+	case *expr.DivideExpr:
+		return true, c.binary(opBinary, 0, e.Left, e.Right, e.Apply)
+	case *expr.FloorDivideExpr:
+		return true, c.binary(opBinary, 0, e.Left, e.Right, e.Apply)
+	case *expr.ModulusExpr:
+		return true, c.binary(opBinary, 0, e.Left, e.Right, e.Apply)
+	case *expr.PowerExpr:
+		return true, c.binary(opBinary, 0, e.Left, e.Right, e.Apply)
+	case *expr.BitwiseAndExpr:
+		return true, c.binary(opBinary, 0, e.Left, e.Right, e.Apply)
+	case *expr.BitwiseOrExpr:
+		return true, c.binary(opBinary, 0, e.Left, e.Right, e.Apply)
+	case *expr.BitwiseXorExpr:
+		return true, c.binary(opBinary, 0, e.Left, e.Right, e.Apply)
+	case *expr.BitwiseShiftLeftExpr:
+		return true, c.binary(opBinary, 0, e.Left, e.Right, e.Apply)
+	case *expr.BitwiseShiftRightExpr:
+		return true, c.binary(opBinary, 0, e.Left, e.Right, e.Apply)
+	case *expr.ImpliesExpr:
+		return true, c.binary(opBinary, 0, e.Left, e.Right, e.Apply)
+	case *expr.InExpr:
+		return true, c.binary(opBinary, 0, e.Left, e.Right, e.Apply)
+	case *expr.MatchExpr:
+		return true, c.binary(opBinary, 0, e.Left, e.Pattern, e.Apply)
+	case *expr.LogicalAndExpr:
+		return true, c.logical(opAnd, e.Left, e.Right)
+	case *expr.LogicalOrExpr:
+		return true, c.logical(opOr, e.Left, e.Right)
+	case *expr.TernaryExpr:
+		return true, c.compileTernary(e)
+	case *expr.CoalesceExpr:
+		if err := c.compile(e.Left); err != nil {
+			return true, err
+		}
+		jump := c.emit(opCoalesce, 0, 0)
+		c.pop(1)
+		if err := c.compile(e.Right); err != nil {
+			return true, err
+		}
+		c.patch(jump)
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+func (c *compiler) current(apply func(*expr.Context, reflect.Value) (reflect.Value, error)) {
+	p := c.program
+	c.emit(opCurrent, len(p.currents), 0)
+	p.currents = append(p.currents, apply)
+	c.push(1)
+}
+
+func (c *compiler) lookup(lookup func(*expr.Context) (reflect.Value, error)) {
+	p := c.program
+	c.emit(opLookup, len(p.lookups), 0)
+	p.lookups = append(p.lookups, lookup)
+	c.push(1)
+}
+
+func (c *compiler) unary(operand expr.Expr, apply func(*expr.Context, reflect.Value) (reflect.Value, error)) error {
+	if err := c.compile(operand); err != nil {
+		return err
+	}
+	p := c.program
+	c.emit(opUnary, len(p.unaries), 0)
+	p.unaries = append(p.unaries, apply)
+	return nil
+}
+
+func (c *compiler) binary(op opcode, b int, left, right expr.Expr, apply func(*expr.Context, reflect.Value, reflect.Value) (reflect.Value, error)) error {
+	if err := c.compile(left); err != nil {
+		return err
+	}
+	if err := c.compile(right); err != nil {
+		return err
+	}
+	p := c.program
+	c.emit(op, len(p.binaries), b)
+	p.binaries = append(p.binaries, apply)
+	c.pop(1)
+	return nil
+}
+
+func (c *compiler) logical(op opcode, left, right expr.Expr) error {
+	if err := c.compile(left); err != nil {
+		return err
+	}
+	jump := c.emit(op, 0, 0)
+	c.pop(1)
+	if err := c.compile(right); err != nil {
+		return err
+	}
+	c.emit(opTruthy, 0, 0)
+	c.patch(jump)
+	return nil
+}
+
+func (c *compiler) compileSequence(e expr.SequenceExpr) error {
+	c.emit(opPushCurrent, 0, 0)
+	c.push(1)
+	if len(e) == 0 {
+		return nil
+	}
+
+	exits := make([]int, 0, len(e)-1)
+	for i, step := range e {
+		c.emit(opCheckErr, 0, 0)
+		if err := c.compile(step); err != nil {
+			return err
+		}
+		if i < len(e)-1 {
+			exits = append(exits, c.emit(opSetCurrent, 0, 0))
+			c.pop(1)
+		}
+	}
+	for _, exit := range exits {
+		c.patch(exit)
+	}
+	c.emit(opRestoreCurrent, 0, 0)
+	c.pop(1)
+	return nil
+}
+
+func (c *compiler) compileSlice(e *expr.IndexSliceExpr) error {
+	p := c.program
+	index := len(p.slices)
+	p.slices = append(p.slices, e)
+
+	guard := c.emit(opSliceGuard, index, 0)
+	if err := c.compile(e.Begin); err != nil {
+		return err
+	}
+	if e.End != nil {
+		if err := c.compile(e.End); err != nil {
+			return err
+		}
+		c.pop(1)
+	}
+	c.emit(opSlice, index, 0)
+	c.patch(guard)
+	return nil
+}
+
+func (c *compiler) compileCall(fn call, args []expr.Expr) error {
+	for _, arg := range args {
+		if err := c.compile(arg); err != nil {
+			return err
+		}
+	}
+	// The depth is of the function node, which has already been entered.
+	fn.depth = c.depth - 1
+
+	p := c.program
+	c.emit(opCall, len(p.calls), 0)
+	p.calls = append(p.calls, fn)
+	c.pop(len(args))
+	c.push(1)
+	return nil
+}
+
+func (c *compiler) compileTernary(e *expr.TernaryExpr) error {
+	if err := c.compile(e.Condition); err != nil {
+		return err
+	}
+	otherwise := c.emit(opJumpIfFalsy, 0, 0)
+	c.pop(1)
+	if err := c.compile(e.TrueExpr); err != nil {
+		return err
+	}
+	end := c.emit(opJump, 0, 0)
+	c.pop(1)
+	c.patch(otherwise)
+	if err := c.compile(e.FalseExpr); err != nil {
+		return err
+	}
+	c.patch(end)
+	return nil
+}
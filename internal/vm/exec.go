@@ -0,0 +1,249 @@
+package vm
+
+import (
+	"math"
+	"reflect"
+
+	"rodusek.dev/pkg/dcell/internal/expr"
+	"rodusek.dev/pkg/dcell/internal/reflectconv"
+)
+
+// run runs a chunk. The current value of ctx is the current value of the
+// evaluation, and is changed while running; ctx must not be shared.
+func (p *Program) run(ctx *expr.Context, c *chunk) (reflect.Value, error) {
+	stack := make([]value, 0, c.stack)
+	code := c.code
+	for pc := 0; pc < len(code); pc++ {
+		in := code[pc]
+		switch in.op {
+		case opEnter:
+			if err := ctx.EnterAt(in.a); err != nil {
+				return reflect.Value{}, err
+			}
+		case opConst:
+			stack = append(stack, p.consts[in.a])
+		case opLookup:
+			got, err := p.lookups[in.a](ctx)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			stack = append(stack, valueOf(got))
+		case opCurrent:
+			got, err := p.currents[in.a](ctx, ctx.Current)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			stack = append(stack, valueOf(got))
+		case opIndexGuard:
+			if reflectconv.IsNil(ctx.Current) {
+				stack = append(stack, value{})
+				pc = in.a - 1
+			}
+		case opIndex:
+			top := len(stack) - 1
+			got, err := p.binaries[in.a](ctx, ctx.Current, stack[top].reflect())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			stack[top] = valueOf(got)
+		case opSliceGuard:
+			if reflectconv.IsNil(ctx.Current) {
+				stack = append(stack, value{})
+				pc = in.b - 1
+				break
+			}
+			if err := p.slices[in.a].Check(ctx.Current); err != nil {
+				return reflect.Value{}, err
+			}
+		case opSlice:
+			e := p.slices[in.a]
+			var high reflect.Value
+			if e.End != nil {
+				high = stack[len(stack)-1].reflect()
+				stack = stack[:len(stack)-1]
+			}
+			top := len(stack) - 1
+			got, err := e.Apply(ctx, ctx.Current, stack[top].reflect(), high)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			stack[top] = valueOf(got)
+		case opCheckErr:
+			if err := ctx.Err(); err != nil {
+				return reflect.Value{}, err
+			}
+		case opMethodGuard:
+			if !ctx.Current.IsValid() {
+				stack = append(stack, value{})
+				pc = in.a - 1
+			}
+		case opCall:
+			fn := &p.calls[in.a]
+			operands := stack[len(stack)-fn.args:]
+			stack = stack[:len(stack)-fn.args]
+			got, err := p.call(ctx, fn, operands)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			stack = append(stack, valueOf(got))
+		case opLambda:
+			l := p.lambdas[in.a]
+			body := func(ctx *expr.Context) (reflect.Value, error) {
+				return p.run(ctx, l.body)
+			}
+			// The callable keeps the context, so it gets a copy that is not
+			// changed by the rest of the run.
+			callable := l.node.Bind(ctx.Next(ctx.Current), body)
+			stack = append(stack, value{kind: kindReflect, rv: reflect.ValueOf(callable)})
+		case opNot:
+			top := len(stack) - 1
+			stack[top] = boolValue(!stack[top].isTruthy())
+		case opTruthy:
+			top := len(stack) - 1
+			stack[top] = boolValue(stack[top].isTruthy())
+		case opUnary:
+			top := len(stack) - 1
+			got, err := p.unaries[in.a](ctx, stack[top].reflect())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			stack[top] = valueOf(got)
+		case opBinary, opAdd, opSub, opMul, opEqual, opCompare:
+			top := len(stack) - 2
+			got, err := p.binary(ctx, in, stack[top], stack[top+1])
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			stack[top] = got
+			stack = stack[:top+1]
+		case opAnd, opOr:
+			top := len(stack) - 1
+			truthy := stack[top].isTruthy()
+			if truthy == (in.op == opOr) {
+				stack[top] = boolValue(truthy)
+				pc = in.a - 1
+				break
+			}
+			stack = stack[:top]
+		case opJumpIfFalsy:
+			top := len(stack) - 1
+			truthy := stack[top].isTruthy()
+			stack = stack[:top]
+			if !truthy {
+				pc = in.a - 1
+			}
+		case opJump:
+			pc = in.a - 1
+		case opCoalesce:
+			top := len(stack) - 1
+			if !stack[top].isNil() {
+				pc = in.a - 1
+				break
+			}
+			stack = stack[:top]
+		case opPushCurrent:
+			stack = append(stack, valueOf(ctx.Current))
+		case opSetCurrent:
+			top := len(stack) - 1
+			if stack[top].isNil() {
+				stack[top] = value{}
+				pc = in.a - 1
+				break
+			}
+			ctx.Current = stack[top].reflect()
+			stack = stack[:top]
+		case opRestoreCurrent:
+			top := len(stack) - 2
+			result := stack[top+1]
+			ctx.Current = stack[top].reflect()
+			if result.isNil() {
+				result = value{}
+			}
+			stack[top] = result
+			stack = stack[:top+1]
+		case opEval:
+			e := p.evals[in.a]
+			ctx.Descend(e.depth)
+			got, err := e.node.Eval(ctx.Next(ctx.Current))
+			ctx.Ascend(e.depth)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			stack = append(stack, valueOf(got))
+		}
+	}
+	return stack[len(stack)-1].reflect(), nil
+}
+
+// call calls a function with the evaluated arguments.
+func (p *Program) call(ctx *expr.Context, fn *call, operands []value) (reflect.Value, error) {
+	args := make([]reflect.Value, 0, len(operands)+1)
+	if fn.member {
+		args = append(args, ctx.Current)
+	}
+	for _, operand := range operands {
+		args = append(args, operand.reflect())
+	}
+
+	// Lambdas called by the function are evaluated below the function node.
+	ctx.Descend(fn.depth + 1)
+	got, err := fn.fn(ctx.GoContext(), args...)
+	ctx.Ascend(fn.depth + 1)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if err := ctx.CheckSize(got); err != nil {
+		return reflect.Value{}, err
+	}
+	return got, nil
+}
+
+// binary applies a binary operation to two operands, using the fast path of
+// the instruction if both operands are unboxed values of the same kind.
+func (p *Program) binary(ctx *expr.Context, in instr, lhs, rhs value) (value, error) {
+	if unboxed(lhs, rhs) {
+		switch in.op {
+		case opAdd:
+			switch lhs.kind {
+			case kindInt:
+				return intValue(lhs.int() + rhs.int()), nil
+			case kindFloat:
+				return floatValue(lhs.float() + rhs.float()), nil
+			case kindString:
+				if err := ctx.CheckStringLength(len(lhs.str) + len(rhs.str)); err != nil {
+					return value{}, err
+				}
+				return stringValue(lhs.str + rhs.str), nil
+			}
+		case opSub:
+			switch lhs.kind {
+			case kindInt:
+				return intValue(lhs.int() - rhs.int()), nil
+			case kindFloat:
+				return floatValue(lhs.float() - rhs.float()), nil
+			}
+		case opMul:
+			switch lhs.kind {
+			case kindInt:
+				// Products that are too large are reported by the node.
+				if float64(lhs.int())*float64(rhs.int()) <= float64(math.MaxInt64) {
+					return intValue(lhs.int() * rhs.int()), nil
+				}
+			case kindFloat:
+				return floatValue(lhs.float() * rhs.float()), nil
+			}
+		case opEqual:
+			return boolValue(compare(lhs, rhs) == 0), nil
+		case opCompare:
+			return boolValue(comparison(in.b).test(compare(lhs, rhs))), nil
+		}
+	}
+
+	l := reflectconv.Deref(lhs.reflect())
+	r := reflectconv.Deref(rhs.reflect())
+	got, err := p.binaries[in.a](ctx, l, r)
+	if err != nil {
+		return value{}, err
+	}
+	return valueOf(got), nil
+}
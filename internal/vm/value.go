@@ -0,0 +1,161 @@
+package vm
+
+import (
+	"cmp"
+	"math"
+	"reflect"
+	"strings"
+
+	"rodusek.dev/pkg/dcell/internal/reflectconv"
+)
+
+// kind is the representation of a value on the stack of the VM.
+type kind uint8
+
+const (
+	kindNull kind = iota
+	kindBool
+	kindInt
+	kindFloat
+	kindString
+	kindReflect
+)
+
+var (
+	typeBool   = reflect.TypeFor[bool]()
+	typeInt    = reflect.TypeFor[int64]()
+	typeFloat  = reflect.TypeFor[float64]()
+	typeString = reflect.TypeFor[string]()
+)
+
+// value is a value on the stack of the VM. Values whose type is exactly bool,
+// int64, float64, or string are kept unboxed, so that the fast paths of the
+// VM can operate on them without reflection. All other values are kept as a
+// [reflect.Value], and are passed to the expression nodes as they are.
+//
+// Since only these exact types are unboxed, boxing a value again always
+// produces a value of the type that it was unboxed from.
+type value struct {
+	kind kind
+	bits uint64
+	str  string
+	rv   reflect.Value
+}
+
+func boolValue(b bool) value {
+	if b {
+		return value{kind: kindBool, bits: 1}
+	}
+	return value{kind: kindBool}
+}
+
+func intValue(i int64) value {
+	return value{kind: kindInt, bits: uint64(i)}
+}
+
+func floatValue(f float64) value {
+	return value{kind: kindFloat, bits: math.Float64bits(f)}
+}
+
+func stringValue(s string) value {
+	return value{kind: kindString, str: s}
+}
+
+// valueOf unboxes rv if it has one of the unboxed types.
+func valueOf(rv reflect.Value) value {
+	if !rv.IsValid() {
+		return value{}
+	}
+	if !rv.CanInterface() {
+		return value{kind: kindReflect, rv: rv}
+	}
+	switch rv.Type() {
+	case typeBool:
+		return boolValue(rv.Bool())
+	case typeInt:
+		return intValue(rv.Int())
+	case typeFloat:
+		return floatValue(rv.Float())
+	case typeString:
+		return stringValue(rv.String())
+	}
+	return value{kind: kindReflect, rv: rv}
+}
+
+func (v value) int() int64 {
+	return int64(v.bits)
+}
+
+func (v value) float() float64 {
+	return math.Float64frombits(v.bits)
+}
+
+// reflect boxes the value.
+func (v value) reflect() reflect.Value {
+	switch v.kind {
+	case kindBool:
+		return reflect.ValueOf(v.bits != 0)
+	case kindInt:
+		return reflect.ValueOf(v.int())
+	case kindFloat:
+		return reflect.ValueOf(v.float())
+	case kindString:
+		return reflect.ValueOf(v.str)
+	case kindReflect:
+		return v.rv
+	}
+	return reflect.Value{}
+}
+
+// isNil reports whether the value is nil, like [reflectconv.IsNil].
+func (v value) isNil() bool {
+	switch v.kind {
+	case kindNull:
+		return true
+	case kindReflect:
+		return reflectconv.IsNil(v.rv)
+	}
+	return false
+}
+
+// isTruthy reports whether the value is truthy, like [reflectconv.IsTruthy].
+func (v value) isTruthy() bool {
+	switch v.kind {
+	case kindBool, kindInt:
+		return v.bits != 0
+	case kindFloat:
+		return v.float() != 0
+	case kindString:
+		return v.str != ""
+	case kindReflect:
+		return reflectconv.IsTruthy(v.rv)
+	}
+	return false
+}
+
+// unboxed reports whether both values are unboxed values of the same kind,
+// which is when the fast paths of the VM apply.
+func unboxed(lhs, rhs value) bool {
+	return lhs.kind == rhs.kind && lhs.kind != kindNull && lhs.kind != kindReflect
+}
+
+// compare compares two unboxed values of the same kind, like
+// [reflectcmp.Compare].
+func compare(lhs, rhs value) int {
+	switch lhs.kind {
+	case kindInt:
+		return cmp.Compare(lhs.int(), rhs.int())
+	case kindFloat:
+		l, r := lhs.float(), rhs.float()
+		if l < r {
+			return -1
+		}
+		if l > r {
+			return 1
+		}
+		return 0
+	case kindString:
+		return strings.Compare(lhs.str, rhs.str)
+	}
+	return cmp.Compare(lhs.bits, rhs.bits)
+}
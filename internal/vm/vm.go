@@ -0,0 +1,82 @@
+/*
+Package vm compiles dcell expression trees into a compact instruction stream,
+and evaluates them on a stack-based virtual machine.
+
+The VM is a drop-in replacement for evaluating the expression tree directly:
+a [Program] is itself an [expr.Expr], and evaluates to the same results and
+errors as the tree that it was compiled from, charging the same evaluation
+budget in the same order.
+
+Unlike the tree, the VM does not allocate a [expr.Context] per evaluation
+step, and keeps booleans, integers, floats, and strings unboxed on its stack,
+so that arithmetic, comparisons, and logic on them do not go through
+reflection. All other operations are delegated to the nodes of the tree.
+*/
+package vm
+
+import (
+	"context"
+	"reflect"
+
+	"rodusek.dev/pkg/dcell/internal/expr"
+)
+
+// Program is an expression tree compiled into instructions for the VM.
+type Program struct {
+	// root is the instruction stream of the root of the expression.
+	root *chunk
+
+	consts   []value
+	lookups  []func(*expr.Context) (reflect.Value, error)
+	currents []func(*expr.Context, reflect.Value) (reflect.Value, error)
+	unaries  []func(*expr.Context, reflect.Value) (reflect.Value, error)
+	binaries []func(*expr.Context, reflect.Value, reflect.Value) (reflect.Value, error)
+	slices   []*expr.IndexSliceExpr
+	calls    []call
+	lambdas  []lambda
+	evals    []eval
+}
+
+// chunk is a single instruction stream, which is either the root of the
+// expression or the body of a lambda.
+type chunk struct {
+	code []instr
+
+	// stack is the maximum size of the stack when running the chunk.
+	stack int
+}
+
+// call is a function called by a chunk.
+type call struct {
+	fn     func(ctx context.Context, args ...reflect.Value) (reflect.Value, error)
+	args   int
+	member bool
+
+	// depth is the depth of the call relative to the root of its chunk.
+	depth int
+}
+
+// lambda is a lambda created by a chunk.
+type lambda struct {
+	node *expr.LambdaExpr
+	body *chunk
+}
+
+// eval is an expression that the VM cannot compile, and evaluates as a tree.
+type eval struct {
+	node expr.Expr
+
+	// depth is the depth of the expression relative to the root of its chunk.
+	depth int
+}
+
+// Eval evaluates the program with the given context. The context is not
+// modified.
+func (p *Program) Eval(ctx *expr.Context) (reflect.Value, error) {
+	if ctx == nil {
+		ctx = expr.NewContext(reflect.Value{})
+	}
+	return p.run(ctx.Next(ctx.Current), p.root)
+}
+
+var _ expr.Expr = (*Program)(nil)
@@ -0,0 +1,248 @@
+package vm_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"rodusek.dev/pkg/dcell/internal/compile"
+	"rodusek.dev/pkg/dcell/internal/errs"
+	"rodusek.dev/pkg/dcell/internal/expr"
+	"rodusek.dev/pkg/dcell/internal/expr/exprtest"
+	"rodusek.dev/pkg/dcell/internal/invocation"
+	"rodusek.dev/pkg/dcell/internal/reflectcmp"
+	"rodusek.dev/pkg/dcell/internal/stdlib"
+	"rodusek.dev/pkg/dcell/internal/vm"
+)
+
+type user struct {
+	Name  string   `dcell:"name"`
+	Age   int64    `dcell:"age"`
+	Score float64  `dcell:"score"`
+	Tags  []string `dcell:"tags"`
+	Admin bool     `dcell:"admin"`
+	Boss  *user    `dcell:"boss"`
+}
+
+var input = struct {
+	Users []user `dcell:"users"`
+	Count int    `dcell:"count"`
+	Owner *user  `dcell:"owner"`
+}{
+	Users: []user{
+		{Name: "alice", Age: 30, Score: 2.5, Tags: []string{"admin", "dev"}, Admin: true},
+		{Name: "bob", Age: 25, Score: 1.5, Tags: []string{"dev"}, Boss: &user{Name: "alice"}},
+	},
+	Count: 2,
+}
+
+func newTable() *invocation.Table {
+	table := invocation.NewTable()
+	stdlib.AddStrings(table)
+	stdlib.AddCollections(table)
+	return table
+}
+
+// expressions are evaluated by both the tree and the VM, which must agree on
+// their results and errors.
+var expressions = []string{
+	`users[0].name + " " + users[1].name`,
+	`users[0].age * 2 - users[1].age + count`,
+	`users[0].score * 2 + users[1].score / 3`,
+	`users[0].age * 4611686018427387904`,
+	`users[0].age < users[1].age || users[0].name >= "alice"`,
+	`users[0].admin == true && !users[1].admin`,
+	`users[0].score > 1 && users[0].score <= 2.5`,
+	`users.name`,
+	`users[1].boss.name`,
+	`users[0].boss.name`,
+	`owner.name ?? "nobody"`,
+	`owner ?: users[0]`,
+	`users[0].tags[1:]`,
+	`users[0].tags[:-1]`,
+	`users[-1].tags[0]`,
+	`users[5]`,
+	`count[1:]`,
+	`users[0].*`,
+	`users[0].age is int ? "int" : "other"`,
+	`(users[0].score as int) + 1`,
+	`"dev" in users[1].tags && "ops" not in users[1].tags`,
+	`any(users, u => u.admin) && all(users, u => "dev" in u.tags)`,
+	`users.where(u => u.age > 26).count()`,
+	`users[0].name.upper().startsWith("AL")`,
+	`$limit - users[0].age`,
+	`$missing`,
+	`users[0].missing`,
+	`-users[0].age + ~count & 7 | 8 ^ 1 << 2 >> 1`,
+	`users[0].age // 7 % 4 ** 2`,
+	`users[0].admin <-> users[1].admin`,
+	`+users[0].name`,
+	`users[0].name + users[0].age`,
+}
+
+func TestProgram_Eval(t *testing.T) {
+	t.Parallel()
+
+	for _, expression := range expressions {
+		t.Run(expression, func(t *testing.T) {
+			t.Parallel()
+			tree, err := compile.NewTree(expression, &compile.Config{FuncTable: newTable()})
+			if err != nil {
+				t.Fatalf("NewTree(%q) error = %v", expression, err)
+			}
+			sut := vm.MustCompile(tree)
+			want, wantErr := tree.Eval(newContext())
+
+			got, err := sut.Eval(newContext())
+
+			if !sameError(err, wantErr) {
+				t.Fatalf("Program.Eval() error = %v, want %v", err, wantErr)
+			}
+			if got, want := valueOf(got), valueOf(want); !cmp.Equal(got, want) {
+				t.Errorf("Program.Eval() = %#v, want %#v", got, want)
+			}
+		})
+	}
+}
+
+func TestProgram_Eval_Budget(t *testing.T) {
+	t.Parallel()
+
+	for _, expression := range expressions {
+		t.Run(expression, func(t *testing.T) {
+			t.Parallel()
+			tree, err := compile.NewTree(expression, &compile.Config{FuncTable: newTable()})
+			if err != nil {
+				t.Fatalf("NewTree(%q) error = %v", expression, err)
+			}
+			sut := vm.MustCompile(tree)
+
+			// Every limit must be exceeded at the same point by both.
+			for limit := 1; limit <= 64; limit++ {
+				for _, budget := range []expr.Budget{
+					{MaxEvaluations: limit},
+					{MaxDepth: limit},
+				} {
+					_, wantErr := tree.Eval(newContext().WithBudget(budget))
+
+					_, err := sut.Eval(newContext().WithBudget(budget))
+
+					if !sameError(err, wantErr) {
+						t.Fatalf("Program.Eval() with budget %+v error = %v, want %v", budget, err, wantErr)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestProgram_Eval_KeepsContext(t *testing.T) {
+	t.Parallel()
+	sut := vm.MustCompile(expr.Sequence(expr.Member("users"), expr.Index(expr.Literal(int64(0)))))
+	ctx := newContext()
+	current := ctx.Current
+
+	_, err := sut.Eval(ctx)
+
+	if err != nil {
+		t.Fatalf("Program.Eval() error = %v", err)
+	}
+	if got, want := ctx.Current, current; got != want {
+		t.Errorf("Program.Eval() changed the current value to %v", got)
+	}
+}
+
+func TestProgram_Eval_Fallback(t *testing.T) {
+	t.Parallel()
+	inner := exprtest.Func(func(ctx *expr.Context) (reflect.Value, error) {
+		return ctx.Current, nil
+	})
+	sut := vm.MustCompile(expr.Sequence(expr.Literal(int64(40)), expr.Add(inner, expr.Literal(int64(2)))))
+
+	got, err := sut.Eval(nil)
+
+	if err != nil {
+		t.Fatalf("Program.Eval() error = %v", err)
+	}
+	if got, want := got, reflect.ValueOf(int64(42)); !reflectcmp.Equal(got, want) {
+		t.Errorf("Program.Eval() = %v, want %v", got, want)
+	}
+}
+
+func TestCompile_Nil(t *testing.T) {
+	t.Parallel()
+
+	_, err := vm.Compile(expr.Add(expr.Literal(1), nil))
+
+	if err == nil {
+		t.Errorf("Compile() error = nil, want error")
+	}
+}
+
+func valueOf(rv reflect.Value) any {
+	if !rv.IsValid() {
+		return nil
+	}
+	return rv.Interface()
+}
+
+func newContext() *expr.Context {
+	return expr.NewContext(reflect.ValueOf(input)).WithVars(map[string]reflect.Value{
+		"limit": reflect.ValueOf(int64(65)),
+	})
+}
+
+// sameError reports whether two errors are both nil, or have the same
+// message.
+func sameError(got, want error) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	var gotBudget, wantBudget *errs.BudgetError
+	if errors.As(want, &wantBudget) {
+		return errors.As(got, &gotBudget) && *gotBudget == *wantBudget
+	}
+	return got.Error() == want.Error()
+}
+
+// benchmarks are expressions whose evaluation by the tree and the VM is
+// compared by BenchmarkProgram_Eval.
+var benchmarks = []struct {
+	name       string
+	expression string
+}{
+	{name: "arithmetic", expression: `users[0].age * 2 - users[1].age + count`},
+	{name: "comparison", expression: `users[0].score > 1 && users[0].age < users[1].age || users[0].name >= "alice"`},
+	{name: "constant", expression: `(1 + 2) * 3 - 4 // 2`},
+	{name: "member", expression: `users[1].boss.name`},
+	{name: "projection", expression: `users.name`},
+	{name: "lambda", expression: `users.where(u => u.age > 26 && "dev" in u.tags).count()`},
+	{name: "function", expression: `users[0].name.upper().startsWith("AL")`},
+}
+
+func BenchmarkProgram_Eval(b *testing.B) {
+	for _, bc := range benchmarks {
+		tree, err := compile.NewTree(bc.expression, &compile.Config{FuncTable: newTable()})
+		if err != nil {
+			b.Fatalf("NewTree(%q) error = %v", bc.expression, err)
+		}
+		for _, backend := range []struct {
+			name string
+			expr expr.Expr
+		}{
+			{name: "tree", expr: tree},
+			{name: "vm", expr: vm.MustCompile(tree)},
+		} {
+			b.Run(bc.name+"/"+backend.name, func(b *testing.B) {
+				ctx := newContext()
+				b.ReportAllocs()
+				for range b.N {
+					if _, err := backend.expr.Eval(ctx); err != nil {
+						b.Fatalf("Eval() error = %v", err)
+					}
+				}
+			})
+		}
+	}
+}
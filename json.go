@@ -0,0 +1,35 @@
+package dcell
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"rodusek.dev/pkg/dcell/internal/jsondoc"
+)
+
+// EvalJSON evaluates the expression against the JSON document in data, as if
+// it were evaluated against the equivalent value with `dcell` tags.
+//
+// Only the members of the document that the expression can touch are decoded.
+// Numbers that are integers decode into int64, or uint64 if they are too
+// large for an int64, so that large IDs stay exact; other numbers decode into
+// float64.
+//
+// Example:
+//
+//	expr.EvalJSON([]byte(`{"github": {"event": {"action": "opened"}}}`))
+func (e *Expr) EvalJSON(data []byte) (*Result, error) {
+	return e.EvalJSONReader(bytes.NewReader(data))
+}
+
+// EvalJSONReader evaluates the expression against the JSON document read
+// from r, as described in [Expr.EvalJSON].
+func (e *Expr) EvalJSONReader(r io.Reader) (*Result, error) {
+	doc, err := jsondoc.Decode(r, e.paths)
+	if err != nil {
+		return nil, fmt.Errorf("dcell: decoding JSON: %w", err)
+	}
+	return e.eval(context.Background(), doc, nil)
+}
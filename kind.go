@@ -0,0 +1,84 @@
+package dcell
+
+import "reflect"
+
+// Kind is the category of a dcell value, as reported by [Result.Kind].
+type Kind int
+
+// Kinds of values.
+const (
+	// KindNull is the kind of nil values, such as nil pointers and maps.
+	KindNull Kind = iota
+
+	// KindBool is the kind of booleans.
+	KindBool
+
+	// KindInt is the kind of signed integers of any size.
+	KindInt
+
+	// KindUint is the kind of unsigned integers of any size.
+	KindUint
+
+	// KindFloat is the kind of floating point numbers of any size.
+	KindFloat
+
+	// KindString is the kind of strings.
+	KindString
+
+	// KindList is the kind of slices and arrays.
+	KindList
+
+	// KindMap is the kind of maps.
+	KindMap
+
+	// KindObject is the kind of structs.
+	KindObject
+
+	// KindOther is the kind of values that dcell has no category for, such as
+	// functions and channels.
+	KindOther
+)
+
+var kindNames = [...]string{
+	KindNull:   "null",
+	KindBool:   "bool",
+	KindInt:    "int",
+	KindUint:   "uint",
+	KindFloat:  "float",
+	KindString: "string",
+	KindList:   "list",
+	KindMap:    "map",
+	KindObject: "object",
+	KindOther:  "other",
+}
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return "unknown"
+	}
+	return kindNames[k]
+}
+
+// kindOf returns the kind of a dereferenced, non-nil value.
+func kindOf(rv reflect.Value) Kind {
+	switch rv.Kind() {
+	case reflect.Bool:
+		return KindBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return KindInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return KindUint
+	case reflect.Float32, reflect.Float64:
+		return KindFloat
+	case reflect.String:
+		return KindString
+	case reflect.Slice, reflect.Array:
+		return KindList
+	case reflect.Map:
+		return KindMap
+	case reflect.Struct:
+		return KindObject
+	}
+	return KindOther
+}
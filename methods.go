@@ -0,0 +1,127 @@
+package dcell
+
+import (
+	"errors"
+	"reflect"
+
+	"rodusek.dev/pkg/dcell/internal/compile"
+	"rodusek.dev/pkg/dcell/internal/members"
+)
+
+// By default, expressions can only read the fields of structs and call the
+// functions of the function table, so that evaluating an expression cannot
+// have side effects beyond those of the functions added to it. The options
+// below allow expressions to call the methods of Go values as well, for the
+// given types only.
+
+// WithMethods allows member function calls that match no function of the
+// function table, such as `$t.Format("2006-01-02")`, to call the exported Go
+// method of the same name on values of the given types, or of pointers to
+// them. Arguments are converted as for functions added with [WithFunc], and
+// methods must return T or (T, error). At least one type must be given; use
+// [WithAllMethods] to allow the methods of every type.
+//
+// Example:
+//
+//	dcell.Compile(`$deadline.Sub($now).Hours()`, dcell.WithMethods(
+//		reflect.TypeFor[time.Time](),
+//		reflect.TypeFor[time.Duration](),
+//	))
+func WithMethods(types ...reflect.Type) Option {
+	return &option{key: methodsKey{types: typesKeyOf(types)}, fn: func(c *compile.Config) error {
+		if len(types) == 0 {
+			return errors.New("dcell: WithMethods requires at least one type")
+		}
+		policy := memberPolicy(c)
+		policy.Methods = addTypes(policy.Methods, types)
+		return nil
+	}}
+}
+
+type methodsKey struct {
+	types any
+}
+
+// WithAllMethods allows member function calls to call the exported Go methods
+// of values of every type, as described in [WithMethods]. It should only be
+// used if no method of the values that expressions can reach has side
+// effects.
+func WithAllMethods() Option {
+	return &option{key: allMethodsKey{}, fn: func(c *compile.Config) error {
+		policy := memberPolicy(c)
+		policy.Methods = allTypes(policy.Methods)
+		return nil
+	}}
+}
+
+type allMethodsKey struct{}
+
+// WithGetters reads members that are not fields of values of the given types,
+// or of pointers to them, from their exported methods named GetX that take no
+// arguments, such as the getters of protocol buffer messages. The getter
+// GetName reads the member `Name`, which may also be written `name`, and
+// methods must return T or (T, error). Fields take precedence over getters.
+// At least one type must be given; use [WithAllGetters] to read the getters
+// of every type.
+//
+// Example:
+//
+//	dcell.Compile(`user.displayName`, dcell.WithGetters(reflect.TypeFor[*pb.User]()))
+func WithGetters(types ...reflect.Type) Option {
+	return &option{key: gettersKey{types: typesKeyOf(types)}, fn: func(c *compile.Config) error {
+		if len(types) == 0 {
+			return errors.New("dcell: WithGetters requires at least one type")
+		}
+		policy := memberPolicy(c)
+		policy.Getters = addTypes(policy.Getters, types)
+		return nil
+	}}
+}
+
+type gettersKey struct {
+	types any
+}
+
+// WithAllGetters reads members from the getters of values of every type, as
+// described in [WithGetters].
+func WithAllGetters() Option {
+	return &option{key: allGettersKey{}, fn: func(c *compile.Config) error {
+		policy := memberPolicy(c)
+		policy.Getters = allTypes(policy.Getters)
+		return nil
+	}}
+}
+
+type allGettersKey struct{}
+
+// addTypes adds the types to the set.
+func addTypes(set *members.TypeSet, types []reflect.Type) *members.TypeSet {
+	if set == nil {
+		set = &members.TypeSet{}
+	}
+	set.Types = append(set.Types, types...)
+	return set
+}
+
+// allTypes makes the set contain every type.
+func allTypes(set *members.TypeSet) *members.TypeSet {
+	if set == nil {
+		set = &members.TypeSet{}
+	}
+	set.All = true
+	return set
+}
+
+// typesKey chains a list of types into a single comparable value.
+type typesKey struct {
+	rt   reflect.Type
+	next any
+}
+
+func typesKeyOf(types []reflect.Type) any {
+	var key any
+	for i := len(types) - 1; i >= 0; i-- {
+		key = typesKey{rt: types[i], next: key}
+	}
+	return key
+}
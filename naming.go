@@ -0,0 +1,69 @@
+package dcell
+
+import (
+	"strings"
+
+	"rodusek.dev/pkg/dcell/internal/compile"
+	"rodusek.dev/pkg/dcell/internal/members"
+)
+
+// By default, the fields of structs are named by their `dcell` tag or, if they
+// have none, their Go name, and a field with a tag of `dcell:"-"` is hidden
+// from expressions. The options below change how fields are named and
+// matched, for type-checking with [WithSchema], for member accesses and
+// wildcards, for the suggestions of unknown names, and for the methods of
+// [Result].
+
+// WithTagFallback names the fields of structs that have no `dcell` tag by the
+// first of the given struct tags that they have, such as `json` or `yaml`.
+// Only the text of the tag up to the first comma is the name, as in
+// `json:"name,omitempty"`, and a tag of "-" hides the field.
+//
+// Example:
+//
+//	dcell.Compile(`pull_request.html_url`, dcell.WithTagFallback("json"))
+func WithTagFallback(keys ...string) Option {
+	return &option{key: tagFallbackKey(strings.Join(keys, "\x00")), fn: func(c *compile.Config) error {
+		policy := memberPolicy(c)
+		if policy.Tags == nil {
+			policy.Tags = []string{members.DefaultTag}
+		}
+		policy.Tags = append(policy.Tags, keys...)
+		return nil
+	}}
+}
+
+type tagFallbackKey string
+
+// WithCaseInsensitiveNames matches the names of the fields of structs
+// case-insensitively, so that `pullRequest` accesses a field named
+// `pullrequest`. A field whose name matches exactly takes precedence.
+func WithCaseInsensitiveNames() Option {
+	return &option{key: caseInsensitiveKey{}, fn: func(c *compile.Config) error {
+		memberPolicy(c).IgnoreCase = true
+		return nil
+	}}
+}
+
+type caseInsensitiveKey struct{}
+
+// WithSeparatorInsensitiveNames matches the names of the fields of structs
+// treating '-' and '_' as equivalent, so that `pull-request` accesses a field
+// named `pull_request`. A field whose name matches exactly takes precedence.
+func WithSeparatorInsensitiveNames() Option {
+	return &option{key: separatorInsensitiveKey{}, fn: func(c *compile.Config) error {
+		memberPolicy(c).FoldSeparators = true
+		return nil
+	}}
+}
+
+type separatorInsensitiveKey struct{}
+
+// memberPolicy returns the member policy of the config, adding one if it has
+// none.
+func memberPolicy(c *compile.Config) *members.Policy {
+	if c.Members == nil {
+		c.Members = &members.Policy{}
+	}
+	return c.Members
+}
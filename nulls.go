@@ -0,0 +1,67 @@
+package dcell
+
+import (
+	"rodusek.dev/pkg/dcell/internal/compile"
+	"rodusek.dev/pkg/dcell/internal/errs"
+)
+
+// ErrNullNavigation is the error wrapped by a [*NullError], which is returned
+// when an expression compiled with [WithStrictNulls] navigates through a null
+// value.
+var ErrNullNavigation = errs.ErrNullNavigation
+
+// NullError is the error returned when an expression compiled with
+// [WithStrictNulls] navigates through a null value. Its path is the source text
+// of the expression that was null, such as `event.pull_request`.
+type NullError = errs.NullError
+
+// WithStrictNulls makes navigating through a null value with `.`, `[]`, or `*`
+// fail with a [*NullError], rather than evaluating to null. Without it,
+// `event.pull_request.title == "x"` is false when `pull_request` is null, which
+// cannot be told apart from a title that does not match.
+//
+// Null values may still be navigated through explicitly:
+//
+//   - `x?.y` evaluates to null if x is null, along with the rest of the
+//     accesses that follow it, as in `x?.y.z`;
+//   - navigation within the left operand of `??` and `?:` evaluates to null,
+//     as in `event.pull_request.title ?? "untitled"`.
+//
+// Example:
+//
+//	dcell.Compile(`event.pull_request?.title == "x"`, dcell.WithStrictNulls())
+func WithStrictNulls() Option {
+	return &option{key: strictNullsKey{}, fn: func(c *compile.Config) error {
+		c.StrictNulls = true
+		return nil
+	}}
+}
+
+type strictNullsKey struct{}
+
+// WithMissingMembersAsNull evaluates members that values do not have to null,
+// rather than failing with an unknown name error. This applies to the keys of
+// maps, the fields of structs, and the names of resolvers, so that payloads
+// whose optional members are omitted can be navigated as if they were null.
+// With [WithSchema], members that the schema does not have are accepted at
+// compile time, since they evaluate to null.
+//
+// Whether a member is present can be checked without this option with the
+// built-in functions `has` and `exists`, which evaluate to false if their
+// argument is null or fails to evaluate because a member, key, index, or
+// variable does not exist. [WithSchema] accepts unknown members in their
+// arguments for the same reason:
+//
+//	has(event.label) && (event.label.name == "bug")
+//
+// Example:
+//
+//	dcell.Compile(`event.label.name == "bug"`, dcell.WithMissingMembersAsNull())
+func WithMissingMembersAsNull() Option {
+	return &option{key: missingMembersKey{}, fn: func(c *compile.Config) error {
+		memberPolicy(c).MissingAsNull = true
+		return nil
+	}}
+}
+
+type missingMembersKey struct{}
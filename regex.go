@@ -0,0 +1,40 @@
+package dcell
+
+import (
+	"fmt"
+
+	"rodusek.dev/pkg/dcell/internal/compile"
+	"rodusek.dev/pkg/dcell/internal/errs"
+	"rodusek.dev/pkg/dcell/internal/regex"
+)
+
+// ErrInvalidPattern is returned when a regular expression pattern, such as the
+// right operand of `=~`, is not valid RE2 syntax, or is longer than allowed by
+// [WithMaxPatternLength]. Invalid literal patterns, such as in `ref =~ "("`,
+// fail at compile time.
+var ErrInvalidPattern = errs.ErrInvalidPattern
+
+// WithMaxPatternLength limits the regular expression patterns of the compiled
+// expression to n bytes, which protects against costly patterns in untrusted
+// expressions. Literal patterns that are too long fail at compile time, and
+// patterns that are only known when evaluating, such as `ref =~ $pattern`,
+// fail when evaluated. Both fail with an error that wraps [ErrInvalidPattern].
+//
+// Patterns are matched with the RE2 syntax of the [regexp] package, in time
+// linear in the length of the input. They are compiled once when they are
+// literals, and through a bounded cache shared by all expressions otherwise.
+//
+// Example:
+//
+//	dcell.Compile(`ref =~ $pattern`, dcell.WithMaxPatternLength(256))
+func WithMaxPatternLength(n int) Option {
+	return &option{key: maxPatternLengthKey(n), fn: func(c *compile.Config) error {
+		if n < 1 {
+			return fmt.Errorf("dcell: max pattern length must be positive, got %d", n)
+		}
+		c.Patterns = &regex.Policy{MaxLength: n}
+		return nil
+	}}
+}
+
+type maxPatternLengthKey int
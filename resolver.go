@@ -0,0 +1,35 @@
+package dcell
+
+import "rodusek.dev/pkg/dcell/internal/expr"
+
+// MemberResolver is implemented by Go types that resolve the members of their
+// values themselves, such as lazily loaded objects or documents backed by raw
+// JSON. Member accesses consult the resolver before traversing the value as a
+// struct or map, and a member that the resolver reports as missing is an
+// unknown name.
+//
+// Example:
+//
+//	type Document map[string]json.RawMessage
+//
+//	func (d Document) DCellMember(name string) (any, bool) {
+//		raw, ok := d[name]
+//		if !ok {
+//			return nil, false
+//		}
+//		var v any
+//		return v, json.Unmarshal(raw, &v) == nil
+//	}
+type MemberResolver = expr.MemberResolver
+
+// KeyLister is implemented by [MemberResolver] types that can list the names
+// of the members of their values. Wildcards enumerate the members of such
+// values in the order of their keys, and unknown names are reported with
+// suggestions from their keys.
+type KeyLister = expr.KeyLister
+
+// IndexResolver is implemented by Go types that resolve the indices of their
+// values themselves. Index accesses consult the resolver before traversing
+// the value as a slice, and an index that the resolver reports as missing is
+// out of bounds.
+type IndexResolver = expr.IndexResolver
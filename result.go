@@ -1,16 +1,34 @@
 package dcell
 
 import (
+	"fmt"
+	"iter"
 	"reflect"
+	"slices"
 
+	"rodusek.dev/pkg/dcell/internal/errs"
+	"rodusek.dev/pkg/dcell/internal/members"
 	"rodusek.dev/pkg/dcell/internal/reflectcmp"
 	"rodusek.dev/pkg/dcell/internal/reflectconv"
 )
 
+// ErrConvert is returned when a result cannot be converted into a Go type by
+// [Result.Decode] or [EvalAs]. The returned error is a [*ConvertError].
+var ErrConvert = errs.ErrConvert
+
+// ConvertError is the error returned when a result cannot be converted into a
+// Go type. Its Path is the path of the element of the result that failed to
+// convert, such as `[1].name`.
+type ConvertError = errs.ConvertError
+
 // Result is the result of evaluating a dcell expression.
 // This may be a
 type Result struct {
 	inner reflect.Value
+
+	// members is the policy that names the fields of structs in the result,
+	// which is the policy of the expression that produced it.
+	members *members.Policy
 }
 
 // String returns the result as a string.
@@ -141,6 +159,141 @@ func (r *Result) Interface() any {
 	return nil
 }
 
+// Decode converts the result into the value pointed to by out, which must be a
+// non-nil pointer.
+//
+// Numbers, strings, and booleans are converted with the same lossless rules as
+// the scalar accessors, such as [Result.Int64], so a float does not decode
+// into an int, and an integer does not decode into a smaller integer type
+// that it overflows. Slices decode element by element, such as `[]any` into
+// `[]string`, and maps entry by entry. Maps with string keys and structs
+// decode into structs field by field, matching fields by their `dcell` tag
+// or, if they have none, their name, following the naming options of the
+// expression such as [WithTagFallback]. A nil result decodes into the zero
+// value.
+//
+// If an element fails to convert, a [*ConvertError] with the path of the
+// element is returned.
+func (r *Result) Decode(out any) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("dcell: Decode requires a non-nil pointer, got %T", out)
+	}
+	return reflectconv.Decode(rv.Elem(), r.inner, r.members)
+}
+
+// Kind returns the category of the value of the result. Nil results, as
+// reported by [Result.IsNil], are of kind [KindNull].
+func (r *Result) Kind() Kind {
+	if r.IsNil() {
+		return KindNull
+	}
+	return kindOf(reflectconv.Deref(r.inner))
+}
+
+// Len returns the number of elements of a list result, or the number of
+// entries of a map result. It returns 0 for results of any other kind.
+func (r *Result) Len() int {
+	switch r.Kind() {
+	case KindList, KindMap:
+		return reflectconv.Deref(r.inner).Len()
+	}
+	return 0
+}
+
+// Index returns the element at index i of a list result. Negative indices
+// count from the end of the list, as in expressions. If the result is not a
+// list, or i is out of bounds, a nil result is returned.
+func (r *Result) Index(i int) *Result {
+	if r.Kind() != KindList {
+		return r.sub(reflect.Value{})
+	}
+	rv := reflectconv.Deref(r.inner)
+	if i < 0 {
+		i += rv.Len()
+	}
+	if i < 0 || i >= rv.Len() {
+		return r.sub(reflect.Value{})
+	}
+	return r.sub(rv.Index(i))
+}
+
+// All returns an iterator over the elements of a list result. Results of any
+// other kind yield no elements.
+func (r *Result) All() iter.Seq[*Result] {
+	return func(yield func(*Result) bool) {
+		if r.Kind() != KindList {
+			return
+		}
+		rv := reflectconv.Deref(r.inner)
+		for i := range rv.Len() {
+			if !yield(r.sub(rv.Index(i))) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns the keys of a map result in sorted order, or the names of the
+// fields of an object result in declaration order. Map keys that are not
+// strings are formatted as by [fmt.Sprint]. It returns nil for results of any
+// other kind.
+func (r *Result) Keys() []string {
+	rv := reflectconv.Deref(r.inner)
+	switch r.Kind() {
+	case KindMap:
+		keys := make([]string, 0, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			keys = append(keys, keyString(iter.Key()))
+		}
+		slices.Sort(keys)
+		return keys
+	case KindObject:
+		return slices.Collect(r.members.Names(rv.Type()))
+	}
+	return nil
+}
+
+// Get returns the value of the entry of a map result, or of the field of an
+// object result, with the given key as returned by [Result.Keys]. Fields are
+// matched following the naming options of the expression. If the
+// result is of any other kind, or has no such key, a nil result is returned.
+func (r *Result) Get(key string) *Result {
+	rv := reflectconv.Deref(r.inner)
+	switch r.Kind() {
+	case KindMap:
+		keyType := rv.Type().Key()
+		if keyType.Kind() == reflect.String {
+			return r.sub(rv.MapIndex(reflect.ValueOf(key).Convert(keyType)))
+		}
+		iter := rv.MapRange()
+		for iter.Next() {
+			if keyString(iter.Key()) == key {
+				return r.sub(iter.Value())
+			}
+		}
+	case KindObject:
+		if field, ok := r.members.Field(rv.Type(), key); ok {
+			value, _ := rv.FieldByIndexErr(field.Index)
+			return r.sub(value)
+		}
+	}
+	return r.sub(reflect.Value{})
+}
+
+// sub returns the result of a value within the result.
+func (r *Result) sub(rv reflect.Value) *Result {
+	return &Result{inner: rv, members: r.members}
+}
+
+func keyString(key reflect.Value) string {
+	if key.Kind() == reflect.String {
+		return key.String()
+	}
+	return fmt.Sprint(key.Interface())
+}
+
 // Value returns the underlying value of the result. If the IsNil would
 // return true, this will return a zero value of the type of the result.
 func (r *Result) Value() reflect.Value {
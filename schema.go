@@ -0,0 +1,62 @@
+package dcell
+
+import (
+	"reflect"
+
+	"rodusek.dev/pkg/dcell/internal/compile"
+)
+
+// ErrCompile is the error wrapped by all errors raised when compiling an
+// expression.
+var ErrCompile = compile.ErrCompile
+
+// SemanticError is the error raised when an expression is syntactically valid
+// but cannot be compiled, such as when it fails to type-check against the
+// schema of [WithSchema]. Its trace lists the sub-expression that caused the
+// error, followed by each expression that encloses it.
+type SemanticError = compile.SemanticError
+
+// WithSchema type-checks the expression at compile time against values of the
+// given Go type, which is the type of the values that the expression will be
+// evaluated against.
+//
+// Member accesses are resolved against the fields of structs, following their
+// `dcell` tags, and the elements of maps and slices. Indexing, the arity and
+// argument types of functions, and the operand types of operators are checked
+// as well. Failing checks are reported as a [*SemanticError].
+//
+// Values whose type is only known at evaluation time, such as interfaces and
+// variables, are accepted by every check.
+//
+// Example:
+//
+//	dcell.Compile(`event.pull_request.title`, dcell.WithSchema(reflect.TypeFor[Payload]()))
+func WithSchema(rt reflect.Type) Option {
+	return &option{key: schemaKey{rt}, fn: func(c *compile.Config) error {
+		c.Schema = rt
+		return nil
+	}}
+}
+
+type schemaKey struct {
+	rt reflect.Type
+}
+
+// CompileFor compiles a dcell expression string into an Expr that is
+// type-checked against values of type T, as if by [WithSchema].
+//
+// Example:
+//
+//	expr, err := dcell.CompileFor[Payload](`event.pull_request.title`)
+func CompileFor[T any](expression string, opts ...Option) (*Expr, error) {
+	opts = append(opts[:len(opts):len(opts)], WithSchema(reflect.TypeFor[T]()))
+	return Compile(expression, opts...)
+}
+
+// ResultType returns the static type of the result of the expression, as
+// inferred when it was compiled with [WithSchema] or [CompileFor]. It returns
+// nil if the expression was compiled without a schema, or if the type of its
+// result is only known at evaluation time.
+func (e *Expr) ResultType() reflect.Type {
+	return e.resultType
+}
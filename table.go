@@ -4,11 +4,15 @@ import (
 	"sync"
 
 	"rodusek.dev/pkg/dcell/internal/invocation"
+	"rodusek.dev/pkg/dcell/internal/stdlib"
 )
 
 // tableV1 creates the function table for the V1 version of this library.
 var tableV1 = sync.OnceValue(func() *invocation.Table {
 	table := invocation.NewTable()
+	stdlib.AddStrings(table)
+	stdlib.AddCollections(table)
+	stdlib.AddRegexps(table)
 
 	return table
 })